@@ -0,0 +1,472 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/reply"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+// ContractABIArgs is arguments that Contract.GetABI accepts.
+type ContractABIArgs struct {
+	Reference string
+}
+
+// ContractABIReply is reply for Contract.GetABI requests.
+type ContractABIReply struct {
+	ABI     *insolar.ContractABI
+	TraceID string
+}
+
+// ContractCallArgs is arguments that Contract.Call accepts.
+type ContractCallArgs struct {
+	Reference string
+	Method    string
+	Params    []json.RawMessage
+}
+
+// ContractCallReply is reply for Contract.Call requests.
+type ContractCallReply struct {
+	Result  []interface{}
+	Error   string
+	TraceID string
+}
+
+// ContractService is a service that provides API for describing contracts and calling their methods with JSON arguments.
+type ContractService struct {
+	runner *Runner
+}
+
+// NewContractService creates new Contract service instance.
+func NewContractService(runner *Runner) *ContractService {
+	return &ContractService{runner: runner}
+}
+
+// GetABI returns ABI of the object's code, it's stored on ledger when code is deployed.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.GetABI",
+//     "params": {
+//       "Reference": str // reference of the object or the prototype
+//     },
+//     "id": str|int|null
+//   }
+//
+//     Response structure:
+// 	{
+// 		"jsonrpc": "2.0",
+// 		"result": {
+// 			"ABI": {
+// 				"Contract": str, // name of the contract
+// 				"Constructors": [...], // same as Methods
+// 				"Methods": [
+// 					{
+// 						"Name": str, // name of the method
+// 						"Arguments": [{"Name": str, "Type": str}], // Go types of arguments
+// 						"Results": [{"Type": str}], // Go types of results
+// 						"API": bool, // whether method can be called through API
+// 						"Immutable": bool // whether method can be called with contract.Query
+// 					}
+// 				]
+// 			},
+// 			"TraceID": str // traceID for request
+// 		},
+// 		"id": str|int|null // same as in request
+// 	}
+//
+func (s *ContractService) GetABI(r *http.Request, args *ContractABIArgs, reply *ContractABIReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ ContractService.GetABI ] Incoming request: %s", r.RequestURI)
+
+	object, err := insolar.NewReferenceFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ ContractService.GetABI ] Can't parse reference")
+	}
+	abi, err := s.getABI(ctx, *object)
+	if err != nil {
+		inslog.Error(err)
+		return errors.Wrap(err, "[ ContractService.GetABI ]")
+	}
+
+	reply.ABI = abi
+	reply.TraceID = traceID
+	return nil
+}
+
+// Call calls method of the object passing arguments in JSON, they are converted to types
+// of the method's arguments according to the object's ABI. Only methods marked as API can be called.
+// References are passed as base58 strings and []byte as base64 strings.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.Call",
+//     "params": {
+//       "Reference": str, // reference of the object
+//       "Method": str, // name of the method
+//       "Params": [any] // arguments of the method
+//     },
+//     "id": str|int|null
+//   }
+//
+//     Response structure:
+// 	{
+// 		"jsonrpc": "2.0",
+// 		"result": {
+// 			"Result": [any], // results of the method except errors
+// 			"Error": str, // error returned by the method if any
+// 			"TraceID": str // traceID for request
+// 		},
+// 		"id": str|int|null // same as in request
+// 	}
+//
+func (s *ContractService) Call(r *http.Request, args *ContractCallArgs, reply *ContractCallReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ ContractService.Call ] Incoming request: %s", r.RequestURI)
+
+	err := s.call(ctx, args, reply, false)
+	if err != nil {
+		inslog.Error(err)
+		return errors.Wrap(err, "[ ContractService.Call ]")
+	}
+	reply.TraceID = traceID
+	return nil
+}
+
+// Query calls immutable method of the object the same way as Call does. Query is executed on the latest
+// state of the object, neither request nor result is registered on ledger. Only methods marked as API
+// and immutable can be queried.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "contract.Query",
+//     "params": {
+//       "Reference": str, // reference of the object
+//       "Method": str, // name of the method
+//       "Params": [any] // arguments of the method
+//     },
+//     "id": str|int|null
+//   }
+//
+//     Response structure is the same as for contract.Call
+//
+func (s *ContractService) Query(r *http.Request, args *ContractCallArgs, reply *ContractCallReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ ContractService.Query ] Incoming request: %s", r.RequestURI)
+
+	err := s.call(ctx, args, reply, true)
+	if err != nil {
+		inslog.Error(err)
+		return errors.Wrap(err, "[ ContractService.Query ]")
+	}
+	reply.TraceID = traceID
+	return nil
+}
+
+func (s *ContractService) call(ctx context.Context, args *ContractCallArgs, rep *ContractCallReply, query bool) error {
+	object, err := insolar.NewReferenceFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "Can't parse reference")
+	}
+	abi, err := s.getABI(ctx, *object)
+	if err != nil {
+		return err
+	}
+	method := abi.Method(args.Method)
+	if method == nil {
+		return errors.Errorf("contract %s has no method %s", abi.Contract, args.Method)
+	}
+	if !method.API {
+		return errors.Errorf("method %s is not available through API", args.Method)
+	}
+	if query && !method.Immutable {
+		return errors.Errorf("method %s is not immutable", args.Method)
+	}
+
+	params, err := argumentsFromJSON(method.Arguments, args.Params)
+	if err != nil {
+		return err
+	}
+
+	send := s.runner.ContractRequester.SendRequest
+	if query {
+		send = s.runner.ContractRequester.SendQuery
+	}
+	res, err := send(ctx, object, args.Method, params)
+	if err != nil {
+		return errors.Wrap(err, "Can't call method")
+	}
+	callReply, ok := res.(*reply.CallMethod)
+	if !ok {
+		return errors.Errorf("unexpected reply of type %T", res)
+	}
+
+	rep.Result, rep.Error, err = resultsToJSON(method.Results, callReply.Result)
+	return err
+}
+
+// getABI returns ABI of the current code of the object's prototype.
+func (s *ContractService) getABI(ctx context.Context, object insolar.Reference) (*insolar.ContractABI, error) {
+	am := s.runner.ArtifactManager
+
+	desc, err := am.GetObject(ctx, object, nil, false)
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't get object")
+	}
+	if !desc.IsPrototype() {
+		prototype, err := desc.Prototype()
+		if err != nil {
+			return nil, errors.Wrap(err, "Can't get prototype of the object")
+		}
+		desc, err = am.GetObject(ctx, *prototype, nil, false)
+		if err != nil {
+			return nil, errors.Wrap(err, "Can't get prototype")
+		}
+	}
+	codeRef, err := desc.Code()
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't get code reference")
+	}
+	code, err := am.GetCode(ctx, *codeRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't get code")
+	}
+	if len(code.ABI()) == 0 {
+		return nil, errors.New("ABI isn't stored for code of the object")
+	}
+
+	abi := &insolar.ContractABI{}
+	err = json.Unmarshal(code.ABI(), abi)
+	if err != nil {
+		return nil, errors.Wrap(err, "Can't unmarshal ABI")
+	}
+	return abi, nil
+}
+
+// referenceTypes are type names contracts use for references, they are passed in JSON as base58 strings.
+var referenceTypes = map[string]bool{
+	"insolar.Reference":    true,
+	"foundation.Reference": true,
+}
+
+// argumentsFromJSON converts JSON arguments to values that are serialized to CBOR as arguments
+// of described types.
+func argumentsFromJSON(described []insolar.ParameterABI, params []json.RawMessage) ([]interface{}, error) {
+	if len(params) != len(described) {
+		return nil, errors.Errorf("expected %d arguments, got %d", len(described), len(params))
+	}
+	res := make([]interface{}, len(params))
+	for i, p := range described {
+		arg, err := argumentFromJSON(p.Type, params[i])
+		if err != nil {
+			return nil, errors.Wrapf(err, "can't convert argument %d to %s", i, p.Type)
+		}
+		res[i] = arg
+	}
+	return res, nil
+}
+
+func argumentFromJSON(typ string, raw json.RawMessage) (interface{}, error) {
+	if strings.HasPrefix(typ, "*") {
+		if string(raw) == "null" {
+			return nil, nil
+		}
+		typ = typ[1:]
+	}
+
+	switch typ {
+	case "string":
+		var v string
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "bool":
+		var v bool
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "[]byte":
+		var v []byte
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "int", "int8", "int16", "int32", "int64":
+		var v int64
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "uint", "uint8", "uint16", "uint32", "uint64", "byte":
+		var v uint64
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	case "float32", "float64":
+		var v float64
+		err := json.Unmarshal(raw, &v)
+		return v, err
+	}
+
+	if referenceTypes[typ] {
+		var v string
+		err := json.Unmarshal(raw, &v)
+		if err != nil {
+			return nil, err
+		}
+		ref, err := insolar.NewReferenceFromBase58(v)
+		if err != nil {
+			return nil, err
+		}
+		return *ref, nil
+	}
+
+	if strings.HasPrefix(typ, "[]") {
+		var items []json.RawMessage
+		err := json.Unmarshal(raw, &items)
+		if err != nil {
+			return nil, err
+		}
+		res := make([]interface{}, len(items))
+		for i, item := range items {
+			res[i], err = argumentFromJSON(typ[2:], item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	if strings.HasPrefix(typ, "map[string]") {
+		var items map[string]json.RawMessage
+		err := json.Unmarshal(raw, &items)
+		if err != nil {
+			return nil, err
+		}
+		res := make(map[string]interface{}, len(items))
+		for k, item := range items {
+			res[k], err = argumentFromJSON(strings.TrimPrefix(typ, "map[string]"), item)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return res, nil
+	}
+
+	// Structures and other types are passed as is, CBOR maps are decoded into structures by field names.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	err := dec.Decode(&v)
+	if err != nil {
+		return nil, err
+	}
+	return normalizeNumbers(v), nil
+}
+
+// normalizeNumbers replaces json.Number values, that are serialized as strings, by integers or floats.
+func normalizeNumbers(v interface{}) interface{} {
+	switch v := v.(type) {
+	case json.Number:
+		if i, err := strconv.ParseInt(string(v), 10, 64); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	case []interface{}:
+		for i := range v {
+			v[i] = normalizeNumbers(v[i])
+		}
+	case map[string]interface{}:
+		for k := range v {
+			v[k] = normalizeNumbers(v[k])
+		}
+	}
+	return v
+}
+
+// resultsToJSON decodes CBOR results of described types into values that can be marshaled to JSON.
+// Errors are not included into results, first non nil error is returned as string instead.
+func resultsToJSON(described []insolar.ParameterABI, data []byte) ([]interface{}, string, error) {
+	holders := make([]interface{}, len(described))
+	for i, p := range described {
+		switch {
+		case p.Type == "error":
+			holders[i] = new(*foundation.Error)
+		case referenceTypes[strings.TrimPrefix(p.Type, "*")]:
+			holders[i] = new(*insolar.Reference)
+		default:
+			holders[i] = new(interface{})
+		}
+	}
+	_, err := insolar.UnMarshalResponse(data, holders)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "Can't unmarshal results")
+	}
+
+	results := []interface{}{}
+	contractErr := ""
+	for _, h := range holders {
+		switch h := h.(type) {
+		case **foundation.Error:
+			if *h != nil && contractErr == "" {
+				contractErr = (*h).Error()
+			}
+		case **insolar.Reference:
+			if *h == nil {
+				results = append(results, nil)
+			} else {
+				results = append(results, (*h).String())
+			}
+		case *interface{}:
+			results = append(results, resultToJSON(*h))
+		}
+	}
+	return results, contractErr, nil
+}
+
+// resultToJSON converts maps with interface keys decoded from CBOR to maps with string keys.
+func resultToJSON(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{}, len(v))
+		for k, item := range v {
+			res[fmt.Sprint(k)] = resultToJSON(item)
+		}
+		return res
+	case []interface{}:
+		for i := range v {
+			v[i] = resultToJSON(v[i])
+		}
+	}
+	return v
+}
@@ -0,0 +1,173 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/reply"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/testutils"
+)
+
+type contractTestPayload struct {
+	Name   string
+	Amount uint64
+	Tags   []string
+}
+
+func TestContractService(t *testing.T) {
+	object := testutils.RandomRef()
+	prototype := testutils.RandomRef()
+	code := testutils.RandomRef()
+	target := testutils.RandomRef()
+
+	abi := insolar.ContractABI{
+		Contract: "Test",
+		Methods: []insolar.FunctionABI{{
+			Name: "Transfer",
+			Arguments: []insolar.ParameterABI{
+				{Name: "to", Type: "insolar.Reference"},
+				{Name: "amount", Type: "int"},
+				{Name: "data", Type: "[]byte"},
+				{Name: "payload", Type: "*contractTestPayload"},
+			},
+			Results: []insolar.ParameterABI{
+				{Type: "*insolar.Reference"}, {Type: "map[string]int"}, {Type: "error"},
+			},
+			API: true,
+		}, {
+			Name:    "Internal",
+			Results: []insolar.ParameterABI{{Type: "error"}},
+		}, {
+			Name:      "Balance",
+			Results:   []insolar.ParameterABI{{Type: "uint"}, {Type: "error"}},
+			API:       true,
+			Immutable: true,
+		}},
+	}
+	abiJSON, err := json.Marshal(abi)
+	require.NoError(t, err)
+
+	am := artifacts.NewClientMock(t)
+	am.GetObjectFunc = func(ctx context.Context, head insolar.Reference, state *insolar.ID, approved bool) (artifacts.ObjectDescriptor, error) {
+		desc := artifacts.NewObjectDescriptorMock(t)
+		switch head {
+		case object:
+			desc.IsPrototypeMock.Return(false)
+			desc.PrototypeMock.Return(&prototype, nil)
+		case prototype:
+			desc.IsPrototypeMock.Return(true)
+			desc.CodeMock.Return(&code, nil)
+		default:
+			t.Fatal("unexpected object", head)
+		}
+		return desc, nil
+	}
+	am.GetCodeFunc = func(ctx context.Context, ref insolar.Reference) (artifacts.CodeDescriptor, error) {
+		require.Equal(t, code, ref)
+		desc := artifacts.NewCodeDescriptorMock(t)
+		desc.ABIMock.Return(abiJSON)
+		return desc, nil
+	}
+
+	contractErr := (*foundation.Error)(nil)
+	cr := testutils.NewContractRequesterMock(t)
+	cr.SendRequestFunc = func(ctx context.Context, ref *insolar.Reference, method string, params []interface{}) (insolar.Reply, error) {
+		require.Equal(t, object, *ref)
+		require.Equal(t, "Transfer", method)
+
+		// Arguments are decoded by contracts into typed values.
+		data, err := insolar.MarshalArgs(params...)
+		require.NoError(t, err)
+		var to insolar.Reference
+		var amount int
+		var raw []byte
+		var payload *contractTestPayload
+		err = insolar.Deserialize(data, []interface{}{&to, &amount, &raw, &payload})
+		require.NoError(t, err)
+		require.Equal(t, target, to)
+		require.Equal(t, 10, amount)
+		require.Equal(t, []byte("hello"), raw)
+		require.Equal(t, &contractTestPayload{Name: "n", Amount: 5, Tags: []string{"a"}}, payload)
+
+		result, err := insolar.Serialize([]interface{}{&target, map[string]int{"left": 90}, contractErr})
+		require.NoError(t, err)
+		return &reply.CallMethod{Result: result}, nil
+	}
+	cr.SendQueryFunc = func(ctx context.Context, ref *insolar.Reference, method string, params []interface{}) (insolar.Reply, error) {
+		require.Equal(t, object, *ref)
+		require.Equal(t, "Balance", method)
+		require.Empty(t, params)
+
+		result, err := insolar.Serialize([]interface{}{uint(100), (*foundation.Error)(nil)})
+		require.NoError(t, err)
+		return &reply.CallMethod{Result: result}, nil
+	}
+
+	s := NewContractService(&Runner{ArtifactManager: am, ContractRequester: cr})
+	r := httptest.NewRequest("POST", "/api/rpc", nil)
+
+	abiReply := ContractABIReply{}
+	err = s.GetABI(r, &ContractABIArgs{Reference: object.String()}, &abiReply)
+	require.NoError(t, err)
+	require.Equal(t, "Test", abiReply.ABI.Contract)
+	require.Len(t, abiReply.ABI.Methods, 3)
+
+	params := []json.RawMessage{
+		json.RawMessage(`"` + target.String() + `"`),
+		json.RawMessage(`10`),
+		json.RawMessage(`"aGVsbG8="`),
+		json.RawMessage(`{"Name": "n", "Amount": 5, "Tags": ["a"]}`),
+	}
+	callReply := ContractCallReply{}
+	err = s.Call(r, &ContractCallArgs{Reference: object.String(), Method: "Transfer", Params: params}, &callReply)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{target.String(), map[string]interface{}{"left": uint64(90)}}, callReply.Result)
+	require.Empty(t, callReply.Error)
+
+	contractErr = &foundation.Error{S: "not enough balance"}
+	callReply = ContractCallReply{}
+	err = s.Call(r, &ContractCallArgs{Reference: object.String(), Method: "Transfer", Params: params}, &callReply)
+	require.NoError(t, err)
+	require.Equal(t, "not enough balance", callReply.Error)
+
+	err = s.Call(r, &ContractCallArgs{Reference: object.String(), Method: "Transfer", Params: params[:2]}, &callReply)
+	require.Contains(t, err.Error(), "expected 4 arguments, got 2")
+
+	err = s.Call(r, &ContractCallArgs{Reference: object.String(), Method: "Internal"}, &callReply)
+	require.Contains(t, err.Error(), "method Internal is not available through API")
+
+	err = s.Call(r, &ContractCallArgs{Reference: object.String(), Method: "Unknown"}, &callReply)
+	require.Contains(t, err.Error(), "contract Test has no method Unknown")
+
+	callReply = ContractCallReply{}
+	err = s.Query(r, &ContractCallArgs{Reference: object.String(), Method: "Balance"}, &callReply)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{uint64(100)}, callReply.Result)
+	require.Equal(t, 1, int(cr.SendQueryCounter))
+
+	err = s.Query(r, &ContractCallArgs{Reference: object.String(), Method: "Transfer", Params: params}, &callReply)
+	require.Contains(t, err.Error(), "method Transfer is not immutable")
+}
@@ -0,0 +1,193 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+)
+
+// eventsPollInterval is how often Subscribe checks for new events.
+var eventsPollInterval = time.Second
+
+// EventsArgs is arguments that Events service accepts.
+type EventsArgs struct {
+	Reference string
+	Name      string
+	Since     uint32
+}
+
+// EventInfo is a contract event in Events service replies.
+type EventInfo struct {
+	Object  string
+	Request string
+	Pulse   uint32
+	Name    string
+	Payload json.RawMessage
+}
+
+// EventsReply is reply for Events service requests.
+type EventsReply struct {
+	Events    []EventInfo
+	NextPulse uint32
+	TraceID   string
+}
+
+// EventsService is a service that provides API for querying and subscribing to events emitted by contracts.
+type EventsService struct {
+	runner *Runner
+}
+
+// NewEventsService creates new Events service instance.
+func NewEventsService(runner *Runner) *EventsService {
+	return &EventsService{runner: runner}
+}
+
+// Get returns events emitted by the object in pulses since requested one up to the current pulse.
+// Events of the current pulse are not returned until it's finished, so NextPulse can be used
+// as Since of the next request without losing or repeating events.
+//
+//   Request structure:
+//   {
+//     "jsonrpc": "2.0",
+//     "method": "events.Get",
+//     "params": {
+//       "Reference": str, // reference of the object emitted events
+//       "Name": str, // optional, name of events to return
+//       "Since": int // pulse number to return events from
+//     },
+//     "id": str|int|null
+//   }
+//
+//     Response structure:
+// 	{
+// 		"jsonrpc": "2.0",
+// 		"result": {
+// 			"Events": [ // events in order of registration
+// 				{
+// 					"Object": str, // reference of the object emitted event
+// 					"Request": str, // reference of the request emitted event
+// 					"Pulse": int, // pulse number event was registered in
+// 					"Name": str, // name of the event
+// 					"Payload": any // payload of the event
+// 				}
+// 			],
+// 			"NextPulse": int, // pulse number to query next events from
+// 			"TraceID": str // traceID for request
+// 		},
+// 		"id": str|int|null // same as in request
+// 	}
+//
+func (s *EventsService) Get(r *http.Request, args *EventsArgs, reply *EventsReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ EventsService.Get ] Incoming request: %s", r.RequestURI)
+
+	err := s.fetch(ctx, args, reply)
+	if err != nil {
+		inslog.Error(err)
+		return err
+	}
+	reply.TraceID = traceID
+	return nil
+}
+
+// Subscribe is the same as Get, but waits for events up to API timeout if there are no events yet.
+// Clients subscribe by calling it in a loop passing NextPulse of the previous reply as Since.
+//
+//   Request and response structures are the same as for events.Get, method is "events.Subscribe".
+//
+func (s *EventsService) Subscribe(r *http.Request, args *EventsArgs, reply *EventsReply) error {
+	traceID := utils.RandTraceID()
+	ctx, inslog := inslogger.WithTraceField(context.Background(), traceID)
+
+	inslog.Infof("[ EventsService.Subscribe ] Incoming request: %s", r.RequestURI)
+
+	timeout := time.After(time.Duration(s.runner.cfg.Timeout) * time.Second)
+	query := *args
+	for {
+		err := s.fetch(ctx, &query, reply)
+		if err != nil {
+			inslog.Error(err)
+			return err
+		}
+		if len(reply.Events) > 0 {
+			break
+		}
+		query.Since = reply.NextPulse
+
+		select {
+		case <-timeout:
+			reply.TraceID = traceID
+			return nil
+		case <-r.Context().Done():
+			return errors.New("[ EventsService.Subscribe ] Request is canceled")
+		case <-time.After(eventsPollInterval):
+		}
+	}
+	reply.TraceID = traceID
+	return nil
+}
+
+func (s *EventsService) fetch(ctx context.Context, args *EventsArgs, reply *EventsReply) error {
+	object, err := insolar.NewReferenceFromBase58(args.Reference)
+	if err != nil {
+		return errors.Wrap(err, "[ EventsService ] Can't parse reference")
+	}
+	since := insolar.PulseNumber(args.Since)
+
+	pulse, err := s.runner.PulseStorage.Current(ctx)
+	if err != nil {
+		return errors.Wrap(err, "[ EventsService ] Can't get current pulse")
+	}
+	// Nothing is finished since requested pulse yet.
+	if pulse.PulseNumber <= since {
+		reply.Events = []EventInfo{}
+		reply.NextPulse = args.Since
+		return nil
+	}
+
+	events, err := s.runner.ArtifactManager.GetEvents(ctx, *object, args.Name, since)
+	if err != nil {
+		return errors.Wrap(err, "[ EventsService ] Can't get events")
+	}
+
+	reply.Events = make([]EventInfo, 0, len(events))
+	for _, e := range events {
+		if e.Pulse >= pulse.PulseNumber {
+			continue
+		}
+		reply.Events = append(reply.Events, EventInfo{
+			Object:  e.Object.String(),
+			Request: e.Request.String(),
+			Pulse:   uint32(e.Pulse),
+			Name:    e.Name,
+			Payload: json.RawMessage(e.Payload),
+		})
+	}
+	reply.NextPulse = uint32(pulse.PulseNumber)
+	return nil
+}
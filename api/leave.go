@@ -0,0 +1,78 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package api
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+// LeaveArgs is arguments that Leave service accepts.
+type LeaveArgs struct {
+	// Pulses is a number of pulses after which node leaves the network
+	Pulses uint32
+}
+
+// LeaveReply is reply for Leave service requests.
+type LeaveReply struct {
+	// ETA is a pulse number at which node leaves the network
+	ETA uint32
+}
+
+// LeaveService is a service that provides API for graceful leave of node.
+type LeaveService struct {
+	runner *Runner
+}
+
+// NewLeaveService creates new LeaveService instance.
+func NewLeaveService(runner *Runner) *LeaveService {
+	return &LeaveService{runner: runner}
+}
+
+// Start initiates graceful leave of node. Allowed only for requests from local host.
+func (s *LeaveService) Start(r *http.Request, args *LeaveArgs, reply *LeaveReply) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), utils.RandTraceID())
+
+	inslog.Infof("[ LeaveService.Start ] Incoming request: %s", r.RequestURI)
+
+	if !isLocalRequest(r) {
+		return errors.New("[ LeaveService.Start ] leave is allowed only from local host")
+	}
+
+	ETA, err := s.runner.TerminationHandler.Leave(ctx, insolar.PulseNumber(args.Pulses))
+	if err != nil {
+		return errors.Wrap(err, "[ LeaveService.Start ]")
+	}
+
+	reply.ETA = uint32(ETA)
+	return nil
+}
+
+func isLocalRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
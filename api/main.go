@@ -35,6 +35,8 @@ import (
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/insolar/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/platformpolicy"
 )
 
@@ -47,6 +49,9 @@ type Runner struct {
 	NetworkSwitcher     insolar.NetworkSwitcher     `inject:""`
 	NodeNetwork         insolar.NodeNetwork         `inject:""`
 	PulseStorage        insolar.PulseStorage        `inject:""`
+	TerminationHandler  insolar.TerminationHandler  `inject:""`
+	PhaseTimings        network.PhaseTimings        `inject:""`
+	ArtifactManager     artifacts.Client            `inject:""`
 	server              *http.Server
 	rpcServer           *rpc.Server
 	cfg                 *configuration.APIRunner
@@ -99,6 +104,21 @@ func (ar *Runner) registerServices(rpcServer *rpc.Server) error {
 		return errors.New("[ registerServices ] Can't RegisterService: cert")
 	}
 
+	err = rpcServer.RegisterService(NewLeaveService(ar), "leave")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: leave")
+	}
+
+	err = rpcServer.RegisterService(NewEventsService(ar), "events")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: events")
+	}
+
+	err = rpcServer.RegisterService(NewContractService(ar), "contract")
+	if err != nil {
+		return errors.New("[ registerServices ] Can't RegisterService: contract")
+	}
+
 	return nil
 }
 
@@ -272,3 +272,28 @@ func Status(url string) (*StatusResponse, error) {
 
 	return res, nil
 }
+
+// Leave makes rpc request to leave.Start method and extracts it
+func Leave(url string, pulses uint32) (*LeaveResponse, error) {
+	params := getDefaultRPCParams("leave.Start")
+	params["params"] = PostParams{
+		"Pulses": pulses,
+	}
+
+	body, err := GetResponseBody(url+"/rpc", params)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Leave ]")
+	}
+
+	leaveResp := rpcLeaveResponse{}
+
+	err = json.Unmarshal(body, &leaveResp)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Leave ] Can't unmarshal")
+	}
+	if leaveResp.Error != nil {
+		return nil, errors.New("[ Leave ] Field 'error' is not nil: " + fmt.Sprint(leaveResp.Error))
+	}
+
+	return &leaveResp.Result, nil
+}
@@ -52,3 +52,13 @@ type rpcInfoResponse struct {
 	rpcResponse
 	Result InfoResponse `json:"result"`
 }
+
+// LeaveResponse represents response from rpc on leave.Start method
+type LeaveResponse struct {
+	ETA uint32 `json:"ETA"`
+}
+
+type rpcLeaveResponse struct {
+	rpcResponse
+	Result LeaveResponse `json:"result"`
+}
@@ -19,6 +19,7 @@ package api
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/insolar/utils"
@@ -44,6 +45,60 @@ type StatusReply struct {
 	Entropy         []byte
 	NodeState       string
 	Version         string
+	Consensus       ConsensusTimings
+}
+
+// PhaseTiming contains timing statistics of a consensus phase.
+type PhaseTiming struct {
+	Phase string
+	// TimeoutFraction is the phase timeout as a fraction of pulse duration.
+	TimeoutFraction float64
+	LastDurationMs  float64
+	LastTimeoutMs   float64
+	PacketDelayMs   float64
+}
+
+// PeerTiming contains counters of missing and late consensus packets from a node.
+type PeerTiming struct {
+	Reference      string
+	MissingPackets uint64
+	LatePackets    uint64
+}
+
+// ConsensusTimings contains timing statistics of consensus phases.
+type ConsensusTimings struct {
+	AdaptiveTimeouts bool
+	Phases           []PhaseTiming
+	Peers            []PeerTiming
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func consensusTimings(status network.PhaseTimingsStatus) ConsensusTimings {
+	result := ConsensusTimings{
+		AdaptiveTimeouts: status.Adaptive,
+		Phases:           make([]PhaseTiming, 0, len(status.Phases)),
+		Peers:            make([]PeerTiming, 0, len(status.Peers)),
+	}
+	for _, p := range status.Phases {
+		result.Phases = append(result.Phases, PhaseTiming{
+			Phase:           p.Phase,
+			TimeoutFraction: p.TimeoutFraction,
+			LastDurationMs:  toMilliseconds(p.LastDuration),
+			LastTimeoutMs:   toMilliseconds(p.LastTimeout),
+			PacketDelayMs:   toMilliseconds(p.PacketDelay),
+		})
+	}
+	for _, p := range status.Peers {
+		result.Peers = append(result.Peers, PeerTiming{
+			Reference:      p.Peer.String(),
+			MissingPackets: p.Missing,
+			LatePackets:    p.Late,
+		})
+	}
+	return result
 }
 
 // StatusService is a service that provides API for getting status of node.
@@ -97,6 +152,7 @@ func (s *StatusService) Get(r *http.Request, args *interface{}, reply *StatusRep
 	reply.PulseNumber = uint32(pulse.PulseNumber)
 	reply.Entropy = pulse.Entropy[:]
 	reply.Version = version.Version
+	reply.Consensus = consensusTimings(s.runner.PhaseTimings.Status())
 
 	return nil
 }
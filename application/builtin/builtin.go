@@ -0,0 +1,27 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package builtin links builtin versions of system contracts into a binary.
+// Wrappers of the contracts are generated by `insgocc builtin` and register
+// themselves in logicrunner builtin executor on import.
+package builtin
+
+import (
+	// system contracts available as insolar.MachineTypeBuiltin
+	_ "github.com/insolar/insolar/application/contract/nodedomain"
+	_ "github.com/insolar/insolar/application/contract/noderecord"
+	_ "github.com/insolar/insolar/application/contract/rootdomain"
+)
@@ -51,6 +51,7 @@ func (a *Allowance) TakeAmount() (uint, error) {
 }
 
 // GetBalanceForOwner returns balance
+// ins:immutable
 func (a *Allowance) GetBalanceForOwner() (uint, error) {
 	return a.Amount, nil
 }
@@ -35,12 +35,14 @@ type Member struct {
 	PublicKey string
 }
 
+// ins:immutable
 func (m *Member) GetName() (string, error) {
 	return m.Name, nil
 }
 
 var INSATTR_GetPublicKey_API = true
 
+// ins:immutable
 func (m *Member) GetPublicKey() (string, error) {
 	return m.PublicKey, nil
 }
@@ -103,6 +105,8 @@ func (m *Member) Call(rootDomain insolar.Reference, method string, params []byte
 		return m.registerNodeCall(rootDomain, params)
 	case "GetNodeRef":
 		return m.getNodeRefCall(rootDomain, params)
+	case "UpgradePrototype":
+		return m.upgradePrototypeCall(rootDomain, params)
 	}
 	return nil, &foundation.Error{S: "Unknown method"}
 }
@@ -242,3 +246,16 @@ func (m *Member) getNodeRefCall(ref insolar.Reference, params []byte) (interface
 
 	return nodeRef, nil
 }
+
+func (m *Member) upgradePrototypeCall(ref insolar.Reference, params []byte) (interface{}, error) {
+	var prototype string
+	var code []byte
+	var machineType insolar.MachineType
+	var abi []byte
+	if err := signer.UnmarshalParams(params, &prototype, &code, &machineType, &abi); err != nil {
+		return nil, fmt.Errorf("[ upgradePrototypeCall ] Can't unmarshal params: %s", err.Error())
+	}
+
+	rootDomain := rootdomain.GetObject(ref)
+	return rootDomain.UpgradePrototype(prototype, code, machineType, abi)
+}
@@ -67,6 +67,7 @@ func (nd *NodeDomain) RegisterNode(publicKey string, role string) (string, error
 }
 
 // GetNodeRefByPK returns node ref
+// ins:immutable
 func (nd *NodeDomain) GetNodeRefByPK(publicKey string) (string, error) {
 	nodeRef, ok := nd.NodeIndexPK[publicKey]
 	if !ok {
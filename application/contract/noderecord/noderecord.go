@@ -61,6 +61,7 @@ func NewNodeRecord(publicKey string, roleStr string) (*NodeRecord, error) {
 var INSATTR_GetNodeInfo_API = true
 
 // GetNodeInfo returns RecordInfo
+// ins:immutable
 func (nr *NodeRecord) GetNodeInfo() (RecordInfo, error) {
 	return nr.Record, nil
 }
@@ -68,11 +69,13 @@ func (nr *NodeRecord) GetNodeInfo() (RecordInfo, error) {
 var INSATTR_GetPublicKey_API = true
 
 // GetPublicKey returns public key
+// ins:immutable
 func (nr *NodeRecord) GetPublicKey() (string, error) {
 	return nr.Record.PublicKey, nil
 }
 
 // GetRole returns role
+// ins:immutable
 func (nr *NodeRecord) GetRole() (insolar.StaticRole, error) {
 	return nr.Record.Role, nil
 }
@@ -53,6 +53,7 @@ func (rd *RootDomain) CreateMember(name string, key string) (string, error) {
 }
 
 // GetRootMemberRef returns root member's reference
+// ins:immutable
 func (rd *RootDomain) GetRootMemberRef() (*insolar.Reference, error) {
 	return &rd.RootMember, nil
 }
@@ -132,6 +133,7 @@ func (rd *RootDomain) DumpAllUsers() ([]byte, error) {
 var INSATTR_Info_API = true
 
 // Info returns information about basic objects
+// ins:immutable
 func (rd *RootDomain) Info() (interface{}, error) {
 	res := map[string]interface{}{
 		"root_member": rd.RootMember.String(),
@@ -144,7 +146,24 @@ func (rd *RootDomain) Info() (interface{}, error) {
 	return resJSON, nil
 }
 
+// UpgradePrototype deploys code with its ABI as the new version of the prototype and returns reference to the code
+func (rd *RootDomain) UpgradePrototype(prototype string, code []byte, machineType insolar.MachineType, abi []byte) (string, error) {
+	if *rd.GetContext().Caller != rd.RootMember {
+		return "", fmt.Errorf("[ UpgradePrototype ] Only root can call this method")
+	}
+	protoRef, err := insolar.NewReferenceFromBase58(prototype)
+	if err != nil {
+		return "", fmt.Errorf("[ UpgradePrototype ] Failed to parse prototype reference: %s", err.Error())
+	}
+	codeRef, err := foundation.UpgradePrototype(*protoRef, code, machineType, abi)
+	if err != nil {
+		return "", fmt.Errorf("[ UpgradePrototype ] Can't upgrade prototype: %s", err.Error())
+	}
+	return codeRef.String(), nil
+}
+
 // GetNodeDomainRef returns reference of NodeDomain instance
+// ins:immutable
 func (rd *RootDomain) GetNodeDomainRef() (insolar.Reference, error) {
 	return rd.NodeDomainRef, nil
 }
@@ -58,7 +58,15 @@ func (w *Wallet) Transfer(amount uint, to *insolar.Reference) error {
 
 	r := a.GetReference()
 	err = toWallet.AcceptNoWait(&r)
-	return err
+	if err != nil {
+		return err
+	}
+
+	return foundation.EmitEvent("Transferred", map[string]interface{}{
+		"from":   w.GetReference().String(),
+		"to":     toWalletRef.String(),
+		"amount": amount,
+	})
 }
 
 // Accept transforms allowance to balance
@@ -419,6 +419,66 @@ func (r *RootDomain) InfoNoWait() error {
 	return nil
 }
 
+// UpgradePrototype is proxy generated method
+func (r *RootDomain) UpgradePrototype(prototype string, code []byte, machineType insolar.MachineType, abi []byte) (string, error) {
+	var args [4]interface{}
+	args[0] = prototype
+	args[1] = code
+	args[2] = machineType
+	args[3] = abi
+
+	var argsSerialized []byte
+
+	ret := [2]interface{}{}
+	var ret0 string
+	ret[0] = &ret0
+	var ret1 *foundation.Error
+	ret[1] = &ret1
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return ret0, err
+	}
+
+	res, err := proxyctx.Current.RouteCall(r.Reference, true, "UpgradePrototype", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return ret0, err
+	}
+
+	err = proxyctx.Current.Deserialize(res, &ret)
+	if err != nil {
+		return ret0, err
+	}
+
+	if ret1 != nil {
+		return ret0, ret1
+	}
+	return ret0, nil
+}
+
+// UpgradePrototypeNoWait is proxy generated method
+func (r *RootDomain) UpgradePrototypeNoWait(prototype string, code []byte, machineType insolar.MachineType, abi []byte) error {
+	var args [4]interface{}
+	args[0] = prototype
+	args[1] = code
+	args[2] = machineType
+	args[3] = abi
+
+	var argsSerialized []byte
+
+	err := proxyctx.Current.Serialize(args, &argsSerialized)
+	if err != nil {
+		return err
+	}
+
+	_, err = proxyctx.Current.RouteCall(r.Reference, false, "UpgradePrototype", argsSerialized, *PrototypeReference)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetNodeDomainRef is proxy generated method
 func (r *RootDomain) GetNodeDomainRef() (insolar.Reference, error) {
 	var args [0]interface{}
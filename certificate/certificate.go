@@ -191,6 +191,11 @@ func (cert *Certificate) GetRootDomainReference() *insolar.Reference {
 	return ref
 }
 
+// GetPulsarPublicKeys returns public keys of pulsars trusted by the network
+func (cert *Certificate) GetPulsarPublicKeys() []string {
+	return cert.PulsarPublicKeys
+}
+
 // GetDiscoveryNodes return bootstrap nodes array
 func (cert *Certificate) GetDiscoveryNodes() []insolar.DiscoveryNode {
 	result := make([]insolar.DiscoveryNode, 0)
@@ -17,6 +17,8 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -24,7 +26,11 @@ import (
 	"os/exec"
 	"path"
 	"path/filepath"
+	"strings"
 
+	"github.com/insolar/insolar/api/requester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/logicrunner/goplugin/preprocessor"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -147,6 +153,39 @@ func main() {
 	}
 	cmdWrapper.Flags().VarP(output, "output", "o", "output file (use - for STDOUT)")
 
+	builtinOut := newOutputFlag("")
+	var cmdBuiltin = &cobra.Command{
+		Use:   "builtin [flags] <file name to process>",
+		Short: "Generate wrapper registering contract in builtin executor",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("builtin command should be followed by exactly one file name to process")
+				os.Exit(1)
+			}
+			parsed, err := preprocessor.ParseFile(args[0])
+			if err != nil {
+				fmt.Println(errors.Wrap(err, "couldn't parse"))
+				os.Exit(1)
+			}
+
+			if builtinOut.String() == "" {
+				ext := filepath.Ext(args[0])
+				err = builtinOut.Set(strings.TrimSuffix(args[0], ext) + ".builtin.go")
+				if err != nil {
+					fmt.Println(err)
+					os.Exit(1)
+				}
+			}
+
+			err = parsed.WriteBuiltinWrapper(builtinOut.writer)
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmdBuiltin.Flags().VarP(builtinOut, "output", "o", "output file (use - for STDOUT)")
+
 	var cmdImports = &cobra.Command{
 		Use:   "imports [flags] <file name to process>",
 		Short: "Rewrite imports in contract file",
@@ -170,95 +209,240 @@ func main() {
 	}
 	cmdImports.Flags().VarP(output, "output", "o", "output file (use - for STDOUT)")
 
-	// PLEASE NOTE that `insgocc compile` is in fact not used for compiling contracts by insolard.
-	// Instead contracts are compiled when `insolard genesis` is executed without using `insgocc`.
-	keepTemp := false
-	var cmdCompile = &cobra.Command{
-		Use:   "compile [flags] <file name to compile>",
-		Short: "Compile contract",
+	var cmdABI = &cobra.Command{
+		Use:   "abi [flags] <file name to process>",
+		Short: "Generate contract's ABI in JSON",
 		Run: func(cmd *cobra.Command, args []string) {
-			dir, err := os.Getwd()
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
 			if len(args) != 1 {
-				fmt.Println("compile command should be followed by exactly one file name to compile")
+				fmt.Println("abi command should be followed by exactly one file name to process")
 				os.Exit(1)
 			}
 			parsed, err := preprocessor.ParseFile(args[0])
 			if err != nil {
-				fmt.Println(err)
+				fmt.Println(errors.Wrap(err, "couldn't parse"))
 				os.Exit(1)
 			}
 
-			// make temporary dir
-			tmpDir, err := ioutil.TempDir("", "temp-")
+			err = parsed.WriteABI(output.writer)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(1)
 			}
+		},
+	}
+	cmdABI.Flags().VarP(output, "output", "o", "output file (use - for STDOUT)")
 
-			defer func() {
-				if keepTemp {
-					fmt.Printf("Temp directory: %s\n", tmpDir)
-				} else {
-					os.RemoveAll(tmpDir) // nolint: errcheck
+	var cmdCheck = &cobra.Command{
+		Use:   "check <file names to check>",
+		Short: "Check that contracts can be executed deterministically",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 0 {
+				fmt.Println("check command should be followed by file names to check")
+				os.Exit(1)
+			}
+			failed := false
+			for _, file := range args {
+				parsed, err := preprocessor.ParseFile(file)
+				if err != nil {
+					fmt.Println(errors.Wrap(err, "couldn't parse"))
+					failed = true
+					continue
 				}
-			}()
-
-			name := parsed.ContractName()
-
-			contract, err := os.Create(filepath.Join(tmpDir, name+".go"))
-			if err != nil {
-				fmt.Println(err)
+				err = parsed.Check()
+				if err != nil {
+					fmt.Println(err)
+					failed = true
+				}
+			}
+			if failed {
 				os.Exit(1)
 			}
-			defer contract.Close()
+		},
+	}
 
-			parsed.ChangePackageToMain()
-			err = parsed.Write(contract)
-			if err != nil {
-				fmt.Println(err)
+	// PLEASE NOTE that `insgocc compile` is in fact not used for compiling contracts by insolard.
+	// Instead contracts are compiled when `insolard genesis` is executed without using `insgocc`.
+	keepTemp := false
+	var cmdCompile = &cobra.Command{
+		Use:   "compile [flags] <file name to compile>",
+		Short: "Compile contract",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("compile command should be followed by exactly one file name to compile")
 				os.Exit(1)
 			}
-
-			wrapper, err := os.Create(filepath.Join(tmpDir, name+".wrapper.go"))
+			_, err := compile(args[0], outdir, keepTemp)
 			if err != nil {
 				fmt.Println(err)
 				os.Exit(1)
 			}
-			defer wrapper.Close()
+		},
+	}
+	// default value for string flags is displayed automatically
+	cmdCompile.Flags().StringVarP(&outdir, "output-dir", "o", ".", "output dir")
+	// default value for bool flags is not displayed automatically, thus it's done manually here
+	cmdCompile.Flags().BoolVarP(&keepTemp, "keep-temp", "k", false, "keep temp directory (default \"false\")")
 
-			err = parsed.WriteWrapper(wrapper)
-			if err != nil {
-				fmt.Println(err)
+	var prototype, configPath, url string
+	var cmdUpgrade = &cobra.Command{
+		Use:   "upgrade [flags] <file name to compile>",
+		Short: "Compile contract and upgrade its prototype to the new code",
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				fmt.Println("upgrade command should be followed by exactly one file name to compile")
 				os.Exit(1)
 			}
-
-			err = os.Chdir(tmpDir)
-			if err != nil {
-				fmt.Println(err)
+			if prototype == "" {
+				fmt.Println("prototype reference is required")
 				os.Exit(1)
 			}
-
-			out, err := exec.Command("go", "build", "-buildmode=plugin", "-o", path.Join(dir, outdir, name+".so")).CombinedOutput()
+			code, err := upgrade(args[0], prototype, configPath, url)
 			if err != nil {
-				fmt.Println(errors.Wrap(err, "can't build contract: "+string(out)))
+				fmt.Println(err)
 				os.Exit(1)
 			}
+			fmt.Println("New code reference:", code)
 		},
 	}
-	// default value for string flags is displayed automatically
-	cmdCompile.Flags().StringVarP(&outdir, "output-dir", "o", ".", "output dir")
-	// default value for bool flags is not displayed automatically, thus it's done manually here
-	cmdCompile.Flags().BoolVarP(&keepTemp, "keep-temp", "k", false, "keep temp directory (default \"false\")")
+	cmdUpgrade.Flags().StringVarP(&prototype, "prototype", "p", "", "reference to prototype to upgrade")
+	cmdUpgrade.Flags().StringVarP(&configPath, "config", "g", "config.json", "path to root member config with private key")
+	cmdUpgrade.Flags().StringVarP(&url, "url", "u", "http://localhost:19101/api", "api url")
 
 	var rootCmd = &cobra.Command{Use: "insgocc"}
-	rootCmd.AddCommand(cmdProxy, cmdWrapper, cmdImports, cmdCompile)
+	rootCmd.AddCommand(cmdProxy, cmdWrapper, cmdBuiltin, cmdImports, cmdABI, cmdCheck, cmdCompile, cmdUpgrade)
 	err := rootCmd.Execute()
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
 }
+
+// compile builds contract in file as plugin and returns path to it.
+func compile(file string, outdir string, keepTemp bool) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	parsed, err := preprocessor.ParseFile(file)
+	if err != nil {
+		return "", err
+	}
+	err = parsed.Check()
+	if err != nil {
+		return "", errors.Wrap(err, "contract check failed")
+	}
+
+	// make temporary dir
+	tmpDir, err := ioutil.TempDir("", "temp-")
+	if err != nil {
+		return "", err
+	}
+
+	defer func() {
+		if keepTemp {
+			fmt.Printf("Temp directory: %s\n", tmpDir)
+		} else {
+			os.RemoveAll(tmpDir) // nolint: errcheck
+		}
+	}()
+
+	name := parsed.ContractName()
+
+	contract, err := os.Create(filepath.Join(tmpDir, name+".go"))
+	if err != nil {
+		return "", err
+	}
+	defer contract.Close()
+
+	parsed.ChangePackageToMain()
+	err = parsed.Write(contract)
+	if err != nil {
+		return "", err
+	}
+
+	wrapper, err := os.Create(filepath.Join(tmpDir, name+".wrapper.go"))
+	if err != nil {
+		return "", err
+	}
+	defer wrapper.Close()
+
+	err = parsed.WriteWrapper(wrapper)
+	if err != nil {
+		return "", err
+	}
+
+	if !filepath.IsAbs(outdir) {
+		outdir = path.Join(dir, outdir)
+	}
+	plugin := path.Join(outdir, name+".so")
+	cmd := exec.Command("go", "build", "-buildmode=plugin", "-o", plugin)
+	cmd.Dir = tmpDir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", errors.Wrap(err, "can't build contract: "+string(out))
+	}
+	return plugin, nil
+}
+
+// upgrade compiles contract and sends request deploying it with its ABI as the new code of prototype.
+// Request is signed by root member, objects of the prototype are migrated lazily on their next call.
+func upgrade(file string, prototype string, configPath string, url string) (string, error) {
+	userCfg, err := requester.ReadUserConfigFromFile(configPath)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+	if userCfg.Caller == "" {
+		info, err := requester.Info(url)
+		if err != nil {
+			return "", errors.Wrap(err, "[ upgrade ]")
+		}
+		userCfg.Caller = info.RootMember
+	}
+
+	outdir, err := ioutil.TempDir("", "upgrade-")
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+	defer os.RemoveAll(outdir) // nolint: errcheck
+
+	plugin, err := compile(file, outdir, false)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+	code, err := ioutil.ReadFile(plugin)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+
+	parsed, err := preprocessor.ParseFile(file)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+	abi, err := json.Marshal(parsed.ABI())
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ] Can't marshal ABI")
+	}
+
+	reqCfg := &requester.RequestConfigJSON{
+		Method: "UpgradePrototype",
+		Params: []interface{}{prototype, code, insolar.MachineTypeGoPlugin, abi},
+	}
+	ctx := inslogger.ContextWithTrace(context.Background(), "insgoccUpgrade")
+	response, err := requester.Send(ctx, url, userCfg, reqCfg)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ]")
+	}
+
+	var res struct {
+		Result string `json:"result"`
+		Error  string `json:"error"`
+	}
+	err = json.Unmarshal(response, &res)
+	if err != nil {
+		return "", errors.Wrap(err, "[ upgrade ] Can't unmarshal response")
+	}
+	if res.Error != "" {
+		return "", errors.New("[ upgrade ] " + res.Error)
+	}
+	return res.Result, nil
+}
@@ -21,8 +21,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"reflect"
+	"time"
 
 	"github.com/insolar/insolar/api/requester"
 	"github.com/insolar/insolar/certificate"
@@ -30,7 +32,9 @@ import (
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/keystore"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/discovery"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/version"
@@ -95,13 +99,15 @@ var (
 	verbose            bool
 	sendUrls           string
 	rootAsCaller       bool
+	leavePulses        uint
+	seedListTTL        time.Duration
 	logLevelServer     insolar.LogLevel
 )
 
 func parseInputParams() {
 	var rootCmd = &cobra.Command{}
 	rootCmd.Flags().StringVarP(&cmd, "cmd", "c", "",
-		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs | get_info | create_member")
+		"available commands: default_config | random_ref | version | gen_keys | gen_certificate | send_request | gen_send_configs | get_info | create_member | leave | gen_seed_list")
 	rootCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "be verbose (default false)")
 	rootCmd.Flags().StringVarP(&output, "output", "o", defaultStdoutPath, "output file (use - for STDOUT)")
 	rootCmd.Flags().StringVarP(&sendUrls, "url", "u", defaultURL, "api url")
@@ -109,6 +115,8 @@ func parseInputParams() {
 	rootCmd.Flags().StringVarP(&configPath, "config", "g", "config.json", "path to configuration file")
 	rootCmd.Flags().StringVarP(&paramsPath, "params", "p", "", "path to params file (default params.json)")
 	rootCmd.Flags().BoolVarP(&rootAsCaller, "root_as_caller", "r", false, "use root member as caller")
+	rootCmd.Flags().UintVarP(&leavePulses, "leave_pulses", "l", 0, "number of pulses after which node leaves the network")
+	rootCmd.Flags().DurationVarP(&seedListTTL, "seed_list_ttl", "t", 7*24*time.Hour, "time after which the generated seed list expires")
 
 	var logLevelServerString string
 	rootCmd.Flags().StringVarP(&logLevelServerString, "log_level_server", "L", "", "server log level")
@@ -154,6 +162,10 @@ func main() {
 		getInfo(out)
 	case "create_member":
 		createMember(out)
+	case "leave":
+		leave(out)
+	case "gen_seed_list":
+		generateSeedList(out)
 	}
 }
 
@@ -322,3 +334,38 @@ func getInfo(out io.Writer) {
 	fmt.Fprintf(out, "NodeDomain : %s\n", info.NodeDomain)
 	fmt.Fprintf(out, "RootDomain : %s\n", info.RootDomain)
 }
+
+func leave(out io.Writer) {
+	resp, err := requester.Leave(sendUrls, uint32(leavePulses))
+	check("[ leave ]", err)
+	fmt.Fprintf(out, "Node leaves the network at pulse %d\n", resp.ETA)
+}
+
+// generateSeedList signs the seed list from the params file with the discovery node keys from the config file.
+// The result can be served as a file or by any http server and set as SeedList in the discovery configuration.
+func generateSeedList(out io.Writer) {
+	data, err := ioutil.ReadFile(paramsPath)
+	check("[ generateSeedList ] failed to read seed list", err)
+	list := &discovery.SeedList{}
+	err = json.Unmarshal(data, list)
+	check("[ generateSeedList ] failed to parse seed list", err)
+
+	signer, err := insolar.NewReferenceFromBase58(list.Signer)
+	check("[ generateSeedList ] invalid signer reference", err)
+	ks, err := keystore.NewKeyStore(configPath)
+	check("[ generateSeedList ] failed to load keys", err)
+	key, err := ks.GetPrivateKey("")
+	check("[ generateSeedList ] failed to get private key", err)
+
+	if list.Version == 0 {
+		// seconds grow between runs, so lists generated later replace earlier ones
+		list.Version = uint64(time.Now().Unix())
+	}
+	list.Expire = time.Now().Add(seedListTTL).UTC()
+	err = list.Sign(*signer, key, platformpolicy.NewPlatformCryptographyScheme())
+	check("[ generateSeedList ] failed to sign seed list", err)
+
+	result, err := json.MarshalIndent(list, "", "    ")
+	check("[ generateSeedList ] failed to marshal seed list", err)
+	writeToOutput(out, string(result)+"\n")
+}
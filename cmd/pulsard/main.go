@@ -43,6 +43,7 @@ import (
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/pulsar/pulsarapi"
 	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
 	"github.com/insolar/insolar/version"
 )
@@ -106,9 +107,24 @@ func main() {
 	go server.StartServer(ctx)
 	pulseTicker, refreshTicker := runPulsar(ctx, server, cfgHolder.Configuration.Pulsar)
 
+	var apiServer *pulsarapi.Server
+	if len(cfgHolder.Configuration.Pulsar.APIListenerAddress) != 0 {
+		apiServer = pulsarapi.NewServer(cfgHolder.Configuration.Pulsar.APIListenerAddress, storage, server)
+		err = apiServer.Start(ctx)
+		if err != nil {
+			inslog.Fatal(err)
+		}
+	}
+
 	defer func() {
 		pulseTicker.Stop()
 		refreshTicker.Stop()
+		if apiServer != nil {
+			err = apiServer.Stop(ctx)
+			if err != nil {
+				inslog.Error(err)
+			}
+		}
 		err = storage.Close()
 		if err != nil {
 			inslog.Error(err)
@@ -166,6 +182,18 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 		inslogger.FromContext(ctx).Fatal(err)
 		panic(err)
 	}
+	// pulsars set could be changed after the config was written
+	neighbours, err := storage.GetNeighbours()
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
+	if neighbours != nil {
+		cfg.Pulsar.Neighbours = neighbours
+	}
+	entropyGenerator, err := entropygenerator.NewEntropyGenerator(cfg.Pulsar.EntropyGenerator, cryptographyScheme, storage)
+	if err != nil {
+		inslogger.FromContext(ctx).Fatal(err)
+	}
 	switcher := &pulsar.StateSwitcherImpl{}
 	server, err := pulsar.NewPulsar(
 		cfg.Pulsar,
@@ -175,7 +203,7 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 		pulseDistributor,
 		storage,
 		&pulsar.RPCClientWrapperFactoryImpl{},
-		&entropygenerator.StandardEntropyGenerator{},
+		entropyGenerator,
 		switcher,
 		net.Listen,
 	)
@@ -191,10 +219,14 @@ func initPulsar(ctx context.Context, cfg configuration.Configuration) (*componen
 
 func runPulsar(ctx context.Context, server *pulsar.Pulsar, cfg configuration.Pulsar) (pulseTicker *time.Ticker, refreshTicker *time.Ticker) {
 	server.CheckConnectionsToPulsars(ctx)
+	err := server.CatchUp(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn(err)
+	}
 
 	nextPulseNumber := insolar.CalculatePulseNumber(time.Now())
 
-	err := server.StartConsensusProcess(ctx, nextPulseNumber)
+	err = server.StartConsensusProcess(ctx, nextPulseNumber)
 	if err != nil {
 		inslogger.FromContext(ctx).Fatal(err)
 		panic(err)
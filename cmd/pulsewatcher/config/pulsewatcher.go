@@ -26,7 +26,9 @@ import (
 )
 
 type Config struct {
-	Nodes    []string
+	Nodes []string
+	// Pulsars are addresses of pulsars' API
+	Pulsars  []string
 	Interval time.Duration
 	Timeout  time.Duration
 }
@@ -221,6 +221,125 @@ func collectNodesStatuses(conf *pulsewatcher.Config) ([][]string, bool) {
 	return results, ready
 }
 
+func collectPulsarsStatuses(conf *pulsewatcher.Config) [][]string {
+	results := make([][]string, len(conf.Pulsars))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(conf.Pulsars))
+	for i, url := range conf.Pulsars {
+		go func(url string, i int) {
+			defer wg.Done()
+			res, err := client.Get("http://" + url + "/api/status")
+			if err != nil {
+				results[i] = []string{url, "", "", "", "", "", "", err.Error()}
+				return
+			}
+			defer res.Body.Close()
+			var out struct {
+				State                 string
+				ProcessingPulseNumber uint32
+				LastPulseNumber       uint32
+				Neighbours            []struct {
+					Connected bool
+				}
+				Rounds []struct {
+					Outcome string
+					Error   string
+				}
+				FailedRounds map[string]int
+			}
+			err = json.NewDecoder(res.Body).Decode(&out)
+			if err != nil {
+				results[i] = []string{url, "", "", "", "", "", "", err.Error()}
+				return
+			}
+
+			connected := 0
+			for _, neighbour := range out.Neighbours {
+				if neighbour.Connected {
+					connected++
+				}
+			}
+			failed := 0
+			for _, count := range out.FailedRounds {
+				failed += count
+			}
+			lastRound, lastError := "", ""
+			if len(out.Rounds) > 0 {
+				lastRound = out.Rounds[len(out.Rounds)-1].Outcome
+				lastError = out.Rounds[len(out.Rounds)-1].Error
+			}
+			results[i] = []string{
+				url,
+				out.State,
+				strconv.Itoa(int(out.LastPulseNumber)),
+				strconv.Itoa(int(out.ProcessingPulseNumber)),
+				fmt.Sprintf("%d/%d", connected, len(out.Neighbours)),
+				strconv.Itoa(failed),
+				lastRound,
+				lastError,
+			}
+		}(url, i)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// displayPulsarsTable prints table of pulsars under the table of nodes,
+// buffer keeps both of them for erasing on the next iteration
+func displayPulsarsTable(results [][]string, buffer *bytes.Buffer) {
+	pulsarsBuffer := &bytes.Buffer{}
+	table := tablewriter.NewWriter(pulsarsBuffer)
+	table.SetHeader([]string{
+		"Pulsar URL",
+		"State",
+		"Last Pulse",
+		"Processing Pulse",
+		"Connected",
+		"Failed Rounds",
+		"Last Round",
+		"Error",
+	})
+	table.SetBorder(false)
+	table.AppendBulk(results)
+	table.Render()
+	fmt.Print(pulsarsBuffer)
+	buffer.Write(pulsarsBuffer.Bytes())
+}
+
+func displayPulsarsJSON(results [][]string) {
+	type DocumentItem struct {
+		URL                   string
+		State                 string
+		LastPulseNumber       int64
+		ProcessingPulseNumber int64
+		Connected             string
+		FailedRounds          int64
+		LastRound             string
+		Error                 string
+	}
+
+	doc := make([]DocumentItem, len(results))
+	for i, res := range results {
+		doc[i].URL = res[0]
+		doc[i].State = res[1]
+		doc[i].LastPulseNumber = parseInt64(res[2])
+		doc[i].ProcessingPulseNumber = parseInt64(res[3])
+		doc[i].Connected = res[4]
+		doc[i].FailedRounds = parseInt64(res[5])
+		doc[i].LastRound = res[6]
+		doc[i].Error = res[7]
+	}
+
+	jsonDoc, err := json.MarshalIndent(doc, "", "    ")
+	if err != nil {
+		panic(err) // should never happen
+	}
+	fmt.Print(string(jsonDoc))
+	fmt.Print("\n\n")
+}
+
 func main() {
 	var configFile string
 	var useJSONFormat bool
@@ -234,7 +353,7 @@ func main() {
 	if err != nil {
 		log.Fatal(errors.Wrap(err, "couldn't load config file"))
 	}
-	if len(conf.Nodes) == 0 {
+	if len(conf.Nodes) == 0 && len(conf.Pulsars) == 0 {
 		log.Fatal("couldn't find any nodes in config file")
 	}
 	if conf.Interval == 0 {
@@ -251,10 +370,17 @@ func main() {
 
 	for {
 		results, ready := collectNodesStatuses(conf)
+		pulsarsResults := collectPulsarsStatuses(conf)
 		if useJSONFormat {
 			displayResultsJSON(results, ready, buffer)
+			if len(pulsarsResults) > 0 {
+				displayPulsarsJSON(pulsarsResults)
+			}
 		} else {
 			displayResultsTable(results, ready, buffer)
+			if len(pulsarsResults) > 0 {
+				displayPulsarsTable(pulsarsResults, buffer)
+			}
 		}
 
 		if singleOutput {
@@ -39,6 +39,7 @@ type Configuration struct {
 	KeysPath        string
 	CertificatePath string
 	Tracer          Tracer
+	MessageBus      MessageBus
 }
 
 // Holder provides methods to manage configuration
@@ -62,6 +63,7 @@ func NewConfiguration() Configuration {
 		KeysPath:        "./",
 		CertificatePath: "",
 		Tracer:          NewTracer(),
+		MessageBus:      NewMessageBus(),
 	}
 
 	return cfg
@@ -16,6 +16,21 @@
 
 package configuration
 
+import (
+	"time"
+)
+
+// ConnectionPool holds limits of transport connection pool
+type ConnectionPool struct {
+	// MaxConnections is a maximum number of open connections, least recently used connection is evicted above it.
+	// Zero means no limit.
+	MaxConnections int
+	// IdleTimeout is a time after which unused connection is closed. Zero disables idle eviction.
+	IdleTimeout time.Duration
+	// KeepalivePeriod is a period of keepalive probes of open connections. Zero leaves OS default.
+	KeepalivePeriod time.Duration
+}
+
 // Transport holds transport protocol configuration for HostNetwork
 type Transport struct {
 	// protocol type
@@ -27,24 +42,63 @@ type Transport struct {
 	// if not empty - this should be public address of instance (to connect from the "other" side to)
 	// conflicts in BehindNAT
 	FixedPublicAddress string
+	// Pool holds limits of connection pool of connection-oriented protocols
+	Pool ConnectionPool
+}
+
+// Relay holds relay mode configuration
+type Relay struct {
+	// Address of relay node to register on if node is not reachable from outside (behind NAT without public address).
+	// Node publishes relay address as its own public address and receives all packets through relay,
+	// consensus packets are forwarded by relay node as well.
+	// Relay nodes are not advertised to the network, so the address is set by the operator of the node,
+	// it should be the address of a node with IsRelay set. Automatic relay selection is not supported.
+	Address string
+	// MaxClients is a maximum number of nodes relayed by this node. Zero means no limit.
+	MaxClients int
+	// MaxPacketsPerSecond is a maximum number of packets relayed per second for a single client. Zero means no limit.
+	MaxPacketsPerSecond int
+}
+
+// Discovery holds sources of discovery node addresses used in addition to the certificate
+type Discovery struct {
+	// DNSDomain is a domain to look up SRV records _insolar._tcp.<DNSDomain>. Empty disables DNS discovery.
+	DNSDomain string
+	// SeedList is a path or http(s) URL of the signed seed list. Empty disables seed list.
+	SeedList string
+	// RefreshInterval is a period of discovery addresses refresh. Zero disables refresh.
+	RefreshInterval time.Duration
+	// Timeout limits a request of a single discovery source. Zero disables the limit.
+	Timeout time.Duration
 }
 
 // HostNetwork holds configuration for HostNetwork
 type HostNetwork struct {
 	Transport           Transport
 	IsRelay             bool  // set if node must be relay explicit
+	Relay               Relay // relay limits if IsRelay is set, relay address if node is behind NAT
 	InfinityBootstrap   bool  // set true for infinity tries to bootstrap
 	MinTimeout          int   // bootstrap timeout min
 	MaxTimeout          int   // bootstrap timeout max
 	TimeoutMult         int   // bootstrap timout multiplier
 	SignMessages        bool  // signing a messages if true
 	HandshakeSessionTTL int32 // ms
+	Discovery           Discovery
 }
 
 // NewHostNetwork creates new default HostNetwork configuration
 func NewHostNetwork() HostNetwork {
 	// IP address should not be 0.0.0.0!!!
-	transport := Transport{Protocol: "TCP", Address: "127.0.0.1:0", BehindNAT: false}
+	transport := Transport{
+		Protocol:  "TCP",
+		Address:   "127.0.0.1:0",
+		BehindNAT: false,
+		Pool: ConnectionPool{
+			MaxConnections:  1000,
+			IdleTimeout:     10 * time.Minute,
+			KeepalivePeriod: 30 * time.Second,
+		},
+	}
 
 	return HostNetwork{
 		Transport:           transport,
@@ -55,5 +109,13 @@ func NewHostNetwork() HostNetwork {
 		InfinityBootstrap:   false,
 		SignMessages:        false,
 		HandshakeSessionTTL: 5000,
+		Relay: Relay{
+			MaxClients:          100,
+			MaxPacketsPerSecond: 1000,
+		},
+		Discovery: Discovery{
+			RefreshInterval: time.Minute,
+			Timeout:         10 * time.Second,
+		},
 	}
 }
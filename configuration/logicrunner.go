@@ -16,6 +16,10 @@
 
 package configuration
 
+import (
+	"time"
+)
+
 // LogicRunner configuration
 type LogicRunner struct {
 	// RPCListen - address logic runner binds RPC API to
@@ -27,6 +31,20 @@ type LogicRunner struct {
 	BuiltIn *BuiltIn
 	// GoPlugin - configuration of executor based on Go plugins
 	GoPlugin *GoPlugin
+	// WASM - configuration of executor of contracts compiled to WebAssembly
+	WASM *WASM
+	// Limits - resource limits of a single contract call
+	Limits ContractLimits
+}
+
+// ContractLimits - limits of resources a single contract call can consume, zero means no limit
+type ContractLimits struct {
+	// Upcalls - number of calls from contract to logic runner (RouteCall, SaveAsChild...)
+	Upcalls uint64
+	// StateBytes - size of object state written by the call
+	StateBytes uint64
+	// CPUTime - time spent in contract code excluding upcalls
+	CPUTime time.Duration
 }
 
 // BuiltIn configuration, no options at the moment
@@ -39,6 +57,27 @@ type GoPlugin struct {
 	// RunnerProtocol - protocol (network) of above address,
 	// e.g. "tcp", "unix"... see `net.Dial`
 	RunnerProtocol string
+	// RunnerPoolSize - number of executors calls are balanced across, executor N listens to
+	// RunnerListen with port increased by N (or with ".N" suffix for unix sockets)
+	RunnerPoolSize int
+	// RunnerPath - path to insgorund binary, if set executors are started and restarted
+	// by the node itself, otherwise they are expected to be started externally
+	RunnerPath string
+	// RunnerHealthCheckInterval - how often executors are pinged, zero disables health checks
+	RunnerHealthCheckInterval time.Duration
+	// RunnerCallTimeout - call is aborted and executor is recycled if the call isn't finished in time,
+	// zero means no timeout
+	RunnerCallTimeout time.Duration
+	// RunnerWarmUp - number of recently called codes loaded into started executor in advance
+	RunnerWarmUp int
+}
+
+// WASM configuration
+type WASM struct {
+	// MaxMemoryPages - limit of contract memory in 64KiB pages
+	MaxMemoryPages int
+	// GasLimit - number of instructions a single contract call can execute
+	GasLimit uint64
 }
 
 // NewLogicRunner - returns default config of the logic runner
@@ -48,8 +87,16 @@ func NewLogicRunner() LogicRunner {
 		RPCProtocol: "tcp",
 		BuiltIn:     &BuiltIn{},
 		GoPlugin: &GoPlugin{
-			RunnerListen:   "127.0.0.1:7777",
-			RunnerProtocol: "tcp",
+			RunnerListen:              "127.0.0.1:7777",
+			RunnerProtocol:            "tcp",
+			RunnerPoolSize:            1,
+			RunnerHealthCheckInterval: 10 * time.Second,
+			RunnerCallTimeout:         10 * time.Minute,
+			RunnerWarmUp:              10,
+		},
+		WASM: &WASM{
+			MaxMemoryPages: 256,
+			GasLimit:       100000000,
 		},
 	}
 }
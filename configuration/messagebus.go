@@ -0,0 +1,78 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package configuration
+
+import "time"
+
+// Lane holds limits of one priority class of incoming messages.
+type Lane struct {
+	// Workers is a maximum number of messages handled concurrently. Zero means no limit.
+	Workers int
+	// QueueSize is a maximum number of messages waiting for a free worker.
+	// Messages above this limit are rejected.
+	QueueSize int
+	// WaitTimeout is a maximum time a queued message waits for a free worker, it's rejected then.
+	// Zero means waiting until the message context is done.
+	WaitTimeout time.Duration
+	// Types lists message types handled in the lane, e.g. "TypeGetChildren".
+	// Types not listed in any lane are handled in the normal lane.
+	Types []string
+}
+
+// MessageBus holds configuration for MessageBus.
+type MessageBus struct {
+	// CriticalLane is a lane for latency-critical messages such as contract execution.
+	CriticalLane Lane
+	// NormalLane is a lane for all messages not assigned to other lanes.
+	NormalLane Lane
+	// BulkLane is a lane for heavy ledger traffic such as heavy sync and children scans.
+	BulkLane Lane
+}
+
+// NewMessageBus creates new default MessageBus configuration.
+func NewMessageBus() MessageBus {
+	return MessageBus{
+		CriticalLane: Lane{
+			Types: []string{
+				"TypeCallMethod",
+				"TypeCallConstructor",
+				"TypeReturnResults",
+				"TypeExecutorResults",
+				"TypePendingFinished",
+				"TypeStillExecuting",
+			},
+		},
+		NormalLane: Lane{
+			Workers:     100,
+			QueueSize:   1000,
+			WaitTimeout: 10 * time.Second,
+			Types:       []string{},
+		},
+		BulkLane: Lane{
+			Workers:     10,
+			QueueSize:   100,
+			WaitTimeout: 30 * time.Second,
+			Types: []string{
+				"TypeHeavyStartStop",
+				"TypeHeavyPayload",
+				"TypeGetChildren",
+				"TypeHotRecords",
+				"TypeJetDrop",
+			},
+		},
+	}
+}
@@ -30,7 +30,9 @@ func (ct ConnectionType) String() string {
 type Pulsar struct {
 	ConnectionType      ConnectionType
 	MainListenerAddress string
-	Storage             Storage
+	// APIListenerAddress is an address of read-only HTTP API with pulse history, empty value disables API
+	APIListenerAddress string
+	Storage            Storage
 
 	PulseTime                      int32 // ms
 	ReceivingSignTimeout           int32 // ms
@@ -42,6 +44,9 @@ type Pulsar struct {
 
 	NumberDelta uint32
 
+	// EntropyGenerator is a type of entropy generator: "standard" or "hashchain"
+	EntropyGenerator string
+
 	DistributionTransport Transport
 	PulseDistributor      PulseDistributor
 }
@@ -64,6 +69,7 @@ type PulsarNodeAddress struct {
 func NewPulsar() Pulsar {
 	return Pulsar{
 		MainListenerAddress: "0.0.0.0:18090",
+		APIListenerAddress:  "0.0.0.0:18092",
 
 		ConnectionType: TCP,
 
@@ -75,7 +81,8 @@ func NewPulsar() Pulsar {
 		Neighbours: []PulsarNodeAddress{},
 		Storage:    Storage{DataDirectory: "./.artifacts/pulsar_data"},
 
-		NumberDelta: 10,
+		NumberDelta:      10,
+		EntropyGenerator: "standard",
 		DistributionTransport: Transport{
 			Protocol:  "TCP",
 			Address:   "0.0.0.0:18091",
@@ -20,12 +20,43 @@ package configuration
 type ServiceNetwork struct {
 	Skip           int // magic number that indicates what delta after last ignored pulse we should wait
 	CacheDirectory string
+	// ViolationBanPulses is the number of pulses a node with proven consensus violation is kept out of active list.
+	// Violation is proven when consensus approves the blame, the node is excluded since the next pulse.
+	// Zero disables exclusion, violations are only reported.
+	ViolationBanPulses int
+	// PhaseTimeouts configures timeouts of consensus phases.
+	PhaseTimeouts PhaseTimeouts
+	// RequireEntropyProofs rejects pulses without entropy proofs. It should be enabled
+	// when all pulsars of the network produce proofs, i.e. after rolling upgrade.
+	RequireEntropyProofs bool
+}
+
+// PhaseTimeouts is configuration of consensus phase timeouts.
+// By default each phase lasts for a fixed fraction of pulse duration. In adaptive mode
+// the fraction is derived from packet delays observed in recent rounds and is kept
+// between MinScale and MaxScale of its default value.
+type PhaseTimeouts struct {
+	Adaptive bool
+	MinScale float64
+	MaxScale float64
+	// Margin is the multiplier applied to observed packet delay to get phase timeout.
+	Margin float64
+	// Window is the number of recent rounds used to estimate packet delay.
+	Window int
 }
 
 // NewServiceNetwork creates a new ServiceNetwork configuration.
 func NewServiceNetwork() ServiceNetwork {
 	return ServiceNetwork{
-		Skip:           10,
-		CacheDirectory: "network_cache",
+		Skip:               10,
+		CacheDirectory:     "network_cache",
+		ViolationBanPulses: 100,
+		PhaseTimeouts: PhaseTimeouts{
+			Adaptive: false,
+			MinScale: 0.5,
+			MaxScale: 2,
+			Margin:   2,
+			Window:   10,
+		},
 	}
 }
@@ -60,6 +60,10 @@ import (
 var (
 	// TagPhase is a tag for consensus metrics.
 	TagPhase = insmetrics.MustTagKey("phase")
+	// TagViolation is a tag for consensus violation metrics.
+	TagViolation = insmetrics.MustTagKey("violation")
+	// TagPeer is a tag for consensus metrics collected per peer node.
+	TagPeer = insmetrics.MustTagKey("peer")
 )
 
 var (
@@ -83,6 +87,20 @@ var (
 	Phase3Exec = stats.Int64("consensus/phase3/exec", "Phase 3 execution counter", stats.UnitDimensionless)
 	// ActiveNodes active nodes count after consensus.
 	ActiveNodes = stats.Int64("consensus/activenodes/count", "Active nodes count after consensus", stats.UnitDimensionless)
+	// ViolationsDetected consensus violations detected by this node.
+	ViolationsDetected = stats.Int64("consensus/violations/detected", "Consensus violations detected by this node", stats.UnitDimensionless)
+	// ExcludedNodes nodes excluded from active list for proven violations.
+	ExcludedNodes = stats.Int64("consensus/violations/excluded", "Nodes excluded from active list for proven violations", stats.UnitDimensionless)
+	// PhaseDuration consensus phase duration.
+	PhaseDuration = stats.Float64("consensus/phase/duration", "Consensus phase duration", stats.UnitMilliseconds)
+	// PhaseTimeout consensus phase timeout.
+	PhaseTimeout = stats.Float64("consensus/phase/timeout", "Consensus phase timeout", stats.UnitMilliseconds)
+	// PacketDelay delay between consensus phase start and packet receipt.
+	PacketDelay = stats.Float64("consensus/packets/delay", "Delay between consensus phase start and packet receipt", stats.UnitMilliseconds)
+	// PacketsMissing consensus packets not received from peer before phase timeout.
+	PacketsMissing = stats.Int64("consensus/packets/missing", "Consensus packets not received from peer before phase timeout", stats.UnitDimensionless)
+	// PacketsLate consensus packets received from peer after phase had finished.
+	PacketsLate = stats.Int64("consensus/packets/late", "Consensus packets received from peer after phase had finished", stats.UnitDimensionless)
 )
 
 func init() {
@@ -150,6 +168,55 @@ func init() {
 			Measure:     ActiveNodes,
 			Aggregation: view.LastValue(),
 		},
+		&view.View{
+			Name:        ViolationsDetected.Name(),
+			Description: ViolationsDetected.Description(),
+			Measure:     ViolationsDetected,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{TagViolation},
+		},
+		&view.View{
+			Name:        ExcludedNodes.Name(),
+			Description: ExcludedNodes.Description(),
+			Measure:     ExcludedNodes,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{TagViolation},
+		},
+		&view.View{
+			Name:        PhaseDuration.Name(),
+			Description: PhaseDuration.Description(),
+			Measure:     PhaseDuration,
+			Aggregation: view.Distribution(10, 25, 50, 100, 250, 500, 1000, 2000, 4000, 8000),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PhaseTimeout.Name(),
+			Description: PhaseTimeout.Description(),
+			Measure:     PhaseTimeout,
+			Aggregation: view.LastValue(),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PacketDelay.Name(),
+			Description: PacketDelay.Description(),
+			Measure:     PacketDelay,
+			Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2000, 4000),
+			TagKeys:     commontags,
+		},
+		&view.View{
+			Name:        PacketsMissing.Name(),
+			Description: PacketsMissing.Description(),
+			Measure:     PacketsMissing,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{TagPhase, TagPeer},
+		},
+		&view.View{
+			Name:        PacketsLate.Name(),
+			Description: PacketsLate.Description(),
+			Measure:     PacketsLate,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{TagPhase, TagPeer},
+		},
 	)
 	if err != nil {
 		panic(err)
@@ -129,10 +129,29 @@ func (cpa *CapabilityPoolingAndActivation) Type() ClaimType {
 	return TypeCapabilityPollingAndActivation
 }
 
+// Violation types carried by NodeViolationBlame.
+const (
+	// ViolationEquivocation means the node signed two different state hashes for the same pulse.
+	ViolationEquivocation = uint8(iota + 1)
+	// ViolationInvalidProof means the node signed a pulse proof for a pulse with forged entropy.
+	ViolationInvalidProof
+	// ViolationClaimMismatch means the node signed a claim that does not match its certificate.
+	ViolationClaimMismatch
+)
+
 // NodeViolationBlame is a type 2.
+// Evidence fields are signed by the blamed node, so any node can verify the blame on its own.
 type NodeViolationBlame struct {
 	BlameNodeID   uint32
 	TypeViolation uint8
+
+	// PulseNumber and Entropy describe the pulse the evidence was signed for.
+	PulseNumber insolar.PulseNumber
+	Entropy     insolar.Entropy
+	// Proofs contain conflicting (ViolationEquivocation) or forged (ViolationInvalidProof, first one only) pulse proofs.
+	Proofs [2]NodePulseProof
+	// Claim contains the signed claim that does not match certificate (ViolationClaimMismatch).
+	Claim NodeJoinClaim
 }
 
 func (nvb *NodeViolationBlame) Clone() ReferendumClaim {
@@ -134,12 +134,34 @@ func (nvb *NodeViolationBlame) Deserialize(data io.Reader) error {
 		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read TypeViolation")
 	}
 
+	err = binary.Read(data, defaultByteOrder, &nvb.PulseNumber)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read PulseNumber")
+	}
+
+	err = binary.Read(data, defaultByteOrder, &nvb.Entropy)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read Entropy")
+	}
+
+	for i := range nvb.Proofs {
+		err = nvb.Proofs[i].Deserialize(data)
+		if err != nil {
+			return errors.Wrapf(err, "[ NodeViolationBlame.Deserialize ] Can't read Proofs[%d]", i)
+		}
+	}
+
+	err = nvb.Claim.Deserialize(data)
+	if err != nil {
+		return errors.Wrap(err, "[ NodeViolationBlame.Deserialize ] Can't read Claim")
+	}
+
 	return nil
 }
 
 // Serialize implements interface method
 func (nvb *NodeViolationBlame) Serialize() ([]byte, error) {
-	result := allocateBuffer(64)
+	result := allocateBuffer(1024)
 	err := binary.Write(result, defaultByteOrder, nvb.BlameNodeID)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write BlameNodeID")
@@ -150,6 +172,36 @@ func (nvb *NodeViolationBlame) Serialize() ([]byte, error) {
 		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write TypeViolation")
 	}
 
+	err = binary.Write(result, defaultByteOrder, nvb.PulseNumber)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write PulseNumber")
+	}
+
+	err = binary.Write(result, defaultByteOrder, nvb.Entropy)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write Entropy")
+	}
+
+	for i := range nvb.Proofs {
+		proofRaw, err := nvb.Proofs[i].Serialize()
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ NodeViolationBlame.Serialize ] Can't serialize Proofs[%d]", i)
+		}
+		_, err = result.Write(proofRaw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ NodeViolationBlame.Serialize ] Can't write Proofs[%d]", i)
+		}
+	}
+
+	claimRaw, err := nvb.Claim.Serialize()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't serialize Claim")
+	}
+	_, err = result.Write(claimRaw)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NodeViolationBlame.Serialize ] Can't write Claim")
+	}
+
 	return result.Bytes(), nil
 }
 
@@ -83,7 +83,17 @@ func TestCapabilityPoolingAndActivation(t *testing.T) {
 
 func makeNodeViolationBlame() *NodeViolationBlame {
 	nodeViolationBlame := &NodeViolationBlame{}
-	nodeViolationBlame.TypeViolation = uint8(4)
+	nodeViolationBlame.BlameNodeID = uint32(33)
+	nodeViolationBlame.TypeViolation = ViolationEquivocation
+	nodeViolationBlame.PulseNumber = insolar.PulseNumber(128)
+	nodeViolationBlame.Entropy = randomArray64()
+	for i := range nodeViolationBlame.Proofs {
+		nodeViolationBlame.Proofs[i] = NodePulseProof{NodeSignature: randomArray66(), NodeStateHash: randomArray64()}
+	}
+	nodeViolationBlame.Claim.ShortNodeID = insolar.ShortNodeID(33)
+	nodeViolationBlame.Claim.NodeRef = testutils.RandomRef()
+	nodeViolationBlame.Claim.NodePK = randomArray66()
+	nodeViolationBlame.Claim.Signature = randomArray66()
 
 	return nodeViolationBlame
 }
@@ -109,6 +119,10 @@ func makeNodeJoinClaim(withSignature bool) *NodeJoinClaim {
 	return nodeJoinClaim
 }
 
+func TestNodeViolationBlame_BadData(t *testing.T) {
+	checkBadDataSerializationDeserialization(t, makeNodeViolationBlame(), "unexpected EOF")
+}
+
 func TestNodeJoinClaim(t *testing.T) {
 	checkSerializationDeserialization(t, makeNodeJoinClaim(true))
 }
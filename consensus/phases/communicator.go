@@ -53,6 +53,7 @@ package phases
 import (
 	"context"
 	"sync/atomic"
+	"time"
 
 	"github.com/pkg/errors"
 	"go.opencensus.io/stats"
@@ -113,6 +114,7 @@ type ConsensusCommunicator struct {
 	PulseHandler     network.PulseHandler        `inject:""`
 	Cryptography     insolar.CryptographyService `inject:""`
 	NodeKeeper       network.NodeKeeper          `inject:""`
+	PhaseTimings     network.PhaseTimings        `inject:""`
 
 	phase1result chan phase1Result
 	phase2result chan phase2Result
@@ -147,6 +149,38 @@ func (nc *ConsensusCommunicator) setPulseNumber(new insolar.PulseNumber) bool {
 	return old < new && atomic.CompareAndSwapUint32(&nc.currentPulseNumber, uint32(old), uint32(new))
 }
 
+// packetReceived records packet delay or counts the packet as late if it belongs to previous pulse.
+// Returns false if the packet should be filtered.
+func (nc *ConsensusCommunicator) packetReceived(ctx context.Context, phase string, sender insolar.Reference,
+	pulseNumber insolar.PulseNumber, start time.Time) bool {
+
+	currentPulse := nc.getPulseNumber()
+	if pulseNumber != currentPulse {
+		inslogger.FromContext(ctx).Debugf("Filtered %s packet, packet pulse %d != %d (current pulse)",
+			phase, pulseNumber, currentPulse)
+		if pulseNumber < currentPulse && !sender.IsEmpty() {
+			nc.PhaseTimings.PacketLate(ctx, phase, sender)
+		}
+		return false
+	}
+	if !sender.IsEmpty() {
+		nc.PhaseTimings.PacketReceived(ctx, phase, sender, time.Since(start))
+	}
+	return true
+}
+
+// packetsMissing counts participants that did not send a packet during the phase.
+func (nc *ConsensusCommunicator) packetsMissing(ctx context.Context, phase string, participants []insolar.NetworkNode,
+	received func(ref insolar.Reference) bool) {
+
+	origin := nc.ConsensusNetwork.GetNodeID()
+	for _, p := range participants {
+		if !p.ID().Equal(origin) && !received(p.ID()) {
+			nc.PhaseTimings.PacketMissing(ctx, phase, p.ID())
+		}
+	}
+}
+
 func (nc *ConsensusCommunicator) sendRequestToNodes(ctx context.Context, participants []insolar.NetworkNode, packet packets.ConsensusPacket) {
 	for _, node := range participants {
 		if node.ID().Equal(nc.NodeKeeper.GetOrigin().ID()) {
@@ -203,7 +237,8 @@ func (nc *ConsensusCommunicator) sendRequestToNodesWithOrigin(ctx context.Contex
 	return nil
 }
 
-func (nc *ConsensusCommunicator) generatePhase2Response(ctx context.Context, origReq, req *packets.Phase2Packet,
+// GeneratePhase2Response builds an answer to MissingNode requests contained in req using data from consensus state.
+func GeneratePhase2Response(ctx context.Context, origReq, req *packets.Phase2Packet,
 	state *ConsensusState) (*packets.Phase2Packet, error) {
 
 	logger := inslogger.FromContext(ctx)
@@ -278,6 +313,7 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 	result := make(map[insolar.Reference]*packets.Phase1Packet, len(participants))
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
 	nc.setPulseNumber(packet.GetPulse().PulseNumber)
+	start := time.Now()
 
 	var request *packets.Phase1Packet
 
@@ -314,10 +350,7 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 		select {
 		case res := <-nc.phase1result:
 			logger.Debugf("Got phase1 request from %s", res.id)
-			currentPulse := nc.getPulseNumber()
-			if res.packet.GetPulseNumber() != currentPulse {
-				logger.Debugf("Filtered phase1 packet, packet pulse %d != %d (current pulse)",
-					res.packet.GetPulseNumber(), currentPulse)
+			if !nc.packetReceived(ctx, phase1Name, res.id, res.packet.GetPulseNumber(), start) {
 				continue
 			}
 
@@ -354,6 +387,10 @@ func (nc *ConsensusCommunicator) ExchangePhase1(
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.packetsMissing(ctx, phase1Name, participants, func(ref insolar.Reference) bool {
+				_, ok := result[ref]
+				return ok
+			})
 			return result, nil
 		}
 	}
@@ -370,6 +407,7 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, state *Cons
 	result := make(map[insolar.Reference]*packets.Phase2Packet, len(participants))
 
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
+	start := time.Now()
 
 	nc.sendRequestToNodes(ctx, participants, packet)
 
@@ -386,10 +424,7 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, state *Cons
 		select {
 		case res := <-nc.phase2result:
 			logger.Debugf("Got phase2 request from %s", res.id)
-			currentPulse := nc.getPulseNumber()
-			if res.packet.GetPulseNumber() != currentPulse {
-				logger.Debugf("Filtered phase2 packet, packet pulse %d != %d (current pulse)",
-					res.packet.GetPulseNumber(), currentPulse)
+			if !nc.packetReceived(ctx, phase2Name, res.id, res.packet.GetPulseNumber(), start) {
 				continue
 			}
 
@@ -398,7 +433,7 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, state *Cons
 				// send response
 				response := packet
 				if res.packet.ContainsRequests() {
-					response, err = nc.generatePhase2Response(ctx, packet, res.packet, state)
+					response, err = GeneratePhase2Response(ctx, packet, res.packet, state)
 					if err != nil {
 						logger.Warnf("Failed to generate phase 2 response packet: %s", err.Error())
 						continue
@@ -417,6 +452,10 @@ func (nc *ConsensusCommunicator) ExchangePhase2(ctx context.Context, state *Cons
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.packetsMissing(ctx, phase2Name, participants, func(ref insolar.Reference) bool {
+				_, ok := result[ref]
+				return ok
+			})
 			return result, nil
 		}
 	}
@@ -462,9 +501,12 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, state *Con
 	type none struct{}
 	incoming := make(map[insolar.Reference]none)
 	responsesFilter := make(map[int]none)
+	candidates := make(map[insolar.Reference]bool)
 	for _, req := range additionalRequests {
 		responsesFilter[req.RequestIndex] = none{}
+		candidates[selectCandidate(req.Candidates)] = false
 	}
+	start := time.Now()
 
 	shouldSendResponse := func(p *phase2Result) bool {
 		_, ok := incoming[p.id]
@@ -490,10 +532,15 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, state *Con
 		select {
 		case res := <-nc.phase2result:
 			logger.Debugf("Got phase2 request from %s", res.id)
-			currentPulse := nc.getPulseNumber()
-			if res.packet.GetPulseNumber() != currentPulse {
-				logger.Debugf("Filtered phase2 packet, packet pulse %d != %d (current pulse)",
-					res.packet.GetPulseNumber(), currentPulse)
+			if !res.packet.ContainsRequests() && !res.packet.ContainsResponses() {
+				// phase 2 packet that did not arrive before phase 2 timeout
+				if res.packet.GetPulseNumber() == nc.getPulseNumber() {
+					nc.PhaseTimings.PacketLate(ctx, phase2Name, res.id)
+				}
+			} else if !nc.packetReceived(ctx, phase21Name, res.id, res.packet.GetPulseNumber(), start) {
+				continue
+			}
+			if res.packet.GetPulseNumber() != nc.getPulseNumber() {
 				continue
 			}
 
@@ -502,7 +549,7 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, state *Con
 				// send response
 				response := packet
 				if res.packet.ContainsRequests() {
-					response, err = nc.generatePhase2Response(ctx, packet, res.packet, state)
+					response, err = GeneratePhase2Response(ctx, packet, res.packet, state)
 					if err != nil {
 						logger.Warnf("Failed to generate phase 2 response packet: %s", err.Error())
 						continue
@@ -515,6 +562,9 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, state *Con
 			}
 
 			if res.packet.ContainsResponses() {
+				if _, ok := candidates[res.id]; ok {
+					candidates[res.id] = true
+				}
 				voteAnswers := res.packet.GetVotes()
 				for _, vote := range voteAnswers {
 					switch v := vote.(type) {
@@ -533,6 +583,11 @@ func (nc *ConsensusCommunicator) ExchangePhase21(ctx context.Context, state *Con
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			for ref, responded := range candidates {
+				if !responded {
+					nc.PhaseTimings.PacketMissing(ctx, phase21Name, ref)
+				}
+			}
 			return result, nil
 		}
 	}
@@ -547,6 +602,7 @@ func (nc *ConsensusCommunicator) ExchangePhase3(ctx context.Context, participant
 	logger := inslogger.FromContext(ctx)
 
 	result[nc.ConsensusNetwork.GetNodeID()] = packet
+	start := time.Now()
 
 	nc.sendRequestToNodes(ctx, participants, packet)
 
@@ -562,10 +618,7 @@ func (nc *ConsensusCommunicator) ExchangePhase3(ctx context.Context, participant
 		select {
 		case res := <-nc.phase3result:
 			logger.Debugf("Got phase3 request from %s", res.id)
-			currentPulse := nc.getPulseNumber()
-			if res.packet.GetPulseNumber() != currentPulse {
-				logger.Debugf("Filtered phase3 packet, packet pulse %d != %d (current pulse)",
-					res.packet.GetPulseNumber(), currentPulse)
+			if !nc.packetReceived(ctx, phase3Name, res.id, res.packet.GetPulseNumber(), start) {
 				continue
 			}
 
@@ -585,6 +638,10 @@ func (nc *ConsensusCommunicator) ExchangePhase3(ctx context.Context, participant
 			// 	return result, nil
 			// }
 		case <-ctx.Done():
+			nc.packetsMissing(ctx, phase3Name, participants, func(ref insolar.Reference) bool {
+				_, ok := result[ref]
+				return ok
+			})
 			return result, nil
 		}
 	}
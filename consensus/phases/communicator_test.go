@@ -59,6 +59,7 @@ import (
 	"github.com/insolar/insolar/network/node"
 
 	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/network"
@@ -116,7 +117,8 @@ func (s *communicatorSuite) SetupTest() {
 
 	})
 
-	s.componentManager.Inject(nodeN, cryptoServ, s.communicator, s.consensusNetworkMock, s.pulseHandlerMock)
+	s.componentManager.Inject(nodeN, cryptoServ, s.communicator, s.consensusNetworkMock, s.pulseHandlerMock,
+		NewPhaseTimings(configuration.NewServiceNetwork()))
 	err := s.componentManager.Start(context.TODO())
 	s.NoError(err)
 }
@@ -96,10 +96,14 @@ func NewFirstPhase() FirstPhase {
 }
 
 type FirstPhaseImpl struct {
-	Calculator   merkle.Calculator           `inject:""`
-	Communicator Communicator                `inject:""`
-	Cryptography insolar.CryptographyService `inject:""`
-	NodeKeeper   network.NodeKeeper          `inject:""`
+	Calculator                 merkle.Calculator                  `inject:""`
+	Communicator               Communicator                       `inject:""`
+	Cryptography               insolar.CryptographyService        `inject:""`
+	NodeKeeper                 network.NodeKeeper                 `inject:""`
+	PlatformCryptographyScheme insolar.PlatformCryptographyScheme `inject:""`
+
+	// prevPulse is used to check that violation evidence is fresh
+	prevPulse *insolar.Pulse
 }
 
 // Execute do first phase
@@ -109,6 +113,10 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *insolar.Pulse) (*F
 	ctx, span := instracer.StartSpan(ctx, "FirstPhase.Execute")
 	span.AddAttributes(trace.Int64Attribute("pulse", int64(pulse.PulseNumber)))
 	defer span.End()
+	defer func() {
+		prevPulse := *pulse
+		fp.prevPulse = &prevPulse
+	}()
 
 	state := NewConsensusState(fp.NodeKeeper.GetConsensusInfo(), fp.NodeKeeper.GetSnapshotCopy())
 
@@ -196,7 +204,7 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *insolar.Pulse) (*F
 			},
 			StateHash: rawProof.StateHash(),
 		}
-		claimMap[ref] = fp.filterClaims(ref, packet.GetClaims())
+		claimMap[ref] = fp.filterClaims(ctx, pulse, ref, packet.GetClaims())
 	}
 
 	var length int
@@ -219,12 +227,14 @@ func (fp *FirstPhaseImpl) Execute(ctx context.Context, pulse *insolar.Pulse) (*F
 		}
 	}
 	valid, fault := validateProofs(fp.Calculator, state.NodesMutator, pulseHash, proofSet)
+	excludeViolators(ctx, fp.NodeKeeper.GetBans(), pulse.PulseNumber, valid)
 	valid[fp.NodeKeeper.GetOrigin()] = pulseProof
 	for node := range valid {
 		state.HashStorage.AddProof(node.ID(), rawProofs[node.ID()])
 	}
 	for nodeID := range fault {
 		logger.Warnf("[ NET Consensus phase-1 ] Failed to validate proof from %s", nodeID)
+		fp.checkForgedPulse(ctx, pulse, resultPackets[nodeID])
 	}
 	logger.Infof("[ NET Consensus phase-1 ] Valid proofs after phase: %d/%d", len(valid), state.BitsetMapper.Length())
 
@@ -319,7 +329,30 @@ func detectSparseBitsetLength(claims map[insolar.Reference][]packets.ReferendumC
 	return 0, errors.New("no announce claims were received")
 }
 
-func (fp *FirstPhaseImpl) filterClaims(nodeID insolar.Reference, claims []packets.ReferendumClaim) []packets.ReferendumClaim {
+// checkForgedPulse blames the node if its proof is signed for the current pulse number with a different entropy.
+func (fp *FirstPhaseImpl) checkForgedPulse(ctx context.Context, pulse *insolar.Pulse, packet *packets.Phase1Packet) {
+	if packet == nil {
+		return
+	}
+	packetPulse := packet.GetPulse()
+	if packetPulse.PulseNumber != pulse.PulseNumber || packetPulse.Entropy == pulse.Entropy {
+		return
+	}
+	offender := fp.NodeKeeper.GetAccessor().GetActiveNodeByShortID(packet.GetOrigin())
+	if offender == nil {
+		return
+	}
+	proof := packet.GetPulseProof()
+	if !fp.Calculator.IsValid(toPulseProof(proof), merkle.PulseHash(fp.PlatformCryptographyScheme, &packetPulse), offender.PublicKey()) {
+		return
+	}
+	reportViolation(ctx, fp.NodeKeeper, newProofViolationBlame(offender.ShortID(), packets.ViolationInvalidProof, packetPulse, *proof))
+}
+
+func (fp *FirstPhaseImpl) filterClaims(ctx context.Context, pulse *insolar.Pulse, nodeID insolar.Reference,
+	claims []packets.ReferendumClaim) []packets.ReferendumClaim {
+
+	bans := fp.NodeKeeper.GetBans()
 	result := make([]packets.ReferendumClaim, 0)
 	for _, claim := range claims {
 		signedClaim, ok := claim.(packets.SignedClaim)
@@ -331,6 +364,25 @@ func (fp *FirstPhaseImpl) filterClaims(nodeID insolar.Reference, claims []packet
 				continue
 			}
 		}
+		switch c := claim.(type) {
+		case *packets.NodeJoinClaim:
+			if isBanned(bans, c.NodeRef, pulse.PulseNumber) {
+				stats.Record(ctx, consensus.DeclinedClaims.M(1))
+				inslogger.FromContext(ctx).Warnf("[ NET Consensus ] Violation audit: join claim of banned node %s is dropped", c.NodeRef)
+				continue
+			}
+			if offender, mismatch := isClaimMismatch(fp.NodeKeeper.GetAccessor(), c); mismatch {
+				stats.Record(ctx, consensus.DeclinedClaims.M(1))
+				reportViolation(ctx, fp.NodeKeeper, newClaimViolationBlame(offender.ShortID(), pulse.PulseNumber, c))
+				continue
+			}
+		case *packets.NodeViolationBlame:
+			if err := fp.checkViolationBlame(c); err != nil {
+				stats.Record(ctx, consensus.DeclinedClaims.M(1))
+				inslogger.FromContext(ctx).Warn("[ NET Consensus ] Failed to check violation blame: " + err.Error())
+				continue
+			}
+		}
 		supClaim, ok := claim.(packets.ClaimSupplementary)
 		if ok {
 			supClaim.AddSupplementaryInfo(nodeID)
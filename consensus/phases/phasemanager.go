@@ -55,17 +55,41 @@ import (
 	"sync"
 	"time"
 
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus"
+	"github.com/insolar/insolar/consensus/packets"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/merkle"
 	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 )
 
 type PhaseManager interface {
 	OnPulse(ctx context.Context, pulse *insolar.Pulse, pulseStartTime time.Time) error
 }
 
+// CloudState is the cloud hash agreed in the last consensus with globule proofs it is calculated from
+// and signatures of the globule hash made by nodes of the globule.
+type CloudState struct {
+	Hash       merkle.OriginHash
+	Entry      *merkle.CloudEntry
+	Signatures map[insolar.Reference]insolar.Signature
+}
+
+// Clock provides current time for measuring consensus delay and phase durations.
+type Clock interface {
+	Now() time.Time
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
 type Phases struct {
 	FirstPhase  FirstPhase  `inject:""`
 	SecondPhase SecondPhase `inject:""`
@@ -74,14 +98,31 @@ type Phases struct {
 	PulseManager insolar.PulseManager `inject:""`
 	NodeKeeper   network.NodeKeeper   `inject:""`
 	Calculator   merkle.Calculator    `inject:""`
+	PhaseTimings network.PhaseTimings `inject:""`
 
 	lastPulse insolar.PulseNumber
 	lock      sync.Mutex
+	clock     Clock
+
+	cloudLock  sync.RWMutex
+	cloudState *CloudState
+
+	// banPulses is the number of pulses a node with proven violation is kept out of consensus
+	banPulses int
 }
 
 // NewPhaseManager creates and returns a new phase manager.
-func NewPhaseManager() PhaseManager {
-	return &Phases{}
+func NewPhaseManager(conf configuration.ServiceNetwork) PhaseManager {
+	return NewPhaseManagerWithClock(conf, realClock{})
+}
+
+// NewPhaseManagerWithClock creates a phase manager which measures consensus delay and phase durations with the clock.
+// Timeouts of phase contexts are still real, so communicator should not wait on them if the clock is virtual.
+func NewPhaseManagerWithClock(conf configuration.ServiceNetwork, clock Clock) PhaseManager {
+	return &Phases{
+		banPulses: conf.ViolationBanPulses,
+		clock:     clock,
+	}
 }
 
 // OnPulse starts calculate args on phases.
@@ -97,7 +138,7 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *insolar.Pulse, pulseStartT
 	}
 	pm.lastPulse = pulse.PulseNumber
 
-	consensusDelay := time.Since(pulseStartTime)
+	consensusDelay := pm.clock.Now().Sub(pulseStartTime)
 	inslogger.FromContext(ctx).Infof("[ NET Consensus ] Starting consensus process, delay: %v", consensusDelay)
 
 	pulseDuration, err := getPulseDuration(pulse)
@@ -108,42 +149,65 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *insolar.Pulse, pulseStartT
 	var tctx context.Context
 	var cancel context.CancelFunc
 
-	tctx, cancel, err = contextTimeoutWithDelay(ctx, *pulseDuration, consensusDelay, 0.3)
+	timeout := pm.PhaseTimings.Timeout(phase1Name, *pulseDuration) - consensusDelay
+	tctx, cancel, err = contextTimeout(ctx, timeout)
 	if err != nil {
 		return err
 	}
 	defer cancel()
 
+	start := pm.clock.Now()
 	firstPhaseState, err := pm.FirstPhase.Execute(tctx, pulse)
+	pm.PhaseTimings.PhaseFinished(ctx, phase1Name, pm.clock.Now().Sub(start), timeout)
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 1")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	timeout = pm.PhaseTimings.Timeout(phase2Name, *pulseDuration)
+	tctx, cancel, err = contextTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
+	start = pm.clock.Now()
 	secondPhaseState, err := pm.SecondPhase.Execute(tctx, pulse, firstPhaseState)
+	pm.PhaseTimings.PhaseFinished(ctx, phase2Name, pm.clock.Now().Sub(start), timeout)
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 2.0")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	timeout = pm.PhaseTimings.Timeout(phase21Name, *pulseDuration)
+	tctx, cancel, err = contextTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
+	start = pm.clock.Now()
 	secondPhaseState, err = pm.SecondPhase.Execute21(tctx, pulse, secondPhaseState)
+	pm.PhaseTimings.PhaseFinished(ctx, phase21Name, pm.clock.Now().Sub(start), timeout)
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 2.1")
 	}
 
-	tctx, cancel = contextTimeout(ctx, *pulseDuration, 0.05)
+	timeout = pm.PhaseTimings.Timeout(phase3Name, *pulseDuration)
+	tctx, cancel, err = contextTimeout(ctx, timeout)
+	if err != nil {
+		return err
+	}
 	defer cancel()
 
+	start = pm.clock.Now()
 	thirdPhaseState, err := pm.ThirdPhase.Execute(tctx, pulse, secondPhaseState)
+	pm.PhaseTimings.PhaseFinished(ctx, phase3Name, pm.clock.Now().Sub(start), timeout)
 	if err != nil {
 		return errors.Wrap(err, "[ NET Consensus ] Error executing phase 3")
 	}
 
 	state := thirdPhaseState
+	pm.updateBans(ctx, pulse, state)
+
 	cloud := &merkle.CloudEntry{
 		ProofSet:      []*merkle.GlobuleProof{state.GlobuleProof},
 		PrevCloudHash: pm.NodeKeeper.GetCloudHash(),
@@ -153,22 +217,70 @@ func (pm *Phases) OnPulse(ctx context.Context, pulse *insolar.Pulse, pulseStartT
 		return errors.Wrap(err, "[ NET Consensus ] Error calculating cloud hash")
 	}
 	pm.NodeKeeper.SetCloudHash(hash)
+	pm.setCloudState(&CloudState{Hash: hash, Entry: cloud, Signatures: state.GlobuleSignatures})
 	return pm.NodeKeeper.Sync(ctx, state.ActiveNodes, state.ApprovedClaims)
 }
 
+// GetCloudState returns the cloud state agreed in the last consensus or nil if consensus is not passed yet.
+func (pm *Phases) GetCloudState() *CloudState {
+	pm.cloudLock.RLock()
+	defer pm.cloudLock.RUnlock()
+
+	return pm.cloudState
+}
+
+func (pm *Phases) setCloudState(state *CloudState) {
+	pm.cloudLock.Lock()
+	defer pm.cloudLock.Unlock()
+
+	pm.cloudState = state
+}
+
+// updateBans bans nodes blamed in claims approved by consensus and drops expired bans.
+// Bans expire at the pulse number calculated from the agreed pulse, so all nodes have the same ban list.
+// Offender is excluded from consensus of banPulses pulses following the current one.
+func (pm *Phases) updateBans(ctx context.Context, pulse *insolar.Pulse, state *ThirdPhaseState) {
+	logger := inslogger.FromContext(ctx)
+
+	bans := pm.NodeKeeper.GetBans()
+	for ref := range bans {
+		if !isBanned(bans, ref, pulse.PulseNumber) {
+			delete(bans, ref)
+		}
+	}
+
+	expiry := pulse.PulseNumber + insolar.PulseNumber(pm.banPulses+1)*(pulse.NextPulseNumber-pulse.PulseNumber)
+	for _, claim := range state.ApprovedClaims {
+		blame, ok := claim.(*packets.NodeViolationBlame)
+		if !ok {
+			continue
+		}
+		offender := pm.NodeKeeper.GetAccessor().GetActiveNodeByShortID(insolar.ShortNodeID(blame.BlameNodeID))
+		if offender == nil {
+			continue
+		}
+		if pm.banPulses > 0 {
+			bans[offender.ID()] = expiry
+		}
+
+		name := violationName(blame.TypeViolation)
+		logger.Warnf("[ NET Consensus ] Violation audit: node %s (short ID %d) blamed at pulse %d, excluded for %d pulses, "+
+			"violation: %s, evidence pulse: %d", offender.ID(), offender.ShortID(), pulse.PulseNumber, pm.banPulses,
+			name, blame.PulseNumber)
+		err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagViolation, name)}, consensus.ExcludedNodes.M(1))
+		if err != nil {
+			logger.Warn("[ NET Consensus ] Failed to record excluded nodes metric: " + err.Error())
+		}
+	}
+	pm.NodeKeeper.SetBans(bans)
+}
+
 func getPulseDuration(pulse *insolar.Pulse) (*time.Duration, error) {
 	duration := time.Duration(pulse.NextPulseNumber-pulse.PulseNumber) * time.Second
 	return &duration, nil
 }
 
-func contextTimeout(ctx context.Context, duration time.Duration, k float64) (context.Context, context.CancelFunc) {
-	timeout := time.Duration(k * float64(duration))
-	timedCtx, cancelFund := context.WithTimeout(ctx, timeout)
-	return timedCtx, cancelFund
-}
-
-func contextTimeoutWithDelay(ctx context.Context, duration, delay time.Duration, k float64) (context.Context, context.CancelFunc, error) {
-	timeout := time.Duration(k*float64(duration)) - delay
+func contextTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc, error) {
 	if timeout < 0 {
 		return nil, nil, errors.New("[ NET Consensus ] Not enough time for consensus process")
 	}
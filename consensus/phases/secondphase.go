@@ -274,6 +274,13 @@ func (sp *SecondPhaseImpl) Execute21(ctx context.Context, pulse *insolar.Pulse,
 			logger.Warnf("[ NET Consensus phase-2.1 ] Failed to validate proof from %s", node.ID())
 			continue
 		}
+		prevProof := state.HashStorage.GetProof(node.ID())
+		if isEquivocation(sp.Calculator, state.PulseHash, node, prevProof, &result.NodePulseProof) {
+			logger.Warnf("[ NET Consensus phase-2.1 ] Node %s signed two different proofs", node.ID())
+			blame := newProofViolationBlame(node.ShortID(), packets.ViolationEquivocation, *pulse, *prevProof, result.NodePulseProof)
+			reportViolation(ctx, sp.NodeKeeper, blame)
+			continue
+		}
 
 		err = state.Matrix.ReceivedProofFromNode(origin, node.ID())
 		if err != nil {
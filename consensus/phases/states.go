@@ -84,9 +84,10 @@ type SecondPhaseState struct {
 }
 
 type ThirdPhaseState struct {
-	ActiveNodes    []insolar.NetworkNode
-	GlobuleProof   *merkle.GlobuleProof
-	ApprovedClaims []packets.ReferendumClaim
+	ActiveNodes       []insolar.NetworkNode
+	GlobuleProof      *merkle.GlobuleProof
+	GlobuleSignatures map[insolar.Reference]insolar.Signature
+	ApprovedClaims    []packets.ReferendumClaim
 }
 
 type ConsensusState struct {
@@ -125,7 +125,7 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *insolar.Pulse, sta
 	}
 
 	prevCloudHash := tp.NodeKeeper.GetCloudHash()
-	validNodes := 0
+	signatures := make(map[insolar.Reference]insolar.Signature)
 	for _, node := range nodes {
 		ghs, ok := state.HashStorage.GetGlobuleHashSignature(node.ID())
 		if !ok {
@@ -143,24 +143,25 @@ func (tp *ThirdPhaseImpl) Execute(ctx context.Context, pulse *insolar.Pulse, sta
 		}
 		valid := tp.Calculator.IsValid(proof, state.GlobuleHash, node.PublicKey())
 		if valid {
-			validNodes++
+			signatures[node.ID()] = proof.Signature
 		} else {
 			logger.Warnf("[ NET Consensus phase-3 ] Failed to validate globule hash from node %s", node.ID())
 		}
 	}
 
-	if !consensusReachedBFT(validNodes, totalCount) {
-		return nil, errors.Errorf("[ NET Consensus phase-3 ] Failed to pass BFT consensus: %d/%d", validNodes, totalCount)
+	if !consensusReachedBFT(len(signatures), totalCount) {
+		return nil, errors.Errorf("[ NET Consensus phase-3 ] Failed to pass BFT consensus: %d/%d", len(signatures), totalCount)
 	}
 
-	logger.Infof("[ NET Consensus phase-3 ] BFT consensus passed: %d/%d", validNodes, totalCount)
+	logger.Infof("[ NET Consensus phase-3 ] BFT consensus passed: %d/%d", len(signatures), totalCount)
 
 	claimSplit := state.ClaimHandler.FilterClaims(state.MatrixState.Active, pulse.Entropy)
 
 	return &ThirdPhaseState{
-		ActiveNodes:    nodes,
-		GlobuleProof:   state.GlobuleProof,
-		ApprovedClaims: claimSplit.ApprovedClaims,
+		ActiveNodes:       nodes,
+		GlobuleProof:      state.GlobuleProof,
+		GlobuleSignatures: signatures,
+		ApprovedClaims:    claimSplit.ApprovedClaims,
 	}, nil
 }
 
@@ -0,0 +1,302 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package phases
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+)
+
+const (
+	phase1Name  = "phase 1"
+	phase2Name  = "phase 2"
+	phase21Name = "phase 21"
+	phase3Name  = "phase 3"
+)
+
+var phaseNames = []string{phase1Name, phase2Name, phase21Name, phase3Name}
+
+// defaultTimeoutFractions are timeouts of consensus phases as fractions of pulse duration.
+var defaultTimeoutFractions = map[string]float64{
+	phase1Name:  0.3,
+	phase2Name:  0.05,
+	phase21Name: 0.05,
+	phase3Name:  0.05,
+}
+
+// delayPercentile is the percentile of recent packet delays used as delay estimation in adaptive mode.
+const delayPercentile = 0.9
+
+type phaseTiming struct {
+	lastDuration      time.Duration
+	lastTimeout       time.Duration
+	lastPulseDuration time.Duration
+
+	// roundDelay is the maximum packet delay in the current round
+	roundDelay time.Duration
+	// roundReceived is true if any packet is received in the current round
+	roundReceived bool
+	// roundMissing is true if any packet is missing in the current round
+	roundMissing bool
+	// delays contains maximum packet delays of recent rounds
+	delays []time.Duration
+}
+
+// finishRound returns packet delay of the current round and resets it.
+// Missing packets are counted as delayed for the whole phase timeout, rounds without packets are skipped,
+// so the estimation isn't biased low by rounds in which nothing arrived.
+func (p *phaseTiming) finishRound(timeout time.Duration) (time.Duration, bool) {
+	delay, ok := p.roundDelay, p.roundReceived
+	if p.roundMissing && timeout > delay {
+		delay, ok = timeout, true
+	}
+	p.roundDelay = 0
+	p.roundReceived = false
+	p.roundMissing = false
+	return delay, ok
+}
+
+type phaseTimings struct {
+	conf configuration.PhaseTimeouts
+
+	lock   sync.RWMutex
+	phases map[string]*phaseTiming
+	peers  map[insolar.Reference]*network.PeerTimingStatus
+}
+
+// NewPhaseTimings creates collector of consensus phase timings.
+func NewPhaseTimings(conf configuration.ServiceNetwork) network.PhaseTimings {
+	pt := &phaseTimings{
+		conf:   conf.PhaseTimeouts,
+		phases: make(map[string]*phaseTiming),
+		peers:  make(map[insolar.Reference]*network.PeerTimingStatus),
+	}
+	for _, name := range phaseNames {
+		pt.phases[name] = &phaseTiming{}
+	}
+	return pt
+}
+
+func (pt *phaseTimings) phase(name string) *phaseTiming {
+	p, ok := pt.phases[name]
+	if !ok {
+		p = &phaseTiming{}
+		pt.phases[name] = p
+	}
+	return p
+}
+
+func (pt *phaseTimings) peer(ref insolar.Reference) *network.PeerTimingStatus {
+	p, ok := pt.peers[ref]
+	if !ok {
+		p = &network.PeerTimingStatus{Peer: ref}
+		pt.peers[ref] = p
+	}
+	return p
+}
+
+// Timeout returns timeout of consensus phase for pulse of given duration.
+func (pt *phaseTimings) Timeout(phase string, pulseDuration time.Duration) time.Duration {
+	pt.lock.Lock()
+	defer pt.lock.Unlock()
+
+	p := pt.phase(phase)
+	p.lastPulseDuration = pulseDuration
+	return pt.timeout(phase, p, pulseDuration)
+}
+
+func (pt *phaseTimings) timeout(phase string, p *phaseTiming, pulseDuration time.Duration) time.Duration {
+	defaultTimeout := time.Duration(defaultTimeoutFractions[phase] * float64(pulseDuration))
+	if !pt.conf.Adaptive || len(p.delays) == 0 {
+		return defaultTimeout
+	}
+
+	timeout := time.Duration(pt.conf.Margin * float64(estimateDelay(p.delays)))
+	minTimeout := time.Duration(pt.conf.MinScale * float64(defaultTimeout))
+	maxTimeout := time.Duration(pt.conf.MaxScale * float64(defaultTimeout))
+	if timeout < minTimeout {
+		return minTimeout
+	}
+	if timeout > maxTimeout {
+		return maxTimeout
+	}
+	return timeout
+}
+
+func estimateDelay(delays []time.Duration) time.Duration {
+	if len(delays) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(delays))
+	copy(sorted, delays)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(delayPercentile*float64(len(sorted)+1)) - 1
+	if index < 0 {
+		index = 0
+	}
+	if index >= len(sorted) {
+		index = len(sorted) - 1
+	}
+	return sorted[index]
+}
+
+func toMilliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// PhaseFinished records actual phase duration and its timeout.
+func (pt *phaseTimings) PhaseFinished(ctx context.Context, phase string, elapsed, timeout time.Duration) {
+	pt.lock.Lock()
+	p := pt.phase(phase)
+	p.lastDuration = elapsed
+	p.lastTimeout = timeout
+	if delay, ok := p.finishRound(timeout); ok {
+		p.delays = append(p.delays, delay)
+	}
+	if pt.conf.Window > 0 && len(p.delays) > pt.conf.Window {
+		p.delays = p.delays[len(p.delays)-pt.conf.Window:]
+	}
+	pt.lock.Unlock()
+
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagPhase, phase)},
+		consensus.PhaseDuration.M(toMilliseconds(elapsed)), consensus.PhaseTimeout.M(toMilliseconds(timeout)))
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to record phase timing metrics: " + err.Error())
+	}
+}
+
+// PacketReceived records delay between phase start and receipt of a packet from peer.
+func (pt *phaseTimings) PacketReceived(ctx context.Context, phase string, peer insolar.Reference, delay time.Duration) {
+	pt.lock.Lock()
+	p := pt.phase(phase)
+	p.roundReceived = true
+	if delay > p.roundDelay {
+		p.roundDelay = delay
+	}
+	pt.lock.Unlock()
+
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagPhase, phase)},
+		consensus.PacketDelay.M(toMilliseconds(delay)))
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to record packet delay metric: " + err.Error())
+	}
+}
+
+// PacketMissing records peer that did not send a packet before phase timeout.
+func (pt *phaseTimings) PacketMissing(ctx context.Context, phase string, peer insolar.Reference) {
+	pt.lock.Lock()
+	pt.phase(phase).roundMissing = true
+	pt.peer(peer).Missing++
+	pt.lock.Unlock()
+
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagPhase, phase), tag.Upsert(consensus.TagPeer, peer.String())},
+		consensus.PacketsMissing.M(1))
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("Failed to record missing packet metric: " + err.Error())
+	}
+}
+
+// PacketLate records packet from peer received after its phase had finished.
+func (pt *phaseTimings) PacketLate(ctx context.Context, phase string, peer insolar.Reference) {
+	pt.lock.Lock()
+	pt.peer(peer).Late++
+	pt.lock.Unlock()
+
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagPhase, phase), tag.Upsert(consensus.TagPeer, peer.String())},
+		consensus.PacketsLate.M(1))
+	if err != nil {
+		inslogger.FromContext(ctx).Debug("Failed to record late packet metric: " + err.Error())
+	}
+}
+
+// Status returns collected statistics.
+func (pt *phaseTimings) Status() network.PhaseTimingsStatus {
+	pt.lock.RLock()
+	defer pt.lock.RUnlock()
+
+	status := network.PhaseTimingsStatus{
+		Adaptive: pt.conf.Adaptive,
+		Phases:   make([]network.PhaseTimingStatus, 0, len(phaseNames)),
+		Peers:    make([]network.PeerTimingStatus, 0, len(pt.peers)),
+	}
+	for _, name := range phaseNames {
+		p := pt.phases[name]
+		fraction := defaultTimeoutFractions[name]
+		if p.lastPulseDuration > 0 {
+			fraction = float64(pt.timeout(name, p, p.lastPulseDuration)) / float64(p.lastPulseDuration)
+		}
+		status.Phases = append(status.Phases, network.PhaseTimingStatus{
+			Phase:           name,
+			TimeoutFraction: fraction,
+			LastDuration:    p.lastDuration,
+			LastTimeout:     p.lastTimeout,
+			PacketDelay:     estimateDelay(p.delays),
+		})
+	}
+	for _, p := range pt.peers {
+		status.Peers = append(status.Peers, *p)
+	}
+	sort.Slice(status.Peers, func(i, j int) bool {
+		return status.Peers[i].Peer.Compare(status.Peers[j].Peer) < 0
+	})
+	return status
+}
@@ -0,0 +1,165 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package phases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/testutils"
+)
+
+func newTestPhaseTimings(adaptive bool) *phaseTimings {
+	conf := configuration.NewServiceNetwork()
+	conf.PhaseTimeouts.Adaptive = adaptive
+	return NewPhaseTimings(conf).(*phaseTimings)
+}
+
+func observeRounds(pt *phaseTimings, phase string, delays ...time.Duration) {
+	ctx := context.Background()
+	for _, d := range delays {
+		pt.PacketReceived(ctx, phase, testutils.RandomRef(), d)
+		pt.PhaseFinished(ctx, phase, d, d)
+	}
+}
+
+func TestPhaseTimings_DefaultTimeouts(t *testing.T) {
+	pt := newTestPhaseTimings(false)
+	observeRounds(pt, phase1Name, time.Millisecond, 2*time.Millisecond)
+
+	require.Equal(t, 3*time.Second, pt.Timeout(phase1Name, 10*time.Second))
+	require.Equal(t, 500*time.Millisecond, pt.Timeout(phase2Name, 10*time.Second))
+	require.Equal(t, 500*time.Millisecond, pt.Timeout(phase21Name, 10*time.Second))
+	require.Equal(t, 500*time.Millisecond, pt.Timeout(phase3Name, 10*time.Second))
+}
+
+func TestPhaseTimings_AdaptiveTimeouts(t *testing.T) {
+	pt := newTestPhaseTimings(true)
+	pulseDuration := 10 * time.Second
+
+	// no observations yet
+	require.Equal(t, 3*time.Second, pt.Timeout(phase1Name, pulseDuration))
+
+	// 2 * 1s fits into bounds
+	observeRounds(pt, phase1Name, 200*time.Millisecond, time.Second, 300*time.Millisecond)
+	require.Equal(t, 2*time.Second, pt.Timeout(phase1Name, pulseDuration))
+
+	// fast network is bounded by MinScale
+	observeRounds(pt, phase2Name, time.Millisecond)
+	require.Equal(t, 250*time.Millisecond, pt.Timeout(phase2Name, pulseDuration))
+
+	// slow network is bounded by MaxScale
+	observeRounds(pt, phase3Name, 5*time.Second)
+	require.Equal(t, time.Second, pt.Timeout(phase3Name, pulseDuration))
+
+	// old rounds leave the window
+	observeRounds(pt, phase3Name, 300*time.Millisecond, 300*time.Millisecond, 300*time.Millisecond, 300*time.Millisecond,
+		300*time.Millisecond, 300*time.Millisecond, 300*time.Millisecond, 300*time.Millisecond, 300*time.Millisecond,
+		300*time.Millisecond)
+	require.Equal(t, 600*time.Millisecond, pt.Timeout(phase3Name, pulseDuration))
+}
+
+func TestPhaseTimings_Status(t *testing.T) {
+	ctx := context.Background()
+	pt := newTestPhaseTimings(true)
+	pulseDuration := 10 * time.Second
+	peer1 := testutils.RandomRef()
+	peer2 := testutils.RandomRef()
+
+	timeout := pt.Timeout(phase1Name, pulseDuration)
+	pt.PacketReceived(ctx, phase1Name, peer1, 400*time.Millisecond)
+	pt.PacketMissing(ctx, phase1Name, peer2)
+	pt.PacketLate(ctx, phase2Name, peer2)
+	pt.PacketMissing(ctx, phase2Name, peer2)
+	pt.PhaseFinished(ctx, phase1Name, 2*time.Second, timeout)
+
+	status := pt.Status()
+	require.True(t, status.Adaptive)
+	require.Len(t, status.Phases, 4)
+
+	phase1 := status.Phases[0]
+	require.Equal(t, phase1Name, phase1.Phase)
+	require.Equal(t, 2*time.Second, phase1.LastDuration)
+	require.Equal(t, 3*time.Second, phase1.LastTimeout)
+	// packet of peer2 is missing, so it's counted as delayed for the whole timeout
+	require.Equal(t, 3*time.Second, phase1.PacketDelay)
+	require.InDelta(t, 0.6, phase1.TimeoutFraction, 1e-9)
+	require.Equal(t, 0.05, status.Phases[1].TimeoutFraction)
+
+	require.Len(t, status.Peers, 1)
+	require.Equal(t, peer2, status.Peers[0].Peer)
+	require.Equal(t, uint64(2), status.Peers[0].Missing)
+	require.Equal(t, uint64(1), status.Peers[0].Late)
+}
+
+func TestPhaseTimings_RoundsWithoutPackets(t *testing.T) {
+	ctx := context.Background()
+	pt := newTestPhaseTimings(true)
+	pulseDuration := 10 * time.Second
+
+	observeRounds(pt, phase1Name, time.Second)
+
+	// nothing is received in the round, it doesn't lower the estimation
+	pt.PhaseFinished(ctx, phase1Name, time.Second, pt.Timeout(phase1Name, pulseDuration))
+	require.Equal(t, []time.Duration{time.Second}, pt.phases[phase1Name].delays)
+	require.Equal(t, 2*time.Second, pt.Timeout(phase1Name, pulseDuration))
+
+	// missing packet is counted as delayed for the current timeout
+	timeout := pt.Timeout(phase1Name, pulseDuration)
+	pt.PacketReceived(ctx, phase1Name, testutils.RandomRef(), 100*time.Millisecond)
+	pt.PacketMissing(ctx, phase1Name, testutils.RandomRef())
+	pt.PhaseFinished(ctx, phase1Name, timeout, timeout)
+	require.Equal(t, []time.Duration{time.Second, timeout}, pt.phases[phase1Name].delays)
+}
@@ -0,0 +1,225 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package phases
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/insolar/insolar/consensus"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+)
+
+var violationNames = map[uint8]string{
+	packets.ViolationEquivocation:  "equivocation",
+	packets.ViolationInvalidProof:  "invalid proof",
+	packets.ViolationClaimMismatch: "claim mismatch",
+}
+
+func violationName(violation uint8) string {
+	name, ok := violationNames[violation]
+	if !ok {
+		return "unknown"
+	}
+	return name
+}
+
+func newProofViolationBlame(offender insolar.ShortNodeID, violation uint8, pulse insolar.Pulse,
+	proofs ...packets.NodePulseProof) *packets.NodeViolationBlame {
+
+	blame := &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(offender),
+		TypeViolation: violation,
+		PulseNumber:   pulse.PulseNumber,
+		Entropy:       pulse.Entropy,
+	}
+	copy(blame.Proofs[:], proofs)
+	return blame
+}
+
+func newClaimViolationBlame(offender insolar.ShortNodeID, pulseNumber insolar.PulseNumber,
+	claim *packets.NodeJoinClaim) *packets.NodeViolationBlame {
+
+	return &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(offender),
+		TypeViolation: packets.ViolationClaimMismatch,
+		PulseNumber:   pulseNumber,
+		Claim:         *claim,
+	}
+}
+
+func toPulseProof(proof *packets.NodePulseProof) *merkle.PulseProof {
+	return &merkle.PulseProof{
+		BaseProof: merkle.BaseProof{
+			Signature: insolar.SignatureFromBytes(proof.Signature()),
+		},
+		StateHash: proof.StateHash(),
+	}
+}
+
+// reportViolation puts blame to the claim queue, so it is sent to other nodes in the next consensus.
+func reportViolation(ctx context.Context, nodeKeeper network.NodeKeeper, blame *packets.NodeViolationBlame) {
+	name := violationName(blame.TypeViolation)
+	inslogger.FromContext(ctx).Warnf("[ NET Consensus ] Detected violation %s of node %d at pulse %d",
+		name, blame.BlameNodeID, blame.PulseNumber)
+
+	err := stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(consensus.TagViolation, name)}, consensus.ViolationsDetected.M(1))
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ NET Consensus ] Failed to record violations metric: " + err.Error())
+	}
+	nodeKeeper.GetClaimQueue().Push(blame)
+}
+
+// isEquivocation checks if the node signed two proofs with different state for the same pulse.
+// Signatures are not compared because the same data may have different valid signatures.
+func isEquivocation(calculator merkle.Calculator, pulseHash merkle.OriginHash, node insolar.NetworkNode,
+	first, second *packets.NodePulseProof) bool {
+
+	if first == nil || second == nil || first.NodeStateHash == second.NodeStateHash {
+		return false
+	}
+	return calculator.IsValid(toPulseProof(first), pulseHash, node.PublicKey()) &&
+		calculator.IsValid(toPulseProof(second), pulseHash, node.PublicKey())
+}
+
+// isBanned checks if the node is banned for proven violation at the pulse.
+func isBanned(bans map[insolar.Reference]insolar.PulseNumber, ref insolar.Reference, pulseNumber insolar.PulseNumber) bool {
+	expiry, ok := bans[ref]
+	return ok && pulseNumber < expiry
+}
+
+// excludeViolators removes banned nodes from the valid proofs, so violators get neither into the globule proof
+// nor into the next active list.
+//
+// Blames received in claims of the current pulse are not used here: a claim may reach only some of the nodes,
+// and nodes excluding different sets would not agree on the globule proof. Blames are applied only after
+// consensus approves them (see Phases.updateBans), so all nodes exclude the offender since the next pulse.
+func excludeViolators(ctx context.Context, bans map[insolar.Reference]insolar.PulseNumber, pulseNumber insolar.PulseNumber,
+	proofs map[insolar.NetworkNode]*merkle.PulseProof) {
+
+	for node := range proofs {
+		if isBanned(bans, node.ID(), pulseNumber) {
+			inslogger.FromContext(ctx).Warnf("[ NET Consensus ] Violation audit: node %s is excluded from consensus", node.ID())
+			delete(proofs, node)
+		}
+	}
+}
+
+// isClaimMismatch checks if the claim is signed by an active node but contradicts its certificate.
+func isClaimMismatch(accessor network.Accessor, claim *packets.NodeJoinClaim) (insolar.NetworkNode, bool) {
+	node := accessor.GetActiveNodeByShortID(claim.ShortNodeID)
+	if node == nil {
+		return nil, false
+	}
+	if claim.NodeRef.Equal(node.ID()) && claim.NodeRoleRecID == node.Role() {
+		return nil, false
+	}
+	key, err := platformpolicy.NewKeyProcessor().ExportPublicKeyBinary(node.PublicKey())
+	if err != nil || !bytes.Equal(key, claim.NodePK[:]) {
+		return nil, false
+	}
+	return node, true
+}
+
+// checkViolationBlame verifies that evidence from blame proves the violation of the blamed node.
+// Evidence must be collected during the previous pulse, so the same violation can't be blamed twice.
+func (fp *FirstPhaseImpl) checkViolationBlame(blame *packets.NodeViolationBlame) error {
+	if fp.prevPulse == nil || blame.PulseNumber != fp.prevPulse.PulseNumber {
+		return errors.Errorf("evidence of pulse %d is outdated", blame.PulseNumber)
+	}
+	offender := fp.NodeKeeper.GetAccessor().GetActiveNodeByShortID(insolar.ShortNodeID(blame.BlameNodeID))
+	if offender == nil {
+		return errors.Errorf("blamed node %d is not active", blame.BlameNodeID)
+	}
+
+	pulseHash := merkle.PulseHash(fp.PlatformCryptographyScheme, &insolar.Pulse{
+		PulseNumber: blame.PulseNumber,
+		Entropy:     blame.Entropy,
+	})
+	switch blame.TypeViolation {
+	case packets.ViolationEquivocation:
+		if blame.Proofs[0].NodeStateHash == blame.Proofs[1].NodeStateHash {
+			return errors.New("proofs are not conflicting")
+		}
+		for i := range blame.Proofs {
+			if !fp.Calculator.IsValid(toPulseProof(&blame.Proofs[i]), pulseHash, offender.PublicKey()) {
+				return errors.Errorf("proof %d is not signed by blamed node", i)
+			}
+		}
+	case packets.ViolationInvalidProof:
+		if blame.Entropy == fp.prevPulse.Entropy {
+			return errors.New("proof is signed for the actual pulse")
+		}
+		if !fp.Calculator.IsValid(toPulseProof(&blame.Proofs[0]), pulseHash, offender.PublicKey()) {
+			return errors.New("proof is not signed by blamed node")
+		}
+	case packets.ViolationClaimMismatch:
+		node, mismatch := isClaimMismatch(fp.NodeKeeper.GetAccessor(), &blame.Claim)
+		if !mismatch || !node.ID().Equal(offender.ID()) {
+			return errors.New("claim is not signed by blamed node or matches its certificate")
+		}
+		err := fp.checkClaimSignature(&blame.Claim)
+		if err != nil {
+			return errors.Wrap(err, "failed to check claim signature")
+		}
+	default:
+		return errors.Errorf("unknown violation type %d", blame.TypeViolation)
+	}
+	return nil
+}
@@ -0,0 +1,358 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package phases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/node"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type stateStub struct {
+	state []byte
+}
+
+func (s *stateStub) State() ([]byte, error) {
+	return s.state, nil
+}
+
+type violationTestNode struct {
+	node    insolar.NetworkNode
+	service insolar.CryptographyService
+}
+
+func newViolationTestNode(t *testing.T) *violationTestNode {
+	key, err := platformpolicy.NewKeyProcessor().GeneratePrivateKey()
+	require.NoError(t, err)
+	service := cryptography.NewKeyBoundCryptographyService(key)
+	publicKey, err := service.GetPublicKey()
+	require.NoError(t, err)
+
+	return &violationTestNode{
+		node:    node.NewNode(testutils.RandomRef(), insolar.StaticRoleVirtual, publicKey, "127.0.0.1:0", ""),
+		service: service,
+	}
+}
+
+func (n *violationTestNode) calculator(t *testing.T, state string) merkle.Calculator {
+	calculator := merkle.NewCalculator()
+	cm := component.Manager{}
+	stateHash := make([]byte, packets.HashLength)
+	copy(stateHash, state)
+	cm.Inject(nodenetwork.NewNodeKeeper(n.node), &stateStub{state: stateHash}, calculator, n.service,
+		platformpolicy.NewPlatformCryptographyScheme())
+	require.NoError(t, cm.Init(context.Background()))
+	return calculator
+}
+
+func (n *violationTestNode) proof(t *testing.T, pulse *insolar.Pulse, state string) packets.NodePulseProof {
+	_, proof, err := n.calculator(t, state).GetPulseProof(&merkle.PulseEntry{Pulse: pulse})
+	require.NoError(t, err)
+
+	result := packets.NodePulseProof{}
+	copy(result.NodeStateHash[:], proof.StateHash)
+	copy(result.NodeSignature[:], proof.Signature.Bytes())
+	return result
+}
+
+func (n *violationTestNode) claim(t *testing.T) *packets.NodeJoinClaim {
+	claim, err := packets.NodeToClaim(n.node)
+	require.NoError(t, err)
+	claim.NodeAddress = packets.NodeAddress{}
+	data, err := claim.SerializeRaw()
+	require.NoError(t, err)
+	signature, err := n.service.Sign(data)
+	require.NoError(t, err)
+	copy(claim.Signature[:], signature.Bytes())
+	return claim
+}
+
+func newViolationTestPulse() *insolar.Pulse {
+	pulse := &insolar.Pulse{
+		PulseNumber:     insolar.FirstPulseNumber + 10,
+		NextPulseNumber: insolar.FirstPulseNumber + 20,
+	}
+	copy(pulse.Entropy[:], testutils.RandomRef().Bytes())
+	return pulse
+}
+
+func newViolationTestFirstPhase(t *testing.T, prevPulse *insolar.Pulse, nodes ...*violationTestNode) (*FirstPhaseImpl, network.NodeKeeper) {
+	origin := newViolationTestNode(t)
+	nodeKeeper := nodenetwork.NewNodeKeeper(origin.node)
+	active := []insolar.NetworkNode{origin.node}
+	for _, n := range nodes {
+		active = append(active, n.node)
+	}
+	nodeKeeper.SetInitialSnapshot(active)
+
+	return &FirstPhaseImpl{
+		Calculator:                 origin.calculator(t, "origin"),
+		Cryptography:               origin.service,
+		NodeKeeper:                 nodeKeeper,
+		PlatformCryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+		prevPulse:                  prevPulse,
+	}, nodeKeeper
+}
+
+func TestFirstPhase_checkViolationBlame_Equivocation(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	fp, _ := newViolationTestFirstPhase(t, pulse, offender)
+
+	first := offender.proof(t, pulse, "state1")
+	second := offender.proof(t, pulse, "state2")
+
+	blame := newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first, second)
+	require.NoError(t, fp.checkViolationBlame(blame))
+
+	blame = newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first, first)
+	require.Error(t, fp.checkViolationBlame(blame))
+
+	other := newViolationTestNode(t)
+	blame = newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first,
+		other.proof(t, pulse, "state2"))
+	require.Error(t, fp.checkViolationBlame(blame))
+
+	fp.prevPulse = newViolationTestPulse()
+	fp.prevPulse.PulseNumber += 10
+	blame = newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first, second)
+	require.Error(t, fp.checkViolationBlame(blame))
+}
+
+func TestFirstPhase_checkViolationBlame_InvalidProof(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	fp, _ := newViolationTestFirstPhase(t, pulse, offender)
+
+	forgedPulse := *pulse
+	copy(forgedPulse.Entropy[:], testutils.RandomRef().Bytes())
+
+	blame := newProofViolationBlame(offender.node.ShortID(), packets.ViolationInvalidProof, forgedPulse,
+		offender.proof(t, &forgedPulse, "state"))
+	require.NoError(t, fp.checkViolationBlame(blame))
+
+	blame = newProofViolationBlame(offender.node.ShortID(), packets.ViolationInvalidProof, *pulse,
+		offender.proof(t, pulse, "state"))
+	require.Error(t, fp.checkViolationBlame(blame))
+}
+
+func TestFirstPhase_checkViolationBlame_ClaimMismatch(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	fp, nodeKeeper := newViolationTestFirstPhase(t, pulse, offender)
+
+	claim := offender.claim(t)
+	_, mismatch := isClaimMismatch(nodeKeeper.GetAccessor(), claim)
+	require.False(t, mismatch)
+	blame := newClaimViolationBlame(offender.node.ShortID(), pulse.PulseNumber, claim)
+	require.Error(t, fp.checkViolationBlame(blame))
+
+	claim = offender.claim(t)
+	claim.NodeRoleRecID = insolar.StaticRoleLightMaterial
+	data, err := claim.SerializeRaw()
+	require.NoError(t, err)
+	signature, err := offender.service.Sign(data)
+	require.NoError(t, err)
+	copy(claim.Signature[:], signature.Bytes())
+
+	_, mismatch = isClaimMismatch(nodeKeeper.GetAccessor(), claim)
+	require.True(t, mismatch)
+	blame = newClaimViolationBlame(offender.node.ShortID(), pulse.PulseNumber, claim)
+	require.NoError(t, fp.checkViolationBlame(blame))
+
+	claim.Signature[0]++
+	blame = newClaimViolationBlame(offender.node.ShortID(), pulse.PulseNumber, claim)
+	require.Error(t, fp.checkViolationBlame(blame))
+}
+
+func TestFirstPhase_filterClaims_DeclinesBadBlame(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	fp, nodeKeeper := newViolationTestFirstPhase(t, pulse, offender)
+
+	first := offender.proof(t, pulse, "state1")
+	valid := newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first,
+		offender.proof(t, pulse, "state2"))
+	invalid := newProofViolationBlame(offender.node.ShortID(), packets.ViolationEquivocation, *pulse, first, first)
+
+	claims := fp.filterClaims(context.Background(), pulse, nodeKeeper.GetOrigin().ID(),
+		[]packets.ReferendumClaim{valid, invalid})
+	require.Equal(t, []packets.ReferendumClaim{valid}, claims)
+}
+
+func TestPhases_updateBans(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	honest := newViolationTestNode(t)
+	nodeKeeper := nodenetwork.NewNodeKeeper(honest.node)
+	nodeKeeper.SetInitialSnapshot([]insolar.NetworkNode{offender.node, honest.node})
+
+	pm := NewPhaseManager(configuration.ServiceNetwork{ViolationBanPulses: 2}).(*Phases)
+	pm.NodeKeeper = nodeKeeper
+	blame := &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(offender.node.ShortID()),
+		TypeViolation: packets.ViolationEquivocation,
+	}
+	pm.updateBans(context.Background(), pulse, &ThirdPhaseState{ApprovedClaims: []packets.ReferendumClaim{blame}})
+	expiry := pulse.PulseNumber + 3*(pulse.NextPulseNumber-pulse.PulseNumber)
+	require.Equal(t, map[insolar.Reference]insolar.PulseNumber{offender.node.ID(): expiry}, nodeKeeper.GetBans())
+
+	// ban expires at the agreed pulse number regardless of how many pulses the node has seen
+	pulse.PulseNumber = expiry - 1
+	pm.updateBans(context.Background(), pulse, &ThirdPhaseState{})
+	require.Len(t, nodeKeeper.GetBans(), 1)
+	pulse.PulseNumber = expiry
+	pm.updateBans(context.Background(), pulse, &ThirdPhaseState{})
+	require.Empty(t, nodeKeeper.GetBans())
+}
+
+func TestFirstPhase_filterClaims_DropsBannedJoinClaim(t *testing.T) {
+	pulse := newViolationTestPulse()
+	fp, nodeKeeper := newViolationTestFirstPhase(t, pulse)
+	joiner := newViolationTestNode(t)
+	claim := joiner.claim(t)
+
+	nodeKeeper.SetBans(map[insolar.Reference]insolar.PulseNumber{joiner.node.ID(): pulse.PulseNumber + 1})
+	claims := fp.filterClaims(context.Background(), pulse, nodeKeeper.GetOrigin().ID(), []packets.ReferendumClaim{claim})
+	require.Empty(t, claims)
+
+	nodeKeeper.SetBans(map[insolar.Reference]insolar.PulseNumber{joiner.node.ID(): pulse.PulseNumber})
+	claims = fp.filterClaims(context.Background(), pulse, nodeKeeper.GetOrigin().ID(), []packets.ReferendumClaim{claim})
+	require.Equal(t, []packets.ReferendumClaim{claim}, claims)
+}
+
+func TestExcludeViolators(t *testing.T) {
+	pulse := newViolationTestPulse()
+	blamed := newViolationTestNode(t)
+	banned := newViolationTestNode(t)
+	honest := newViolationTestNode(t)
+
+	proofs := map[insolar.NetworkNode]*merkle.PulseProof{
+		blamed.node: {},
+		banned.node: {},
+		honest.node: {},
+	}
+	bans := map[insolar.Reference]insolar.PulseNumber{banned.node.ID(): pulse.PulseNumber + 1}
+	excludeViolators(context.Background(), bans, pulse.PulseNumber, proofs)
+	require.Equal(t, map[insolar.NetworkNode]*merkle.PulseProof{blamed.node: {}, honest.node: {}}, proofs)
+}
+
+// TestPartiallyDeliveredBlame checks that nodes which received a blame and nodes which didn't
+// exclude the same nodes: the blame takes effect only after it is approved by consensus.
+func TestPartiallyDeliveredBlame(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	first := newViolationTestNode(t)
+	second := newViolationTestNode(t)
+	active := []insolar.NetworkNode{offender.node, first.node, second.node}
+	blame := &packets.NodeViolationBlame{
+		BlameNodeID:   uint32(offender.node.ShortID()),
+		TypeViolation: packets.ViolationEquivocation,
+	}
+
+	// first node has got the blame in claims, second one hasn't
+	pms := make([]*Phases, 0, 2)
+	for _, n := range []*violationTestNode{first, second} {
+		nodeKeeper := nodenetwork.NewNodeKeeper(n.node)
+		nodeKeeper.SetInitialSnapshot(active)
+		pm := NewPhaseManager(configuration.ServiceNetwork{ViolationBanPulses: 1}).(*Phases)
+		pm.NodeKeeper = nodeKeeper
+		pms = append(pms, pm)
+	}
+
+	excluded := func(pm *Phases, pulseNumber insolar.PulseNumber) map[insolar.NetworkNode]*merkle.PulseProof {
+		proofs := map[insolar.NetworkNode]*merkle.PulseProof{offender.node: {}, first.node: {}, second.node: {}}
+		excludeViolators(context.Background(), pm.NodeKeeper.GetBans(), pulseNumber, proofs)
+		return proofs
+	}
+	require.Equal(t, excluded(pms[0], pulse.PulseNumber), excluded(pms[1], pulse.PulseNumber))
+	require.Len(t, excluded(pms[0], pulse.PulseNumber), 3)
+
+	// consensus approves the blame, both nodes exclude the offender since the next pulse
+	for _, pm := range pms {
+		pm.updateBans(context.Background(), pulse, &ThirdPhaseState{ApprovedClaims: []packets.ReferendumClaim{blame}})
+	}
+	next := pulse.NextPulseNumber
+	require.Equal(t, excluded(pms[0], next), excluded(pms[1], next))
+	require.Equal(t, map[insolar.NetworkNode]*merkle.PulseProof{first.node: {}, second.node: {}}, excluded(pms[0], next))
+}
+
+func TestIsEquivocation(t *testing.T) {
+	pulse := newViolationTestPulse()
+	offender := newViolationTestNode(t)
+	calculator := offender.calculator(t, "state")
+	pulseHash := merkle.PulseHash(platformpolicy.NewPlatformCryptographyScheme(), pulse)
+
+	first := offender.proof(t, pulse, "state1")
+	second := offender.proof(t, pulse, "state2")
+	require.True(t, isEquivocation(calculator, pulseHash, offender.node, &first, &second))
+
+	// the same state signed twice is not equivocation even if signatures differ
+	same := offender.proof(t, pulse, "state1")
+	require.False(t, isEquivocation(calculator, pulseHash, offender.node, &first, &same))
+
+	forged := second
+	forged.NodeSignature[0]++
+	require.False(t, isEquivocation(calculator, pulseHash, offender.node, &first, &forged))
+	require.False(t, isEquivocation(calculator, pulseHash, offender.node, nil, &second))
+}
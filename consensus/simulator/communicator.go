@@ -0,0 +1,148 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package simulator
+
+import (
+	"context"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/insolar"
+)
+
+// communicator is phases.Communicator implementation that exchanges packets through simulated network.
+type communicator struct {
+	network *Network
+	node    *Node
+}
+
+func (c *communicator) Init(ctx context.Context) error {
+	return nil
+}
+
+func participantsSet(participants []insolar.NetworkNode) map[insolar.Reference]bool {
+	result := make(map[insolar.Reference]bool, len(participants))
+	for _, p := range participants {
+		result[p.ID()] = true
+	}
+	return result
+}
+
+// ExchangePhase1 used in first consensus step to exchange data between participants
+func (c *communicator) ExchangePhase1(
+	ctx context.Context,
+	originClaim *packets.NodeAnnounceClaim,
+	participants []insolar.NetworkNode,
+	packet *packets.Phase1Packet,
+) (map[insolar.Reference]*packets.Phase1Packet, error) {
+
+	res := c.network.exchange(c.node, Phase1, &submission{
+		participants: participantsSet(participants),
+		packet:       packet,
+	})
+	result := make(map[insolar.Reference]*packets.Phase1Packet, len(res.packets))
+	for ref, p := range res.packets {
+		if phase1Packet, ok := p.(*packets.Phase1Packet); ok {
+			result[ref] = phase1Packet
+		}
+	}
+	return result, nil
+}
+
+// ExchangePhase2 used in second consensus step to exchange data between participants
+func (c *communicator) ExchangePhase2(ctx context.Context, state *phases.ConsensusState,
+	participants []insolar.NetworkNode, packet *packets.Phase2Packet) (map[insolar.Reference]*packets.Phase2Packet, error) {
+
+	res := c.network.exchange(c.node, Phase2, &submission{
+		participants: participantsSet(participants),
+		packet:       packet,
+		state:        state,
+	})
+	result := make(map[insolar.Reference]*packets.Phase2Packet, len(res.packets))
+	for ref, p := range res.packets {
+		if phase2Packet, ok := p.(*packets.Phase2Packet); ok {
+			result[ref] = phase2Packet
+		}
+	}
+	return result, nil
+}
+
+// ExchangePhase21 is used between phases 2 and 3 of consensus to send additional MissingNode requests
+func (c *communicator) ExchangePhase21(ctx context.Context, state *phases.ConsensusState,
+	packet *packets.Phase2Packet, additionalRequests []*phases.AdditionalRequest) ([]packets.ReferendumVote, error) {
+
+	res := c.network.exchange(c.node, Phase21, &submission{
+		packet:   packet,
+		state:    state,
+		requests: additionalRequests,
+	})
+	if res.votes == nil {
+		return make([]packets.ReferendumVote, 0), nil
+	}
+	return res.votes, nil
+}
+
+// ExchangePhase3 used in third consensus step to exchange data between participants
+func (c *communicator) ExchangePhase3(ctx context.Context,
+	participants []insolar.NetworkNode, packet *packets.Phase3Packet) (map[insolar.Reference]*packets.Phase3Packet, error) {
+
+	res := c.network.exchange(c.node, Phase3, &submission{
+		participants: participantsSet(participants),
+		packet:       packet,
+	})
+	result := make(map[insolar.Reference]*packets.Phase3Packet, len(res.packets))
+	for ref, p := range res.packets {
+		if phase3Packet, ok := p.(*packets.Phase3Packet); ok {
+			result[ref] = phase3Packet
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,563 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package simulator
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network/node"
+)
+
+// DefaultPulseDelta is the number of seconds between simulated pulses.
+const DefaultPulseDelta = 10
+
+// phaseWindows mirror timeouts used by phases.Phases, as fractions of pulse duration.
+var phaseWindows = []struct {
+	phase Phase
+	k     float64
+}{
+	{Phase1, 0.3},
+	{Phase2, 0.05},
+	{Phase21, 0.05},
+	{Phase3, 0.05},
+}
+
+// RoundResult contains outcome of a single consensus round.
+type RoundResult struct {
+	Pulse insolar.Pulse
+	// Errors contains nodes that failed to complete the round.
+	Errors map[insolar.Reference]error
+}
+
+// Network runs virtual nodes through consensus rounds in one process.
+// Time in the network is virtual: packet delays are compared against phase windows instead of
+// real timeouts, so the outcome of a round depends only on the scripted rules.
+type Network struct {
+	lock sync.Mutex
+	cond *sync.Cond
+
+	nodes        []*Node
+	rules        []Rule
+	defaultDelay time.Duration
+	pulseDelta   insolar.PulseNumber
+	pulse        insolar.Pulse
+	now          time.Time
+
+	round *round
+}
+
+// NewNetwork creates network of count virtual nodes that are all present in each other's active lists.
+func NewNetwork(ctx context.Context, count int) (*Network, error) {
+	n := &Network{
+		pulseDelta: DefaultPulseDelta,
+		pulse:      *insolar.GenesisPulse,
+		now:        time.Unix(int64(insolar.GenesisPulse.PulseTimestamp), 0).UTC(),
+	}
+	n.cond = sync.NewCond(&n.lock)
+
+	for i := 0; i < count; i++ {
+		node, err := newNode(i)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewNetwork ] failed to create node")
+		}
+		n.nodes = append(n.nodes, node)
+	}
+	for _, node := range n.nodes {
+		err := node.init(ctx, &communicator{network: n, node: node}, n, n.nodes)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewNetwork ] failed to init node")
+		}
+	}
+	return n, nil
+}
+
+// Nodes returns all virtual nodes including crashed ones.
+func (n *Network) Nodes() []*Node {
+	return n.nodes
+}
+
+// Node returns virtual node by index.
+func (n *Network) Node(index int) *Node {
+	return n.nodes[index]
+}
+
+// LiveNodes returns nodes that were not crashed.
+func (n *Network) LiveNodes() []*Node {
+	result := make([]*Node, 0, len(n.nodes))
+	for _, node := range n.nodes {
+		if !node.crashed {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// Now returns current virtual time.
+func (n *Network) Now() time.Time {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.now
+}
+
+// Pulse returns the last pulse the network ran consensus on.
+func (n *Network) Pulse() insolar.Pulse {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return n.pulse
+}
+
+// SetDefaultDelay sets delivery delay applied to every packet before rules.
+func (n *Network) SetDefaultDelay(delay time.Duration) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.defaultDelay = delay
+}
+
+// AddRule adds delivery rule to the network.
+func (n *Network) AddRule(rule Rule) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.rules = append(n.rules, rule)
+}
+
+// ClearRules removes all delivery rules.
+func (n *Network) ClearRules() {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.rules = nil
+}
+
+// Crash stops the node: it does not take part in following rounds and does not answer packets.
+func (n *Network) Crash(ref insolar.Reference) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	for _, node := range n.nodes {
+		if node.id.Equal(ref) {
+			node.crashed = true
+		}
+	}
+}
+
+// RunRound runs a full consensus round on the next pulse on all live nodes and moves synced lists to active.
+func (n *Network) RunRound(ctx context.Context) (*RoundResult, error) {
+	n.lock.Lock()
+	if n.round != nil {
+		n.lock.Unlock()
+		return nil, errors.New("[ RunRound ] previous round is still in progress")
+	}
+	pulse := n.nextPulse()
+	live := n.LiveNodes()
+	r := newRound(pulse, n.now, live)
+	n.round = r
+	n.lock.Unlock()
+
+	errs := make([]error, len(live))
+	wg := sync.WaitGroup{}
+	wg.Add(len(live))
+	for i, node := range live {
+		go func(i int, node *Node) {
+			defer wg.Done()
+			defer n.finish(node)
+
+			errs[i] = node.phaseManager.OnPulse(ctx, &pulse, r.start)
+		}(i, node)
+	}
+	wg.Wait()
+
+	result := &RoundResult{Pulse: pulse, Errors: make(map[insolar.Reference]error)}
+	for i, node := range live {
+		if errs[i] != nil {
+			inslogger.FromContext(ctx).Warnf("[ RunRound ] node %s failed consensus: %s", node.id, errs[i])
+			result.Errors[node.id] = errs[i]
+			continue
+		}
+		err := node.nodeKeeper.MoveSyncToActive(ctx)
+		if err != nil {
+			result.Errors[node.id] = errors.Wrap(err, "[ RunRound ] failed to move sync list to active")
+		}
+	}
+
+	n.lock.Lock()
+	n.pulse = pulse
+	n.now = r.start.Add(time.Duration(n.pulseDelta) * time.Second)
+	n.round = nil
+	n.lock.Unlock()
+	return result, nil
+}
+
+// RunRounds runs count consensus rounds one after another.
+func (n *Network) RunRounds(ctx context.Context, count int) ([]*RoundResult, error) {
+	results := make([]*RoundResult, 0, count)
+	for i := 0; i < count; i++ {
+		result, err := n.RunRound(ctx)
+		if err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// CheckAgreement checks that all live nodes have the same snapshot, cloud hash and active list.
+func (n *Network) CheckAgreement() error {
+	return CheckAgreement(n.LiveNodes()...)
+}
+
+// CheckAgreement checks that nodes have the same snapshot, cloud hash and active list.
+// Snapshots are compared regardless of node order inside lists.
+func CheckAgreement(nodes ...*Node) error {
+	if len(nodes) == 0 {
+		return nil
+	}
+	first := nodes[0]
+	firstSnapshot := snapshotDigest(first.Snapshot())
+	for _, node := range nodes[1:] {
+		if snapshotDigest(node.Snapshot()) != firstSnapshot {
+			return errors.Errorf("snapshot of node %s differs from snapshot of node %s", node.id, first.id)
+		}
+		if !bytes.Equal(first.CloudHash(), node.CloudHash()) {
+			return errors.Errorf("cloud hash of node %s differs from cloud hash of node %s", node.id, first.id)
+		}
+	}
+	return nil
+}
+
+func snapshotDigest(snapshot *node.Snapshot) string {
+	nodes := node.GetSnapshotActiveNodes(snapshot)
+	sort.Slice(nodes, func(i, j int) bool {
+		return nodes[i].ID().Compare(nodes[j].ID()) < 0
+	})
+	buf := bytes.NewBufferString(fmt.Sprintf("%d", snapshot.GetPulse()))
+	for _, n := range nodes {
+		buf.WriteString(fmt.Sprintf("|%s:%d:%d:%d", n.ID(), n.ShortID(), n.GetState(), n.LeavingETA()))
+	}
+	return buf.String()
+}
+
+func (n *Network) nextPulse() insolar.Pulse {
+	pn := n.pulse.PulseNumber + n.pulseDelta
+	buf := make([]byte, 4)
+	binary.BigEndian.PutUint32(buf, uint32(pn))
+	pulse := insolar.Pulse{
+		PulseNumber:      pn,
+		PrevPulseNumber:  n.pulse.PulseNumber,
+		NextPulseNumber:  pn + n.pulseDelta,
+		PulseTimestamp:   n.now.Unix(),
+		EpochPulseNumber: n.pulse.EpochPulseNumber,
+		Entropy:          insolar.Entropy(sha512.Sum512(buf)),
+	}
+	return pulse
+}
+
+func (n *Network) node(ref insolar.Reference) *Node {
+	for _, node := range n.nodes {
+		if node.id.Equal(ref) {
+			return node
+		}
+	}
+	return nil
+}
+
+// exchange blocks until every running node submitted its packet for the phase (or finished the round)
+// and returns what the node receives during the phase window.
+func (n *Network) exchange(node *Node, phase Phase, sub *submission) *exchangeResult {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	if n.round == nil {
+		return &exchangeResult{}
+	}
+	st := n.round.stage(phase)
+	sub.node = node
+	st.submissions[node.id] = sub
+	n.tryComplete(st)
+	for !st.complete {
+		n.cond.Wait()
+	}
+	return st.results[node.id]
+}
+
+func (n *Network) finish(node *Node) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	delete(n.round.running, node.id)
+	for _, st := range n.round.stages {
+		n.tryComplete(st)
+	}
+}
+
+func (n *Network) tryComplete(st *stage) {
+	if st.complete {
+		return
+	}
+	for ref := range n.round.running {
+		if _, ok := st.submissions[ref]; !ok {
+			return
+		}
+	}
+
+	receivers := st.sortedSubmissions()
+	for _, rcv := range receivers {
+		if st.phase == Phase21 {
+			st.results[rcv.node.id] = n.computeAdditional(st, rcv)
+		} else {
+			st.results[rcv.node.id] = n.computeExchange(st, rcv, receivers)
+		}
+	}
+	st.complete = true
+	n.now = st.start.Add(st.window)
+	n.cond.Broadcast()
+}
+
+func (n *Network) computeExchange(st *stage, rcv *submission, senders []*submission) *exchangeResult {
+	result := &exchangeResult{packets: map[insolar.Reference]packets.ConsensusPacket{rcv.node.id: rcv.packet}}
+	for _, snd := range senders {
+		if snd == rcv {
+			continue
+		}
+		if snd.participants[rcv.node.id] {
+			// sender broadcasts its packet to receiver
+			if packet, delay, ok := n.deliver(st, snd.node, rcv.node, snd.packet); ok && delay <= st.window {
+				result.packets[snd.node.id] = packet
+			}
+			continue
+		}
+		if !rcv.participants[snd.node.id] {
+			continue
+		}
+		// sender does not know receiver and only answers on its request
+		_, requestDelay, ok := n.deliver(st, rcv.node, snd.node, rcv.packet)
+		if !ok || requestDelay > st.window {
+			continue
+		}
+		packet, responseDelay, ok := n.deliver(st, snd.node, rcv.node, snd.packet)
+		if ok && requestDelay+responseDelay <= st.window {
+			result.packets[snd.node.id] = packet
+		}
+	}
+	return result
+}
+
+func (n *Network) computeAdditional(st *stage, rcv *submission) *exchangeResult {
+	result := &exchangeResult{votes: make([]packets.ReferendumVote, 0)}
+	filter := make(map[int]bool)
+	for _, req := range rcv.requests {
+		filter[req.RequestIndex] = true
+	}
+
+	origReq, ok := rcv.packet.(*packets.Phase2Packet)
+	if !ok {
+		return result
+	}
+	for _, req := range rcv.requests {
+		if len(req.Candidates) == 0 {
+			continue
+		}
+		candidate, ok := st.submissions[req.Candidates[0]]
+		if !ok {
+			continue
+		}
+		request := origReq.Clone().(*packets.Phase2Packet)
+		request.AddVote(&packets.MissingNode{NodeIndex: uint16(req.RequestIndex)})
+		delivered, requestDelay, ok := n.deliver(st, rcv.node, candidate.node, request)
+		if !ok || requestDelay > st.window {
+			continue
+		}
+		candidatePacket, ok := candidate.packet.(*packets.Phase2Packet)
+		if !ok {
+			continue
+		}
+		response, err := phases.GeneratePhase2Response(context.Background(), candidatePacket,
+			delivered.(*packets.Phase2Packet), candidate.state)
+		if err != nil {
+			continue
+		}
+		answer, responseDelay, ok := n.deliver(st, candidate.node, rcv.node, response)
+		if !ok || requestDelay+responseDelay > st.window {
+			continue
+		}
+		answerPacket, ok := answer.(*packets.Phase2Packet)
+		if !ok {
+			continue
+		}
+		for _, vote := range answerPacket.GetVotes() {
+			switch v := vote.(type) {
+			case *packets.MissingNodeSupplementaryVote:
+				if filter[int(v.NodeIndex)] {
+					result.votes = append(result.votes, v)
+				}
+			case *packets.MissingNodeClaim:
+				if filter[int(v.NodeIndex)] {
+					result.votes = append(result.votes, v)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// deliver applies rules to the packet copy, signs it with sender key and returns it with resulting delay.
+func (n *Network) deliver(st *stage, from, to *Node, packet packets.ConsensusPacket) (packets.ConsensusPacket, time.Duration, bool) {
+	d := &Delivery{
+		Pulse:  n.round.pulse.PulseNumber,
+		Phase:  st.phase,
+		From:   from.id,
+		To:     to.id,
+		Packet: packet.Clone(),
+		Delay:  n.defaultDelay,
+	}
+	for _, rule := range n.rules {
+		rule(d)
+	}
+	if d.Drop || d.Packet == nil {
+		return nil, 0, false
+	}
+	if p, ok := d.Packet.(interface{ GetPulseNumber() insolar.PulseNumber }); ok && p.GetPulseNumber() != d.Pulse {
+		return nil, 0, false
+	}
+
+	d.Packet.SetRouting(from.shortID, to.shortID)
+	if err := d.Packet.Sign(from.cryptography); err != nil {
+		return nil, 0, false
+	}
+	return d.Packet, d.Delay, true
+}
+
+type submission struct {
+	node         *Node
+	participants map[insolar.Reference]bool
+	packet       packets.ConsensusPacket
+	state        *phases.ConsensusState
+	requests     []*phases.AdditionalRequest
+}
+
+type exchangeResult struct {
+	packets map[insolar.Reference]packets.ConsensusPacket
+	votes   []packets.ReferendumVote
+}
+
+type stage struct {
+	phase       Phase
+	start       time.Time
+	window      time.Duration
+	submissions map[insolar.Reference]*submission
+	results     map[insolar.Reference]*exchangeResult
+	complete    bool
+}
+
+func (st *stage) sortedSubmissions() []*submission {
+	result := make([]*submission, 0, len(st.submissions))
+	for _, sub := range st.submissions {
+		result = append(result, sub)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].node.id.Compare(result[j].node.id) < 0
+	})
+	return result
+}
+
+type round struct {
+	pulse   insolar.Pulse
+	start   time.Time
+	running map[insolar.Reference]bool
+	stages  map[Phase]*stage
+}
+
+func newRound(pulse insolar.Pulse, start time.Time, nodes []*Node) *round {
+	r := &round{
+		pulse:   pulse,
+		start:   start,
+		running: make(map[insolar.Reference]bool),
+		stages:  make(map[Phase]*stage),
+	}
+	for _, node := range nodes {
+		r.running[node.id] = true
+	}
+
+	duration := time.Duration(pulse.NextPulseNumber-pulse.PulseNumber) * time.Second
+	stageStart := start
+	for _, w := range phaseWindows {
+		window := time.Duration(w.k * float64(duration))
+		r.stages[w.phase] = &stage{
+			phase:       w.phase,
+			start:       stageStart,
+			window:      window,
+			submissions: make(map[insolar.Reference]*submission),
+			results:     make(map[insolar.Reference]*exchangeResult),
+		}
+		stageStart = stageStart.Add(window)
+	}
+	return r
+}
+
+func (r *round) stage(phase Phase) *stage {
+	return r.stages[phase]
+}
@@ -0,0 +1,213 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package simulator
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/node"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// Node is a virtual node running real consensus phases on top of simulated network.
+type Node struct {
+	id           insolar.Reference
+	shortID      insolar.ShortNodeID
+	role         insolar.StaticRole
+	address      string
+	privateKey   crypto.PrivateKey
+	publicKey    crypto.PublicKey
+	cryptography insolar.CryptographyService
+
+	nodeKeeper   network.NodeKeeper
+	phaseManager phases.PhaseManager
+	phaseTimings network.PhaseTimings
+	cm           *component.Manager
+
+	crashed bool
+}
+
+func newNode(index int) (*Node, error) {
+	kp := platformpolicy.NewKeyProcessor()
+	privateKey, err := kp.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newNode ] failed to generate private key")
+	}
+	hash := sha256.Sum256([]byte(fmt.Sprintf("simulator node %d", index)))
+	id := insolar.NewID(insolar.FirstPulseNumber, hash[:])
+
+	return &Node{
+		id:           *insolar.NewReference(*id, *id),
+		role:         insolar.StaticRoleVirtual,
+		address:      fmt.Sprintf("127.0.0.1:%d", 10000+index),
+		privateKey:   privateKey,
+		publicKey:    kp.ExtractPublicKey(privateKey),
+		cryptography: cryptography.NewKeyBoundCryptographyService(privateKey),
+	}, nil
+}
+
+// networkNode returns new instance of node record. Each virtual node gets its own copies to avoid shared state.
+func (n *Node) networkNode() insolar.NetworkNode {
+	return node.NewNode(n.id, n.role, n.publicKey, n.address, "")
+}
+
+func (n *Node) init(ctx context.Context, comm phases.Communicator, clock phases.Clock, nodes []*Node) error {
+	origin := n.networkNode()
+	n.shortID = origin.ShortID()
+
+	active := make([]insolar.NetworkNode, 0, len(nodes))
+	for _, other := range nodes {
+		if other == n {
+			active = append(active, origin)
+			continue
+		}
+		active = append(active, other.networkNode())
+	}
+	n.nodeKeeper = nodenetwork.NewNodeKeeper(origin)
+	n.nodeKeeper.SetInitialSnapshot(active)
+	// phases measure durations in virtual time of the network, real timeouts of phase contexts are ignored by communicator
+	n.phaseManager = phases.NewPhaseManagerWithClock(configuration.NewServiceNetwork(), clock)
+	n.phaseTimings = phases.NewPhaseTimings(configuration.NewServiceNetwork())
+
+	n.cm = component.NewManager(nil)
+	n.cm.Inject(
+		n.cryptography,
+		platformpolicy.NewPlatformCryptographyScheme(),
+		n.nodeKeeper,
+		merkle.NewCalculator(),
+		&stater{id: n.id},
+		&pulseManager{},
+		comm,
+		phases.NewFirstPhase(),
+		phases.NewSecondPhase(),
+		phases.NewThirdPhase(),
+		n.phaseTimings,
+		n.phaseManager,
+	)
+	return n.cm.Init(ctx)
+}
+
+// ID returns node reference.
+func (n *Node) ID() insolar.Reference {
+	return n.id
+}
+
+// ShortID returns node short ID.
+func (n *Node) ShortID() insolar.ShortNodeID {
+	return n.shortID
+}
+
+// NodeKeeper returns node keeper of the virtual node.
+func (n *Node) NodeKeeper() network.NodeKeeper {
+	return n.nodeKeeper
+}
+
+// Snapshot returns copy of current node snapshot.
+func (n *Node) Snapshot() *node.Snapshot {
+	return n.nodeKeeper.GetSnapshotCopy()
+}
+
+// CloudHash returns cloud hash calculated in the last consensus round.
+func (n *Node) CloudHash() []byte {
+	return n.nodeKeeper.GetCloudHash()
+}
+
+// ActiveNodes returns sorted references of nodes in current active list.
+func (n *Node) ActiveNodes() []insolar.Reference {
+	return sortedRefs(n.nodeKeeper.GetAccessor().GetActiveNodes())
+}
+
+// Crashed returns true if the node was stopped with Network.Crash.
+func (n *Node) Crashed() bool {
+	return n.crashed
+}
+
+func sortedRefs(nodes []insolar.NetworkNode) []insolar.Reference {
+	result := make([]insolar.Reference, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, n.ID())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Compare(result[j]) < 0
+	})
+	return result
+}
+
+// stater provides constant node state hash for pulse proofs.
+type stater struct {
+	id insolar.Reference
+}
+
+func (s *stater) State() ([]byte, error) {
+	hash := sha512.Sum512(s.id[:])
+	return hash[:], nil
+}
+
+type pulseManager struct{}
+
+func (pm *pulseManager) Set(ctx context.Context, pulse insolar.Pulse, persist bool) error {
+	return nil
+}
@@ -0,0 +1,155 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package simulator
+
+import (
+	"time"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/insolar"
+)
+
+// Phase identifies consensus exchange stage.
+type Phase int
+
+const (
+	// Phase1 is exchange of pulse proofs and claims.
+	Phase1 = Phase(iota + 1)
+	// Phase2 is exchange of bitsets and globule hash signatures.
+	Phase2
+	// Phase21 is exchange of additional MissingNode requests and responses.
+	Phase21
+	// Phase3 is exchange of final bitsets.
+	Phase3
+)
+
+func (p Phase) String() string {
+	switch p {
+	case Phase1:
+		return "Phase1"
+	case Phase2:
+		return "Phase2"
+	case Phase21:
+		return "Phase2.1"
+	case Phase3:
+		return "Phase3"
+	default:
+		return "Unknown"
+	}
+}
+
+// Delivery describes a single packet sent from one virtual node to another.
+// Rules can change delay, drop the packet or replace it with a forged one.
+type Delivery struct {
+	Pulse insolar.PulseNumber
+	Phase Phase
+	From  insolar.Reference
+	To    insolar.Reference
+
+	// Packet is unsigned copy of the packet. It is signed with sender key after all rules are applied.
+	Packet packets.ConsensusPacket
+	Delay  time.Duration
+	Drop   bool
+}
+
+// Rule modifies delivery of a packet. Rules are applied in order they were added to the network.
+type Rule func(d *Delivery)
+
+func anyPhase(phase Phase, phases []Phase) bool {
+	if len(phases) == 0 {
+		return true
+	}
+	for _, p := range phases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// DelayLink adds delay to packets sent from one node to another in specified phases (all phases if none set).
+func DelayLink(from, to insolar.Reference, delay time.Duration, phases ...Phase) Rule {
+	return func(d *Delivery) {
+		if d.From.Equal(from) && d.To.Equal(to) && anyPhase(d.Phase, phases) {
+			d.Delay += delay
+		}
+	}
+}
+
+// DropLink drops packets sent from one node to another in specified phases (all phases if none set).
+func DropLink(from, to insolar.Reference, phases ...Phase) Rule {
+	return func(d *Delivery) {
+		if d.From.Equal(from) && d.To.Equal(to) && anyPhase(d.Phase, phases) {
+			d.Drop = true
+		}
+	}
+}
+
+// Isolate drops all packets sent to or from the node in specified phases (all phases if none set).
+func Isolate(ref insolar.Reference, phases ...Phase) Rule {
+	return func(d *Delivery) {
+		if (d.From.Equal(ref) || d.To.Equal(ref)) && anyPhase(d.Phase, phases) {
+			d.Drop = true
+		}
+	}
+}
+
+// Tamper makes the node byzantine: f is called for every packet the node sends in the phase
+// and may return modified or completely different packet. Returned packet is signed with node key.
+func Tamper(from insolar.Reference, phase Phase,
+	f func(to insolar.Reference, packet packets.ConsensusPacket) packets.ConsensusPacket) Rule {
+
+	return func(d *Delivery) {
+		if d.From.Equal(from) && d.Phase == phase {
+			d.Packet = f(d.To, d.Packet)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package simulator
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/insolar"
+)
+
+func refs(nodes ...*Node) []insolar.Reference {
+	result := make([]insolar.Reference, 0, len(nodes))
+	for _, n := range nodes {
+		result = append(result, n.ID())
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Compare(result[j]) < 0
+	})
+	return result
+}
+
+func requireActive(t *testing.T, expected []*Node, nodes ...*Node) {
+	for _, n := range nodes {
+		require.Equal(t, refs(expected...), n.ActiveNodes())
+	}
+	require.NoError(t, CheckAgreement(nodes...))
+}
+
+func TestNetwork_NormalRounds(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	start := n.Now()
+	results, err := n.RunRounds(ctx, 3)
+	require.NoError(t, err)
+	for _, r := range results {
+		require.Empty(t, r.Errors)
+	}
+
+	require.Equal(t, start.Add(3*DefaultPulseDelta*time.Second), n.Now())
+	require.Equal(t, insolar.PulseNumber(insolar.FirstPulseNumber+3*DefaultPulseDelta), n.Pulse().PulseNumber)
+	requireActive(t, n.Nodes(), n.Nodes()...)
+}
+
+func TestNetwork_CrashedNodeExcluded(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	crashed := n.Node(4)
+	n.Crash(crashed.ID())
+	result, err := n.RunRound(ctx)
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+
+	require.True(t, crashed.Crashed())
+	requireActive(t, n.Nodes()[:4], n.LiveNodes()...)
+}
+
+func TestNetwork_DelayWithinPhaseWindow(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	n.SetDefaultDelay(100 * time.Millisecond)
+	n.AddRule(DelayLink(n.Node(0).ID(), n.Node(1).ID(), 2*time.Second, Phase1))
+	result, err := n.RunRound(ctx)
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+
+	requireActive(t, n.Nodes(), n.Nodes()...)
+}
+
+func TestNetwork_DroppedLinkRecoveredInPhase21(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	n.AddRule(DropLink(n.Node(0).ID(), n.Node(1).ID(), Phase1))
+	result, err := n.RunRound(ctx)
+	require.NoError(t, err)
+	require.Empty(t, result.Errors)
+
+	requireActive(t, n.Nodes(), n.Nodes()...)
+}
+
+func TestNetwork_SlowNodeExcluded(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	slow := n.Node(0)
+	for _, other := range n.Nodes()[1:] {
+		n.AddRule(DelayLink(slow.ID(), other.ID(), 5*time.Second))
+	}
+	result, err := n.RunRound(ctx)
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	require.Contains(t, result.Errors, slow.ID())
+
+	requireActive(t, n.Nodes()[1:], n.Nodes()[1:]...)
+}
+
+func TestNetwork_ByzantineProofExcluded(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 5)
+	require.NoError(t, err)
+
+	byzantine := n.Node(0)
+	n.AddRule(Tamper(byzantine.ID(), Phase1, func(to insolar.Reference, packet packets.ConsensusPacket) packets.ConsensusPacket {
+		p := packet.(*packets.Phase1Packet)
+		proof := p.GetPulseProof()
+		err := p.SetPulseProof(proof.StateHash(), make([]byte, packets.SignatureLength))
+		require.NoError(t, err)
+		return p
+	}))
+	result, err := n.RunRound(ctx)
+	require.NoError(t, err)
+	require.Contains(t, result.Errors, byzantine.ID())
+
+	requireActive(t, n.Nodes()[1:], n.Nodes()[1:]...)
+}
+
+func TestNetwork_Deterministic(t *testing.T) {
+	ctx := context.Background()
+	run := func() [][]insolar.Reference {
+		n, err := NewNetwork(ctx, 7)
+		require.NoError(t, err)
+		n.SetDefaultDelay(50 * time.Millisecond)
+		n.AddRule(DropLink(n.Node(1).ID(), n.Node(2).ID(), Phase1, Phase2))
+		n.AddRule(DelayLink(n.Node(3).ID(), n.Node(4).ID(), time.Second))
+		n.AddRule(Isolate(n.Node(6).ID()))
+		_, err = n.RunRounds(ctx, 2)
+		require.NoError(t, err)
+
+		result := make([][]insolar.Reference, 0)
+		for _, node := range n.Nodes() {
+			result = append(result, node.ActiveNodes())
+		}
+		return result
+	}
+
+	first := run()
+	for i := 0; i < 3; i++ {
+		require.Equal(t, first, run())
+	}
+}
+
+func TestNetwork_PhaseDurationsInVirtualTime(t *testing.T) {
+	ctx := context.Background()
+	n, err := NewNetwork(ctx, 3)
+	require.NoError(t, err)
+
+	_, err = n.RunRounds(ctx, 2)
+	require.NoError(t, err)
+
+	// phases last for their windows regardless of real time spent on them
+	window := time.Duration(phaseWindows[0].k * float64(DefaultPulseDelta*time.Second))
+	for _, node := range n.Nodes() {
+		status := node.phaseTimings.Status()
+		require.Equal(t, window, status.Phases[0].LastDuration)
+	}
+}
@@ -178,6 +178,68 @@ func (cr *ContractRequester) CallMethod(ctx context.Context, base insolar.Messag
 	return result, nil
 }
 
+// SendQuery makes synchronously call to immutable method of contract by its ref without additional information
+func (cr *ContractRequester) SendQuery(ctx context.Context, ref *insolar.Reference, method string, argsIn []interface{}) (insolar.Reply, error) {
+	ctx, span := instracer.StartSpan(ctx, "SendQuery "+method)
+	defer span.End()
+
+	args, err := insolar.MarshalArgs(argsIn...)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ContractRequester::SendQuery ] Can't marshal")
+	}
+
+	bm := &message.BaseLogicMessage{
+		Nonce: randomUint64(),
+	}
+	res, err := cr.CallQuery(ctx, bm, ref, method, args, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ ContractRequester::SendQuery ] Can't route call")
+	}
+
+	return res, nil
+}
+
+// CallQuery calls immutable method of contract. Executor replies with results right away,
+// request and result are not registered on ledger.
+func (cr *ContractRequester) CallQuery(ctx context.Context, base insolar.Message, ref *insolar.Reference, method string, argsIn insolar.Arguments, mustPrototype *insolar.Reference) (insolar.Reply, error) {
+	ctx, span := instracer.StartSpan(ctx, "ContractRequester.CallQuery "+method)
+	defer span.End()
+
+	baseMessage, ok := base.(*message.BaseLogicMessage)
+	if !ok {
+		return nil, errors.New("Wrong type for BaseMessage")
+	}
+
+	mb := insolar.MessageBusFromContext(ctx, cr.MessageBus)
+	if mb == nil {
+		inslogger.FromContext(ctx).Debug("Context doesn't provide MessageBus")
+		mb = cr.MessageBus
+	}
+
+	msg := &message.CallMethod{
+		BaseLogicMessage: *baseMessage,
+		ReturnMode:       message.ReturnResult,
+		ObjectRef:        *ref,
+		Method:           method,
+		Arguments:        argsIn,
+		Immutable:        true,
+	}
+	if mustPrototype != nil {
+		msg.ProxyPrototype = *mustPrototype
+	}
+
+	res, err := mb.Send(ctx, msg, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "couldn't dispatch event")
+	}
+
+	if _, ok := res.(*reply.CallMethod); !ok {
+		return nil, errors.New("Got not reply.CallMethod in reply for immutable CallMethod")
+	}
+
+	return res, nil
+}
+
 func (cr *ContractRequester) CallConstructor(ctx context.Context, base insolar.Message, async bool,
 	prototype *insolar.Reference, to *insolar.Reference, method string,
 	argsIn insolar.Arguments, saveAs int) (*insolar.Reference, error) {
@@ -208,3 +208,40 @@ func TestCallMethodWaitResults(t *testing.T) {
 	_, err = cr.CallMethod(ctx, msg, false, &ref, method, insolar.Arguments{}, &prototypeRef)
 	require.NoError(t, err)
 }
+
+func TestCallQuery(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+
+	cr, err := New()
+	require.NoError(t, err)
+
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	mb := testutils.NewMessageBusMock(mc)
+	cr.MessageBus = mb
+
+	ref := testutils.RandomRef()
+	method := testutils.RandomString()
+	result := []byte{1, 2, 3}
+
+	mb.SendFunc = func(p context.Context, p1 insolar.Message, p2 *insolar.MessageSendOptions) (r insolar.Reply, r1 error) {
+		msg, ok := p1.(*message.CallMethod)
+		require.True(t, ok)
+		require.True(t, msg.Immutable)
+		require.Equal(t, ref, msg.ObjectRef)
+		require.Equal(t, method, msg.Method)
+		return &reply.CallMethod{Result: result}, nil
+	}
+
+	rep, err := cr.SendQuery(ctx, &ref, method, []interface{}{})
+	require.NoError(t, err)
+	require.Equal(t, result, rep.(*reply.CallMethod).Result)
+	require.Empty(t, cr.ResultMap)
+
+	mb.SendFunc = func(p context.Context, p1 insolar.Message, p2 *insolar.MessageSendOptions) (r insolar.Reply, r1 error) {
+		return &reply.RegisterRequest{}, nil
+	}
+	_, err = cr.SendQuery(ctx, &ref, method, []interface{}{})
+	require.Error(t, err)
+}
@@ -18,6 +18,7 @@ package genesis
 
 import (
 	"context"
+	"encoding/json"
 	"go/build"
 	"io/ioutil"
 	"os"
@@ -28,6 +29,7 @@ import (
 	"github.com/insolar/insolar/insolar/message"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/builtin"
 	"github.com/insolar/insolar/logicrunner/goplugin/preprocessor"
 	"github.com/pkg/errors"
 )
@@ -69,6 +71,8 @@ type ContractsBuilder struct {
 	ArtifactManager artifacts.Client
 	Prototypes      map[string]*insolar.Reference
 	Codes           map[string]*insolar.Reference
+	// BuiltinContracts are deployed as insolar.MachineTypeBuiltin instead of plugins
+	BuiltinContracts map[string]bool
 }
 
 // NewContractBuilder returns a new `ContractsBuilder`, takes in: path to tmp directory,
@@ -80,10 +84,11 @@ func NewContractBuilder(am artifacts.Client) *ContractsBuilder {
 	}
 
 	cb := &ContractsBuilder{
-		root:            tmpDir,
-		Prototypes:      make(map[string]*insolar.Reference),
-		Codes:           make(map[string]*insolar.Reference),
-		ArtifactManager: am}
+		root:             tmpDir,
+		Prototypes:       make(map[string]*insolar.Reference),
+		Codes:            make(map[string]*insolar.Reference),
+		BuiltinContracts: make(map[string]bool),
+		ArtifactManager:  am}
 	return cb
 }
 
@@ -137,6 +142,10 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 			return errors.Wrap(err, "[ Build ] Can't write proxy")
 		}
 
+		if cb.BuiltinContracts[name] {
+			continue
+		}
+
 		wrp, err := OpenFile(filepath.Join(cb.root, "src/contract", name), "main_wrapper.go")
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't open wrapper file")
@@ -148,17 +157,14 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 		}
 	}
 
-	for name := range contracts {
-		log.Debugf("Building plugin for contract %q in %q", name, cb.root)
-		err := cb.plugin(name)
+	for name, parsed := range contracts {
+		code, machineType, err := cb.code(name)
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't call plugin")
+			return errors.Wrap(err, "[ Build ]")
 		}
-		log.Debugf("Built plugin for contract %q", name)
-
-		pluginBinary, err := ioutil.ReadFile(filepath.Join(cb.root, "plugins", name+".so"))
+		abi, err := json.Marshal(parsed.ABI())
 		if err != nil {
-			return errors.Wrap(err, "[ Build ] Can't ReadFile")
+			return errors.Wrap(err, "[ Build ] Can't marshal ABI")
 		}
 		codeReq, err := cb.ArtifactManager.RegisterRequest(
 			ctx, *domainRef, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_code"}},
@@ -171,13 +177,13 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 		codeID, err := cb.ArtifactManager.DeployCode(
 			ctx,
 			*domainRef, *insolar.NewReference(*domain, *codeReq),
-			pluginBinary, insolar.MachineTypeGoPlugin,
+			code, machineType, abi,
 		)
 		codeRef := insolar.NewReference(*domain, *codeID)
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't SetRecord")
 		}
-		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *codeRef, nil)
+		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *codeRef, nil, insolar.ResourceUsage{}, nil)
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't SetRecord")
 		}
@@ -196,7 +202,7 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't ActivatePrototype")
 		}
-		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *cb.Prototypes[name], nil)
+		_, err = cb.ArtifactManager.RegisterResult(ctx, *domainRef, *cb.Prototypes[name], nil, insolar.ResourceUsage{}, nil)
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't RegisterResult of prototype")
 		}
@@ -205,6 +211,31 @@ func (cb *ContractsBuilder) Build(ctx context.Context, contracts map[string]*pre
 	return nil
 }
 
+// code returns code of the contract to deploy: builtin contracts are referenced by name,
+// others are built as plugins
+func (cb *ContractsBuilder) code(name string) ([]byte, insolar.MachineType, error) {
+	if cb.BuiltinContracts[name] {
+		if !builtin.IsRegistered(name) {
+			return nil, 0, errors.Errorf("builtin contract %q is not registered", name)
+		}
+		log.Debugf("Using builtin version of contract %q", name)
+		return []byte(name), insolar.MachineTypeBuiltin, nil
+	}
+
+	log.Debugf("Building plugin for contract %q in %q", name, cb.root)
+	err := cb.plugin(name)
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Can't call plugin")
+	}
+	log.Debugf("Built plugin for contract %q", name)
+
+	pluginBinary, err := ioutil.ReadFile(filepath.Join(cb.root, "plugins", name+".so"))
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "Can't ReadFile")
+	}
+	return pluginBinary, insolar.MachineTypeGoPlugin, nil
+}
+
 // Plugin ...
 func (cb *ContractsBuilder) plugin(name string) error {
 	dstDir := filepath.Join(cb.root, "plugins")
@@ -46,6 +46,7 @@ type Config struct {
 	PulsarPublicKeys []string `mapstructure:"pulsar_public_keys"`
 	DiscoveryNodes   []Node   `mapstructure:"discovery_nodes"`
 	Nodes            []Node   `mapstructure:"nodes"`
+	BuiltinContracts []string `mapstructure:"builtin_contracts"`
 }
 
 // It's very light check. It's not about majority rule
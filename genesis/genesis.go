@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"strconv"
 
+	_ "github.com/insolar/insolar/application/builtin" // register builtin contracts
 	"github.com/insolar/insolar/application/contract/member"
 	"github.com/insolar/insolar/application/contract/nodedomain"
 	"github.com/insolar/insolar/application/contract/noderecord"
@@ -54,6 +55,15 @@ const (
 
 var contractNames = []string{walletContract, memberContract, allowanceContract, rootDomain, nodeDomain, nodeRecord}
 
+func isContractName(name string) bool {
+	for _, n := range contractNames {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
 type messageBusLocker interface {
 	Lock(ctx context.Context)
 	Unlock(ctx context.Context)
@@ -132,11 +142,12 @@ func (g *Genesis) activateRootDomain(
 		*cb.Prototypes[rootDomain],
 		false,
 		instanceData,
+		cb.Codes[rootDomain],
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ActivateRootDomain ] Couldn't create rootdomain instance")
 	}
-	_, err = g.ArtifactManager.RegisterResult(ctx, *g.ArtifactManager.GenesisRef(), *contract, nil)
+	_, err = g.ArtifactManager.RegisterResult(ctx, *g.ArtifactManager.GenesisRef(), *contract, nil, insolar.ResourceUsage{}, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ActivateRootDomain ] Couldn't create rootdomain instance")
 	}
@@ -172,11 +183,12 @@ func (g *Genesis) activateNodeDomain(
 		*cb.Prototypes[nodeDomain],
 		false,
 		instanceData,
+		cb.Codes[nodeDomain],
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ActivateNodeDomain ] couldn't create nodedomain instance")
 	}
-	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil)
+	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil, insolar.ResourceUsage{}, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ ActivateNodeDomain ] couldn't create nodedomain instance")
 	}
@@ -214,11 +226,12 @@ func (g *Genesis) activateRootMember(
 		*cb.Prototypes[memberContract],
 		false,
 		instanceData,
+		cb.Codes[memberContract],
 	)
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootMember ] couldn't create root member instance")
 	}
-	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil)
+	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil, insolar.ResourceUsage{}, nil)
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootMember ] couldn't create root member instance")
 	}
@@ -240,6 +253,7 @@ func (g *Genesis) updateRootDomain(
 		insolar.Reference{},
 		domainDesc,
 		updateData,
+		nil,
 	)
 	if err != nil {
 		return errors.Wrap(err, "[ updateRootDomain ]")
@@ -276,11 +290,12 @@ func (g *Genesis) activateRootMemberWallet(
 		*cb.Prototypes[walletContract],
 		true,
 		instanceData,
+		cb.Codes[walletContract],
 	)
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootWallet ] couldn't create root wallet")
 	}
-	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil)
+	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil, insolar.ResourceUsage{}, nil)
 	if err != nil {
 		return errors.Wrap(err, "[ ActivateRootWallet ] couldn't create root wallet")
 	}
@@ -421,11 +436,12 @@ func (g *Genesis) activateNodeRecord(ctx context.Context, cb *ContractsBuilder,
 		*cb.Prototypes[nodeRecord],
 		false,
 		nodeData,
+		cb.Codes[nodeRecord],
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ activateNodeRecord ] Could'n activateNodeRecord node object")
 	}
-	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil)
+	_, err = g.ArtifactManager.RegisterResult(ctx, *g.rootDomainRef, *contract, nil, insolar.ResourceUsage{}, nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "[ activateNodeRecord ] Couldn't register result to artifact manager")
 	}
@@ -569,6 +585,13 @@ func (g *Genesis) Start(ctx context.Context) error {
 	g.prototypeRefs = cb.Prototypes
 	defer cb.Clean()
 
+	for _, name := range g.config.BuiltinContracts {
+		if !isContractName(name) {
+			return errors.Errorf("[ Genesis ] unknown builtin contract %q", name)
+		}
+		cb.BuiltinContracts[name] = true
+	}
+
 	err = buildSmartContracts(ctx, cb, rootDomainID)
 	if err != nil {
 		return errors.Wrap(err, "[ Genesis ] couldn't build contracts")
@@ -657,6 +680,7 @@ func (g *Genesis) updateNodeDomainIndex(ctx context.Context, nodeDomainDesc arti
 		*g.nodeDomainRef,
 		nodeDomainDesc,
 		updateData,
+		nil,
 	)
 	if err != nil {
 		return errors.Wrap(err, "[ updateNodeDomainIndex ]  Couldn't update NodeDomain")
@@ -40,10 +40,10 @@ func mockArtifactManager(t *testing.T) *artifacts.ClientMock {
 		id := testutils.RandomID()
 		return &id, nil
 	}
-	amMock.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r artifacts.ObjectDescriptor, r1 error) {
+	amMock.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r artifacts.ObjectDescriptor, r1 error) {
 		return artifacts.NewObjectDescriptorMock(t), nil
 	}
-	amMock.RegisterResultFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) (r *insolar.ID, r1 error) {
+	amMock.RegisterResultFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) (r *insolar.ID, r1 error) {
 		id := testutils.RandomID()
 		return &id, nil
 	}
@@ -215,7 +215,7 @@ func TestActivateNodeRecord_Activate_Err(t *testing.T) {
 		id := testutils.RandomID()
 		return &id, nil
 	}
-	am.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r artifacts.ObjectDescriptor, r1 error) {
+	am.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r artifacts.ObjectDescriptor, r1 error) {
 		return nil, errors.New("test reasons")
 	}
 
@@ -242,10 +242,10 @@ func TestActivateNodeRecord_RegisterResult_Err(t *testing.T) {
 		id := testutils.RandomID()
 		return &id, nil
 	}
-	am.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r artifacts.ObjectDescriptor, r1 error) {
+	am.ActivateObjectFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r artifacts.ObjectDescriptor, r1 error) {
 		return artifacts.NewObjectDescriptorMock(t), nil
 	}
-	am.RegisterResultFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) (r *insolar.ID, r1 error) {
+	am.RegisterResultFunc = func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) (r *insolar.ID, r1 error) {
 		return nil, errors.New("test reasons")
 	}
 
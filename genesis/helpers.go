@@ -72,6 +72,10 @@ func getContractsMap() (map[string]*preprocessor.ParsedFile, error) {
 		if err != nil {
 			return nil, errors.Wrap(err, "[ contractsMap ] couldn't read contract: ")
 		}
+		err = parsed.Check()
+		if err != nil {
+			return nil, errors.Wrap(err, "[ contractsMap ] contract check failed: ")
+		}
 		contracts[name] = parsed
 	}
 	return contracts, nil
@@ -0,0 +1,55 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package insolar
+
+// ContractABI is a machine readable description of the contract interface.
+// It's generated from contract sources and stored alongside the code on deploy.
+type ContractABI struct {
+	// Contract is the name of the contract type.
+	Contract     string
+	Constructors []FunctionABI
+	Methods      []FunctionABI
+}
+
+// FunctionABI describes a method or a constructor of the contract.
+type FunctionABI struct {
+	Name      string
+	Arguments []ParameterABI
+	Results   []ParameterABI
+	// API is true if the function is allowed to be called through API (INSATTR_<Name>_API flag is set).
+	API bool
+	// Immutable is true if the method doesn't change state of the object and can be called
+	// as a query without registering request and result on ledger (`// ins:immutable` directive).
+	Immutable bool
+}
+
+// ParameterABI describes an argument or a result of the contract function.
+// Type is the Go type expression as written in the contract sources, e.g. "[]byte" or "insolar.Reference".
+type ParameterABI struct {
+	Name string `json:",omitempty"`
+	Type string
+}
+
+// Method returns description of the method with provided name or nil if there is no such method.
+func (a *ContractABI) Method(name string) *FunctionABI {
+	for i := range a.Methods {
+		if a.Methods[i].Name == name {
+			return &a.Methods[i]
+		}
+	}
+	return nil
+}
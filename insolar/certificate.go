@@ -32,6 +32,7 @@ type Certificate interface {
 
 	GetRootDomainReference() *Reference
 	GetDiscoveryNodes() []DiscoveryNode
+	GetPulsarPublicKeys() []string
 }
 
 //go:generate minimock -i github.com/insolar/insolar/insolar.DiscoveryNode -o ../testutils -s _mock.go
@@ -30,4 +30,10 @@ type ContractRequester interface {
 		mustPrototype *Reference) (Reply, error)
 	CallConstructor(ctx context.Context, base Message, async bool,
 		prototype *Reference, to *Reference, method string, argsIn Arguments, saveType int) (*Reference, error)
+	// SendQuery calls immutable method of contract, request and result are not registered on ledger
+	SendQuery(ctx context.Context, ref *Reference, method string, argsIn []interface{}) (Reply, error)
+	// CallQuery - low level calls immutable method of contract
+	CallQuery(ctx context.Context, base Message,
+		ref *Reference, method string, argsIn Arguments,
+		mustPrototype *Reference) (Reply, error)
 }
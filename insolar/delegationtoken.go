@@ -22,6 +22,7 @@ type DelegationTokenFactory interface {
 	IssueGetObjectRedirect(sender *Reference, redirectedMessage Message) (DelegationToken, error)
 	IssueGetChildrenRedirect(sender *Reference, redirectedMessage Message) (DelegationToken, error)
 	IssueGetCodeRedirect(sender *Reference, redirectedMessage Message) (DelegationToken, error)
+	IssueGetEventsRedirect(sender *Reference, redirectedMessage Message) (DelegationToken, error)
 	Verify(parcel Parcel) (bool, error)
 }
 
@@ -89,9 +89,25 @@ func (t *GetCodeRedirectToken) Verify(parcel insolar.Parcel) (bool, error) {
 	panic("implement me")
 }
 
+// GetEventsRedirectToken is a redirect token for the GetEvents method
+type GetEventsRedirectToken struct {
+	Signature []byte
+}
+
+// Type implementation of Token interface.
+func (t *GetEventsRedirectToken) Type() insolar.DelegationTokenType {
+	return insolar.DTTypeGetEventsRedirect
+}
+
+// Verify implementation of Token interface.
+func (t *GetEventsRedirectToken) Verify(parcel insolar.Parcel) (bool, error) {
+	panic("implement me")
+}
+
 func init() {
 	gob.Register(&PendingExecutionToken{})
 	gob.Register(&GetObjectRedirectToken{})
 	gob.Register(&GetChildrenRedirectToken{})
 	gob.Register(&GetCodeRedirectToken{})
+	gob.Register(&GetEventsRedirectToken{})
 }
@@ -93,6 +93,19 @@ func (f *delegationTokenFactory) IssueGetCodeRedirect(
 	return &GetCodeRedirectToken{Signature: sign.Bytes()}, nil
 }
 
+// IssueGetEventsRedirect creates new token for provided message.
+func (f *delegationTokenFactory) IssueGetEventsRedirect(
+	sender *insolar.Reference, redirectedMessage insolar.Message,
+) (insolar.DelegationToken, error) {
+	parsedMessage := redirectedMessage.(*message.GetEvents)
+	dataForSign := append(sender.Bytes(), message.ToBytes(parsedMessage)...)
+	sign, err := f.Cryptography.Sign(dataForSign)
+	if err != nil {
+		return nil, err
+	}
+	return &GetEventsRedirectToken{Signature: sign.Bytes()}, nil
+}
+
 // Verify performs token validation.
 func (f *delegationTokenFactory) Verify(parcel insolar.Parcel) (bool, error) {
 	if parcel.DelegationToken() == nil {
@@ -12,11 +12,12 @@ func _() {
 	_ = x[DTTypeGetObjectRedirect-2]
 	_ = x[DTTypeGetChildrenRedirect-3]
 	_ = x[DTTypeGetCodeRedirect-4]
+	_ = x[DTTypeGetEventsRedirect-5]
 }
 
-const _DelegationTokenType_name = "DTTypePendingExecutionDTTypeGetObjectRedirectDTTypeGetChildrenRedirectDTTypeGetCodeRedirect"
+const _DelegationTokenType_name = "DTTypePendingExecutionDTTypeGetObjectRedirectDTTypeGetChildrenRedirectDTTypeGetCodeRedirectDTTypeGetEventsRedirect"
 
-var _DelegationTokenType_index = [...]uint8{0, 22, 45, 70, 91}
+var _DelegationTokenType_index = [...]uint8{0, 22, 45, 70, 91, 114}
 
 func (i DelegationTokenType) String() string {
 	i -= 1
@@ -33,4 +33,8 @@ var (
 	ErrNotFound = errors.New("not found")
 	// ErrTooManyPendingRequests is returned when a limit of pending requests has been reached on a current LME
 	ErrTooManyPendingRequests = errors.New("the limit of pending requests count has been reached")
+	// ErrLaneOverloaded is returned when message bus rejects a message because its priority lane is saturated
+	ErrLaneOverloaded = errors.New("message lane is overloaded, message rejected")
+	// ErrLeaveApproved is returned when the network has excluded the leaving node from the active list
+	ErrLeaveApproved = errors.New("node leave approved by network")
 )
@@ -198,6 +198,63 @@ func (*RegisterChild) Type() insolar.MessageType {
 	return insolar.TypeRegisterChild
 }
 
+// RegisterEvents registers events emitted by contract on the object.
+type RegisterEvents struct {
+	ledgerMessage
+	Record []byte
+	Object insolar.Reference
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *RegisterEvents) AllowedSenderObjectAndRole() (*insolar.Reference, insolar.DynamicRole) {
+	return &m.Object, insolar.DynamicRoleVirtualExecutor
+}
+
+// DefaultRole returns role for this event
+func (*RegisterEvents) DefaultRole() insolar.DynamicRole {
+	return insolar.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *RegisterEvents) DefaultTarget() *insolar.Reference {
+	return &m.Object
+}
+
+// Type implementation of Message interface.
+func (*RegisterEvents) Type() insolar.MessageType {
+	return insolar.TypeRegisterEvents
+}
+
+// GetEvents retrieves a chunk of object events, the latest events go first.
+type GetEvents struct {
+	ledgerMessage
+	Object    insolar.Reference
+	Name      string // if not empty, only events with this name are returned
+	Since     insolar.PulseNumber
+	FromEvent *insolar.ID
+	Amount    int
+}
+
+// AllowedSenderObjectAndRole implements interface method
+func (m *GetEvents) AllowedSenderObjectAndRole() (*insolar.Reference, insolar.DynamicRole) {
+	return nil, insolar.DynamicRoleUndefined
+}
+
+// DefaultRole returns role for this event
+func (*GetEvents) DefaultRole() insolar.DynamicRole {
+	return insolar.DynamicRoleLightExecutor
+}
+
+// DefaultTarget returns of target of this event.
+func (m *GetEvents) DefaultTarget() *insolar.Reference {
+	return &m.Object
+}
+
+// Type implementation of Message interface.
+func (*GetEvents) Type() insolar.MessageType {
+	return insolar.TypeGetEvents
+}
+
 // GetChildren retrieves a chunk of children references.
 type GetChildren struct {
 	ledgerMessage
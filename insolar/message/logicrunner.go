@@ -132,6 +132,9 @@ type CallMethod struct {
 	Method         string
 	Arguments      insolar.Arguments
 	ProxyPrototype insolar.Reference
+	// Immutable calls are executed on the latest state of the object without queueing,
+	// request and result are not registered on ledger
+	Immutable bool
 }
 
 // AllowedSenderObjectAndRole implements interface method
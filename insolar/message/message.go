@@ -83,6 +83,10 @@ func getEmptyMessage(mt insolar.MessageType) (insolar.Message, error) {
 		return &GetPendingRequestID{}, nil
 	case insolar.TypeGetRequest:
 		return &GetRequest{}, nil
+	case insolar.TypeRegisterEvents:
+		return &RegisterEvents{}, nil
+	case insolar.TypeGetEvents:
+		return &GetEvents{}, nil
 
 	// heavy sync
 	case insolar.TypeHeavyStartStop:
@@ -220,6 +224,8 @@ func init() {
 	gob.Register(&HotData{})
 	gob.Register(&GetPendingRequestID{})
 	gob.Register(&GetRequest{})
+	gob.Register(&RegisterEvents{})
+	gob.Register(&GetEvents{})
 
 	// heavy
 	gob.Register(&HeavyStartStop{})
@@ -279,6 +279,13 @@ const (
 
 	// TypeNodeSignRequest used to request sign for new node
 	TypeNodeSignRequest
+
+	// Ledger events
+
+	// TypeRegisterEvents registers events emitted by contract on the object.
+	TypeRegisterEvents
+	// TypeGetEvents retrieves object's events.
+	TypeGetEvents
 )
 
 // DelegationTokenType is an enum type of delegation token
@@ -291,4 +298,5 @@ const (
 	DTTypeGetObjectRedirect
 	DTTypeGetChildrenRedirect
 	DTTypeGetCodeRedirect
+	DTTypeGetEventsRedirect
 )
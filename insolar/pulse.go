@@ -74,6 +74,36 @@ type Pulse struct {
 
 	Entropy Entropy
 	Signs   map[string]PulseSenderConfirmation
+	// EntropyProofs contains contributions of pulsars which were combined into Entropy
+	EntropyProofs map[string]PulseEntropyProof
+}
+
+// PulseEntropyProof is an entropy contribution of a pulsar signed by it
+// Entropy of the pulse is XOR of all contributions, so every node can recompute and check it
+type PulseEntropyProof struct {
+	Entropy Entropy
+	// Chain is a commitment to entropy hash chains of the pulsar, it is empty if the pulsar doesn't use hash chains
+	Chain     EntropyChainCommitment
+	Signature []byte
+}
+
+// EntropyChainCommitment commits a pulsar to its entropy hash chains.
+// Every contribution from the current chain is a preimage of Anchor,
+// Next is an anchor of the next chain, so the pulsar can't replace its chain unnoticed.
+type EntropyChainCommitment struct {
+	Anchor Entropy
+	Next   Entropy
+}
+
+// EntropyProofData returns data signed by a pulsar for its entropy contribution
+func EntropyProofData(entropy Entropy, chain EntropyChainCommitment) []byte {
+	if chain == (EntropyChainCommitment{}) {
+		return entropy[:]
+	}
+	data := make([]byte, 0, 3*EntropySize)
+	data = append(data, entropy[:]...)
+	data = append(data, chain.Anchor[:]...)
+	return append(data, chain.Next[:]...)
 }
 
 // PulseSenderConfirmation contains confirmations of the pulse from other pulsars
@@ -42,6 +42,8 @@ const (
 	TypeGetObjectRedirect
 	// TypeGetChildrenRedirect is a redirect reply for children-call
 	TypeGetChildrenRedirect
+	// TypeGetEventsRedirect is a redirect reply for events-call
+	TypeGetEventsRedirect
 
 	// Logicrunner
 
@@ -74,6 +76,8 @@ const (
 	TypeJet
 	// TypeRequest contains request.
 	TypeRequest
+	// TypeEvents is a reply for fetching object events in chunks.
+	TypeEvents
 	// TypeHeavyError carries heavy record sync
 	TypeHeavyError
 
@@ -94,6 +98,8 @@ const (
 	ErrNoPendingRequests
 	// ErrTooManyPendingRequests is returned when a limit of pending requests has been reached
 	ErrTooManyPendingRequests
+	// ErrLaneOverloaded is returned when message bus rejects a message because its priority lane is saturated
+	ErrLaneOverloaded
 )
 
 func getEmptyReply(t insolar.ReplyType) (insolar.Reply, error) {
@@ -128,6 +134,8 @@ func getEmptyReply(t insolar.ReplyType) (insolar.Reply, error) {
 		return &GetObjectRedirectReply{}, nil
 	case TypeGetChildrenRedirect:
 		return &GetChildrenRedirectReply{}, nil
+	case TypeGetEventsRedirect:
+		return &GetEventsRedirectReply{}, nil
 	case TypeJetMiss:
 		return &JetMiss{}, nil
 	case TypePendingRequests:
@@ -136,6 +144,8 @@ func getEmptyReply(t insolar.ReplyType) (insolar.Reply, error) {
 		return &Jet{}, nil
 	case TypeRequest:
 		return &Request{}, nil
+	case TypeEvents:
+		return &Events{}, nil
 
 	case TypeNodeSign:
 		return &NodeSign{}, nil
@@ -199,9 +209,11 @@ func init() {
 	gob.Register(&GetCodeRedirectReply{})
 	gob.Register(&GetObjectRedirectReply{})
 	gob.Register(&GetChildrenRedirectReply{})
+	gob.Register(&GetEventsRedirectReply{})
 	gob.Register(&HeavyError{})
 	gob.Register(&JetMiss{})
 	gob.Register(&NodeSign{})
 	gob.Register(&HasPendingRequests{})
 	gob.Register(&Request{})
+	gob.Register(&Events{})
 }
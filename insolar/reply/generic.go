@@ -59,6 +59,8 @@ func (e *Error) Error() error {
 		return insolar.ErrNoPendingRequest
 	case ErrTooManyPendingRequests:
 		return insolar.ErrTooManyPendingRequests
+	case ErrLaneOverloaded:
+		return insolar.ErrLaneOverloaded
 	}
 
 	return insolar.ErrUnknown
@@ -24,6 +24,7 @@ import (
 type Code struct {
 	Code        []byte
 	MachineType insolar.MachineType
+	ABI         []byte
 }
 
 // Type implementation of Reply interface.
@@ -40,6 +41,7 @@ type Object struct {
 	ChildPointer *insolar.ID
 	Memory       []byte
 	Parent       insolar.Reference
+	Version      *insolar.Reference
 }
 
 // Type implementation of Reply interface.
@@ -78,6 +80,17 @@ func (e *Children) Type() insolar.ReplyType {
 	return TypeChildren
 }
 
+// Events is a event list reply, the latest events go first.
+type Events struct {
+	Events   []insolar.ObjectEvent
+	NextFrom *insolar.ID
+}
+
+// Type implementation of Reply interface.
+func (e *Events) Type() insolar.ReplyType {
+	return TypeEvents
+}
+
 // ObjectIndex contains serialized object index. It can be stored in DB without processing.
 type ObjectIndex struct {
 	Index []byte
@@ -124,6 +124,59 @@ func (r *GetChildrenRedirectReply) Redirected(genericMsg insolar.Message) insola
 	}
 }
 
+// GetEventsRedirectReply is a redirect reply for get events.
+type GetEventsRedirectReply struct {
+	Receiver *insolar.Reference
+	Token    insolar.DelegationToken
+
+	FromEvent insolar.ID
+}
+
+// NewGetEventsRedirect creates a new instance of GetEventsRedirectReply.
+func NewGetEventsRedirect(
+	factory insolar.DelegationTokenFactory, parcel insolar.Parcel, receiver *insolar.Reference, fromEvent insolar.ID,
+) (*GetEventsRedirectReply, error) {
+	var err error
+	rep := GetEventsRedirectReply{
+		Receiver:  receiver,
+		FromEvent: fromEvent,
+	}
+	redirectedMessage := rep.Redirected(parcel.Message())
+	sender := parcel.GetSender()
+	rep.Token, err = factory.IssueGetEventsRedirect(&sender, redirectedMessage)
+	if err != nil {
+		return nil, err
+	}
+	return &rep, nil
+}
+
+// GetReceiver returns node reference to send message to.
+func (r *GetEventsRedirectReply) GetReceiver() *insolar.Reference {
+	return r.Receiver
+}
+
+// GetToken returns delegation token.
+func (r *GetEventsRedirectReply) GetToken() insolar.DelegationToken {
+	return r.Token
+}
+
+// Type returns type of the reply
+func (r *GetEventsRedirectReply) Type() insolar.ReplyType {
+	return TypeGetEventsRedirect
+}
+
+// Redirected creates redirected message from redirect data.
+func (r *GetEventsRedirectReply) Redirected(genericMsg insolar.Message) insolar.Message {
+	msg := genericMsg.(*message.GetEvents)
+	return &message.GetEvents{
+		Object:    msg.Object,
+		Name:      msg.Name,
+		Since:     msg.Since,
+		FromEvent: &r.FromEvent,
+		Amount:    msg.Amount,
+	}
+}
+
 // GetCodeRedirectReply is a redirect reply for get children.
 type GetCodeRedirectReply struct {
 	Receiver *insolar.Reference
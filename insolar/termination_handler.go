@@ -16,18 +16,31 @@
 
 package insolar
 
+import (
+	"context"
+)
+
 // TerminationHandler handles such node events as graceful stop, abort, etc.
 type TerminationHandler interface {
+	// Leave announces that the node leaves the network after leaveAfterPulses pulses, waits until
+	// pending work is finished and gracefully stops the node. Returns pulse number the node leaves at.
+	// If the leave isn't approved by the network till the pulse after ETA, the node keeps working and
+	// the leave can be requested again. The node that left rejoins the network after restart.
+	Leave(ctx context.Context, leaveAfterPulses PulseNumber) (PulseNumber, error)
+	// OnLeaveApproved is called when the network has excluded the leaving node from the active list.
+	OnLeaveApproved(ctx context.Context)
 	// Abort forces to stop all node components
 	Abort()
 }
 
-type terminationHandler struct{}
-
-func (terminationHandler) Abort() {
-	panic("NetworkNode leave acknowledged by network. Goodbye!")
+// Leaver announces the leave of the node to the network.
+type Leaver interface {
+	// Leave sends leave claim with pulse number after which the node leaves the network.
+	Leave(ctx context.Context, ETA PulseNumber)
 }
 
-func NewTerminationHandler() TerminationHandler {
-	return &terminationHandler{}
+// Drainer is implemented by components that have to finish pending work before the node leaves the network.
+type Drainer interface {
+	// IsDrained returns true if component has no pending work.
+	IsDrained(ctx context.Context) bool
 }
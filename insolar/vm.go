@@ -18,6 +18,7 @@ package insolar
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
@@ -29,6 +30,7 @@ const (
 	MachineTypeNotExist             = 0
 	MachineTypeBuiltin  MachineType = iota + 1
 	MachineTypeGoPlugin
+	MachineTypeWASM
 
 	MachineTypesLastID
 )
@@ -68,7 +70,7 @@ type LogicRunner interface {
 
 // LogicCallContext is a context of contract execution
 type LogicCallContext struct {
-	Mode            string     // either "execution" or "validation"
+	Mode            string     // either "execution", "validation" or "query"
 	Callee          *Reference // Contract that was called
 	Request         *Reference // ref of request
 	Prototype       *Reference // Image of the callee
@@ -79,4 +81,58 @@ type LogicCallContext struct {
 	Time            time.Time  // Time when call was made
 	Pulse           Pulse      // Number of the pulse
 	TraceID         string
+
+	Limits ResourceLimits // Limits of resources the call can consume
+	Usage  ResourceUsage  // Resources consumed by the call, filled by executor
+}
+
+// QueryMode is a mode of LogicCallContext for calls of immutable methods. Such calls are executed
+// on the latest state of the object, their requests and results are not registered on ledger.
+const QueryMode = "query"
+
+// Resources metered during contract execution
+const (
+	ResourceUpcalls    = "upcalls"
+	ResourceStateBytes = "state bytes"
+	ResourceCPUTime    = "cpu time"
+)
+
+// ResourceLimits are limits of resources a single contract call can consume. Zero value means no limit.
+type ResourceLimits struct {
+	Upcalls    uint64        // Number of calls from contract to the logic runner (RouteCall, SaveAsChild...)
+	StateBytes uint64        // Size of object state written by the call
+	CPUTime    time.Duration // Time spent in contract code excluding upcalls, enforced by executor asynchronously
+}
+
+// ResourceUsage is resources consumed by a contract call. It's registered with the result, so it holds
+// only deterministic counters, wall-clock CPU time is reported by executors to metrics only.
+type ResourceUsage struct {
+	Upcalls    uint64
+	StateBytes uint64
+}
+
+// ResourceLimitError is returned when contract call exceeds one of the resource limits. CPU time is in nanoseconds.
+type ResourceLimitError struct {
+	Resource string
+	Limit    uint64
+	Used     uint64
+}
+
+func (e *ResourceLimitError) Error() string {
+	return fmt.Sprintf("resource limit exceeded: %s used %d, limit %d", e.Resource, e.Used, e.Limit)
+}
+
+// ContractEvent is a structured event emitted by a contract during a call.
+type ContractEvent struct {
+	Name    string
+	Payload []byte
+}
+
+// ObjectEvent is a contract event registered on ledger for the object that emitted it.
+type ObjectEvent struct {
+	Object  Reference
+	Request Reference
+	Pulse   PulseNumber
+	Name    string
+	Payload []byte
 }
@@ -145,6 +145,13 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			m.checkJet,
 			m.waitForHotData))
 
+	h.Bus.MustRegister(insolar.TypeGetEvents,
+		BuildMiddleware(h.handleGetEvents,
+			instrumentHandler("handleGetEvents"),
+			m.addFieldsToLogger,
+			m.checkJet,
+			m.waitForHotData))
+
 	h.Bus.MustRegister(insolar.TypeSetRecord,
 		BuildMiddleware(h.handleSetRecord,
 			instrumentHandler("handleSetRecord"),
@@ -166,6 +173,13 @@ func (h *MessageHandler) setHandlersForLight(m *middleware) {
 			m.checkJet,
 			m.waitForHotData))
 
+	h.Bus.MustRegister(insolar.TypeRegisterEvents,
+		BuildMiddleware(h.handleRegisterEvents,
+			instrumentHandler("handleRegisterEvents"),
+			m.addFieldsToLogger,
+			m.checkJet,
+			m.waitForHotData))
+
 	h.Bus.MustRegister(insolar.TypeSetBlob,
 		BuildMiddleware(h.handleSetBlob,
 			instrumentHandler("handleSetBlob"),
@@ -237,9 +251,11 @@ func (h *MessageHandler) setReplayHandlers(m *middleware) {
 	h.replayHandlers[insolar.TypeGetObject] = BuildMiddleware(h.handleGetObject, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeGetDelegate] = BuildMiddleware(h.handleGetDelegate, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeGetChildren] = BuildMiddleware(h.handleGetChildren, m.addFieldsToLogger, m.checkJet)
+	h.replayHandlers[insolar.TypeGetEvents] = BuildMiddleware(h.handleGetEvents, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeSetRecord] = BuildMiddleware(h.handleSetRecord, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeUpdateObject] = BuildMiddleware(h.handleUpdateObject, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeRegisterChild] = BuildMiddleware(h.handleRegisterChild, m.addFieldsToLogger, m.checkJet)
+	h.replayHandlers[insolar.TypeRegisterEvents] = BuildMiddleware(h.handleRegisterEvents, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeSetBlob] = BuildMiddleware(h.handleSetBlob, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeGetObjectIndex] = BuildMiddleware(h.handleGetObjectIndex, m.addFieldsToLogger, m.checkJet)
 	h.replayHandlers[insolar.TypeGetPendingRequests] = BuildMiddleware(h.handleHasPendingRequests, m.addFieldsToLogger, m.checkJet)
@@ -327,6 +343,7 @@ func (h *MessageHandler) handleGetCode(ctx context.Context, parcel insolar.Parce
 	rep := reply.Code{
 		Code:        code,
 		MachineType: codeRec.MachineType,
+		ABI:         codeRec.ABI,
 	}
 
 	return &rep, nil
@@ -411,6 +428,7 @@ func (h *MessageHandler) handleGetObject(
 			ChildPointer: idx.ChildPointer,
 			Parent:       idx.Parent,
 			Memory:       obj.Memory,
+			Version:      obj.Version,
 		}, nil
 	}
 
@@ -456,6 +474,7 @@ func (h *MessageHandler) handleGetObject(
 			ChildPointer: idx.ChildPointer,
 			Parent:       idx.Parent,
 			Memory:       obj.Memory,
+			Version:      obj.Version,
 		}, nil
 	}
 	if err != nil {
@@ -480,6 +499,7 @@ func (h *MessageHandler) handleGetObject(
 		IsPrototype:  state.GetIsPrototype(),
 		ChildPointer: childPointer,
 		Parent:       idx.Parent,
+		Version:      state.GetVersion(),
 	}
 
 	if state.GetMemory() != nil {
@@ -666,6 +686,127 @@ func (h *MessageHandler) handleGetChildren(
 	return &reply.Children{Refs: refs, NextFrom: nil}, nil
 }
 
+func (h *MessageHandler) handleGetEvents(
+	ctx context.Context, parcel insolar.Parcel,
+) (insolar.Reply, error) {
+	msg := parcel.Message().(*message.GetEvents)
+	jetID := jetFromContext(ctx)
+
+	h.RecentStorageProvider.GetIndexStorage(ctx, jetID).AddObject(ctx, *msg.Object.Record())
+
+	h.IDLocker.Lock(msg.Object.Record())
+	defer h.IDLocker.Unlock(msg.Object.Record())
+
+	idx, err := h.ObjectStorage.GetObjectIndex(ctx, jetID, msg.Object.Record())
+	if err == insolar.ErrNotFound {
+		heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, heavy)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch index from heavy")
+		}
+	} else if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch object index")
+	}
+
+	// Counting from specified event or the latest.
+	currentEvent := idx.EventPointer
+	if msg.FromEvent != nil {
+		currentEvent = msg.FromEvent
+	}
+
+	// The object has no events or all of them are older than requested.
+	if currentEvent == nil || currentEvent.Pulse() < msg.Since {
+		return &reply.Events{}, nil
+	}
+
+	onHeavy, err := h.JetCoordinator.IsBeyondLimit(ctx, parcel.Pulse(), currentEvent.Pulse())
+	if err != nil {
+		return nil, err
+	}
+	if onHeavy {
+		node, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		return reply.NewGetEventsRedirect(h.DelegationTokenFactory, parcel, node, *currentEvent)
+	}
+
+	eventJetID, actual := h.JetStorage.ForID(ctx, currentEvent.Pulse(), *msg.Object.Record())
+	eventJet := insolar.ID(eventJetID)
+	if !actual {
+		actualJet, err := h.jetTreeUpdater.fetchJet(ctx, *msg.Object.Record(), currentEvent.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		eventJet = *actualJet
+	}
+
+	// Try to fetch the first event.
+	_, err = h.ObjectStorage.GetRecord(ctx, eventJet, currentEvent)
+	if err == insolar.ErrNotFound {
+		node, err := h.JetCoordinator.NodeForJet(ctx, eventJet, parcel.Pulse(), currentEvent.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		return reply.NewGetEventsRedirect(h.DelegationTokenFactory, parcel, node, *currentEvent)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to fetch event")
+	}
+
+	return collectEvents(ctx, h.ObjectStorage, eventJet, msg, currentEvent)
+}
+
+// collectEvents walks events chain of the object starting from provided record. Records of the chain go
+// in reverse order, so the walk stops on the first record older than requested pulse.
+func collectEvents(
+	ctx context.Context, objectStorage storage.ObjectStorage, jetID insolar.ID, msg *message.GetEvents, from *insolar.ID,
+) (*reply.Events, error) {
+	var events []insolar.ObjectEvent
+	counter := 0
+	current := from
+	for current != nil && current.Pulse() >= msg.Since {
+		// We have enough results.
+		if counter >= msg.Amount {
+			return &reply.Events{Events: events, NextFrom: current}, nil
+		}
+		counter++
+
+		rec, err := objectStorage.GetRecord(ctx, jetID, current)
+		// We don't have this event record. Return what was collected.
+		if err == insolar.ErrNotFound {
+			return &reply.Events{Events: events, NextFrom: current}, nil
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to retrieve events")
+		}
+
+		eventRec, ok := rec.(*object.EventRecord)
+		if !ok {
+			return nil, errors.New("failed to retrieve events: unexpected record type")
+		}
+		for i := len(eventRec.Events) - 1; i >= 0; i-- {
+			e := eventRec.Events[i]
+			if msg.Name != "" && e.Name != msg.Name {
+				continue
+			}
+			events = append(events, insolar.ObjectEvent{
+				Object:  eventRec.Object,
+				Request: eventRec.Request,
+				Pulse:   current.Pulse(),
+				Name:    e.Name,
+				Payload: e.Payload,
+			})
+		}
+		current = eventRec.PrevEvent
+	}
+
+	return &reply.Events{Events: events}, nil
+}
+
 func (h *MessageHandler) handleGetRequest(ctx context.Context, parcel insolar.Parcel) (insolar.Reply, error) {
 	jetID := jetFromContext(ctx)
 	msg := parcel.Message().(*message.GetRequest)
@@ -799,6 +940,7 @@ func (h *MessageHandler) handleUpdateObject(ctx context.Context, parcel insolar.
 		IsPrototype:  state.GetIsPrototype(),
 		ChildPointer: idx.ChildPointer,
 		Parent:       idx.Parent,
+		Version:      state.GetVersion(),
 	}
 	return &rep, nil
 }
@@ -863,6 +1005,68 @@ func (h *MessageHandler) handleRegisterChild(ctx context.Context, parcel insolar
 	return &reply.ID{ID: *child}, nil
 }
 
+func (h *MessageHandler) handleRegisterEvents(ctx context.Context, parcel insolar.Parcel) (insolar.Reply, error) {
+	logger := inslogger.FromContext(ctx)
+
+	msg := parcel.Message().(*message.RegisterEvents)
+	jetID := jetFromContext(ctx)
+	rec := object.DeserializeRecord(msg.Record)
+	eventRec, ok := rec.(*object.EventRecord)
+	if !ok {
+		return nil, errors.New("wrong event record")
+	}
+
+	h.RecentStorageProvider.GetIndexStorage(ctx, jetID).AddObject(ctx, *msg.Object.Record())
+
+	h.IDLocker.Lock(msg.Object.Record())
+	defer h.IDLocker.Unlock(msg.Object.Record())
+
+	idx, err := h.ObjectStorage.GetObjectIndex(ctx, jetID, msg.Object.Record())
+	if err == insolar.ErrNotFound {
+		heavy, err := h.JetCoordinator.Heavy(ctx, parcel.Pulse())
+		if err != nil {
+			return nil, err
+		}
+		idx, err = h.saveIndexFromHeavy(ctx, jetID, msg.Object, heavy)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to fetch index from heavy")
+		}
+	} else if err != nil {
+		return nil, err
+	}
+
+	// VM doesn't know the latest event of the object, so the chain is linked here.
+	// For the case when vm can't save or send result to another vm and it tries to register the same events again
+	// the chain is left as is.
+	if idx.EventPointer != nil {
+		latest, err := h.ObjectStorage.GetRecord(ctx, jetID, idx.EventPointer)
+		if err != nil && err != insolar.ErrNotFound {
+			return nil, errors.Wrap(err, "failed to fetch latest event")
+		}
+		if latestRec, ok := latest.(*object.EventRecord); ok && latestRec.Result == eventRec.Result {
+			return &reply.ID{ID: *idx.EventPointer}, nil
+		}
+	}
+	eventRec.PrevEvent = idx.EventPointer
+
+	id, err := h.ObjectStorage.SetRecord(ctx, jetID, parcel.Pulse(), eventRec)
+	if err == storage.ErrOverride {
+		logger.WithField("type", fmt.Sprintf("%T", rec)).Warn("set record override (#3)")
+		id = object.NewRecordIDFromRecord(h.PlatformCryptographyScheme, parcel.Pulse(), eventRec)
+	} else if err != nil {
+		return nil, err
+	}
+
+	idx.EventPointer = id
+	idx.LatestUpdate = parcel.Pulse()
+	err = h.ObjectStorage.SetObjectIndex(ctx, jetID, msg.Object.Record(), idx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &reply.ID{ID: *id}, nil
+}
+
 func (h *MessageHandler) handleJetDrop(ctx context.Context, parcel insolar.Parcel) (insolar.Reply, error) {
 	msg := parcel.Message().(*message.JetDrop)
 
@@ -975,6 +975,136 @@ func (s *handlerSuite) TestMessageHandler_HandleRegisterChild_IndexStateUpdated(
 	require.Equal(s.T(), int(idx.LatestUpdate), insolar.FirstPulseNumber+100)
 }
 
+func (s *handlerSuite) TestMessageHandler_HandleRegisterEvents_ChainsEvents() {
+	mc := minimock.NewController(s.T())
+	defer mc.Finish()
+	jetID := insolar.ID(*insolar.NewJetID(0, nil))
+
+	indexMock := recentstorage.NewRecentIndexStorageMock(s.T())
+	indexMock.AddObjectMock.Return()
+	provideMock := recentstorage.NewProviderMock(s.T())
+	provideMock.GetIndexStorageMock.Return(indexMock)
+
+	h := NewMessageHandler(&configuration.Ledger{
+		LightChainLimit: 2,
+	})
+	h.JetStorage = s.jetStorage
+	h.Nodes = s.nodeStorage
+	h.DBContext = s.db
+	h.PulseTracker = s.pulseTracker
+	h.ObjectStorage = s.objectStorage
+	h.RecentStorageProvider = provideMock
+	h.PlatformCryptographyScheme = s.scheme
+
+	idLockMock := storage.NewIDLockerMock(s.T())
+	idLockMock.LockMock.Return()
+	idLockMock.UnlockMock.Return()
+	h.IDLocker = idLockMock
+
+	obj := *genRandomRef(0)
+	err := s.objectStorage.SetObjectIndex(s.ctx, jetID, obj.Record(), &object.Lifeline{
+		LatestState:  genRandomID(0),
+		State:        object.StateActivation,
+		LatestUpdate: insolar.FirstPulseNumber,
+	})
+	require.NoError(s.T(), err)
+
+	register := func(pn insolar.PulseNumber, rec *object.EventRecord) insolar.ID {
+		rep, err := h.handleRegisterEvents(contextWithJet(s.ctx, jetID), &message.Parcel{
+			Msg: &message.RegisterEvents{
+				Record: object.SerializeRecord(rec),
+				Object: obj,
+			},
+			PulseNumber: pn,
+		})
+		require.NoError(s.T(), err)
+		id, ok := rep.(*reply.ID)
+		require.True(s.T(), ok)
+		return id.ID
+	}
+
+	first := &object.EventRecord{
+		Object:  obj,
+		Request: *genRandomRef(insolar.FirstPulseNumber + 1),
+		Result:  *genRandomID(insolar.FirstPulseNumber + 1),
+		Events: []insolar.ContractEvent{
+			{Name: "Transferred", Payload: []byte{1}},
+			{Name: "Burned", Payload: []byte{2}},
+		},
+	}
+	second := &object.EventRecord{
+		Object:  obj,
+		Request: *genRandomRef(insolar.FirstPulseNumber + 2),
+		Result:  *genRandomID(insolar.FirstPulseNumber + 2),
+		Events: []insolar.ContractEvent{
+			{Name: "Transferred", Payload: []byte{3}},
+		},
+	}
+
+	firstID := register(insolar.FirstPulseNumber+1, first)
+	secondID := register(insolar.FirstPulseNumber+2, second)
+
+	s.T().Run("links chain through index", func(t *testing.T) {
+		idx, err := s.objectStorage.GetObjectIndex(s.ctx, jetID, obj.Record())
+		require.NoError(t, err)
+		require.Equal(t, secondID, *idx.EventPointer)
+		require.Equal(t, insolar.PulseNumber(insolar.FirstPulseNumber+2), idx.LatestUpdate)
+
+		rec, err := s.objectStorage.GetRecord(s.ctx, jetID, &secondID)
+		require.NoError(t, err)
+		require.Equal(t, firstID, *rec.(*object.EventRecord).PrevEvent)
+	})
+
+	s.T().Run("repeated registration is idempotent", func(t *testing.T) {
+		id := register(insolar.FirstPulseNumber+2, second)
+		require.Equal(t, secondID, id)
+	})
+
+	s.T().Run("collects events newest first", func(t *testing.T) {
+		rep, err := collectEvents(s.ctx, s.objectStorage, jetID, &message.GetEvents{
+			Object: obj,
+			Amount: 10,
+		}, &secondID)
+		require.NoError(t, err)
+		require.Nil(t, rep.NextFrom)
+		require.Len(t, rep.Events, 3)
+		require.Equal(t, []byte{3}, rep.Events[0].Payload)
+		require.Equal(t, "Burned", rep.Events[1].Name)
+		require.Equal(t, []byte{1}, rep.Events[2].Payload)
+		require.Equal(t, insolar.PulseNumber(insolar.FirstPulseNumber+1), rep.Events[2].Pulse)
+	})
+
+	s.T().Run("filters by name and pulse", func(t *testing.T) {
+		rep, err := collectEvents(s.ctx, s.objectStorage, jetID, &message.GetEvents{
+			Object: obj,
+			Name:   "Burned",
+			Amount: 10,
+		}, &secondID)
+		require.NoError(t, err)
+		require.Len(t, rep.Events, 1)
+		require.Equal(t, []byte{2}, rep.Events[0].Payload)
+
+		rep, err = collectEvents(s.ctx, s.objectStorage, jetID, &message.GetEvents{
+			Object: obj,
+			Since:  insolar.FirstPulseNumber + 2,
+			Amount: 10,
+		}, &secondID)
+		require.NoError(t, err)
+		require.Len(t, rep.Events, 1)
+		require.Equal(t, []byte{3}, rep.Events[0].Payload)
+	})
+
+	s.T().Run("returns next pointer when amount is reached", func(t *testing.T) {
+		rep, err := collectEvents(s.ctx, s.objectStorage, jetID, &message.GetEvents{
+			Object: obj,
+			Amount: 1,
+		}, &secondID)
+		require.NoError(t, err)
+		require.Len(t, rep.Events, 1)
+		require.Equal(t, firstID, *rep.NextFrom)
+	})
+}
+
 func (s *handlerSuite) TestMessageHandler_HandleHotRecords() {
 	mc := minimock.NewController(s.T())
 	jetID := gen.JetID()
@@ -118,6 +118,11 @@ func (m *middleware) checkJet(handler insolar.MessageHandler) insolar.MessageHan
 					return nil, errors.New("fetching children without child pointer is forbidden")
 				}
 				pulse = tm.FromChild.Pulse()
+			case *message.GetEvents:
+				if tm.FromEvent == nil {
+					return nil, errors.New("fetching events without event pointer is forbidden")
+				}
+				pulse = tm.FromEvent.Pulse()
 			case *message.GetRequest:
 				pulse = tm.Request.Pulse()
 			}
@@ -53,6 +53,7 @@ func (h *Handler) Init(ctx context.Context) error {
 	h.Bus.MustRegister(insolar.TypeGetObject, h.handleGetObject)
 	h.Bus.MustRegister(insolar.TypeGetDelegate, h.handleGetDelegate)
 	h.Bus.MustRegister(insolar.TypeGetChildren, h.handleGetChildren)
+	h.Bus.MustRegister(insolar.TypeGetEvents, h.handleGetEvents)
 	h.Bus.MustRegister(insolar.TypeGetObjectIndex, h.handleGetObjectIndex)
 	h.Bus.MustRegister(insolar.TypeGetRequest, h.handleGetRequest)
 	return nil
@@ -74,6 +75,7 @@ func (h *Handler) handleGetCode(ctx context.Context, parcel insolar.Parcel) (ins
 	rep := reply.Code{
 		Code:        code,
 		MachineType: codeRec.MachineType,
+		ABI:         codeRec.ABI,
 	}
 
 	return &rep, nil
@@ -129,6 +131,7 @@ func (h *Handler) handleGetObject(
 		IsPrototype:  state.GetIsPrototype(),
 		ChildPointer: childPointer,
 		Parent:       idx.Parent,
+		Version:      state.GetVersion(),
 	}
 
 	if state.GetMemory() != nil {
@@ -232,6 +235,64 @@ func (h *Handler) handleGetChildren(
 	return &reply.Children{Refs: refs, NextFrom: nil}, nil
 }
 
+func (h *Handler) handleGetEvents(
+	ctx context.Context, parcel insolar.Parcel,
+) (insolar.Reply, error) {
+	msg := parcel.Message().(*message.GetEvents)
+
+	idx, err := h.ObjectStorage.GetObjectIndex(ctx, insolar.ID(h.jetID), msg.Object.Record())
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("failed to fetch index for %v", msg.Object.Record()))
+	}
+
+	// Counting from specified event or the latest.
+	currentEvent := idx.EventPointer
+	if msg.FromEvent != nil {
+		currentEvent = msg.FromEvent
+	}
+
+	var events []insolar.ObjectEvent
+	counter := 0
+	for currentEvent != nil && currentEvent.Pulse() >= msg.Since {
+		// We have enough results.
+		if counter >= msg.Amount {
+			return &reply.Events{Events: events, NextFrom: currentEvent}, nil
+		}
+		counter++
+
+		rec, err := h.ObjectStorage.GetRecord(ctx, insolar.ID(h.jetID), currentEvent)
+		if err != nil {
+			text := fmt.Sprintf(
+				"failed to fetch event %s for %s",
+				currentEvent.DebugString(),
+				msg.Object.Record().DebugString(),
+			)
+			return nil, errors.Wrap(err, text)
+		}
+
+		eventRec, ok := rec.(*object.EventRecord)
+		if !ok {
+			return nil, errors.New("failed to retrieve events: unexpected record type")
+		}
+		for i := len(eventRec.Events) - 1; i >= 0; i-- {
+			e := eventRec.Events[i]
+			if msg.Name != "" && e.Name != msg.Name {
+				continue
+			}
+			events = append(events, insolar.ObjectEvent{
+				Object:  eventRec.Object,
+				Request: eventRec.Request,
+				Pulse:   currentEvent.Pulse(),
+				Name:    e.Name,
+				Payload: e.Payload,
+			})
+		}
+		currentEvent = eventRec.PrevEvent
+	}
+
+	return &reply.Events{Events: events}, nil
+}
+
 func (h *Handler) handleGetRequest(ctx context.Context, parcel insolar.Parcel) (insolar.Reply, error) {
 	msg := parcel.Message().(*message.GetRequest)
 
@@ -140,6 +140,15 @@ func (scp *Pool) AllClients(ctx context.Context) []*JetClient {
 	return clients
 }
 
+// PulsesLeft returns total count of pulses not yet synced to heavy by all clients in Pool.
+func (scp *Pool) PulsesLeft(ctx context.Context) int {
+	left := 0
+	for _, c := range scp.AllClients(ctx) {
+		left += c.pulsesLeft()
+	}
+	return left
+}
+
 // LightCleanup starts async cleanup on all heavy synchronization clients (per jet cleanup).
 //
 // Waits until all cleanup will done and mesaures time.
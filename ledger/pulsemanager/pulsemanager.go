@@ -771,6 +771,14 @@ func (m *PulseManager) restoreGenesisRecentObjects(ctx context.Context) error {
 	})
 }
 
+// IsDrained implements insolar.Drainer, returns true if all pulses are synced to heavy.
+func (m *PulseManager) IsDrained(ctx context.Context) bool {
+	if m.syncClientsPool == nil {
+		return true
+	}
+	return m.syncClientsPool.PulsesLeft(ctx) == 0
+}
+
 // Stop stops PulseManager. Waits replication goroutine is done.
 func (m *PulseManager) Stop(ctx context.Context) error {
 	// There should not to be any Set call after Stop call
@@ -48,6 +48,7 @@ type Lifeline struct {
 	LatestState         *insolar.ID // Amend or activate record.
 	LatestStateApproved *insolar.ID // State approved by VM.
 	ChildPointer        *insolar.ID // Meta record about child activation.
+	EventPointer        *insolar.ID // Meta record about events emitted by the object.
 	Parent              insolar.Reference
 	Delegates           map[insolar.Reference]insolar.Reference
 	State               StateID
@@ -89,6 +90,11 @@ func CloneIndex(idx Lifeline) Lifeline {
 		idx.ChildPointer = &tmp
 	}
 
+	if idx.EventPointer != nil {
+		tmp := *idx.EventPointer
+		idx.EventPointer = &tmp
+	}
+
 	if idx.Delegates != nil {
 		cp := make(map[insolar.Reference]insolar.Reference)
 		for k, v := range idx.Delegates {
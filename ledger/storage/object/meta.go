@@ -46,6 +46,11 @@ func (*GenesisRecord) GetMemory() *insolar.ID {
 	return nil
 }
 
+// GetVersion returns code reference state memory was produced by.
+func (*GenesisRecord) GetVersion() *insolar.Reference {
+	return nil
+}
+
 // GetImage returns state code.
 func (*GenesisRecord) GetImage() *insolar.Reference {
 	return nil
@@ -67,3 +72,18 @@ type ChildRecord struct {
 func (r *ChildRecord) WriteHashData(w io.Writer) (int, error) {
 	return w.Write(SerializeRecord(r))
 }
+
+// EventRecord holds events emitted by contract during a call. Its used for events iterating.
+type EventRecord struct {
+	PrevEvent *insolar.ID
+
+	Object  insolar.Reference
+	Request insolar.Reference
+	Result  insolar.ID // Result record of the call emitted events.
+	Events  []insolar.ContractEvent
+}
+
+// WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
+func (r *EventRecord) WriteHashData(w io.Writer) (int, error) {
+	return w.Write(SerializeRecord(r))
+}
@@ -46,6 +46,8 @@ type State interface {
 	GetIsPrototype() bool
 	// GetMemory returns state memory.
 	GetMemory() *insolar.ID
+	// GetVersion returns code reference state memory was produced by.
+	GetVersion() *insolar.Reference
 	// PrevStateID returns previous state id.
 	PrevStateID() *insolar.ID
 }
@@ -55,6 +57,7 @@ type ResultRecord struct {
 	Object  insolar.ID
 	Request insolar.Reference
 	Payload []byte
+	Usage   insolar.ResourceUsage
 }
 
 // WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
@@ -86,6 +89,7 @@ type CodeRecord struct {
 
 	Code        *insolar.ID
 	MachineType insolar.MachineType
+	ABI         []byte
 }
 
 // WriteHashData writes record data to provided writer. This data is used to calculate record's hash.
@@ -96,8 +100,9 @@ func (r *CodeRecord) WriteHashData(w io.Writer) (int, error) {
 // StateRecord is a record containing data for an object state.
 type StateRecord struct {
 	Memory      *insolar.ID
-	Image       insolar.Reference // If code or prototype object reference.
-	IsPrototype bool              // If true, Image should point to a prototype object. Otherwise to a code.
+	Image       insolar.Reference  // If code or prototype object reference.
+	IsPrototype bool               // If true, Image should point to a prototype object. Otherwise to a code.
+	Version     *insolar.Reference // Code reference Memory was produced by. Nil if unknown.
 }
 
 // GetMemory returns state memory.
@@ -105,6 +110,11 @@ func (r *StateRecord) GetMemory() *insolar.ID {
 	return r.Memory
 }
 
+// GetVersion returns code reference state memory was produced by.
+func (r *StateRecord) GetVersion() *insolar.Reference {
+	return r.Version
+}
+
 // GetImage returns state code.
 func (r *StateRecord) GetImage() *insolar.Reference {
 	return &r.Image
@@ -193,6 +203,11 @@ func (*DeactivationRecord) GetMemory() *insolar.ID {
 	return nil
 }
 
+// GetVersion returns code reference state memory was produced by.
+func (*DeactivationRecord) GetVersion() *insolar.Reference {
+	return nil
+}
+
 // GetImage returns state code.
 func (r *DeactivationRecord) GetImage() *insolar.Reference {
 	return nil
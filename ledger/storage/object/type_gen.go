@@ -13,6 +13,8 @@ func TypeFromRecord(generic record.VirtualRecord) TypeID {
 		return 100
 	case *ChildRecord:
 		return 101
+	case *EventRecord:
+		return 102
 	case *RequestRecord:
 		return 200
 	case *ResultRecord:
@@ -38,6 +40,8 @@ func RecordFromType(i TypeID) record.VirtualRecord {
 		return new(GenesisRecord)
 	case 101:
 		return new(ChildRecord)
+	case 102:
+		return new(EventRecord)
 	case 200:
 		return new(RequestRecord)
 	case 300:
@@ -63,6 +67,8 @@ func (i TypeID) String() string {
 		return "GenesisRecord"
 	case 101:
 		return "ChildRecord"
+	case 102:
+		return "EventRecord"
 	case 200:
 		return "RequestRecord"
 	case 300:
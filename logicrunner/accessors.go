@@ -73,6 +73,35 @@ func (lr *LogicRunner) MustObjectState(ref Ref) *ObjectState {
 	return res
 }
 
+func (lr *LogicRunner) addQuery(ref Ref, es *ExecutionState) {
+	lr.queriesMutex.Lock()
+	defer lr.queriesMutex.Unlock()
+
+	if lr.queries == nil {
+		lr.queries = make(map[Ref]*ExecutionState)
+	}
+	lr.queries[ref] = es
+}
+
+func (lr *LogicRunner) removeQuery(ref Ref) {
+	lr.queriesMutex.Lock()
+	defer lr.queriesMutex.Unlock()
+
+	delete(lr.queries, ref)
+}
+
+// MustQueryState returns state of immutable method call in progress by its query reference
+func (lr *LogicRunner) MustQueryState(ref Ref) *ExecutionState {
+	lr.queriesMutex.RLock()
+	defer lr.queriesMutex.RUnlock()
+
+	res, ok := lr.queries[ref]
+	if !ok {
+		panic("No requested query state. ref: " + ref.String())
+	}
+	return res
+}
+
 func (lr *LogicRunner) pulse(ctx context.Context) *insolar.Pulse {
 	pulse, err := lr.PulseStorage.Current(ctx)
 	if err != nil {
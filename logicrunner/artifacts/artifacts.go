@@ -39,8 +39,14 @@ type Client interface {
 	// When fetching object, validity can be specified.
 	RegisterValidation(ctx context.Context, object insolar.Reference, state insolar.ID, isValid bool, validationMessages []insolar.Message) error
 
-	// RegisterResult saves VM method call result.
-	RegisterResult(ctx context.Context, object, request insolar.Reference, payload []byte) (*insolar.ID, error)
+	// RegisterResult saves VM method call result, resources consumed by the call and events emitted by it.
+	RegisterResult(
+		ctx context.Context,
+		object, request insolar.Reference,
+		payload []byte,
+		usage insolar.ResourceUsage,
+		events []insolar.ContractEvent,
+	) (*insolar.ID, error)
 
 	// GetCode returns code from code record by provided reference according to provided machine preference.
 	//
@@ -70,6 +76,11 @@ type Client interface {
 	// During iteration children refs will be fetched from remote source (parent object).
 	GetChildren(ctx context.Context, parent insolar.Reference, pulse *insolar.PulseNumber) (RefIterator, error)
 
+	// GetEvents returns events emitted by the object since provided pulse in order of their registration.
+	//
+	// If name is not empty, only events with this name are returned.
+	GetEvents(ctx context.Context, object insolar.Reference, name string, since insolar.PulseNumber) ([]insolar.ObjectEvent, error)
+
 	// DeclareType creates new type record in storage.
 	//
 	// Type is a contract interface. It contains one method signature.
@@ -77,8 +88,8 @@ type Client interface {
 
 	// DeployCode creates new code record in storage.
 	//
-	// Code records are used to activate prototype.
-	DeployCode(ctx context.Context, domain, request insolar.Reference, code []byte, machineType insolar.MachineType) (*insolar.ID, error)
+	// Code records are used to activate prototype. ABI of the code is stored in the record as is.
+	DeployCode(ctx context.Context, domain, request insolar.Reference, code []byte, machineType insolar.MachineType, abi []byte) (*insolar.ID, error)
 
 	// ActivatePrototype creates activate object record in storage. Provided prototype reference will be used as objects prototype
 	// memory as memory of created object. If memory is not provided, the prototype default memory will be used.
@@ -91,7 +102,7 @@ type Client interface {
 	) (ObjectDescriptor, error)
 
 	// ActivateObject creates activate object record in storage. If memory is not provided, the prototype default
-	// memory will be used.
+	// memory will be used. Provided code reference is recorded as memory version (see ObjectDescriptor.Version).
 	//
 	// Request reference will be this object's identifier and referred as "object head".
 	ActivateObject(
@@ -99,6 +110,7 @@ type Client interface {
 		domain, request, parent, prototype insolar.Reference,
 		asDelegate bool,
 		memory []byte,
+		code *insolar.Reference,
 	) (ObjectDescriptor, error)
 
 	// UpdatePrototype creates amend object record in storage. Provided reference should be a reference to the head of
@@ -114,7 +126,8 @@ type Client interface {
 	) (ObjectDescriptor, error)
 
 	// UpdateObject creates amend object record in storage. Provided reference should be a reference to the head of the
-	// object. Provided memory well be the new object memory.
+	// object. Provided memory well be the new object memory. Provided code reference is recorded as memory version,
+	// if it's nil, version of the previous state is kept.
 	//
 	// Returned reference will be the latest object state (exact) reference.
 	UpdateObject(
@@ -122,6 +135,7 @@ type Client interface {
 		domain, request insolar.Reference,
 		obj ObjectDescriptor,
 		memory []byte,
+		code *insolar.Reference,
 	) (ObjectDescriptor, error)
 
 	// DeactivateObject creates deactivate object record in storage. Provided reference should be a reference to the head
@@ -146,6 +160,9 @@ type CodeDescriptor interface {
 
 	// Code returns code data.
 	Code() ([]byte, error)
+
+	// ABI returns ABI of the code in JSON, it's empty if ABI wasn't provided on deploy.
+	ABI() []byte
 }
 
 //go:generate minimock -i github.com/insolar/insolar/logicrunner/artifacts.ObjectDescriptor -o ./ -s _mock.go
@@ -178,6 +195,10 @@ type ObjectDescriptor interface {
 
 	// Parent returns object's parent.
 	Parent() *insolar.Reference
+
+	// Version returns code reference object memory was produced by. It differs from prototype's code after
+	// prototype upgrade until memory is migrated. Nil means version is unknown.
+	Version() *insolar.Reference
 }
 
 // RefIterator is used for iteration over affined children(parts) of container.
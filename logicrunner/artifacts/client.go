@@ -36,6 +36,7 @@ import (
 
 const (
 	getChildrenChunkSize = 10 * 1000
+	getEventsChunkSize   = 1000
 	jetMissRetryCount    = 10
 )
 
@@ -157,6 +158,7 @@ func (m *client) GetCode(
 			ref:         code,
 			machineType: rep.MachineType,
 			code:        rep.Code,
+			abi:         rep.ABI,
 		}
 		return &desc, nil
 	case *reply.Error:
@@ -228,6 +230,7 @@ func (m *client) GetObject(
 			childPointer: r.ChildPointer,
 			memory:       r.Memory,
 			parent:       r.Parent,
+			version:      r.Version,
 		}
 		return desc, err
 	case *reply.Error:
@@ -420,6 +423,72 @@ func (m *client) GetChildren(
 	return iter, err
 }
 
+// GetEvents returns events emitted by the object since provided pulse in order of their registration.
+//
+// If name is not empty, only events with this name are returned.
+func (m *client) GetEvents(
+	ctx context.Context, obj insolar.Reference, name string, since insolar.PulseNumber,
+) ([]insolar.ObjectEvent, error) {
+	var err error
+
+	ctx, span := instracer.StartSpan(ctx, "artifactmanager.GetEvents")
+	instrumenter := instrument(ctx, "GetEvents").err(&err)
+	defer func() {
+		if err != nil {
+			span.AddAttributes(trace.StringAttribute("error", err.Error()))
+		}
+		span.End()
+		instrumenter.end()
+	}()
+
+	currentPN, err := m.pulse(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bus := insolar.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, followRedirectSender(bus), retryJetSender(currentPN, m.JetStorage))
+
+	// Ledger returns the latest events first.
+	var (
+		events       []insolar.ObjectEvent
+		fromEvent    *insolar.ID
+		genericReply insolar.Reply
+	)
+	for {
+		genericReply, err = sender(ctx, &message.GetEvents{
+			Object:    obj,
+			Name:      name,
+			Since:     since,
+			FromEvent: fromEvent,
+			Amount:    getEventsChunkSize,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		switch rep := genericReply.(type) {
+		case *reply.Events:
+			events = append(events, rep.Events...)
+			fromEvent = rep.NextFrom
+		case *reply.Error:
+			err = rep.Error()
+			return nil, err
+		default:
+			err = fmt.Errorf("GetEvents: unexpected reply: %#v", rep)
+			return nil, err
+		}
+		if fromEvent == nil {
+			break
+		}
+	}
+
+	for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+		events[i], events[j] = events[j], events[i]
+	}
+	return events, nil
+}
+
 // DeclareType creates new type record in storage.
 //
 // Type is a contract interface. It contains one method signature.
@@ -466,6 +535,7 @@ func (m *client) DeployCode(
 	request insolar.Reference,
 	code []byte,
 	machineType insolar.MachineType,
+	abi []byte,
 ) (*insolar.ID, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.DeployCode")
@@ -490,6 +560,7 @@ func (m *client) DeployCode(
 		},
 		Code:        object.CalculateIDForBlob(m.PlatformCryptographyScheme, currentPN, code),
 		MachineType: machineType,
+		ABI:         abi,
 	}
 	codeID := object.NewRecordIDFromRecord(m.PlatformCryptographyScheme, currentPN, codeRec)
 	codeRef := insolar.NewReference(*domain.Record(), *codeID)
@@ -530,7 +601,7 @@ func (m *client) ActivatePrototype(
 		span.End()
 		instrumenter.end()
 	}()
-	desc, err := m.activateObject(ctx, domain, object, code, true, parent, false, memory)
+	desc, err := m.activateObject(ctx, domain, object, code, true, parent, false, memory, nil)
 	return desc, err
 }
 
@@ -543,6 +614,7 @@ func (m *client) ActivateObject(
 	domain, object, parent, prototype insolar.Reference,
 	asDelegate bool,
 	memory []byte,
+	code *insolar.Reference,
 ) (ObjectDescriptor, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.ActivateObject")
@@ -554,7 +626,7 @@ func (m *client) ActivateObject(
 		span.End()
 		instrumenter.end()
 	}()
-	desc, err := m.activateObject(ctx, domain, object, prototype, false, parent, asDelegate, memory)
+	desc, err := m.activateObject(ctx, domain, object, prototype, false, parent, asDelegate, memory, code)
 	return desc, err
 }
 
@@ -628,7 +700,8 @@ func (m *client) UpdatePrototype(
 }
 
 // UpdateObject creates amend object record in storage. Provided reference should be a reference to the head of the
-// object. Provided memory well be the new object memory.
+// object. Provided memory well be the new object memory. Provided code reference is recorded as memory version,
+// if it's nil, version of the previous state is kept.
 //
 // Returned reference will be the latest object state (exact) reference.
 func (m *client) UpdateObject(
@@ -636,6 +709,7 @@ func (m *client) UpdateObject(
 	domain, request insolar.Reference,
 	object ObjectDescriptor,
 	memory []byte,
+	code *insolar.Reference,
 ) (ObjectDescriptor, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.UpdateObject")
@@ -652,7 +726,7 @@ func (m *client) UpdateObject(
 		err = errors.New("object is not an instance")
 		return nil, err
 	}
-	desc, err := m.updateObject(ctx, domain, request, object, nil, memory)
+	desc, err := m.updateObject(ctx, domain, request, object, code, memory)
 	return desc, err
 }
 
@@ -696,9 +770,13 @@ func (m *client) RegisterValidation(
 	return err
 }
 
-// RegisterResult saves VM method call result.
+// RegisterResult saves VM method call result and resources consumed by the call.
 func (m *client) RegisterResult(
-	ctx context.Context, obj, request insolar.Reference, payload []byte,
+	ctx context.Context,
+	obj, request insolar.Reference,
+	payload []byte,
+	usage insolar.ResourceUsage,
+	events []insolar.ContractEvent,
 ) (*insolar.ID, error) {
 	var err error
 	ctx, span := instracer.StartSpan(ctx, "artifactmanager.RegisterResult")
@@ -722,11 +800,30 @@ func (m *client) RegisterResult(
 			Object:  *obj.Record(),
 			Request: request,
 			Payload: payload,
+			Usage:   usage,
 		},
 		request,
 		currentPN,
 	)
-	return recid, err
+	if err != nil || len(events) == 0 {
+		return recid, err
+	}
+
+	_, err = m.registerEvents(
+		ctx,
+		&object.EventRecord{
+			Object:  obj,
+			Request: request,
+			Result:  *recid,
+			Events:  events,
+		},
+		obj,
+		currentPN,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to register events")
+	}
+	return recid, nil
 }
 
 // pulse returns current PulseNumber for artifact manager
@@ -749,6 +846,7 @@ func (m *client) activateObject(
 	parent insolar.Reference,
 	asDelegate bool,
 	memory []byte,
+	version *insolar.Reference,
 ) (ObjectDescriptor, error) {
 	parentDesc, err := m.GetObject(ctx, parent, nil, false)
 	if err != nil {
@@ -770,6 +868,7 @@ func (m *client) activateObject(
 				Memory:      object.CalculateIDForBlob(m.PlatformCryptographyScheme, currentPN, memory),
 				Image:       prototype,
 				IsPrototype: isPrototype,
+				Version:     version,
 			},
 			Parent:     parent,
 			IsDelegate: asDelegate,
@@ -816,6 +915,7 @@ func (m *client) activateObject(
 		childPointer: o.ChildPointer,
 		memory:       memory,
 		parent:       o.Parent,
+		version:      version,
 	}, nil
 }
 
@@ -827,8 +927,9 @@ func (m *client) updateObject(
 	memory []byte,
 ) (ObjectDescriptor, error) {
 	var (
-		image *insolar.Reference
-		err   error
+		image   *insolar.Reference
+		version *insolar.Reference
+		err     error
 	)
 	if obj.IsPrototype() {
 		if code != nil {
@@ -838,6 +939,10 @@ func (m *client) updateObject(
 		}
 	} else {
 		image, err = obj.Prototype()
+		version = code
+		if version == nil {
+			version = obj.Version()
+		}
 	}
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to update object")
@@ -861,6 +966,7 @@ func (m *client) updateObject(
 			StateRecord: object.StateRecord{
 				Image:       *image,
 				IsPrototype: obj.IsPrototype(),
+				Version:     version,
 			},
 			PrevState: *obj.StateID(),
 		},
@@ -881,6 +987,7 @@ func (m *client) updateObject(
 		childPointer: o.ChildPointer,
 		memory:       memory,
 		parent:       o.Parent,
+		version:      version,
 	}, nil
 }
 
@@ -912,6 +1019,33 @@ func (m *client) setRecord(
 	}
 }
 
+func (m *client) registerEvents(
+	ctx context.Context,
+	rec record.VirtualRecord,
+	obj insolar.Reference,
+	currentPN insolar.PulseNumber,
+) (*insolar.ID, error) {
+	bus := insolar.MessageBusFromContext(ctx, m.DefaultBus)
+	sender := BuildSender(bus.Send, retryJetSender(currentPN, m.JetStorage))
+	genericReply, err := sender(ctx, &message.RegisterEvents{
+		Record: object.SerializeRecord(rec),
+		Object: obj,
+	}, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	switch rep := genericReply.(type) {
+	case *reply.ID:
+		return &rep.ID, nil
+	case *reply.Error:
+		return nil, rep.Error()
+	default:
+		return nil, fmt.Errorf("registerEvents: unexpected reply: %#v", rep)
+	}
+}
+
 func (m *client) setBlob(
 	ctx context.Context,
 	blob []byte,
@@ -20,7 +20,7 @@ import (
 type ClientMock struct {
 	t minimock.Tester
 
-	ActivateObjectFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r ObjectDescriptor, r1 error)
+	ActivateObjectFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r ObjectDescriptor, r1 error)
 	ActivateObjectCounter    uint64
 	ActivateObjectPreCounter uint64
 	ActivateObjectMock       mClientMockActivateObject
@@ -40,7 +40,7 @@ type ClientMock struct {
 	DeclareTypePreCounter uint64
 	DeclareTypeMock       mClientMockDeclareType
 
-	DeployCodeFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType) (r *insolar.ID, r1 error)
+	DeployCodeFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType, p5 []byte) (r *insolar.ID, r1 error)
 	DeployCodeCounter    uint64
 	DeployCodePreCounter uint64
 	DeployCodeMock       mClientMockDeployCode
@@ -65,6 +65,11 @@ type ClientMock struct {
 	GetDelegatePreCounter uint64
 	GetDelegateMock       mClientMockGetDelegate
 
+	GetEventsFunc       func(p context.Context, p1 insolar.Reference, p2 string, p3 insolar.PulseNumber) (r []insolar.ObjectEvent, r1 error)
+	GetEventsCounter    uint64
+	GetEventsPreCounter uint64
+	GetEventsMock       mClientMockGetEvents
+
 	GetObjectFunc       func(p context.Context, p1 insolar.Reference, p2 *insolar.ID, p3 bool) (r ObjectDescriptor, r1 error)
 	GetObjectCounter    uint64
 	GetObjectPreCounter uint64
@@ -85,7 +90,7 @@ type ClientMock struct {
 	RegisterRequestPreCounter uint64
 	RegisterRequestMock       mClientMockRegisterRequest
 
-	RegisterResultFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) (r *insolar.ID, r1 error)
+	RegisterResultFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) (r *insolar.ID, r1 error)
 	RegisterResultCounter    uint64
 	RegisterResultPreCounter uint64
 	RegisterResultMock       mClientMockRegisterResult
@@ -100,7 +105,7 @@ type ClientMock struct {
 	StatePreCounter uint64
 	StateMock       mClientMockState
 
-	UpdateObjectFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte) (r ObjectDescriptor, r1 error)
+	UpdateObjectFunc       func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte, p5 *insolar.Reference) (r ObjectDescriptor, r1 error)
 	UpdateObjectCounter    uint64
 	UpdateObjectPreCounter uint64
 	UpdateObjectMock       mClientMockUpdateObject
@@ -128,6 +133,7 @@ func NewClientMock(t minimock.Tester) *ClientMock {
 	m.GetChildrenMock = mClientMockGetChildren{mock: m}
 	m.GetCodeMock = mClientMockGetCode{mock: m}
 	m.GetDelegateMock = mClientMockGetDelegate{mock: m}
+	m.GetEventsMock = mClientMockGetEvents{mock: m}
 	m.GetObjectMock = mClientMockGetObject{mock: m}
 	m.GetPendingRequestMock = mClientMockGetPendingRequest{mock: m}
 	m.HasPendingRequestsMock = mClientMockHasPendingRequests{mock: m}
@@ -160,6 +166,7 @@ type ClientMockActivateObjectInput struct {
 	p4 insolar.Reference
 	p5 bool
 	p6 []byte
+	p7 *insolar.Reference
 }
 
 type ClientMockActivateObjectResult struct {
@@ -168,14 +175,14 @@ type ClientMockActivateObjectResult struct {
 }
 
 //Expect specifies that invocation of Client.ActivateObject is expected from 1 to Infinity times
-func (m *mClientMockActivateObject) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) *mClientMockActivateObject {
+func (m *mClientMockActivateObject) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) *mClientMockActivateObject {
 	m.mock.ActivateObjectFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ClientMockActivateObjectExpectation{}
 	}
-	m.mainExpectation.input = &ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6}
+	m.mainExpectation.input = &ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6, p7}
 	return m
 }
 
@@ -192,12 +199,12 @@ func (m *mClientMockActivateObject) Return(r ObjectDescriptor, r1 error) *Client
 }
 
 //ExpectOnce specifies that invocation of Client.ActivateObject is expected once
-func (m *mClientMockActivateObject) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) *ClientMockActivateObjectExpectation {
+func (m *mClientMockActivateObject) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) *ClientMockActivateObjectExpectation {
 	m.mock.ActivateObjectFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &ClientMockActivateObjectExpectation{}
-	expectation.input = &ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6}
+	expectation.input = &ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6, p7}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -207,7 +214,7 @@ func (e *ClientMockActivateObjectExpectation) Return(r ObjectDescriptor, r1 erro
 }
 
 //Set uses given function f as a mock of Client.ActivateObject method
-func (m *mClientMockActivateObject) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r ObjectDescriptor, r1 error)) *ClientMock {
+func (m *mClientMockActivateObject) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r ObjectDescriptor, r1 error)) *ClientMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -216,18 +223,18 @@ func (m *mClientMockActivateObject) Set(f func(p context.Context, p1 insolar.Ref
 }
 
 //ActivateObject implements github.com/insolar/insolar/logicrunner/artifacts.Client interface
-func (m *ClientMock) ActivateObject(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte) (r ObjectDescriptor, r1 error) {
+func (m *ClientMock) ActivateObject(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 insolar.Reference, p4 insolar.Reference, p5 bool, p6 []byte, p7 *insolar.Reference) (r ObjectDescriptor, r1 error) {
 	counter := atomic.AddUint64(&m.ActivateObjectPreCounter, 1)
 	defer atomic.AddUint64(&m.ActivateObjectCounter, 1)
 
 	if len(m.ActivateObjectMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.ActivateObjectMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to ClientMock.ActivateObject. %v %v %v %v %v %v %v", p, p1, p2, p3, p4, p5, p6)
+			m.t.Fatalf("Unexpected call to ClientMock.ActivateObject. %v %v %v %v %v %v %v %v", p, p1, p2, p3, p4, p5, p6, p7)
 			return
 		}
 
 		input := m.ActivateObjectMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6}, "Client.ActivateObject got unexpected parameters")
+		testify_assert.Equal(m.t, *input, ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6, p7}, "Client.ActivateObject got unexpected parameters")
 
 		result := m.ActivateObjectMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -245,7 +252,7 @@ func (m *ClientMock) ActivateObject(p context.Context, p1 insolar.Reference, p2
 
 		input := m.ActivateObjectMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6}, "Client.ActivateObject got unexpected parameters")
+			testify_assert.Equal(m.t, *input, ClientMockActivateObjectInput{p, p1, p2, p3, p4, p5, p6, p7}, "Client.ActivateObject got unexpected parameters")
 		}
 
 		result := m.ActivateObjectMock.mainExpectation.result
@@ -260,11 +267,11 @@ func (m *ClientMock) ActivateObject(p context.Context, p1 insolar.Reference, p2
 	}
 
 	if m.ActivateObjectFunc == nil {
-		m.t.Fatalf("Unexpected call to ClientMock.ActivateObject. %v %v %v %v %v %v %v", p, p1, p2, p3, p4, p5, p6)
+		m.t.Fatalf("Unexpected call to ClientMock.ActivateObject. %v %v %v %v %v %v %v %v", p, p1, p2, p3, p4, p5, p6, p7)
 		return
 	}
 
-	return m.ActivateObjectFunc(p, p1, p2, p3, p4, p5, p6)
+	return m.ActivateObjectFunc(p, p1, p2, p3, p4, p5, p6, p7)
 }
 
 //ActivateObjectMinimockCounter returns a count of ClientMock.ActivateObjectFunc invocations
@@ -775,6 +782,7 @@ type ClientMockDeployCodeInput struct {
 	p2 insolar.Reference
 	p3 []byte
 	p4 insolar.MachineType
+	p5 []byte
 }
 
 type ClientMockDeployCodeResult struct {
@@ -783,14 +791,14 @@ type ClientMockDeployCodeResult struct {
 }
 
 //Expect specifies that invocation of Client.DeployCode is expected from 1 to Infinity times
-func (m *mClientMockDeployCode) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType) *mClientMockDeployCode {
+func (m *mClientMockDeployCode) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType, p5 []byte) *mClientMockDeployCode {
 	m.mock.DeployCodeFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ClientMockDeployCodeExpectation{}
 	}
-	m.mainExpectation.input = &ClientMockDeployCodeInput{p, p1, p2, p3, p4}
+	m.mainExpectation.input = &ClientMockDeployCodeInput{p, p1, p2, p3, p4, p5}
 	return m
 }
 
@@ -807,12 +815,12 @@ func (m *mClientMockDeployCode) Return(r *insolar.ID, r1 error) *ClientMock {
 }
 
 //ExpectOnce specifies that invocation of Client.DeployCode is expected once
-func (m *mClientMockDeployCode) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType) *ClientMockDeployCodeExpectation {
+func (m *mClientMockDeployCode) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType, p5 []byte) *ClientMockDeployCodeExpectation {
 	m.mock.DeployCodeFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &ClientMockDeployCodeExpectation{}
-	expectation.input = &ClientMockDeployCodeInput{p, p1, p2, p3, p4}
+	expectation.input = &ClientMockDeployCodeInput{p, p1, p2, p3, p4, p5}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -822,7 +830,7 @@ func (e *ClientMockDeployCodeExpectation) Return(r *insolar.ID, r1 error) {
 }
 
 //Set uses given function f as a mock of Client.DeployCode method
-func (m *mClientMockDeployCode) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType) (r *insolar.ID, r1 error)) *ClientMock {
+func (m *mClientMockDeployCode) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType, p5 []byte) (r *insolar.ID, r1 error)) *ClientMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -831,18 +839,18 @@ func (m *mClientMockDeployCode) Set(f func(p context.Context, p1 insolar.Referen
 }
 
 //DeployCode implements github.com/insolar/insolar/logicrunner/artifacts.Client interface
-func (m *ClientMock) DeployCode(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType) (r *insolar.ID, r1 error) {
+func (m *ClientMock) DeployCode(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.MachineType, p5 []byte) (r *insolar.ID, r1 error) {
 	counter := atomic.AddUint64(&m.DeployCodePreCounter, 1)
 	defer atomic.AddUint64(&m.DeployCodeCounter, 1)
 
 	if len(m.DeployCodeMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.DeployCodeMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to ClientMock.DeployCode. %v %v %v %v %v", p, p1, p2, p3, p4)
+			m.t.Fatalf("Unexpected call to ClientMock.DeployCode. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 			return
 		}
 
 		input := m.DeployCodeMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, ClientMockDeployCodeInput{p, p1, p2, p3, p4}, "Client.DeployCode got unexpected parameters")
+		testify_assert.Equal(m.t, *input, ClientMockDeployCodeInput{p, p1, p2, p3, p4, p5}, "Client.DeployCode got unexpected parameters")
 
 		result := m.DeployCodeMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -860,7 +868,7 @@ func (m *ClientMock) DeployCode(p context.Context, p1 insolar.Reference, p2 inso
 
 		input := m.DeployCodeMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, ClientMockDeployCodeInput{p, p1, p2, p3, p4}, "Client.DeployCode got unexpected parameters")
+			testify_assert.Equal(m.t, *input, ClientMockDeployCodeInput{p, p1, p2, p3, p4, p5}, "Client.DeployCode got unexpected parameters")
 		}
 
 		result := m.DeployCodeMock.mainExpectation.result
@@ -875,11 +883,11 @@ func (m *ClientMock) DeployCode(p context.Context, p1 insolar.Reference, p2 inso
 	}
 
 	if m.DeployCodeFunc == nil {
-		m.t.Fatalf("Unexpected call to ClientMock.DeployCode. %v %v %v %v %v", p, p1, p2, p3, p4)
+		m.t.Fatalf("Unexpected call to ClientMock.DeployCode. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 		return
 	}
 
-	return m.DeployCodeFunc(p, p1, p2, p3, p4)
+	return m.DeployCodeFunc(p, p1, p2, p3, p4, p5)
 }
 
 //DeployCodeMinimockCounter returns a count of ClientMock.DeployCodeFunc invocations
@@ -1501,6 +1509,159 @@ func (m *ClientMock) GetDelegateFinished() bool {
 	return true
 }
 
+type mClientMockGetEvents struct {
+	mock              *ClientMock
+	mainExpectation   *ClientMockGetEventsExpectation
+	expectationSeries []*ClientMockGetEventsExpectation
+}
+
+type ClientMockGetEventsExpectation struct {
+	input  *ClientMockGetEventsInput
+	result *ClientMockGetEventsResult
+}
+
+type ClientMockGetEventsInput struct {
+	p  context.Context
+	p1 insolar.Reference
+	p2 string
+	p3 insolar.PulseNumber
+}
+
+type ClientMockGetEventsResult struct {
+	r  []insolar.ObjectEvent
+	r1 error
+}
+
+//Expect specifies that invocation of Client.GetEvents is expected from 1 to Infinity times
+func (m *mClientMockGetEvents) Expect(p context.Context, p1 insolar.Reference, p2 string, p3 insolar.PulseNumber) *mClientMockGetEvents {
+	m.mock.GetEventsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ClientMockGetEventsExpectation{}
+	}
+	m.mainExpectation.input = &ClientMockGetEventsInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of Client.GetEvents
+func (m *mClientMockGetEvents) Return(r []insolar.ObjectEvent, r1 error) *ClientMock {
+	m.mock.GetEventsFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ClientMockGetEventsExpectation{}
+	}
+	m.mainExpectation.result = &ClientMockGetEventsResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Client.GetEvents is expected once
+func (m *mClientMockGetEvents) ExpectOnce(p context.Context, p1 insolar.Reference, p2 string, p3 insolar.PulseNumber) *ClientMockGetEventsExpectation {
+	m.mock.GetEventsFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ClientMockGetEventsExpectation{}
+	expectation.input = &ClientMockGetEventsInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ClientMockGetEventsExpectation) Return(r []insolar.ObjectEvent, r1 error) {
+	e.result = &ClientMockGetEventsResult{r, r1}
+}
+
+//Set uses given function f as a mock of Client.GetEvents method
+func (m *mClientMockGetEvents) Set(f func(p context.Context, p1 insolar.Reference, p2 string, p3 insolar.PulseNumber) (r []insolar.ObjectEvent, r1 error)) *ClientMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetEventsFunc = f
+	return m.mock
+}
+
+//GetEvents implements github.com/insolar/insolar/logicrunner/artifacts.Client interface
+func (m *ClientMock) GetEvents(p context.Context, p1 insolar.Reference, p2 string, p3 insolar.PulseNumber) (r []insolar.ObjectEvent, r1 error) {
+	counter := atomic.AddUint64(&m.GetEventsPreCounter, 1)
+	defer atomic.AddUint64(&m.GetEventsCounter, 1)
+
+	if len(m.GetEventsMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetEventsMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ClientMock.GetEvents. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.GetEventsMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ClientMockGetEventsInput{p, p1, p2, p3}, "Client.GetEvents got unexpected parameters")
+
+		result := m.GetEventsMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ClientMock.GetEvents")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetEventsMock.mainExpectation != nil {
+
+		input := m.GetEventsMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ClientMockGetEventsInput{p, p1, p2, p3}, "Client.GetEvents got unexpected parameters")
+		}
+
+		result := m.GetEventsMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ClientMock.GetEvents")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.GetEventsFunc == nil {
+		m.t.Fatalf("Unexpected call to ClientMock.GetEvents. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.GetEventsFunc(p, p1, p2, p3)
+}
+
+//GetEventsMinimockCounter returns a count of ClientMock.GetEventsFunc invocations
+func (m *ClientMock) GetEventsMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetEventsCounter)
+}
+
+//GetEventsMinimockPreCounter returns the value of ClientMock.GetEvents invocations
+func (m *ClientMock) GetEventsMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetEventsPreCounter)
+}
+
+//GetEventsFinished returns true if mock invocations count is ok
+func (m *ClientMock) GetEventsFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetEventsMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetEventsCounter) == uint64(len(m.GetEventsMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetEventsMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetEventsCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetEventsFunc != nil {
+		return atomic.LoadUint64(&m.GetEventsCounter) > 0
+	}
+
+	return true
+}
+
 type mClientMockGetObject struct {
 	mock              *ClientMock
 	mainExpectation   *ClientMockGetObjectExpectation
@@ -2124,6 +2285,8 @@ type ClientMockRegisterResultInput struct {
 	p1 insolar.Reference
 	p2 insolar.Reference
 	p3 []byte
+	p4 insolar.ResourceUsage
+	p5 []insolar.ContractEvent
 }
 
 type ClientMockRegisterResultResult struct {
@@ -2132,14 +2295,14 @@ type ClientMockRegisterResultResult struct {
 }
 
 //Expect specifies that invocation of Client.RegisterResult is expected from 1 to Infinity times
-func (m *mClientMockRegisterResult) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) *mClientMockRegisterResult {
+func (m *mClientMockRegisterResult) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) *mClientMockRegisterResult {
 	m.mock.RegisterResultFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ClientMockRegisterResultExpectation{}
 	}
-	m.mainExpectation.input = &ClientMockRegisterResultInput{p, p1, p2, p3}
+	m.mainExpectation.input = &ClientMockRegisterResultInput{p, p1, p2, p3, p4, p5}
 	return m
 }
 
@@ -2156,12 +2319,12 @@ func (m *mClientMockRegisterResult) Return(r *insolar.ID, r1 error) *ClientMock
 }
 
 //ExpectOnce specifies that invocation of Client.RegisterResult is expected once
-func (m *mClientMockRegisterResult) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) *ClientMockRegisterResultExpectation {
+func (m *mClientMockRegisterResult) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) *ClientMockRegisterResultExpectation {
 	m.mock.RegisterResultFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &ClientMockRegisterResultExpectation{}
-	expectation.input = &ClientMockRegisterResultInput{p, p1, p2, p3}
+	expectation.input = &ClientMockRegisterResultInput{p, p1, p2, p3, p4, p5}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -2171,7 +2334,7 @@ func (e *ClientMockRegisterResultExpectation) Return(r *insolar.ID, r1 error) {
 }
 
 //Set uses given function f as a mock of Client.RegisterResult method
-func (m *mClientMockRegisterResult) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) (r *insolar.ID, r1 error)) *ClientMock {
+func (m *mClientMockRegisterResult) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) (r *insolar.ID, r1 error)) *ClientMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -2180,18 +2343,18 @@ func (m *mClientMockRegisterResult) Set(f func(p context.Context, p1 insolar.Ref
 }
 
 //RegisterResult implements github.com/insolar/insolar/logicrunner/artifacts.Client interface
-func (m *ClientMock) RegisterResult(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte) (r *insolar.ID, r1 error) {
+func (m *ClientMock) RegisterResult(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 []byte, p4 insolar.ResourceUsage, p5 []insolar.ContractEvent) (r *insolar.ID, r1 error) {
 	counter := atomic.AddUint64(&m.RegisterResultPreCounter, 1)
 	defer atomic.AddUint64(&m.RegisterResultCounter, 1)
 
 	if len(m.RegisterResultMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.RegisterResultMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to ClientMock.RegisterResult. %v %v %v %v", p, p1, p2, p3)
+			m.t.Fatalf("Unexpected call to ClientMock.RegisterResult. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 			return
 		}
 
 		input := m.RegisterResultMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, ClientMockRegisterResultInput{p, p1, p2, p3}, "Client.RegisterResult got unexpected parameters")
+		testify_assert.Equal(m.t, *input, ClientMockRegisterResultInput{p, p1, p2, p3, p4, p5}, "Client.RegisterResult got unexpected parameters")
 
 		result := m.RegisterResultMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -2209,7 +2372,7 @@ func (m *ClientMock) RegisterResult(p context.Context, p1 insolar.Reference, p2
 
 		input := m.RegisterResultMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, ClientMockRegisterResultInput{p, p1, p2, p3}, "Client.RegisterResult got unexpected parameters")
+			testify_assert.Equal(m.t, *input, ClientMockRegisterResultInput{p, p1, p2, p3, p4, p5}, "Client.RegisterResult got unexpected parameters")
 		}
 
 		result := m.RegisterResultMock.mainExpectation.result
@@ -2224,11 +2387,11 @@ func (m *ClientMock) RegisterResult(p context.Context, p1 insolar.Reference, p2
 	}
 
 	if m.RegisterResultFunc == nil {
-		m.t.Fatalf("Unexpected call to ClientMock.RegisterResult. %v %v %v %v", p, p1, p2, p3)
+		m.t.Fatalf("Unexpected call to ClientMock.RegisterResult. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 		return
 	}
 
-	return m.RegisterResultFunc(p, p1, p2, p3)
+	return m.RegisterResultFunc(p, p1, p2, p3, p4, p5)
 }
 
 //RegisterResultMinimockCounter returns a count of ClientMock.RegisterResultFunc invocations
@@ -2566,6 +2729,7 @@ type ClientMockUpdateObjectInput struct {
 	p2 insolar.Reference
 	p3 ObjectDescriptor
 	p4 []byte
+	p5 *insolar.Reference
 }
 
 type ClientMockUpdateObjectResult struct {
@@ -2574,14 +2738,14 @@ type ClientMockUpdateObjectResult struct {
 }
 
 //Expect specifies that invocation of Client.UpdateObject is expected from 1 to Infinity times
-func (m *mClientMockUpdateObject) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte) *mClientMockUpdateObject {
+func (m *mClientMockUpdateObject) Expect(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte, p5 *insolar.Reference) *mClientMockUpdateObject {
 	m.mock.UpdateObjectFunc = nil
 	m.expectationSeries = nil
 
 	if m.mainExpectation == nil {
 		m.mainExpectation = &ClientMockUpdateObjectExpectation{}
 	}
-	m.mainExpectation.input = &ClientMockUpdateObjectInput{p, p1, p2, p3, p4}
+	m.mainExpectation.input = &ClientMockUpdateObjectInput{p, p1, p2, p3, p4, p5}
 	return m
 }
 
@@ -2598,12 +2762,12 @@ func (m *mClientMockUpdateObject) Return(r ObjectDescriptor, r1 error) *ClientMo
 }
 
 //ExpectOnce specifies that invocation of Client.UpdateObject is expected once
-func (m *mClientMockUpdateObject) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte) *ClientMockUpdateObjectExpectation {
+func (m *mClientMockUpdateObject) ExpectOnce(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte, p5 *insolar.Reference) *ClientMockUpdateObjectExpectation {
 	m.mock.UpdateObjectFunc = nil
 	m.mainExpectation = nil
 
 	expectation := &ClientMockUpdateObjectExpectation{}
-	expectation.input = &ClientMockUpdateObjectInput{p, p1, p2, p3, p4}
+	expectation.input = &ClientMockUpdateObjectInput{p, p1, p2, p3, p4, p5}
 	m.expectationSeries = append(m.expectationSeries, expectation)
 	return expectation
 }
@@ -2613,7 +2777,7 @@ func (e *ClientMockUpdateObjectExpectation) Return(r ObjectDescriptor, r1 error)
 }
 
 //Set uses given function f as a mock of Client.UpdateObject method
-func (m *mClientMockUpdateObject) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte) (r ObjectDescriptor, r1 error)) *ClientMock {
+func (m *mClientMockUpdateObject) Set(f func(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte, p5 *insolar.Reference) (r ObjectDescriptor, r1 error)) *ClientMock {
 	m.mainExpectation = nil
 	m.expectationSeries = nil
 
@@ -2622,18 +2786,18 @@ func (m *mClientMockUpdateObject) Set(f func(p context.Context, p1 insolar.Refer
 }
 
 //UpdateObject implements github.com/insolar/insolar/logicrunner/artifacts.Client interface
-func (m *ClientMock) UpdateObject(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte) (r ObjectDescriptor, r1 error) {
+func (m *ClientMock) UpdateObject(p context.Context, p1 insolar.Reference, p2 insolar.Reference, p3 ObjectDescriptor, p4 []byte, p5 *insolar.Reference) (r ObjectDescriptor, r1 error) {
 	counter := atomic.AddUint64(&m.UpdateObjectPreCounter, 1)
 	defer atomic.AddUint64(&m.UpdateObjectCounter, 1)
 
 	if len(m.UpdateObjectMock.expectationSeries) > 0 {
 		if counter > uint64(len(m.UpdateObjectMock.expectationSeries)) {
-			m.t.Fatalf("Unexpected call to ClientMock.UpdateObject. %v %v %v %v %v", p, p1, p2, p3, p4)
+			m.t.Fatalf("Unexpected call to ClientMock.UpdateObject. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 			return
 		}
 
 		input := m.UpdateObjectMock.expectationSeries[counter-1].input
-		testify_assert.Equal(m.t, *input, ClientMockUpdateObjectInput{p, p1, p2, p3, p4}, "Client.UpdateObject got unexpected parameters")
+		testify_assert.Equal(m.t, *input, ClientMockUpdateObjectInput{p, p1, p2, p3, p4, p5}, "Client.UpdateObject got unexpected parameters")
 
 		result := m.UpdateObjectMock.expectationSeries[counter-1].result
 		if result == nil {
@@ -2651,7 +2815,7 @@ func (m *ClientMock) UpdateObject(p context.Context, p1 insolar.Reference, p2 in
 
 		input := m.UpdateObjectMock.mainExpectation.input
 		if input != nil {
-			testify_assert.Equal(m.t, *input, ClientMockUpdateObjectInput{p, p1, p2, p3, p4}, "Client.UpdateObject got unexpected parameters")
+			testify_assert.Equal(m.t, *input, ClientMockUpdateObjectInput{p, p1, p2, p3, p4, p5}, "Client.UpdateObject got unexpected parameters")
 		}
 
 		result := m.UpdateObjectMock.mainExpectation.result
@@ -2666,11 +2830,11 @@ func (m *ClientMock) UpdateObject(p context.Context, p1 insolar.Reference, p2 in
 	}
 
 	if m.UpdateObjectFunc == nil {
-		m.t.Fatalf("Unexpected call to ClientMock.UpdateObject. %v %v %v %v %v", p, p1, p2, p3, p4)
+		m.t.Fatalf("Unexpected call to ClientMock.UpdateObject. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
 		return
 	}
 
-	return m.UpdateObjectFunc(p, p1, p2, p3, p4)
+	return m.UpdateObjectFunc(p, p1, p2, p3, p4, p5)
 }
 
 //UpdateObjectMinimockCounter returns a count of ClientMock.UpdateObjectFunc invocations
@@ -2898,6 +3062,10 @@ func (m *ClientMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ClientMock.GetDelegate")
 	}
 
+	if !m.GetEventsFinished() {
+		m.t.Fatal("Expected call to ClientMock.GetEvents")
+	}
+
 	if !m.GetObjectFinished() {
 		m.t.Fatal("Expected call to ClientMock.GetObject")
 	}
@@ -2987,6 +3155,10 @@ func (m *ClientMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ClientMock.GetDelegate")
 	}
 
+	if !m.GetEventsFinished() {
+		m.t.Fatal("Expected call to ClientMock.GetEvents")
+	}
+
 	if !m.GetObjectFinished() {
 		m.t.Fatal("Expected call to ClientMock.GetObject")
 	}
@@ -3046,6 +3218,7 @@ func (m *ClientMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetChildrenFinished()
 		ok = ok && m.GetCodeFinished()
 		ok = ok && m.GetDelegateFinished()
+		ok = ok && m.GetEventsFinished()
 		ok = ok && m.GetObjectFinished()
 		ok = ok && m.GetPendingRequestFinished()
 		ok = ok && m.HasPendingRequestsFinished()
@@ -3099,6 +3272,10 @@ func (m *ClientMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ClientMock.GetDelegate")
 			}
 
+			if !m.GetEventsFinished() {
+				m.t.Error("Expected call to ClientMock.GetEvents")
+			}
+
 			if !m.GetObjectFinished() {
 				m.t.Error("Expected call to ClientMock.GetObject")
 			}
@@ -3183,6 +3360,10 @@ func (m *ClientMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetEventsFinished() {
+		return false
+	}
+
 	if !m.GetObjectFinished() {
 		return false
 	}
@@ -145,12 +145,14 @@ func genRandomRef(pulse insolar.PulseNumber) *insolar.Reference {
 
 func (s *amSuite) TestLedgerArtifactManager_GetCodeWithCache() {
 	code := []byte("test_code")
+	abi := []byte(`{"Contract":"Test"}`)
 	codeRef := testutils.RandomRef()
 
 	mb := testutils.NewMessageBusMock(s.T())
 	mb.SendFunc = func(p context.Context, p1 insolar.Message, p3 *insolar.MessageSendOptions) (r insolar.Reply, r1 error) {
 		return &reply.Code{
 			Code: code,
+			ABI:  abi,
 		}, nil
 	}
 
@@ -177,6 +179,7 @@ func (s *amSuite) TestLedgerArtifactManager_GetCodeWithCache() {
 	receivedCode, err := desc.Code()
 	require.NoError(s.T(), err)
 	require.Equal(s.T(), code, receivedCode)
+	require.Equal(s.T(), abi, desc.ABI())
 
 	mb.SendFunc = func(p context.Context, p1 insolar.Message, p3 *insolar.MessageSendOptions) (r insolar.Reply, r1 error) {
 		s.T().Fatal("Func must not be called here")
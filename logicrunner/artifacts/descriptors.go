@@ -32,6 +32,7 @@ type codeDescriptor struct {
 	code        []byte
 	machineType insolar.MachineType
 	ref         insolar.Reference
+	abi         []byte
 
 	ctx context.Context
 }
@@ -51,6 +52,11 @@ func (d *codeDescriptor) Code() ([]byte, error) {
 	return d.code, nil
 }
 
+// ABI returns ABI of the code in JSON.
+func (d *codeDescriptor) ABI() []byte {
+	return d.abi
+}
+
 // ObjectDescriptor represents meta info required to fetch all object data.
 type objectDescriptor struct {
 	ctx context.Context
@@ -63,6 +69,7 @@ type objectDescriptor struct {
 	childPointer *insolar.ID // can be nil.
 	memory       []byte
 	parent       insolar.Reference
+	version      *insolar.Reference
 }
 
 // IsPrototype determines if the object is a prototype.
@@ -122,6 +129,11 @@ func (d *objectDescriptor) Parent() *insolar.Reference {
 	return &d.parent
 }
 
+// Version returns code reference object memory was produced by.
+func (d *objectDescriptor) Version() *insolar.Reference {
+	return d.version
+}
+
 // ChildIterator is used to iterate over objects children. During iteration children refs will be fetched from remote
 // source (parent object).
 //
@@ -63,6 +63,11 @@ type ObjectDescriptorMock struct {
 	StateIDCounter    uint64
 	StateIDPreCounter uint64
 	StateIDMock       mObjectDescriptorMockStateID
+
+	VersionFunc       func() (r *insolar.Reference)
+	VersionCounter    uint64
+	VersionPreCounter uint64
+	VersionMock       mObjectDescriptorMockVersion
 }
 
 //NewObjectDescriptorMock returns a mock for github.com/insolar/insolar/logicrunner/artifacts.ObjectDescriptor
@@ -82,6 +87,7 @@ func NewObjectDescriptorMock(t minimock.Tester) *ObjectDescriptorMock {
 	m.ParentMock = mObjectDescriptorMockParent{mock: m}
 	m.PrototypeMock = mObjectDescriptorMockPrototype{mock: m}
 	m.StateIDMock = mObjectDescriptorMockStateID{mock: m}
+	m.VersionMock = mObjectDescriptorMockVersion{mock: m}
 
 	return m
 }
@@ -1314,6 +1320,140 @@ func (m *ObjectDescriptorMock) StateIDFinished() bool {
 	return true
 }
 
+type mObjectDescriptorMockVersion struct {
+	mock              *ObjectDescriptorMock
+	mainExpectation   *ObjectDescriptorMockVersionExpectation
+	expectationSeries []*ObjectDescriptorMockVersionExpectation
+}
+
+type ObjectDescriptorMockVersionExpectation struct {
+	result *ObjectDescriptorMockVersionResult
+}
+
+type ObjectDescriptorMockVersionResult struct {
+	r *insolar.Reference
+}
+
+//Expect specifies that invocation of ObjectDescriptor.Version is expected from 1 to Infinity times
+func (m *mObjectDescriptorMockVersion) Expect() *mObjectDescriptorMockVersion {
+	m.mock.VersionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectDescriptorMockVersionExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of ObjectDescriptor.Version
+func (m *mObjectDescriptorMockVersion) Return(r *insolar.Reference) *ObjectDescriptorMock {
+	m.mock.VersionFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ObjectDescriptorMockVersionExpectation{}
+	}
+	m.mainExpectation.result = &ObjectDescriptorMockVersionResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ObjectDescriptor.Version is expected once
+func (m *mObjectDescriptorMockVersion) ExpectOnce() *ObjectDescriptorMockVersionExpectation {
+	m.mock.VersionFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ObjectDescriptorMockVersionExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ObjectDescriptorMockVersionExpectation) Return(r *insolar.Reference) {
+	e.result = &ObjectDescriptorMockVersionResult{r}
+}
+
+//Set uses given function f as a mock of ObjectDescriptor.Version method
+func (m *mObjectDescriptorMockVersion) Set(f func() (r *insolar.Reference)) *ObjectDescriptorMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.VersionFunc = f
+	return m.mock
+}
+
+//Version implements github.com/insolar/insolar/logicrunner/artifacts.ObjectDescriptor interface
+func (m *ObjectDescriptorMock) Version() (r *insolar.Reference) {
+	counter := atomic.AddUint64(&m.VersionPreCounter, 1)
+	defer atomic.AddUint64(&m.VersionCounter, 1)
+
+	if len(m.VersionMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.VersionMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ObjectDescriptorMock.Version.")
+			return
+		}
+
+		result := m.VersionMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectDescriptorMock.Version")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.VersionMock.mainExpectation != nil {
+
+		result := m.VersionMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ObjectDescriptorMock.Version")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.VersionFunc == nil {
+		m.t.Fatalf("Unexpected call to ObjectDescriptorMock.Version.")
+		return
+	}
+
+	return m.VersionFunc()
+}
+
+//VersionMinimockCounter returns a count of ObjectDescriptorMock.VersionFunc invocations
+func (m *ObjectDescriptorMock) VersionMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.VersionCounter)
+}
+
+//VersionMinimockPreCounter returns the value of ObjectDescriptorMock.Version invocations
+func (m *ObjectDescriptorMock) VersionMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.VersionPreCounter)
+}
+
+//VersionFinished returns true if mock invocations count is ok
+func (m *ObjectDescriptorMock) VersionFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.VersionMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.VersionCounter) == uint64(len(m.VersionMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.VersionMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.VersionCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.VersionFunc != nil {
+		return atomic.LoadUint64(&m.VersionCounter) > 0
+	}
+
+	return true
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *ObjectDescriptorMock) ValidateCallCounters() {
@@ -1354,6 +1494,10 @@ func (m *ObjectDescriptorMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ObjectDescriptorMock.StateID")
 	}
 
+	if !m.VersionFinished() {
+		m.t.Fatal("Expected call to ObjectDescriptorMock.Version")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -1407,6 +1551,10 @@ func (m *ObjectDescriptorMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ObjectDescriptorMock.StateID")
 	}
 
+	if !m.VersionFinished() {
+		m.t.Fatal("Expected call to ObjectDescriptorMock.Version")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -1430,6 +1578,7 @@ func (m *ObjectDescriptorMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.ParentFinished()
 		ok = ok && m.PrototypeFinished()
 		ok = ok && m.StateIDFinished()
+		ok = ok && m.VersionFinished()
 
 		if ok {
 			return
@@ -1474,6 +1623,10 @@ func (m *ObjectDescriptorMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ObjectDescriptorMock.StateID")
 			}
 
+			if !m.VersionFinished() {
+				m.t.Error("Expected call to ObjectDescriptorMock.Version")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -1522,5 +1675,9 @@ func (m *ObjectDescriptorMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.VersionFinished() {
+		return false
+	}
+
 	return true
 }
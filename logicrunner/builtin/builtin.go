@@ -19,117 +19,161 @@ package builtin
 
 import (
 	"context"
-	"reflect"
+	"sync"
 
+	"github.com/pkg/errors"
+	"github.com/tylerb/gls"
+
+	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/instracer"
 	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
 
-	"github.com/pkg/errors"
-	"github.com/ugorji/go/codec"
+// ContractMethod is a generated wrapper of a contract method,
+// takes object's memory and serialized arguments and returns new memory and serialized results
+type ContractMethod func(object []byte, data []byte) ([]byte, []byte, error)
 
-	"github.com/insolar/insolar/insolar"
-	"github.com/insolar/insolar/logicrunner/builtin/helloworld"
+// ContractConstructor is a generated wrapper of a contract constructor,
+// takes serialized arguments and returns memory of a new object
+type ContractConstructor func(data []byte) ([]byte, error)
+
+// Contract describes builtin contract: wrappers of its methods and constructors
+type Contract struct {
+	Methods      map[string]ContractMethod
+	Immutable    map[string]bool // methods that can be called as queries
+	Constructors map[string]ContractConstructor
+}
+
+var (
+	registry      = make(map[string]*Contract)
+	registryMutex sync.RWMutex
 )
 
-// Contract is a interface for builtin contract
-type Contract interface {
+// Register adds contract into the list of contracts available to builtin executor.
+// Code of a builtin contract on ledger is the name it was registered with.
+// It's called from init of generated builtin wrappers.
+func Register(name string, contract *Contract) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic("builtin contract " + name + " is already registered")
+	}
+	registry[name] = contract
+}
+
+// IsRegistered checks that contract with provided name can be executed by builtin executor
+func IsRegistered(name string) bool {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	_, ok := registry[name]
+	return ok
 }
 
 // BuiltIn is a contract runner engine
 type BuiltIn struct {
 	AM       artifacts.Client
 	EB       insolar.MessageBus
-	Registry map[string]Contract
+	Registry map[string]*Contract
 }
 
 // NewBuiltIn is an constructor
-func NewBuiltIn(eb insolar.MessageBus, am artifacts.Client) *BuiltIn {
+func NewBuiltIn(eb insolar.MessageBus, am artifacts.Client, upstream rpctypes.UpstreamRPC) *BuiltIn {
 	bi := BuiltIn{
 		AM:       am,
 		EB:       eb,
-		Registry: make(map[string]Contract),
+		Registry: make(map[string]*Contract),
+	}
+
+	registryMutex.RLock()
+	for name, contract := range registry {
+		bi.Registry[name] = contract
 	}
+	registryMutex.RUnlock()
 
-	bi.Registry["helloworld"] = helloworld.NewHelloWorld()
+	// builtin contracts are executed inside of insolard, so generated wrappers
+	// and proxies use helper talking to logicrunner directly
+	proxyctx.Current = NewProxyHelper(upstream)
 
 	return &bi
 }
 
-func (bi *BuiltIn) CallConstructor(ctx context.Context, callCtx *insolar.LogicCallContext, code insolar.Reference, name string, args insolar.Arguments) (objectState []byte, err error) {
-	panic("implement me")
-}
+func (bi *BuiltIn) contract(ctx context.Context, codeRef insolar.Reference) (string, *Contract, error) {
+	codeDescriptor, err := bi.AM.GetCode(ctx, codeRef)
+	if err != nil {
+		return "", nil, errors.Wrap(err, "can't find code")
+	}
+	code, err := codeDescriptor.Code()
+	if err != nil {
+		return "", nil, errors.Wrap(err, "can't fetch code")
+	}
 
-func (bi *BuiltIn) Stop() error {
-	return nil
+	name := string(code)
+	c, ok := bi.Registry[name]
+	if !ok {
+		return "", nil, errors.Errorf("builtin contract %q is not registered", name)
+	}
+	return name, c, nil
 }
 
-// CallMethod runs a method on contract
-func (bi *BuiltIn) CallMethod(ctx context.Context, callCtx *insolar.LogicCallContext, codeRef insolar.Reference, data []byte, method string, args insolar.Arguments) (newObjectState []byte, methodResults insolar.Arguments, err error) {
-	am := bi.AM
-	ctx, span := instracer.StartSpan(ctx, "buildin.CallMethod")
+// CallConstructor runs a constructor of builtin contract
+func (bi *BuiltIn) CallConstructor(ctx context.Context, callCtx *insolar.LogicCallContext, code insolar.Reference, name string, args insolar.Arguments) (objectState []byte, err error) {
+	ctx, span := instracer.StartSpan(ctx, "builtin.CallConstructor")
 	defer span.End()
 
-	codeDescriptor, err := am.GetCode(ctx, codeRef)
-
+	contractName, c, err := bi.contract(ctx, code)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "Can't find code")
+		return nil, errors.Wrap(err, "[ CallConstructor ]")
 	}
-	code, err := codeDescriptor.Code()
-	c, ok := bi.Registry[string(code)]
+
+	constructor, ok := c.Constructors[name]
 	if !ok {
-		return nil, nil, errors.New("Wrong reference for builtin contract")
+		return nil, errors.Errorf("[ CallConstructor ] no constructor %q in builtin contract %q", name, contractName)
 	}
 
-	zv := reflect.New(reflect.TypeOf(c).Elem()).Interface()
-	ch := new(codec.CborHandle)
+	gls.Set("callCtx", callCtx)
+	defer gls.Cleanup()
 
-	err = codec.NewDecoderBytes(data, ch).Decode(zv)
+	objectState, err = constructor(args)
 	if err != nil {
-		return nil, nil, errors.Wrapf(err, "couldn't decode data into %T", zv)
+		return nil, errors.Wrapf(err, "[ CallConstructor ] constructor %q of builtin contract %q failed", name, contractName)
 	}
 
-	m := reflect.ValueOf(zv).MethodByName(method)
-	if !m.IsValid() {
-		return nil, nil, errors.New("no method " + method + " in the contract")
-	}
+	return objectState, nil
+}
 
-	inLen := m.Type().NumIn()
+func (bi *BuiltIn) Stop() error {
+	return nil
+}
 
-	mask := make([]interface{}, inLen)
-	for i := 0; i < inLen; i++ {
-		argType := m.Type().In(i)
-		mask[i] = reflect.Zero(argType).Interface()
-	}
+// CallMethod runs a method on contract
+func (bi *BuiltIn) CallMethod(ctx context.Context, callCtx *insolar.LogicCallContext, codeRef insolar.Reference, data []byte, method string, args insolar.Arguments) (newObjectState []byte, methodResults insolar.Arguments, err error) {
+	ctx, span := instracer.StartSpan(ctx, "builtin.CallMethod")
+	defer span.End()
 
-	err = codec.NewDecoderBytes(args, ch).Decode(&mask)
+	contractName, c, err := bi.contract(ctx, codeRef)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "couldn't unmarshal CBOR for arguments of the method")
+		return nil, nil, errors.Wrap(err, "[ CallMethod ]")
 	}
 
-	in := make([]reflect.Value, inLen)
-	for i := 0; i < inLen; i++ {
-		in[i] = reflect.ValueOf(mask[i])
+	m, ok := c.Methods[method]
+	if !ok {
+		return nil, nil, errors.Errorf("[ CallMethod ] no method %q in builtin contract %q", method, contractName)
 	}
-
-	resValues := m.Call(in)
-
-	err = codec.NewEncoderBytes(&newObjectState, ch).Encode(zv)
-	if err != nil {
-		return nil, nil, errors.Wrap(err, "couldn't marshal new object data into cbor")
+	if callCtx.Mode == insolar.QueryMode && !c.Immutable[method] {
+		return nil, nil, errors.Errorf("[ CallMethod ] method %q of builtin contract %q is not immutable", method, contractName)
 	}
 
-	res := make([]interface{}, len(resValues))
-	for i, v := range resValues {
-		res[i] = v.Interface()
-	}
+	gls.Set("callCtx", callCtx)
+	defer gls.Cleanup()
 
-	var resSerialized []byte
-	err = codec.NewEncoderBytes(&resSerialized, ch).Encode(res)
+	newObjectState, methodResults, err = m(data, args)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "couldn't marshal returned values into cbor")
+		return nil, nil, errors.Wrapf(err, "[ CallMethod ] method %q of builtin contract %q failed", method, contractName)
 	}
 
-	methodResults = resSerialized
-
 	return newObjectState, methodResults, nil
 }
@@ -0,0 +1,126 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package builtin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/builtin"
+	"github.com/insolar/insolar/logicrunner/builtin/helloworld"
+	"github.com/insolar/insolar/testutils"
+)
+
+func cborMarshal(t *testing.T, o interface{}) []byte {
+	var data []byte
+	err := codec.NewEncoderBytes(&data, new(codec.CborHandle)).Encode(o)
+	require.NoError(t, err)
+	return data
+}
+
+func cborUnmarshal(t *testing.T, data []byte, o interface{}) {
+	err := codec.NewDecoderBytes(data, new(codec.CborHandle)).Decode(o)
+	require.NoError(t, err)
+}
+
+func newBuiltIn(t *testing.T, mc *minimock.Controller, code string) *builtin.BuiltIn {
+	cd := artifacts.NewCodeDescriptorMock(mc)
+	cd.CodeMock.Return([]byte(code), nil)
+	am := artifacts.NewClientMock(mc)
+	am.GetCodeMock.Return(cd, nil)
+
+	return builtin.NewBuiltIn(nil, am, nil)
+}
+
+func TestBuiltIn_IsRegistered(t *testing.T) {
+	require.True(t, builtin.IsRegistered("helloworld"))
+	require.False(t, builtin.IsRegistered("unknown"))
+}
+
+func TestBuiltIn_CallConstructor(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	bi := newBuiltIn(t, mc, "helloworld")
+	callCtx := &insolar.LogicCallContext{}
+
+	state, err := bi.CallConstructor(ctx, callCtx, testutils.RandomRef(), "NewHelloWorld", cborMarshal(t, []interface{}{}))
+	require.NoError(t, err)
+
+	hw := helloworld.HelloWorld{}
+	cborUnmarshal(t, state, &hw)
+	require.Equal(t, 0, hw.Greeted)
+
+	_, err = bi.CallConstructor(ctx, callCtx, testutils.RandomRef(), "NewUnknown", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no constructor "NewUnknown" in builtin contract "helloworld"`)
+}
+
+func TestBuiltIn_CallMethod(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	bi := newBuiltIn(t, mc, "helloworld")
+	callCtx := &insolar.LogicCallContext{}
+
+	state := cborMarshal(t, helloworld.HelloWorld{Greeted: 1})
+	newState, result, err := bi.CallMethod(ctx, callCtx, testutils.RandomRef(), state, "Greet", cborMarshal(t, []interface{}{"Vany"}))
+	require.NoError(t, err)
+
+	hw := helloworld.HelloWorld{}
+	cborUnmarshal(t, newState, &hw)
+	require.Equal(t, 2, hw.Greeted)
+
+	var res []interface{}
+	cborUnmarshal(t, result, &res)
+	require.Equal(t, []interface{}{"Hello Vany's world", nil}, res)
+
+	_, _, err = bi.CallMethod(ctx, callCtx, testutils.RandomRef(), state, "Unknown", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no method "Unknown" in builtin contract "helloworld"`)
+
+	_, _, err = bi.CallMethod(ctx, callCtx, testutils.RandomRef(), nil, "Greet", cborMarshal(t, []interface{}{"Vany"}))
+	require.Error(t, err)
+
+	queryCtx := &insolar.LogicCallContext{Mode: insolar.QueryMode}
+	_, _, err = bi.CallMethod(ctx, queryCtx, testutils.RandomRef(), state, "Greet", cborMarshal(t, []interface{}{"Vany"}))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `method "Greet" of builtin contract "helloworld" is not immutable`)
+}
+
+func TestBuiltIn_UnknownContract(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	bi := newBuiltIn(t, mc, "unknown")
+
+	_, _, err := bi.CallMethod(ctx, &insolar.LogicCallContext{}, testutils.RandomRef(), nil, "Greet", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `builtin contract "unknown" is not registered`)
+
+	_, err = bi.CallConstructor(ctx, &insolar.LogicCallContext{}, testutils.RandomRef(), "NewHelloWorld", nil)
+	require.Error(t, err)
+}
@@ -16,28 +16,24 @@
 
 package helloworld
 
-import "github.com/insolar/insolar/insolar"
+import (
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
 
 // HelloWorld contract
 type HelloWorld struct {
+	foundation.BaseContract
 	// Greeted - how many callers we "greated"
 	Greeted int
 }
 
-// CodeRef returns something strange
-func CodeRef() insolar.Reference {
-	var ref insolar.Reference
-	ref[insolar.RecordRefSize-1] = 1
-	return ref
-}
-
 // NewHelloWorld returns a new empty contract
-func NewHelloWorld() *HelloWorld {
-	return &HelloWorld{}
+func NewHelloWorld() (*HelloWorld, error) {
+	return &HelloWorld{}, nil
 }
 
 // Greet greats the caller
-func (hw *HelloWorld) Greet(name string) string {
+func (hw *HelloWorld) Greet(name string) (string, error) {
 	hw.Greeted++
-	return "Hello " + name + "'s world"
+	return "Hello " + name + "'s world", nil
 }
@@ -0,0 +1,228 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package builtin
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/tylerb/gls"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
+
+// ProxyHelper is an implementation of proxyctx.ProxyHelper for builtin contracts
+type ProxyHelper struct {
+	upstream rpctypes.UpstreamRPC
+}
+
+// NewProxyHelper creates new ProxyHelper calling provided upstream
+func NewProxyHelper(upstream rpctypes.UpstreamRPC) *ProxyHelper {
+	return &ProxyHelper{upstream: upstream}
+}
+
+func makeUpBaseReq() rpctypes.UpBaseReq {
+	callCtx, ok := gls.Get("callCtx").(*insolar.LogicCallContext)
+	if !ok {
+		panic("Wrong or unexistent call context, you probably started a goroutine")
+	}
+
+	return rpctypes.UpBaseReq{
+		Mode:      callCtx.Mode,
+		Callee:    *callCtx.Callee,
+		Prototype: *callCtx.Prototype,
+		Request:   *callCtx.Request,
+	}
+}
+
+// RouteCall routes call to another contract
+func (h *ProxyHelper) RouteCall(ref insolar.Reference, wait bool, method string, args []byte, proxyPrototype insolar.Reference) ([]byte, error) {
+	req := rpctypes.UpRouteReq{
+		UpBaseReq:      makeUpBaseReq(),
+		Wait:           wait,
+		Object:         ref,
+		Method:         method,
+		Arguments:      args,
+		ProxyPrototype: proxyPrototype,
+	}
+
+	res := rpctypes.UpRouteResp{}
+	err := h.upstream.RouteCall(req, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ RouteCall ]")
+	}
+
+	return []byte(res.Result), nil
+}
+
+// SaveAsChild creates new object as a child of parent
+func (h *ProxyHelper) SaveAsChild(parentRef, classRef insolar.Reference, constructorName string, argsSerialized []byte) (insolar.Reference, error) {
+	req := rpctypes.UpSaveAsChildReq{
+		UpBaseReq:       makeUpBaseReq(),
+		Parent:          parentRef,
+		Prototype:       classRef,
+		ConstructorName: constructorName,
+		ArgsSerialized:  argsSerialized,
+	}
+
+	res := rpctypes.UpSaveAsChildResp{}
+	err := h.upstream.SaveAsChild(req, &res)
+	if err != nil {
+		return insolar.Reference{}, errors.Wrap(err, "[ SaveAsChild ]")
+	}
+
+	return *res.Reference, nil
+}
+
+// GetObjChildrenIterator returns iterator over children of object with specified prototype
+func (h *ProxyHelper) GetObjChildrenIterator(obj insolar.Reference, prototype insolar.Reference, iteratorID string) (*proxyctx.ChildrenTypedIterator, error) {
+	req := rpctypes.UpGetObjChildrenIteratorReq{
+		UpBaseReq:  makeUpBaseReq(),
+		IteratorID: iteratorID,
+		Obj:        obj,
+		Prototype:  prototype,
+	}
+
+	res := rpctypes.UpGetObjChildrenIteratorResp{}
+	err := h.upstream.GetObjChildrenIterator(req, &res)
+	if err != nil {
+		return &proxyctx.ChildrenTypedIterator{}, errors.Wrap(err, "[ GetObjChildrenIterator ]")
+	}
+
+	return &proxyctx.ChildrenTypedIterator{
+		Parent:         obj,
+		ChildPrototype: prototype,
+		IteratorID:     res.Iterator.ID,
+		Buff:           res.Iterator.Buff,
+		CanFetch:       res.Iterator.CanFetch,
+	}, nil
+}
+
+// SaveAsDelegate creates new object as a delegate of another one
+func (h *ProxyHelper) SaveAsDelegate(intoRef, classRef insolar.Reference, constructorName string, argsSerialized []byte) (insolar.Reference, error) {
+	req := rpctypes.UpSaveAsDelegateReq{
+		UpBaseReq:       makeUpBaseReq(),
+		Into:            intoRef,
+		Prototype:       classRef,
+		ConstructorName: constructorName,
+		ArgsSerialized:  argsSerialized,
+	}
+
+	res := rpctypes.UpSaveAsDelegateResp{}
+	err := h.upstream.SaveAsDelegate(req, &res)
+	if err != nil {
+		return insolar.Reference{}, errors.Wrap(err, "[ SaveAsDelegate ]")
+	}
+
+	return *res.Reference, nil
+}
+
+// GetDelegate returns delegate of object with provided type
+func (h *ProxyHelper) GetDelegate(object, ofType insolar.Reference) (insolar.Reference, error) {
+	req := rpctypes.UpGetDelegateReq{
+		UpBaseReq: makeUpBaseReq(),
+		Object:    object,
+		OfType:    ofType,
+	}
+
+	res := rpctypes.UpGetDelegateResp{}
+	err := h.upstream.GetDelegate(req, &res)
+	if err != nil {
+		return insolar.Reference{}, errors.Wrap(err, "[ GetDelegate ]")
+	}
+
+	return res.Object, nil
+}
+
+// DeactivateObject deactivates current object
+func (h *ProxyHelper) DeactivateObject(object insolar.Reference) error {
+	req := rpctypes.UpDeactivateObjectReq{
+		UpBaseReq: makeUpBaseReq(),
+	}
+
+	res := rpctypes.UpDeactivateObjectResp{}
+	err := h.upstream.DeactivateObject(req, &res)
+	if err != nil {
+		return errors.Wrap(err, "[ DeactivateObject ]")
+	}
+
+	return nil
+}
+
+// EmitEvent emits event of current object
+func (h *ProxyHelper) EmitEvent(name string, payload []byte) error {
+	req := rpctypes.UpEmitEventReq{
+		UpBaseReq: makeUpBaseReq(),
+		Name:      name,
+		Payload:   payload,
+	}
+
+	res := rpctypes.UpEmitEventResp{}
+	err := h.upstream.EmitEvent(req, &res)
+	if err != nil {
+		return errors.Wrap(err, "[ EmitEvent ]")
+	}
+
+	return nil
+}
+
+// UpgradePrototype deploys new code of the prototype
+func (h *ProxyHelper) UpgradePrototype(
+	prototype insolar.Reference, code []byte, machineType insolar.MachineType, abi []byte,
+) (
+	insolar.Reference, error,
+) {
+	req := rpctypes.UpUpgradePrototypeReq{
+		UpBaseReq:   makeUpBaseReq(),
+		Prototype:   prototype,
+		Code:        code,
+		MachineType: machineType,
+		ABI:         abi,
+	}
+
+	res := rpctypes.UpUpgradePrototypeResp{}
+	err := h.upstream.UpgradePrototype(req, &res)
+	if err != nil {
+		return insolar.Reference{}, errors.Wrap(err, "[ UpgradePrototype ]")
+	}
+
+	return res.Code, nil
+}
+
+// Serialize - CBOR serializer wrapper: `what` -> `to`
+func (h *ProxyHelper) Serialize(what interface{}, to *[]byte) error {
+	ch := new(codec.CborHandle)
+	return codec.NewEncoderBytes(to, ch).Encode(what)
+}
+
+// Deserialize - CBOR de-serializer wrapper: `from` -> `into`
+func (h *ProxyHelper) Deserialize(from []byte, into interface{}) error {
+	ch := new(codec.CborHandle)
+	return codec.NewDecoderBytes(from, ch).Decode(into)
+}
+
+// MakeErrorSerializable converts errors satisfying error interface to foundation.Error
+func (h *ProxyHelper) MakeErrorSerializable(e error) error {
+	if e == nil || e == (*foundation.Error)(nil) || reflect.ValueOf(e).IsNil() {
+		return nil
+	}
+	return &foundation.Error{S: e.Error()}
+}
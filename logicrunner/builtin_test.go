@@ -118,11 +118,12 @@ func TestBareHelloworld(t *testing.T) {
 
 	MessageBusTrivialBehavior(mb, lr)
 
-	hw := helloworld.NewHelloWorld()
+	hw, err := helloworld.NewHelloWorld()
+	require.NoError(t, err)
 
 	domain := byteRecorRef(2)
 	request := byteRecorRef(3)
-	_, _, protoRef, err := goplugintestutils.AMPublishCode(t, am, domain, request, insolar.MachineTypeBuiltin, []byte("helloworld"))
+	_, codeRef, protoRef, err := goplugintestutils.AMPublishCode(t, am, domain, request, insolar.MachineTypeBuiltin, []byte("helloworld"))
 	assert.NoError(t, err)
 
 	contract, err := am.RegisterRequest(ctx, *am.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: byteRecorRef(4)}})
@@ -134,7 +135,7 @@ func TestBareHelloworld(t *testing.T) {
 
 	_, err = am.ActivateObject(
 		ctx, domain, reqref, *am.GenesisRef(), *protoRef, false,
-		goplugintestutils.CBORMarshal(t, hw),
+		goplugintestutils.CBORMarshal(t, hw), codeRef,
 	)
 	assert.NoError(t, err)
 	assert.Equal(t, true, contract != nil, "contract created")
@@ -155,7 +156,7 @@ func TestBareHelloworld(t *testing.T) {
 	assert.NoError(t, err, "contract call")
 
 	r := goplugintestutils.CBORUnMarshal(t, resp.(*reply.CallMethod).Result)
-	assert.Equal(t, []interface{}([]interface{}{"Hello Vany's world"}), r)
+	assert.Equal(t, []interface{}([]interface{}{"Hello Vany's world", nil}), r)
 
 	msg = &message.CallMethod{
 		ObjectRef: reqref,
@@ -173,5 +174,5 @@ func TestBareHelloworld(t *testing.T) {
 	assert.NoError(t, err, "contract call")
 
 	r = goplugintestutils.CBORUnMarshal(t, resp.(*reply.CallMethod).Result)
-	assert.Equal(t, []interface{}([]interface{}{"Hello Ruz's world"}), r)
+	assert.Equal(t, []interface{}([]interface{}{"Hello Ruz's world", nil}), r)
 }
@@ -0,0 +1,304 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package contracttest is an in-process harness for unit testing of smart contracts.
+//
+// Harness runs real logicrunner with builtin executor on top of temporary ledger,
+// so contracts are executed with foundation context, proxies and child/delegate
+// semantics as they are in the network, but inside plain `go test`.
+//
+// Contracts are executed as builtin ones, so their wrappers have to be generated
+// with `insgocc builtin` and the packages have to be imported by the test:
+//
+//	import _ "github.com/insolar/insolar/application/contract/nodedomain"
+//
+// Prototypes should be deployed with PrototypeReference of generated proxies,
+// then contracts can call each other through the proxies.
+package contracttest
+
+import (
+	"context"
+	"crypto"
+	"sync/atomic"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/contractrequester"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/delegationtoken"
+	"github.com/insolar/insolar/insolar/message"
+	"github.com/insolar/insolar/insolar/reply"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/ledger/pulsemanager"
+	"github.com/insolar/insolar/ledger/recentstorage"
+	"github.com/insolar/insolar/ledger/storage/drop"
+	"github.com/insolar/insolar/logicrunner"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/builtin"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+	"github.com/insolar/insolar/messagebus"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/insolar/insolar/testutils/network"
+	"github.com/insolar/insolar/testutils/nodekeeper"
+	"github.com/insolar/insolar/testutils/terminationhandler"
+	"github.com/insolar/insolar/testutils/testmessagebus"
+)
+
+// Harness executes contracts in-process
+type Harness struct {
+	// Caller is a reference of an object requests of the harness are sent from
+	Caller insolar.Reference
+
+	LogicRunner     *logicrunner.LogicRunner
+	ArtifactManager artifacts.Client
+	PulseManager    insolar.PulseManager
+
+	t         *testing.T
+	ctx       context.Context
+	bus       *testmessagebus.TestMessageBus
+	requester *contractrequester.ContractRequester
+	nonce     uint64
+	cleaner   func()
+}
+
+// New starts logicrunner with builtin executor and temporary ledger.
+// Harness should be stopped with Stop at the end of the test.
+func New(t *testing.T) *Harness {
+	ctx := inslogger.TestContext(t)
+
+	lr, err := logicrunner.NewLogicRunner(&configuration.LogicRunner{
+		BuiltIn: &configuration.BuiltIn{},
+	})
+	require.NoError(t, err)
+
+	cs := testutils.NewCryptographyServiceMock(t)
+	cs.SignFunc = func(p []byte) (*insolar.Signature, error) {
+		signature := insolar.SignatureFromBytes(nil)
+		return &signature, nil
+	}
+	cs.GetPublicKeyFunc = func() (crypto.PublicKey, error) {
+		return nil, nil
+	}
+	cs.VerifyFunc = func(crypto.PublicKey, insolar.Signature, []byte) bool {
+		return true
+	}
+
+	nk := nodekeeper.GetTestNodekeeper(cs)
+	nw := network.GetTestNetwork()
+	mb := testmessagebus.NewTestMessageBus(t)
+
+	// FIXME: TmpLedger is deprecated. Use mocks instead.
+	l, db, cleaner := artifacts.TmpLedger(
+		t, "", insolar.StaticRoleLightMaterial,
+		insolar.Components{
+			LogicRunner: lr,
+			NodeNetwork: nk,
+			MessageBus:  mb,
+			Network:     nw,
+		},
+		false,
+	)
+
+	indexMock := recentstorage.NewRecentIndexStorageMock(t)
+	indexMock.AddObjectMock.Return()
+
+	providerMock := recentstorage.NewProviderMock(t)
+	providerMock.GetIndexStorageMock.Return(indexMock)
+	providerMock.DecreaseIndexesTTLMock.Return(nil)
+
+	cr, err := contractrequester.New()
+	require.NoError(t, err)
+
+	pulseStorage := l.PulseManager.(*pulsemanager.PulseManager).PulseStorage
+
+	cm := &component.Manager{}
+	cm.Register(platformpolicy.NewPlatformCryptographyScheme())
+	cm.Register(l.GetArtifactManager(), l.GetPulseManager(), l.GetJetCoordinator())
+	cm.Inject(
+		db, pulseStorage, nk, providerMock, l, lr, nw, mb, cr, cs,
+		delegationtoken.NewDelegationTokenFactory(),
+		messagebus.NewParcelFactory(),
+		terminationhandler.NewTestHandler(),
+	)
+	require.NoError(t, cm.Init(ctx))
+	require.NoError(t, cm.Start(ctx))
+
+	h := &Harness{
+		Caller:          testutils.RandomRef(),
+		LogicRunner:     lr,
+		ArtifactManager: l.GetArtifactManager(),
+		PulseManager:    l.GetPulseManager(),
+
+		t:         t,
+		ctx:       ctx,
+		bus:       mb,
+		requester: cr,
+		cleaner: func() {
+			lr.Stop(ctx) // nolint: errcheck
+			cleaner()
+		},
+	}
+
+	h.NextPulse()
+
+	return h
+}
+
+// Stop stops logicrunner and removes temporary ledger
+func (h *Harness) Stop() {
+	h.cleaner()
+}
+
+// NextPulse switches ledger and logicrunner to the next pulse
+func (h *Harness) NextPulse() {
+	pulseStorage := h.PulseManager.(*pulsemanager.PulseManager).PulseStorage
+	currentPulse, err := pulseStorage.Current(h.ctx)
+	require.NoError(h.t, err)
+
+	newPulseNumber := currentPulse.PulseNumber + 1
+	err = h.PulseManager.Set(
+		h.ctx,
+		insolar.Pulse{PulseNumber: newPulseNumber, Entropy: insolar.Entropy{}},
+		true,
+	)
+	require.NoError(h.t, err)
+
+	rootJetID := *insolar.NewJetID(0, nil)
+	_, err = h.bus.Send(
+		h.ctx,
+		&message.HotData{
+			Jet:         *insolar.NewReference(insolar.DomainID, insolar.ID(rootJetID)),
+			Drop:        drop.Drop{Pulse: currentPulse.PulseNumber, JetID: rootJetID},
+			PulseNumber: newPulseNumber,
+		}, nil,
+	)
+	require.NoError(h.t, err)
+}
+
+// Deploy publishes code of builtin contract registered with provided name and
+// activates prototype with provided reference pointing to it. Prototype reference
+// should be PrototypeReference of the contract's proxy.
+func (h *Harness) Deploy(name string, prototype insolar.Reference) error {
+	if !builtin.IsRegistered(name) {
+		return errors.Errorf("[ Deploy ] builtin contract %q is not registered", name)
+	}
+
+	am := h.ArtifactManager
+	domain := *am.GenesisRef()
+
+	codeReq, err := am.RegisterRequest(
+		h.ctx, domain, &message.Parcel{Msg: &message.GenesisRequest{Name: name + "_code"}},
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Deploy ] Can't register request")
+	}
+	codeID, err := am.DeployCode(
+		h.ctx, domain, *insolar.NewReference(*domain.Record(), *codeReq),
+		[]byte(name), insolar.MachineTypeBuiltin, nil,
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Deploy ] Can't deploy code")
+	}
+
+	_, err = am.ActivatePrototype(
+		h.ctx, domain, prototype, domain, *insolar.NewReference(*domain.Record(), *codeID), nil,
+	)
+	if err != nil {
+		return errors.Wrap(err, "[ Deploy ] Can't activate prototype")
+	}
+	return nil
+}
+
+// Construct calls constructor of prototype and saves created object as a child of parent
+func (h *Harness) Construct(
+	parent insolar.Reference, prototype insolar.Reference, constructor string, args ...interface{},
+) (
+	*insolar.Reference, error,
+) {
+	argsSerialized, err := insolar.MarshalArgs(args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Construct ]")
+	}
+
+	ref, err := h.requester.CallConstructor(
+		h.ctx, h.baseMessage(), false, &prototype, &parent, constructor, argsSerialized, int(message.Child),
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Construct ]")
+	}
+	return ref, nil
+}
+
+// CallMethod calls method of object and waits for its results. Error returned by the
+// contract itself is reported by Result.Decode.
+func (h *Harness) CallMethod(object insolar.Reference, method string, args ...interface{}) (Result, error) {
+	argsSerialized, err := insolar.MarshalArgs(args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ CallMethod ]")
+	}
+
+	rep, err := h.requester.CallMethod(h.ctx, h.baseMessage(), false, &object, method, argsSerialized, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ CallMethod ]")
+	}
+	res, ok := rep.(*reply.CallMethod)
+	if !ok {
+		return nil, errors.Errorf("[ CallMethod ] unexpected reply %T", rep)
+	}
+	return Result(res.Result), nil
+}
+
+// State fetches the latest memory of object from ledger and unmarshals it into contract
+func (h *Harness) State(object insolar.Reference, contract interface{}) error {
+	desc, err := h.ArtifactManager.GetObject(h.ctx, object, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "[ State ] Can't get object")
+	}
+	err = insolar.Deserialize(desc.Memory(), contract)
+	if err != nil {
+		return errors.Wrap(err, "[ State ] Can't unmarshal object memory")
+	}
+	return nil
+}
+
+func (h *Harness) baseMessage() *message.BaseLogicMessage {
+	return &message.BaseLogicMessage{
+		Caller: h.Caller,
+		Nonce:  atomic.AddUint64(&h.nonce, 1),
+	}
+}
+
+// Result holds serialized results of a method call
+type Result []byte
+
+// Decode unmarshals results of a method into provided pointers, error the method
+// returned as its last result is returned
+func (r Result) Decode(results ...interface{}) error {
+	var contractErr *foundation.Error
+	_, err := insolar.UnMarshalResponse(r, append(results, &contractErr))
+	if err != nil {
+		return errors.Wrap(err, "[ Decode ]")
+	}
+	if contractErr != nil {
+		return contractErr
+	}
+	return nil
+}
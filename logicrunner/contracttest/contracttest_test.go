@@ -0,0 +1,89 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package contracttest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/application/contract/nodedomain"
+	"github.com/insolar/insolar/application/contract/noderecord"
+	nodedomainproxy "github.com/insolar/insolar/application/proxy/nodedomain"
+	noderecordproxy "github.com/insolar/insolar/application/proxy/noderecord"
+	rootdomainproxy "github.com/insolar/insolar/application/proxy/rootdomain"
+	"github.com/insolar/insolar/insolar"
+
+	_ "github.com/insolar/insolar/application/contract/rootdomain"
+)
+
+func TestHarness(t *testing.T) {
+	h := New(t)
+	defer h.Stop()
+
+	require.NoError(t, h.Deploy("rootdomain", *rootdomainproxy.PrototypeReference))
+	require.NoError(t, h.Deploy("nodedomain", *nodedomainproxy.PrototypeReference))
+	require.NoError(t, h.Deploy("noderecord", *noderecordproxy.PrototypeReference))
+
+	err := h.Deploy("unknown", insolar.Reference{})
+	require.EqualError(t, err, `[ Deploy ] builtin contract "unknown" is not registered`)
+
+	rootDomain, err := h.Construct(*h.ArtifactManager.GenesisRef(), *rootdomainproxy.PrototypeReference, "NewRootDomain")
+	require.NoError(t, err)
+	nodeDomain, err := h.Construct(*rootDomain, *nodedomainproxy.PrototypeReference, "NewNodeDomain")
+	require.NoError(t, err)
+
+	// node domain asks root domain for root member through proxy
+	res, err := h.CallMethod(*nodeDomain, "RegisterNode", "pk", "virtual")
+	require.NoError(t, err)
+	var nodeRef string
+	err = res.Decode(&nodeRef)
+	require.EqualError(t, err, "[ RegisterNode ] Only Root member can register node")
+
+	// root member isn't set in the new root domain
+	h.Caller = insolar.Reference{}
+	res, err = h.CallMethod(*nodeDomain, "RegisterNode", "pk", "virtual")
+	require.NoError(t, err)
+	require.NoError(t, res.Decode(&nodeRef))
+
+	node, err := insolar.NewReferenceFromBase58(nodeRef)
+	require.NoError(t, err)
+
+	var record noderecord.NodeRecord
+	require.NoError(t, h.State(*node, &record))
+	require.Equal(t, "pk", record.Record.PublicKey)
+	require.Equal(t, insolar.StaticRoleVirtual, record.Record.Role)
+
+	h.NextPulse()
+
+	res, err = h.CallMethod(*node, "GetPublicKey")
+	require.NoError(t, err)
+	var pk string
+	require.NoError(t, res.Decode(&pk))
+	require.Equal(t, "pk", pk)
+
+	res, err = h.CallMethod(*nodeDomain, "RemoveNode", *node)
+	require.NoError(t, err)
+	require.NoError(t, res.Decode())
+
+	var domain nodedomain.NodeDomain
+	require.NoError(t, h.State(*nodeDomain, &domain))
+	require.Empty(t, domain.NodeIndexPK)
+
+	_, err = h.CallMethod(*node, "GetPublicKey")
+	require.Error(t, err)
+}
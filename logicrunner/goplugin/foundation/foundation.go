@@ -18,8 +18,11 @@
 package foundation
 
 import (
+	"encoding/json"
+
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
+	"github.com/pkg/errors"
 	"github.com/tylerb/gls"
 )
 
@@ -98,6 +101,24 @@ func (bc *BaseContract) SelfDestruct() error {
 	return proxyctx.Current.DeactivateObject(bc.GetReference())
 }
 
+// EmitEvent emits named event from the current call, events are registered on ledger along with the call result.
+// Payload is stored as JSON, so off-chain systems can index it.
+//    foundation.EmitEvent("Transferred", map[string]interface{}{"amount": amount})
+func EmitEvent(name string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return errors.Wrap(err, "[ EmitEvent ] Can't marshal payload")
+	}
+	return proxyctx.Current.EmitEvent(name, data)
+}
+
+// UpgradePrototype deploys code as the new version of the prototype and returns reference to the code.
+// Objects of the prototype are migrated on their next call, see Migrate function of contracts.
+// ABI of the new code is stored alongside it. Only domains can upgrade prototypes.
+func UpgradePrototype(prototype insolar.Reference, code []byte, machineType insolar.MachineType, abi []byte) (insolar.Reference, error) {
+	return proxyctx.Current.UpgradePrototype(prototype, code, machineType, abi)
+}
+
 // Error elementary string based error struct satisfying builtin error interface
 //    foundation.Error{"some err"}
 type Error struct {
@@ -78,9 +78,9 @@ func recoverRPC(ctx context.Context, err *error) {
 	if r := recover(); r != nil {
 		if err != nil {
 			if *err == nil {
-				*err = errors.New(fmt.Sprint(r))
+				*err = errors.New(rpctypes.PanicErrorPrefix + fmt.Sprint(r))
 			} else {
-				*err = errors.New(fmt.Sprint(*err, r))
+				*err = errors.New(rpctypes.PanicErrorPrefix + fmt.Sprint(*err, r))
 			}
 		}
 		inslogger.FromContext(ctx).Error("panic: ", r, string(debug.Stack()))
@@ -104,6 +104,14 @@ func (t *RPC) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCa
 		return errors.Wrapf(err, "Couldn't get plugin by code reference %s", args.Code.String())
 	}
 
+	m := newMeter(args.Context.Limits)
+	defer func() {
+		if limitErr := recoverLimit(recover()); limitErr != nil {
+			*reply = rpctypes.DownCallMethodResp{LimitExceeded: limitErr, Abandoned: m.abandoned()}
+			reply.Usage, reply.CPUTime = m.report()
+		}
+	}()
+
 	if args.Context.Caller.IsEmpty() {
 		attr, err := p.Lookup("INSATTR_" + args.Method + "_API")
 		if err != nil {
@@ -121,6 +129,20 @@ func (t *RPC) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCa
 		}
 	}
 
+	if args.Context.Mode == insolar.QueryMode {
+		attr, err := p.Lookup("INSATTR_" + args.Method + "_Immutable")
+		if err != nil {
+			return errors.Wrapf(
+				err, "Calling non immutable method %s as query (code ref: %s)",
+				args.Method, args.Code.String(),
+			)
+		}
+		immutable, ok := attr.(*bool)
+		if !ok || !*immutable {
+			return errors.Errorf("INSATTR_%s_Immutable attribute is not true", args.Method)
+		}
+	}
+
 	symbol, err := p.Lookup("INSMETHOD_" + args.Method)
 	if err != nil {
 		return errors.Wrapf(
@@ -134,10 +156,19 @@ func (t *RPC) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCa
 		return errors.New("Wrapper with wrong signature")
 	}
 
-	state, result, err := wrapper(args.Data, args.Arguments) // may be entire args???
+	var state, result []byte
+	var callErr error
+	m.run(func() {
+		state, result, callErr = wrapper(args.Data, args.Arguments) // may be entire args???
+	})
 
-	if err != nil {
-		return errors.Wrapf(err, "Method call returned error")
+	if callErr != nil {
+		return errors.Wrapf(callErr, "Method call returned error")
+	}
+	reply.LimitExceeded = m.finish(state)
+	reply.Usage, reply.CPUTime = m.report()
+	if reply.LimitExceeded != nil {
+		return nil
 	}
 	reply.Data = state
 	reply.Ret = result
@@ -163,6 +194,14 @@ func (t *RPC) CallConstructor(args rpctypes.DownCallConstructorReq, reply *rpcty
 		return err
 	}
 
+	m := newMeter(args.Context.Limits)
+	defer func() {
+		if limitErr := recoverLimit(recover()); limitErr != nil {
+			*reply = rpctypes.DownCallConstructorResp{LimitExceeded: limitErr, Abandoned: m.abandoned()}
+			reply.Usage, reply.CPUTime = m.report()
+		}
+	}()
+
 	symbol, err := p.Lookup("INSCONSTRUCTOR_" + args.Name)
 	if err != nil {
 		return errors.Wrapf(err, "Can't find wrapper for %s", args.Name)
@@ -173,16 +212,45 @@ func (t *RPC) CallConstructor(args rpctypes.DownCallConstructorReq, reply *rpcty
 		return errors.New("Wrapper with wrong signature")
 	}
 
-	resValues, err := f(args.Arguments)
-	if err != nil {
-		return errors.Wrapf(err, "Can't call constructor %s", args.Name)
+	var resValues []byte
+	var callErr error
+	m.run(func() {
+		resValues, callErr = f(args.Arguments)
+	})
+	if callErr != nil {
+		return errors.Wrapf(callErr, "Can't call constructor %s", args.Name)
 	}
 
+	reply.LimitExceeded = m.finish(resValues)
+	reply.Usage, reply.CPUTime = m.report()
+	if reply.LimitExceeded != nil {
+		return nil
+	}
 	reply.Ret = resValues
 
 	return nil
 }
 
+// Ping is an RPC goplugin uses to check that the runner is alive
+func (t *RPC) Ping(args rpctypes.DownPingReq, reply *rpctypes.DownPingResp) error {
+	return nil
+}
+
+// WarmUp is an RPC that stores code and loads it as plugin in advance,
+// so the first call of the code isn't delayed by fetching and loading
+func (t *RPC) WarmUp(args rpctypes.DownWarmUpReq, reply *rpctypes.DownWarmUpResp) (err error) {
+	ctx := context.Background()
+	inslogger.FromContext(ctx).Debugf("Warming up code %q", args.Code)
+	defer recoverRPC(ctx, &err)
+
+	err = t.GI.StoreCode(args.Code, args.Binary)
+	if err != nil {
+		return err
+	}
+	_, err = t.GI.Plugin(ctx, args.Code)
+	return err
+}
+
 // Upstream returns RPC client connected to upstream server (goplugin)
 func (gi *GoInsider) Upstream() (*rpc.Client, error) {
 	gi.upstreamMutex.Lock()
@@ -242,6 +310,32 @@ func (gi *GoInsider) ObtainCode(ctx context.Context, ref insolar.Reference) (str
 	return path, nil
 }
 
+// StoreCode writes code of the plugin into the storage unless it's already there
+func (gi *GoInsider) StoreCode(ref insolar.Reference, code []byte) error {
+	rec := gi.getPluginRec(ref)
+
+	rec.Lock()
+	defer rec.Unlock()
+
+	if rec.plugin != nil {
+		return nil
+	}
+
+	path := filepath.Join(gi.dir, ref.String())
+	_, err := os.Stat(path)
+	if err == nil {
+		return nil
+	} else if !os.IsNotExist(err) {
+		return errors.Wrap(err, "[ StoreCode ] file !notexists()")
+	}
+
+	err = ioutil.WriteFile(path, code, 0666)
+	if err != nil {
+		return errors.Wrap(err, "[ StoreCode ] on writing file down")
+	}
+	return nil
+}
+
 // Plugin loads Go plugin by reference and returns `*plugin.Plugin`
 // ready to lookup symbols
 func (gi *GoInsider) Plugin(ctx context.Context, ref insolar.Reference) (*plugin.Plugin, error) {
@@ -299,6 +393,8 @@ func MakeUpBaseReq() rpctypes.UpBaseReq {
 
 // RouteCall ...
 func (gi *GoInsider) RouteCall(ref insolar.Reference, wait bool, method string, args []byte, proxyPrototype insolar.Reference) ([]byte, error) {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return nil, err
@@ -327,6 +423,8 @@ func (gi *GoInsider) RouteCall(ref insolar.Reference, wait bool, method string,
 
 // SaveAsChild ...
 func (gi *GoInsider) SaveAsChild(parentRef, classRef insolar.Reference, constructorName string, argsSerialized []byte) (insolar.Reference, error) {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return insolar.Reference{}, err
@@ -357,6 +455,8 @@ func (gi *GoInsider) SaveAsChild(parentRef, classRef insolar.Reference, construc
 // at first time call it without iteratorID
 // iteratorID is a cache key on service side, use it in all calls, except first
 func (gi *GoInsider) GetObjChildrenIterator(obj insolar.Reference, prototype insolar.Reference, iteratorID string) (*proxyctx.ChildrenTypedIterator, error) {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return &proxyctx.ChildrenTypedIterator{}, err
@@ -390,6 +490,8 @@ func (gi *GoInsider) GetObjChildrenIterator(obj insolar.Reference, prototype ins
 
 // SaveAsDelegate ...
 func (gi *GoInsider) SaveAsDelegate(intoRef, classRef insolar.Reference, constructorName string, argsSerialized []byte) (insolar.Reference, error) {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return insolar.Reference{}, err
@@ -418,6 +520,8 @@ func (gi *GoInsider) SaveAsDelegate(intoRef, classRef insolar.Reference, constru
 
 // GetDelegate ...
 func (gi *GoInsider) GetDelegate(object, ofType insolar.Reference) (insolar.Reference, error) {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return insolar.Reference{}, err
@@ -444,6 +548,8 @@ func (gi *GoInsider) GetDelegate(object, ofType insolar.Reference) (insolar.Refe
 
 // DeactivateObject ...
 func (gi *GoInsider) DeactivateObject(object insolar.Reference) error {
+	defer meterUpcall()()
+
 	client, err := gi.Upstream()
 	if err != nil {
 		return err
@@ -466,6 +572,68 @@ func (gi *GoInsider) DeactivateObject(object insolar.Reference) error {
 	return nil
 }
 
+// EmitEvent ...
+func (gi *GoInsider) EmitEvent(name string, payload []byte) error {
+	defer meterUpcall()()
+
+	client, err := gi.Upstream()
+	if err != nil {
+		return err
+	}
+
+	req := rpctypes.UpEmitEventReq{
+		UpBaseReq: MakeUpBaseReq(),
+		Name:      name,
+		Payload:   payload,
+	}
+
+	res := rpctypes.UpEmitEventResp{}
+	err = client.Call("RPC.EmitEvent", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return errors.Wrap(err, "[ EmitEvent ] on calling main API")
+	}
+
+	return nil
+}
+
+// UpgradePrototype ...
+func (gi *GoInsider) UpgradePrototype(
+	prototype insolar.Reference, code []byte, machineType insolar.MachineType, abi []byte,
+) (
+	insolar.Reference, error,
+) {
+	defer meterUpcall()()
+
+	client, err := gi.Upstream()
+	if err != nil {
+		return insolar.Reference{}, err
+	}
+
+	req := rpctypes.UpUpgradePrototypeReq{
+		UpBaseReq:   MakeUpBaseReq(),
+		Prototype:   prototype,
+		Code:        code,
+		MachineType: machineType,
+		ABI:         abi,
+	}
+
+	res := rpctypes.UpUpgradePrototypeResp{}
+	err = client.Call("RPC.UpgradePrototype", req, &res)
+	if err != nil {
+		if err == rpc.ErrShutdown {
+			log.Error("Insgorund can't connect to Insolard")
+			os.Exit(0)
+		}
+		return insolar.Reference{}, errors.Wrap(err, "[ UpgradePrototype ] on calling main API")
+	}
+
+	return res.Code, nil
+}
+
 // Serialize - CBOR serializer wrapper: `what` -> `to`
 func (gi *GoInsider) Serialize(what interface{}, to *[]byte) error {
 	ch := new(codec.CborHandle)
@@ -0,0 +1,216 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ginsider
+
+import (
+	"sync"
+	"time"
+
+	"github.com/tylerb/gls"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+// meter counts resources consumed by a contract call and checks them against limits of the call.
+// Upcalls and state size are counted deterministically. CPU time is wall-clock time, so it isn't
+// a part of the usage, the limit is enforced asynchronously by watchdog and the time goes to metrics.
+type meter struct {
+	limits insolar.ResourceLimits
+
+	lock     sync.Mutex
+	usage    insolar.ResourceUsage
+	cpuTime  time.Duration // time spent in contract code excluding upcalls
+	running  bool          // contract code is running, not waiting for an upcall
+	resumed  time.Time     // when contract code started or resumed after an upcall
+	watchdog *time.Timer
+	aborted  chan struct{}
+	exited   chan struct{}               // closed when goroutine of contract code exits
+	err      *insolar.ResourceLimitError // reason of the abort
+}
+
+func newMeter(limits insolar.ResourceLimits) *meter {
+	return &meter{limits: limits, aborted: make(chan struct{}), exited: make(chan struct{})}
+}
+
+// run executes contract code in a separate goroutine, so the call can be aborted by watchdog
+// when the code exceeds CPU time limit. Panics of the code are passed to the calling goroutine.
+// Go can't stop the goroutine of aborted code, it's stopped at its next upcall by meterUpcall.
+// Code that makes no upcalls keeps running, see abandoned.
+func (m *meter) run(code func()) {
+	done := make(chan interface{}, 1)
+	callCtx := gls.Get("callCtx")
+	go func() {
+		defer close(m.exited)
+		defer gls.Cleanup()
+		defer func() { done <- recover() }()
+		gls.Set("callCtx", callCtx)
+		gls.Set("meter", m)
+
+		defer m.pause()
+		m.resume()
+		code()
+	}()
+
+	select {
+	case r := <-done:
+		if r != nil {
+			panic(r)
+		}
+	case <-m.aborted:
+		panic(m.err)
+	}
+}
+
+func (m *meter) resume() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.running = true
+	m.resumed = time.Now()
+	if m.limits.CPUTime > 0 && m.err == nil {
+		m.watchdog = time.AfterFunc(m.limits.CPUTime-m.cpuTime, m.abort)
+	}
+}
+
+func (m *meter) pause() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.stop()
+}
+
+// stop accounts time spent in contract code since it was resumed, should be called under lock
+func (m *meter) stop() {
+	if !m.running {
+		return
+	}
+	m.running = false
+	m.cpuTime += time.Since(m.resumed)
+	if m.watchdog != nil {
+		m.watchdog.Stop()
+	}
+}
+
+// abort is called by watchdog when contract code exceeds CPU time limit
+func (m *meter) abort() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.running || m.err != nil {
+		// watchdog fired concurrently with the upcall, the limit is rechecked when the code resumes
+		return
+	}
+	m.stop()
+	m.err = &insolar.ResourceLimitError{
+		Resource: insolar.ResourceCPUTime,
+		Limit:    uint64(m.limits.CPUTime),
+		Used:     uint64(m.cpuTime),
+	}
+	close(m.aborted)
+}
+
+// abandoned returns true if the call was aborted while contract code is still running,
+// the code can be stopped only by restart of the runner then.
+func (m *meter) abandoned() bool {
+	select {
+	case <-m.aborted:
+	default:
+		return false
+	}
+	select {
+	case <-m.exited:
+		return false
+	default:
+		return true
+	}
+}
+
+// upcallStarted pauses accounting of contract code time and counts the upcall
+func (m *meter) upcallStarted() *insolar.ResourceLimitError {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.stop()
+	m.usage.Upcalls++
+	return m.check()
+}
+
+// upcallFinished resumes accounting of contract code time
+func (m *meter) upcallFinished() {
+	m.resume()
+}
+
+// finish accounts size of the written state, returns error if the call was aborted or exceeded the limits
+func (m *meter) finish(state []byte) *insolar.ResourceLimitError {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.usage.StateBytes += uint64(len(state))
+	return m.check()
+}
+
+// report returns resources consumed by the call and wall-clock time spent in contract code
+func (m *meter) report() (insolar.ResourceUsage, time.Duration) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return m.usage, m.cpuTime
+}
+
+func (m *meter) check() *insolar.ResourceLimitError {
+	switch {
+	case m.limits.Upcalls > 0 && m.usage.Upcalls > m.limits.Upcalls:
+		return &insolar.ResourceLimitError{
+			Resource: insolar.ResourceUpcalls,
+			Limit:    m.limits.Upcalls,
+			Used:     m.usage.Upcalls,
+		}
+	case m.limits.StateBytes > 0 && m.usage.StateBytes > m.limits.StateBytes:
+		return &insolar.ResourceLimitError{
+			Resource: insolar.ResourceStateBytes,
+			Limit:    m.limits.StateBytes,
+			Used:     m.usage.StateBytes,
+		}
+	}
+	return nil
+}
+
+// meterUpcall accounts upcall of the current contract call, returns function that should be called
+// when the upcall is finished. The call is aborted with panic if one of the limits is exceeded,
+// the panic is recovered in CallMethod and CallConstructor RPCs.
+func meterUpcall() func() {
+	m, ok := gls.Get("meter").(*meter)
+	if !ok {
+		return func() {}
+	}
+	if err := m.upcallStarted(); err != nil {
+		panic(err)
+	}
+	return m.upcallFinished
+}
+
+// recoverLimit converts panic of exceeded limit to the error, other panics are passed through
+func recoverLimit(r interface{}) *insolar.ResourceLimitError {
+	if r == nil {
+		return nil
+	}
+	err, ok := r.(*insolar.ResourceLimitError)
+	if !ok {
+		panic(r)
+	}
+	return err
+}
@@ -0,0 +1,179 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package ginsider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+func TestMeter_Upcalls(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{Upcalls: 2})
+
+	require.Nil(t, m.upcallStarted())
+	m.upcallFinished()
+	require.Nil(t, m.upcallStarted())
+	m.upcallFinished()
+
+	err := m.upcallStarted()
+	require.NotNil(t, err)
+	require.Equal(t, insolar.ResourceUpcalls, err.Resource)
+	require.Equal(t, uint64(2), err.Limit)
+	require.Equal(t, uint64(3), err.Used)
+}
+
+func TestMeter_StateBytes(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{StateBytes: 4})
+	require.Nil(t, m.finish([]byte{1, 2, 3, 4}))
+	require.Equal(t, uint64(4), m.usage.StateBytes)
+
+	m = newMeter(insolar.ResourceLimits{StateBytes: 4})
+	err := m.finish([]byte{1, 2, 3, 4, 5})
+	require.NotNil(t, err)
+	require.Equal(t, insolar.ResourceStateBytes, err.Resource)
+	require.Equal(t, uint64(5), err.Used)
+}
+
+func TestMeter_CPUTime(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: 10 * time.Millisecond})
+	stop := make(chan struct{})
+	defer close(stop)
+
+	var limitErr *insolar.ResourceLimitError
+	func() {
+		defer func() { limitErr = recoverLimit(recover()) }()
+		m.run(func() { <-stop })
+	}()
+	require.NotNil(t, limitErr)
+	require.Equal(t, insolar.ResourceCPUTime, limitErr.Resource)
+	require.Equal(t, limitErr, m.finish(nil))
+
+	usage, cpuTime := m.report()
+	require.True(t, cpuTime >= 10*time.Millisecond)
+	require.Equal(t, insolar.ResourceUsage{}, usage)
+}
+
+func TestMeter_CPUTimeExcludesUpcalls(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: 20 * time.Millisecond})
+	m.run(func() {
+		for i := 0; i < 3; i++ {
+			finished := meterUpcall()
+			time.Sleep(20 * time.Millisecond)
+			finished()
+		}
+	})
+	require.Nil(t, m.finish(nil))
+
+	usage, cpuTime := m.report()
+	require.True(t, cpuTime < 20*time.Millisecond)
+	require.Equal(t, uint64(3), usage.Upcalls)
+}
+
+func TestMeter_AbortedCodeStopsAtUpcall(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: time.Millisecond})
+	resume := make(chan struct{})
+	stopped := make(chan interface{})
+
+	func() {
+		defer func() { recoverLimit(recover()) }()
+		m.run(func() {
+			defer func() { stopped <- recover() }()
+			<-resume
+			meterUpcall()
+		})
+	}()
+	close(resume)
+
+	r := <-stopped
+	require.NotNil(t, recoverLimit(r))
+	usage, _ := m.report()
+	require.Equal(t, uint64(0), usage.Upcalls)
+}
+
+func TestMeter_Abandoned(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: time.Millisecond})
+	stop := make(chan struct{})
+	require.False(t, m.abandoned())
+
+	func() {
+		defer func() { recoverLimit(recover()) }()
+		m.run(func() {
+			// busy loop without upcalls isn't stopped by the abort
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+			}
+		})
+	}()
+	require.True(t, m.abandoned())
+
+	close(stop)
+	<-m.exited
+	require.False(t, m.abandoned())
+}
+
+func TestMeter_FinishedCodeIsNotAbandoned(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: time.Second})
+	m.run(func() {})
+	<-m.exited
+	require.False(t, m.abandoned())
+}
+
+func TestMeter_RunPassesPanic(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{CPUTime: time.Second})
+	require.PanicsWithValue(t, "contract panic", func() {
+		m.run(func() { panic("contract panic") })
+	})
+}
+
+func TestMeter_NoLimits(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{})
+	for i := 0; i < 100; i++ {
+		require.Nil(t, m.upcallStarted())
+		m.upcallFinished()
+	}
+	require.Nil(t, m.finish(make([]byte, 1000)))
+	require.Equal(t, uint64(100), m.usage.Upcalls)
+}
+
+func TestRecoverLimit_LimitExceeded(t *testing.T) {
+	m := newMeter(insolar.ResourceLimits{Upcalls: 1})
+	require.Nil(t, m.upcallStarted())
+	m.upcallFinished()
+
+	var limitErr *insolar.ResourceLimitError
+	func() {
+		defer func() { limitErr = recoverLimit(recover()) }()
+		if err := m.upcallStarted(); err != nil {
+			panic(err)
+		}
+	}()
+	require.NotNil(t, limitErr)
+	require.Equal(t, insolar.ResourceUpcalls, limitErr.Resource)
+}
+
+func TestRecoverLimit(t *testing.T) {
+	require.Nil(t, recoverLimit(nil))
+	require.Panics(t, func() { recoverLimit("other panic") })
+}
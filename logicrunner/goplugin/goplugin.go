@@ -20,7 +20,6 @@ package goplugin
 import (
 	"context"
 	"net/rpc"
-	"sync"
 	"time"
 
 	"github.com/insolar/insolar/logicrunner/artifacts"
@@ -55,16 +54,26 @@ type GoPlugin struct {
 	MessageBus      insolar.MessageBus
 	ArtifactManager artifacts.Client
 
-	clientMutex sync.Mutex
-	client      *rpc.Client
+	pool *runnerPool
 }
 
 // NewGoPlugin returns a new started GoPlugin
 func NewGoPlugin(conf *configuration.LogicRunner, eb insolar.MessageBus, am artifacts.Client) (*GoPlugin, error) {
+	pool, err := newRunnerPool(conf, am)
+	if err != nil {
+		return nil, err
+	}
+	err = pool.start(context.Background())
+	if err != nil {
+		pool.close()
+		return nil, err
+	}
+
 	gp := GoPlugin{
 		Cfg:             conf,
 		MessageBus:      eb,
 		ArtifactManager: am,
+		pool:            pool,
 	}
 
 	return &gp, nil
@@ -72,78 +81,13 @@ func NewGoPlugin(conf *configuration.LogicRunner, eb insolar.MessageBus, am arti
 
 // Stop stops runner(s) and RPC service
 func (gp *GoPlugin) Stop() error {
+	gp.pool.close()
 	return nil
 }
 
-const timeout = time.Minute * 10
-
-// Downstream returns a connection to `ginsider`
+// Downstream returns a connection to `ginsider`, the least loaded one of the pool
 func (gp *GoPlugin) Downstream(ctx context.Context) (*rpc.Client, error) {
-	gp.clientMutex.Lock()
-	defer gp.clientMutex.Unlock()
-
-	if gp.client != nil {
-		return gp.client, nil
-	}
-
-	client, err := rpc.Dial(gp.Cfg.GoPlugin.RunnerProtocol, gp.Cfg.GoPlugin.RunnerListen)
-	if err != nil {
-		return nil, errors.Wrapf(
-			err, "couldn't dial '%s' over %s",
-			gp.Cfg.GoPlugin.RunnerListen, gp.Cfg.GoPlugin.RunnerProtocol,
-		)
-	}
-
-	gp.client = client
-	return gp.client, nil
-}
-
-func (gp *GoPlugin) CloseDownstream() {
-	gp.clientMutex.Lock()
-	defer gp.clientMutex.Unlock()
-
-	gp.client.Close()
-	gp.client = nil
-}
-
-func (gp *GoPlugin) callClientWithReconnect(ctx context.Context, method string, req interface{}, res interface{}) error {
-	inslogger.FromContext(ctx).Debug("GoPlugin.callClientWithReconnect starts")
-	var err error
-	var client *rpc.Client
-
-	for {
-		inslogger.FromContext(ctx).Info("Connect to insgorund")
-		client, err = gp.Downstream(ctx)
-		if err == nil {
-			call := <-client.Go(method, req, res, nil).Done
-			err = call.Error
-
-			if err != rpc.ErrShutdown {
-				break
-			} else {
-				inslogger.FromContext(ctx).Debug("Connection to insgorund is closed, need to reconnect")
-				gp.CloseDownstream()
-				inslogger.FromContext(ctx).Debugf("Reconnecting...")
-			}
-		} else {
-			inslogger.FromContext(ctx).Debugf("Can't connect to to insgorund, err: %s", err.Error())
-			inslogger.FromContext(ctx).Debugf("Reconnecting...")
-		}
-	}
-
-	return err
-}
-
-type CallMethodResult struct {
-	Response rpctypes.DownCallMethodResp
-	Error    error
-}
-
-func (gp *GoPlugin) CallMethodRPC(ctx context.Context, req rpctypes.DownCallMethodReq, res rpctypes.DownCallMethodResp, resultChan chan CallMethodResult) {
-	inslogger.FromContext(ctx).Debug("GoPlugin.CallMethodRPC starts ...")
-	method := "RPC.CallMethod"
-	callClientError := gp.callClientWithReconnect(ctx, method, req, &res)
-	resultChan <- CallMethodResult{Response: res, Error: callClientError}
+	return gp.pool.connect(ctx, gp.pool.pick(insolar.Reference{}))
 }
 
 // CallMethod runs a method on an object in controlled environment
@@ -173,29 +117,16 @@ func (gp *GoPlugin) CallMethod(
 		Arguments: args,
 	}
 
-	resultChan := make(chan CallMethodResult)
-	go gp.CallMethodRPC(ctx, req, res, resultChan)
-
-	select {
-	case callResult := <-resultChan:
-		if callResult.Error != nil {
-			return nil, nil, errors.Wrap(callResult.Error, "problem with API call")
-		}
-		return callResult.Response.Data, callResult.Response.Ret, nil
-	case <-time.After(timeout):
-		return nil, nil, errors.New("logicrunner execution timeout")
+	err := gp.pool.call(ctx, code, "RPC.CallMethod", req, &res)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "problem with API call")
 	}
-}
-
-type CallConstructorResult struct {
-	Response rpctypes.DownCallConstructorResp
-	Error    error
-}
-
-func (gp *GoPlugin) CallConstructorRPC(ctx context.Context, req rpctypes.DownCallConstructorReq, res rpctypes.DownCallConstructorResp, resultChan chan CallConstructorResult) {
-	method := "RPC.CallConstructor"
-	callClientError := gp.callClientWithReconnect(ctx, method, req, &res)
-	resultChan <- CallConstructorResult{Response: res, Error: callClientError}
+	callContext.Usage = res.Usage
+	recordUsage(ctx, callContext, method, res.Usage, res.CPUTime, res.LimitExceeded)
+	if res.LimitExceeded != nil {
+		return nil, nil, res.LimitExceeded
+	}
+	return res.Data, res.Ret, nil
 }
 
 // CallConstructor runs a constructor of a contract in controlled environment
@@ -214,16 +145,14 @@ func (gp *GoPlugin) CallConstructor(
 		Arguments: args,
 	}
 
-	resultChan := make(chan CallConstructorResult)
-	go gp.CallConstructorRPC(ctx, req, res, resultChan)
-
-	select {
-	case callResult := <-resultChan:
-		if callResult.Error != nil {
-			return nil, errors.Wrap(callResult.Error, "problem with API call")
-		}
-		return callResult.Response.Ret, nil
-	case <-time.After(timeout):
-		return nil, errors.New("logicrunner execution timeout")
+	err := gp.pool.call(ctx, code, "RPC.CallConstructor", req, &res)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem with API call")
+	}
+	callContext.Usage = res.Usage
+	recordUsage(ctx, callContext, name, res.Usage, res.CPUTime, res.LimitExceeded)
+	if res.LimitExceeded != nil {
+		return nil, res.LimitExceeded
 	}
+	return res.Ret, nil
 }
@@ -62,6 +62,7 @@ type TestCodeDescriptor struct {
 	ARef         insolar.Reference
 	ACode        []byte
 	AMachineType insolar.MachineType
+	AABI         []byte
 }
 
 // Ref implementation for tests
@@ -79,6 +80,11 @@ func (t *TestCodeDescriptor) Code() ([]byte, error) {
 	return t.ACode, nil
 }
 
+// ABI implementation for tests
+func (t *TestCodeDescriptor) ABI() []byte {
+	return t.AABI
+}
+
 // TestObjectDescriptor implementation for tests
 type TestObjectDescriptor struct {
 	AM                *TestArtifactManager
@@ -88,6 +94,7 @@ type TestObjectDescriptor struct {
 	PrototypeRef      *insolar.Reference
 	Delegates         map[insolar.Reference]insolar.Reference
 	ChildrenContainer []insolar.Reference
+	CodeVersion       *insolar.Reference
 }
 
 func (t *TestObjectDescriptor) HasPendingRequests() bool {
@@ -109,6 +116,11 @@ func (t *TestObjectDescriptor) HeadRef() *insolar.Reference {
 	return t.ARef
 }
 
+// Version implementation for tests
+func (t *TestObjectDescriptor) Version() *insolar.Reference {
+	return t.CodeVersion
+}
+
 // StateID implementation for tests
 func (t *TestObjectDescriptor) StateID() *insolar.ID {
 	return t.State
@@ -166,6 +178,13 @@ func (t *TestArtifactManager) State() ([]byte, error) {
 	panic("implement me")
 }
 
+// GetEvents implementation for tests
+func (t *TestArtifactManager) GetEvents(
+	ctx context.Context, object insolar.Reference, name string, since insolar.PulseNumber,
+) ([]insolar.ObjectEvent, error) {
+	panic("implement me")
+}
+
 // GetChildren implementation for tests
 func (t *TestArtifactManager) GetChildren(ctx context.Context, parent insolar.Reference, pulse *insolar.PulseNumber) (artifacts.RefIterator, error) {
 	panic("implement me")
@@ -191,7 +210,11 @@ func (t *TestArtifactManager) RegisterRequest(ctx context.Context, obj insolar.R
 
 // RegisterResult saves VM method call result.
 func (t *TestArtifactManager) RegisterResult(
-	ctx context.Context, object, request insolar.Reference, payload []byte,
+	ctx context.Context,
+	object, request insolar.Reference,
+	payload []byte,
+	usage insolar.ResourceUsage,
+	events []insolar.ContractEvent,
 ) (*insolar.ID, error) {
 	panic("implement me")
 }
@@ -226,13 +249,14 @@ func (t *TestArtifactManager) DeclareType(ctx context.Context, domain insolar.Re
 }
 
 // DeployCode implementation for tests
-func (t *TestArtifactManager) DeployCode(ctx context.Context, domain insolar.Reference, request insolar.Reference, code []byte, mt insolar.MachineType) (*insolar.ID, error) {
+func (t *TestArtifactManager) DeployCode(ctx context.Context, domain insolar.Reference, request insolar.Reference, code []byte, mt insolar.MachineType, abi []byte) (*insolar.ID, error) {
 	ref := testutils.RandomRef()
 
 	t.Codes[ref] = &TestCodeDescriptor{
 		ARef:         ref,
 		ACode:        code,
 		AMachineType: insolar.MachineTypeGoPlugin,
+		AABI:         abi,
 	}
 	id := ref.Record()
 	return id, nil
@@ -273,6 +297,7 @@ func (t *TestArtifactManager) ActivateObject(
 	domain, request, parent, prototype insolar.Reference,
 	asDelegate bool,
 	memory []byte,
+	code *insolar.Reference,
 ) (artifacts.ObjectDescriptor, error) {
 	id := testutils.RandomID()
 
@@ -283,6 +308,7 @@ func (t *TestArtifactManager) ActivateObject(
 		State:        &id,
 		PrototypeRef: &prototype,
 		Delegates:    make(map[insolar.Reference]insolar.Reference),
+		CodeVersion:  code,
 	}
 	if asDelegate {
 		pObj, ok := t.Objects[parent]
@@ -331,6 +357,7 @@ func (t *TestArtifactManager) UpdateObject(
 	request insolar.Reference,
 	object artifacts.ObjectDescriptor,
 	memory []byte,
+	code *insolar.Reference,
 ) (artifacts.ObjectDescriptor, error) {
 	objDesc, ok := t.Objects[*object.HeadRef()]
 	if !ok {
@@ -338,6 +365,9 @@ func (t *TestArtifactManager) UpdateObject(
 	}
 
 	objDesc.Data = memory
+	if code != nil {
+		objDesc.CodeVersion = code
+	}
 
 	// TODO: return real exact "ref"
 	return objDesc, nil
@@ -392,7 +422,7 @@ func AMPublishCode(
 ) {
 	ctx := context.TODO()
 	codeID, err := am.DeployCode(
-		ctx, domain, request, code, mtype,
+		ctx, domain, request, code, mtype, nil,
 	)
 	assert.NoError(t, err, "create code on ledger")
 	codeRef = &insolar.Reference{}
@@ -493,6 +523,10 @@ func (cb *ContractsBuilder) Build(contracts map[string]string) error {
 		if err != nil {
 			return errors.Wrap(err, "[ Build ] Can't ReadFile")
 		}
+		abi, err := cb.abi(name)
+		if err != nil {
+			return errors.Wrap(err, "[ Build ] Can't call abi")
+		}
 		nonce := testutils.RandomRef()
 		codeReq, err := cb.ArtifactManager.RegisterRequest(
 			ctx, *cb.ArtifactManager.GenesisRef(), &message.Parcel{Msg: &message.CallConstructor{PrototypeRef: nonce}},
@@ -505,7 +539,7 @@ func (cb *ContractsBuilder) Build(contracts map[string]string) error {
 		codeID, err := cb.ArtifactManager.DeployCode(
 			ctx,
 			insolar.Reference{}, *insolar.NewReference(insolar.ID{}, *codeReq),
-			pluginBinary, insolar.MachineTypeGoPlugin,
+			pluginBinary, insolar.MachineTypeGoPlugin, abi,
 		)
 		codeRef := &insolar.Reference{}
 		codeRef.SetRecord(*codeID)
@@ -554,6 +588,16 @@ func (cb *ContractsBuilder) proxy(name string) error {
 	return nil
 }
 
+func (cb *ContractsBuilder) abi(name string) ([]byte, error) {
+	contractPath := filepath.Join(cb.root, "src/contract", name, "main.go")
+
+	out, err := exec.Command(cb.IccPath, "abi", "-o", "-", contractPath).Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't generate abi: "+string(out))
+	}
+	return out, nil
+}
+
 func (cb *ContractsBuilder) wrapper(name string) error {
 	contractPath := filepath.Join(cb.root, "src/contract", name, "main.go")
 	wrapperPath := filepath.Join(cb.root, "src/contract", name, "main_wrapper.go")
@@ -17,15 +17,24 @@
 package goplugin
 
 import (
+	"context"
+	"time"
+
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/insmetrics"
 )
 
 var (
 	tagMethodName = insmetrics.MustTagKey("methodName")
+	tagPrototype  = insmetrics.MustTagKey("prototype")
+	tagResource   = insmetrics.MustTagKey("resource")
+	tagRunner     = insmetrics.MustTagKey("runner")
+	tagReason     = insmetrics.MustTagKey("reason")
 )
 
 var (
@@ -34,6 +43,46 @@ var (
 		"time spent on execution contract, measured in goplugin",
 		stats.UnitMilliseconds,
 	)
+	statContractUpcalls = stats.Int64(
+		"goplugin/contract/upcalls",
+		"number of upcalls made by contract call",
+		stats.UnitDimensionless,
+	)
+	statContractStateBytes = stats.Int64(
+		"goplugin/contract/state/bytes",
+		"size of object state written by contract call",
+		stats.UnitBytes,
+	)
+	statContractCPUTime = stats.Float64(
+		"goplugin/contract/cpu/time",
+		"time spent in contract code excluding upcalls",
+		stats.UnitMilliseconds,
+	)
+	statContractLimitExceeded = stats.Int64(
+		"goplugin/contract/limit/exceeded",
+		"number of contract calls aborted because of exceeded resource limit",
+		stats.UnitDimensionless,
+	)
+	statRunnerCalls = stats.Int64(
+		"goplugin/runner/calls",
+		"number of calls sent to insgorund",
+		stats.UnitDimensionless,
+	)
+	statRunnerActive = stats.Int64(
+		"goplugin/runner/active",
+		"number of calls in progress in insgorund",
+		stats.UnitDimensionless,
+	)
+	statRunnerRecycles = stats.Int64(
+		"goplugin/runner/recycles",
+		"number of times insgorund was recycled",
+		stats.UnitDimensionless,
+	)
+	statRunnerHealthy = stats.Int64(
+		"goplugin/runner/healthy",
+		"result of the last health check of insgorund, 1 if it's healthy",
+		stats.UnitDimensionless,
+	)
 )
 
 func init() {
@@ -43,8 +92,78 @@ func init() {
 			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
 			TagKeys:     []tag.Key{tagMethodName},
 		},
+		&view.View{
+			Measure:     statContractUpcalls,
+			Aggregation: view.Distribution(0, 1, 2, 5, 10, 20, 50, 100, 1000),
+			TagKeys:     []tag.Key{tagPrototype, tagMethodName},
+		},
+		&view.View{
+			Measure:     statContractStateBytes,
+			Aggregation: view.Distribution(0, 100, 1000, 10000, 100000, 1000000, 10000000),
+			TagKeys:     []tag.Key{tagPrototype, tagMethodName},
+		},
+		&view.View{
+			Measure:     statContractCPUTime,
+			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
+			TagKeys:     []tag.Key{tagPrototype, tagMethodName},
+		},
+		&view.View{
+			Measure:     statContractLimitExceeded,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagPrototype, tagMethodName, tagResource},
+		},
+		&view.View{
+			Measure:     statRunnerCalls,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagRunner},
+		},
+		&view.View{
+			Measure:     statRunnerActive,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagRunner},
+		},
+		&view.View{
+			Measure:     statRunnerRecycles,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagRunner, tagReason},
+		},
+		&view.View{
+			Measure:     statRunnerHealthy,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagRunner},
+		},
 	)
 	if err != nil {
 		panic(err)
 	}
 }
+
+// recordUsage records resources consumed by contract call per prototype and method
+func recordUsage(
+	ctx context.Context, callContext *insolar.LogicCallContext, method string,
+	usage insolar.ResourceUsage, cpuTime time.Duration, limitErr *insolar.ResourceLimitError,
+) {
+	prototype := ""
+	if callContext.Prototype != nil {
+		prototype = callContext.Prototype.String()
+	}
+	mutators := []tag.Mutator{tag.Upsert(tagPrototype, prototype), tag.Upsert(tagMethodName, method)}
+	measurements := []stats.Measurement{
+		statContractUpcalls.M(int64(usage.Upcalls)),
+		statContractStateBytes.M(int64(usage.StateBytes)),
+		statContractCPUTime.M(float64(cpuTime.Nanoseconds()) / 1e6),
+	}
+	err := stats.RecordWithTags(ctx, mutators, measurements...)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to record contract resources metrics: " + err.Error())
+	}
+	if limitErr == nil {
+		return
+	}
+	err = stats.RecordWithTags(
+		ctx, append(mutators, tag.Upsert(tagResource, limitErr.Resource)), statContractLimitExceeded.M(1),
+	)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("Failed to record contract limit metric: " + err.Error())
+	}
+}
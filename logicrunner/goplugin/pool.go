@@ -0,0 +1,509 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package goplugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/rpc"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
+
+// reconnectDelay is a pause between attempts to call a runner when none of runners is reachable.
+var reconnectDelay = 100 * time.Millisecond
+
+// runner is a single insgorund process of the pool. Process is started by the pool
+// if path to insgorund binary is configured, otherwise it's started externally
+// and the pool only reconnects to it.
+type runner struct {
+	id       string
+	protocol string
+	address  string
+
+	active int64 // number of calls in progress, accessed atomically
+
+	mu      sync.Mutex
+	gen     uint64 // incremented on each recycle, so failures of replaced connections are ignored
+	client  *rpc.Client
+	cmd     *exec.Cmd
+	exited  chan struct{}
+	healthy bool
+	codes   map[insolar.Reference]bool // codes loaded by the runner since it's (re)started
+}
+
+// runnerPool balances calls across runners, checks their health and recycles broken ones.
+type runnerPool struct {
+	cfg *configuration.LogicRunner
+	am  artifacts.Client
+
+	runners []*runner
+
+	recentMutex sync.Mutex
+	recent      []insolar.Reference // recently called codes, the latest first
+
+	stop     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+}
+
+func newRunnerPool(cfg *configuration.LogicRunner, am artifacts.Client) (*runnerPool, error) {
+	gpCfg := cfg.GoPlugin
+	size := gpCfg.RunnerPoolSize
+	if size < 1 {
+		size = 1
+	}
+
+	p := &runnerPool{
+		cfg:  cfg,
+		am:   am,
+		stop: make(chan struct{}),
+	}
+	for i := 0; i < size; i++ {
+		address, err := runnerAddress(gpCfg.RunnerProtocol, gpCfg.RunnerListen, i)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ newRunnerPool ]")
+		}
+		p.runners = append(p.runners, &runner{
+			id:       strconv.Itoa(i),
+			protocol: gpCfg.RunnerProtocol,
+			address:  address,
+			healthy:  true,
+			codes:    map[insolar.Reference]bool{},
+		})
+	}
+	return p, nil
+}
+
+// runnerAddress returns address of the runner with provided index in the pool.
+func runnerAddress(protocol string, listen string, index int) (string, error) {
+	if index == 0 {
+		return listen, nil
+	}
+	if !strings.HasPrefix(protocol, "tcp") {
+		return fmt.Sprintf("%s.%d", listen, index), nil
+	}
+	host, port, err := net.SplitHostPort(listen)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't parse runner address %q", listen)
+	}
+	portNum, err := strconv.Atoi(port)
+	if err != nil {
+		return "", errors.Wrapf(err, "can't parse port of runner address %q", listen)
+	}
+	return net.JoinHostPort(host, strconv.Itoa(portNum+index)), nil
+}
+
+// start starts processes of runners if they are managed by the pool and health checks.
+func (p *runnerPool) start(ctx context.Context) error {
+	if p.cfg.GoPlugin.RunnerPath != "" {
+		for _, r := range p.runners {
+			err := p.spawn(ctx, r)
+			if err != nil {
+				return errors.Wrapf(err, "[ runnerPool.start ] can't start runner %s", r.id)
+			}
+		}
+	}
+
+	if p.cfg.GoPlugin.RunnerHealthCheckInterval > 0 {
+		p.wg.Add(1)
+		go p.healthCheck(ctx)
+	}
+	return nil
+}
+
+// close stops health checks, disconnects runners and kills processes managed by the pool.
+func (p *runnerPool) close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+	p.wg.Wait()
+
+	for _, r := range p.runners {
+		r.mu.Lock()
+		r.disconnect()
+		r.kill()
+		r.mu.Unlock()
+	}
+}
+
+// spawn starts insgorund process for the runner, runner.mu should be held or runner not yet used.
+func (p *runnerPool) spawn(ctx context.Context, r *runner) error {
+	cmd := exec.Command(
+		p.cfg.GoPlugin.RunnerPath,
+		"--listen", r.address,
+		"--proto", r.protocol,
+		"--rpc", p.cfg.RPCListen,
+		"--rpc-proto", p.cfg.RPCProtocol,
+	)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err := cmd.Start()
+	if err != nil {
+		return err
+	}
+
+	exited := make(chan struct{})
+	go func() {
+		err := cmd.Wait()
+		inslogger.FromContext(ctx).Infof("insgorund %s at %s exited: %v", r.id, r.address, err)
+		close(exited)
+	}()
+
+	r.cmd = cmd
+	r.exited = exited
+	return nil
+}
+
+// abandoner is implemented by responses of calls that can leave aborted contract code running in the runner.
+type abandoner interface {
+	CodeAbandoned() bool
+}
+
+// call calls method of a runner, picking the least loaded one and preferring runners that already loaded the code.
+// Calls are retried on other runners if connection is lost, runner is recycled if call times out
+// or contract code aborted by CPU time limit keeps running in it.
+// Panics of contracts are recovered by the runner and returned as errors, they don't break the runner.
+func (p *runnerPool) call(ctx context.Context, code insolar.Reference, method string, req interface{}, res interface{}) error {
+	logger := inslogger.FromContext(ctx)
+
+	var deadline <-chan time.Time
+	if p.cfg.GoPlugin.RunnerCallTimeout > 0 {
+		timer := time.NewTimer(p.cfg.GoPlugin.RunnerCallTimeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+
+	for {
+		r := p.pick(code)
+		gen := r.generation()
+		client, err := p.connect(ctx, r)
+		if err == nil {
+			ctx := insmetrics.InsertTag(ctx, tagRunner, r.id)
+			stats.Record(ctx, statRunnerCalls.M(1), statRunnerActive.M(atomic.AddInt64(&r.active, 1)))
+
+			call := client.Go(method, req, res, make(chan *rpc.Call, 1))
+			select {
+			case <-call.Done:
+				err = call.Error
+				stats.Record(ctx, statRunnerActive.M(atomic.AddInt64(&r.active, -1)))
+			case <-deadline:
+				stats.Record(ctx, statRunnerActive.M(atomic.AddInt64(&r.active, -1)))
+				p.recycle(ctx, r, gen, "timeout")
+				return errors.New("logicrunner execution timeout")
+			}
+
+			if !isConnectionError(err) {
+				if a, ok := res.(abandoner); ok && err == nil && a.CodeAbandoned() {
+					// goroutine of the aborted code can't be stopped, only restart of the process frees the CPU
+					p.recycle(ctx, r, gen, "abandoned code")
+					return nil
+				}
+				p.loaded(r, code)
+				return err
+			}
+			logger.Debugf("Connection to insgorund %s is closed, need to reconnect", r.id)
+			p.recycle(ctx, r, gen, "disconnect")
+		} else {
+			logger.Debugf("Can't connect to insgorund %s, err: %s", r.id, err.Error())
+			r.setHealthy(false)
+		}
+
+		select {
+		case <-deadline:
+			return errors.New("logicrunner execution timeout")
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// pick returns healthy runner with the least number of calls in progress,
+// runners that already loaded the code win ties.
+func (p *runnerPool) pick(code insolar.Reference) *runner {
+	var best *runner
+	var bestActive int64
+	var bestLoaded, bestHealthy bool
+	for _, r := range p.runners {
+		r.mu.Lock()
+		healthy, loaded := r.healthy, r.codes[code]
+		r.mu.Unlock()
+		active := atomic.LoadInt64(&r.active)
+
+		better := best == nil ||
+			(healthy && !bestHealthy) ||
+			(healthy == bestHealthy && (active < bestActive || (active == bestActive && loaded && !bestLoaded)))
+		if better {
+			best, bestActive, bestLoaded, bestHealthy = r, active, loaded, healthy
+		}
+	}
+	return best
+}
+
+// connect returns connection to the runner, new connections are followed by warm up of recently called codes.
+func (p *runnerPool) connect(ctx context.Context, r *runner) (*rpc.Client, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.client != nil {
+		return r.client, nil
+	}
+
+	client, err := rpc.Dial(r.protocol, r.address)
+	if err != nil {
+		return nil, errors.Wrapf(err, "couldn't dial '%s' over %s", r.address, r.protocol)
+	}
+	r.client = client
+	r.healthy = true
+
+	go p.warmUp(ctx, r, client)
+
+	return client, nil
+}
+
+// recycle drops connection to the runner, process managed by the pool is restarted.
+// Nothing is done if the runner was already recycled after the failure, i.e. its generation changed.
+func (p *runnerPool) recycle(ctx context.Context, r *runner, gen uint64, reason string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.gen != gen {
+		inslogger.FromContext(ctx).Debugf("Insgorund %s is already recycled, skipping recycle by %s", r.id, reason)
+		return
+	}
+	r.gen++
+
+	inslogger.FromContext(ctx).Warnf("Recycling insgorund %s at %s, reason: %s", r.id, r.address, reason)
+	ctx = insmetrics.InsertTag(ctx, tagRunner, r.id)
+	ctx = insmetrics.InsertTag(ctx, tagReason, reason)
+	stats.Record(ctx, statRunnerRecycles.M(1))
+
+	r.disconnect()
+	r.codes = map[insolar.Reference]bool{}
+	if r.cmd == nil {
+		return
+	}
+	r.kill()
+	select {
+	case <-p.stop:
+		return
+	default:
+	}
+	err := p.spawn(ctx, r)
+	if err != nil {
+		inslogger.FromContext(ctx).Errorf("Can't restart insgorund %s: %s", r.id, err)
+		r.healthy = false
+	}
+}
+
+// loaded remembers that the runner loaded the code and moves the code to the head of recently called ones.
+func (p *runnerPool) loaded(r *runner, code insolar.Reference) {
+	r.mu.Lock()
+	r.codes[code] = true
+	r.mu.Unlock()
+
+	limit := p.cfg.GoPlugin.RunnerWarmUp
+	if limit <= 0 {
+		return
+	}
+
+	p.recentMutex.Lock()
+	defer p.recentMutex.Unlock()
+
+	recent := []insolar.Reference{code}
+	for _, ref := range p.recent {
+		if ref != code && len(recent) < limit {
+			recent = append(recent, ref)
+		}
+	}
+	p.recent = recent
+}
+
+func (p *runnerPool) recentCodes() []insolar.Reference {
+	p.recentMutex.Lock()
+	defer p.recentMutex.Unlock()
+
+	return append([]insolar.Reference(nil), p.recent...)
+}
+
+// warmUp loads recently called codes into the runner, so calls balanced to it aren't delayed by loading plugins.
+func (p *runnerPool) warmUp(ctx context.Context, r *runner, client *rpc.Client) {
+	if p.am == nil {
+		return
+	}
+
+	for _, ref := range p.recentCodes() {
+		r.mu.Lock()
+		skip := r.client != client || r.codes[ref]
+		r.mu.Unlock()
+		if skip {
+			continue
+		}
+
+		desc, err := p.am.GetCode(ctx, ref)
+		if err != nil {
+			inslogger.FromContext(ctx).Warnf("Can't get code %s to warm up insgorund %s: %s", ref, r.id, err)
+			continue
+		}
+		binary, err := desc.Code()
+		if err != nil {
+			continue
+		}
+
+		err = client.Call("RPC.WarmUp", rpctypes.DownWarmUpReq{Code: ref, Binary: binary}, &rpctypes.DownWarmUpResp{})
+		if err != nil {
+			inslogger.FromContext(ctx).Warnf("Can't warm up insgorund %s with code %s: %s", r.id, ref, err)
+			if isConnectionError(err) {
+				return
+			}
+			continue
+		}
+
+		r.mu.Lock()
+		if r.client == client {
+			r.codes[ref] = true
+		}
+		r.mu.Unlock()
+	}
+}
+
+// healthCheck pings runners periodically, runners not answering in time are recycled.
+func (p *runnerPool) healthCheck(ctx context.Context) {
+	defer p.wg.Done()
+
+	interval := p.cfg.GoPlugin.RunnerHealthCheckInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		for _, r := range p.runners {
+			p.check(ctx, r, interval)
+		}
+	}
+}
+
+func (p *runnerPool) check(ctx context.Context, r *runner, timeout time.Duration) {
+	ctx = insmetrics.InsertTag(ctx, tagRunner, r.id)
+
+	gen := r.generation()
+	client, err := p.connect(ctx, r)
+	if err != nil {
+		stats.Record(ctx, statRunnerHealthy.M(0))
+		r.setHealthy(false)
+		if r.hasExited() {
+			p.recycle(ctx, r, gen, "exited")
+		}
+		return
+	}
+
+	call := client.Go("RPC.Ping", rpctypes.DownPingReq{}, &rpctypes.DownPingResp{}, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		err = call.Error
+	case <-time.After(timeout):
+		err = errors.New("ping timeout")
+	case <-p.stop:
+		return
+	}
+	// Runner answering with an error is alive.
+	if _, ok := err.(rpc.ServerError); err != nil && !ok {
+		stats.Record(ctx, statRunnerHealthy.M(0))
+		p.recycle(ctx, r, gen, "healthcheck")
+		return
+	}
+
+	stats.Record(ctx, statRunnerHealthy.M(1))
+	r.setHealthy(true)
+}
+
+func (r *runner) setHealthy(healthy bool) {
+	r.mu.Lock()
+	r.healthy = healthy
+	r.mu.Unlock()
+}
+
+func (r *runner) generation() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.gen
+}
+
+func (r *runner) hasExited() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.exited == nil {
+		return false
+	}
+	select {
+	case <-r.exited:
+		return true
+	default:
+		return false
+	}
+}
+
+// disconnect closes connection to the runner, runner.mu should be held.
+func (r *runner) disconnect() {
+	if r.client != nil {
+		r.client.Close() // nolint: errcheck
+		r.client = nil
+	}
+}
+
+// kill kills process of the runner if it's managed by the pool, runner.mu should be held.
+func (r *runner) kill() {
+	if r.cmd == nil {
+		return
+	}
+	r.cmd.Process.Kill() // nolint: errcheck
+	<-r.exited
+	r.cmd = nil
+}
+
+// isConnectionError returns true if the call failed because connection to the runner is broken.
+func isConnectionError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(rpc.ServerError); ok {
+		return false
+	}
+	return err == rpc.ErrShutdown || err == io.ErrUnexpectedEOF || err == io.EOF
+}
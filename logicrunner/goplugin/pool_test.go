@@ -0,0 +1,297 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package goplugin
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/rpc"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+	"github.com/insolar/insolar/testutils"
+)
+
+// fakeRunner implements RPC interface of insgorund for pool tests.
+type fakeRunner struct {
+	id       string
+	listener net.Listener
+
+	mu        sync.Mutex
+	called    []insolar.Reference
+	warmed    []insolar.Reference
+	block     chan struct{}
+	failure   error
+	abandoned bool
+}
+
+func (f *fakeRunner) CallMethod(args rpctypes.DownCallMethodReq, reply *rpctypes.DownCallMethodResp) error {
+	f.mu.Lock()
+	f.called = append(f.called, args.Code)
+	block, failure := f.block, f.failure
+	f.mu.Unlock()
+
+	if block != nil {
+		<-block
+	}
+	if failure != nil {
+		return failure
+	}
+	reply.Data = []byte(f.id)
+	reply.Abandoned = f.abandoned
+	return nil
+}
+
+func (f *fakeRunner) Ping(args rpctypes.DownPingReq, reply *rpctypes.DownPingResp) error {
+	return nil
+}
+
+func (f *fakeRunner) WarmUp(args rpctypes.DownWarmUpReq, reply *rpctypes.DownWarmUpResp) error {
+	f.mu.Lock()
+	f.warmed = append(f.warmed, args.Code)
+	f.mu.Unlock()
+	return nil
+}
+
+func startFakeRunner(t *testing.T, id string) *fakeRunner {
+	f := &fakeRunner{id: id}
+	server := rpc.NewServer()
+	require.NoError(t, server.RegisterName("RPC", f))
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	f.listener = l
+	go server.Accept(l)
+	return f
+}
+
+func newTestPool(t *testing.T, am artifacts.Client, runners ...*fakeRunner) *runnerPool {
+	cfg := configuration.NewLogicRunner()
+	cfg.GoPlugin.RunnerPoolSize = len(runners)
+	cfg.GoPlugin.RunnerHealthCheckInterval = 0
+	cfg.GoPlugin.RunnerCallTimeout = 5 * time.Second
+
+	p, err := newRunnerPool(&cfg, am)
+	require.NoError(t, err)
+	for i, f := range runners {
+		p.runners[i].address = f.listener.Addr().String()
+	}
+	return p
+}
+
+func callRunner(p *runnerPool, code insolar.Reference) (string, error) {
+	res := rpctypes.DownCallMethodResp{}
+	err := p.call(context.Background(), code, "RPC.CallMethod", rpctypes.DownCallMethodReq{Code: code}, &res)
+	return string(res.Data), err
+}
+
+func TestRunnerAddress(t *testing.T) {
+	address, err := runnerAddress("tcp", "127.0.0.1:7777", 0)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:7777", address)
+
+	address, err = runnerAddress("tcp", "127.0.0.1:7777", 2)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:7779", address)
+
+	address, err = runnerAddress("unix", "/tmp/insgorund.sock", 1)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/insgorund.sock.1", address)
+
+	_, err = runnerAddress("tcp", "localhost", 1)
+	require.Error(t, err)
+}
+
+func TestRunnerPool_Balancing(t *testing.T) {
+	first, second := startFakeRunner(t, "0"), startFakeRunner(t, "1")
+	p := newTestPool(t, nil, first, second)
+	defer p.close()
+
+	code, other := testutils.RandomRef(), testutils.RandomRef()
+
+	// Busy runner isn't picked while there is an idle one.
+	first.block = make(chan struct{})
+	done := make(chan string)
+	go func() {
+		id, err := callRunner(p, code)
+		require.NoError(t, err)
+		done <- id
+	}()
+	for {
+		first.mu.Lock()
+		n := len(first.called)
+		first.mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	id, err := callRunner(p, other)
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+
+	close(first.block)
+	require.Equal(t, "0", <-done)
+
+	// Idle runners that already loaded the code are preferred.
+	id, err = callRunner(p, other)
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	id, err = callRunner(p, code)
+	require.NoError(t, err)
+	require.Equal(t, "0", id)
+}
+
+func TestRunnerPool_Reconnect(t *testing.T) {
+	dead, alive := startFakeRunner(t, "0"), startFakeRunner(t, "1")
+	require.NoError(t, dead.listener.Close())
+	p := newTestPool(t, nil, dead, alive)
+	defer p.close()
+
+	id, err := callRunner(p, testutils.RandomRef())
+	require.NoError(t, err)
+	require.Equal(t, "1", id)
+	require.False(t, p.runners[0].healthy)
+
+	p.check(context.Background(), p.runners[1], time.Second)
+	require.True(t, p.runners[1].healthy)
+	p.check(context.Background(), p.runners[0], time.Second)
+	require.False(t, p.runners[0].healthy)
+}
+
+func TestRunnerPool_Recycle(t *testing.T) {
+	f := startFakeRunner(t, "0")
+	p := newTestPool(t, nil, f)
+	defer p.close()
+	r := p.runners[0]
+	code := testutils.RandomRef()
+
+	_, err := callRunner(p, code)
+	require.NoError(t, err)
+	require.True(t, r.codes[code])
+
+	// Contract errors and recovered panics keep the runner.
+	f.failure = errors.New("contract error")
+	_, err = callRunner(p, code)
+	require.EqualError(t, err, "contract error")
+	require.NotNil(t, r.client)
+
+	f.failure = errors.New(rpctypes.PanicErrorPrefix + "haha")
+	_, err = callRunner(p, code)
+	require.Error(t, err)
+	require.NotNil(t, r.client)
+	require.True(t, r.codes[code])
+
+	f.failure = nil
+	f.block = make(chan struct{})
+	defer close(f.block)
+	p.cfg.GoPlugin.RunnerCallTimeout = 100 * time.Millisecond
+	_, err = callRunner(p, code)
+	require.EqualError(t, err, "logicrunner execution timeout")
+	require.Nil(t, r.client)
+	require.Empty(t, r.codes)
+}
+
+func TestRunnerPool_RecycleAbandoned(t *testing.T) {
+	f := startFakeRunner(t, "0")
+	p := newTestPool(t, nil, f)
+	defer p.close()
+	r := p.runners[0]
+	code := testutils.RandomRef()
+
+	_, err := callRunner(p, code)
+	require.NoError(t, err)
+	require.NotNil(t, r.client)
+	gen := r.generation()
+
+	// Runner left running code aborted by CPU time limit, it's restarted to stop the code.
+	f.abandoned = true
+	_, err = callRunner(p, code)
+	require.NoError(t, err)
+	require.Nil(t, r.client)
+	require.Empty(t, r.codes)
+	require.Equal(t, gen+1, r.generation())
+}
+
+func TestRunnerPool_RecycleStale(t *testing.T) {
+	f := startFakeRunner(t, "0")
+	p := newTestPool(t, nil, f)
+	defer p.close()
+	r := p.runners[0]
+	ctx := context.Background()
+
+	gen := r.generation()
+	_, err := p.connect(ctx, r)
+	require.NoError(t, err)
+	p.recycle(ctx, r, gen, "first")
+	require.Nil(t, r.client)
+
+	// Failure of the replaced connection doesn't recycle the new one.
+	client, err := p.connect(ctx, r)
+	require.NoError(t, err)
+	p.recycle(ctx, r, gen, "stale")
+	require.Equal(t, client, r.client)
+
+	p.recycle(ctx, r, r.generation(), "current")
+	require.Nil(t, r.client)
+}
+
+func TestRunnerPool_WarmUp(t *testing.T) {
+	f := startFakeRunner(t, "0")
+	code := testutils.RandomRef()
+
+	am := artifacts.NewClientMock(t)
+	am.GetCodeFunc = func(ctx context.Context, ref insolar.Reference) (artifacts.CodeDescriptor, error) {
+		require.Equal(t, code, ref)
+		desc := artifacts.NewCodeDescriptorMock(t)
+		desc.CodeMock.Return([]byte("binary"), nil)
+		return desc, nil
+	}
+	p := newTestPool(t, am, f)
+	defer p.close()
+	r := p.runners[0]
+
+	_, err := callRunner(p, code)
+	require.NoError(t, err)
+	require.Equal(t, []insolar.Reference{code}, p.recentCodes())
+
+	// Recycled runner gets recently called code in advance.
+	p.recycle(context.Background(), r, r.generation(), "test")
+	_, err = p.connect(context.Background(), r)
+	require.NoError(t, err)
+	for {
+		r.mu.Lock()
+		warmed := r.codes[code]
+		r.mu.Unlock()
+		if warmed {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	f.mu.Lock()
+	require.Equal(t, []insolar.Reference{code}, f.warmed)
+	f.mu.Unlock()
+}
@@ -0,0 +1,105 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/token"
+	"io"
+	"strings"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+// ABI returns machine readable description of the contract's constructors and methods.
+func (pf *ParsedFile) ABI() *insolar.ContractABI {
+	apiFlags := pf.apiFlags()
+	abi := &insolar.ContractABI{
+		Contract:     pf.contract,
+		Constructors: []insolar.FunctionABI{},
+		Methods:      []insolar.FunctionABI{},
+	}
+	for _, fun := range pf.constructors[pf.contract] {
+		abi.Constructors = append(abi.Constructors, pf.functionABI(fun, apiFlags))
+	}
+	for _, fun := range pf.methods[pf.contract] {
+		abi.Methods = append(abi.Methods, pf.functionABI(fun, apiFlags))
+	}
+	return abi
+}
+
+// WriteABI writes ABI of the contract into `out` as JSON.
+func (pf *ParsedFile) WriteABI(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "\t")
+	return enc.Encode(pf.ABI())
+}
+
+func (pf *ParsedFile) functionABI(fun *ast.FuncDecl, apiFlags map[string]bool) insolar.FunctionABI {
+	return insolar.FunctionABI{
+		Name:      fun.Name.Name,
+		Arguments: pf.parametersABI(fun.Type.Params),
+		Results:   pf.parametersABI(fun.Type.Results),
+		API:       apiFlags[fun.Name.Name],
+		Immutable: isImmutable(fun),
+	}
+}
+
+func (pf *ParsedFile) parametersABI(list *ast.FieldList) []insolar.ParameterABI {
+	res := []insolar.ParameterABI{}
+	if list == nil {
+		return res
+	}
+	for _, field := range list.List {
+		typ := pf.codeOfNode(field.Type)
+		if len(field.Names) == 0 {
+			res = append(res, insolar.ParameterABI{Type: typ})
+			continue
+		}
+		for _, name := range field.Names {
+			res = append(res, insolar.ParameterABI{Name: name.Name, Type: typ})
+		}
+	}
+	return res
+}
+
+// apiFlags collects names of functions marked with `var INSATTR_<Name>_API = true`.
+func (pf *ParsedFile) apiFlags() map[string]bool {
+	res := map[string]bool{}
+	for _, decl := range pf.node.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, name := range vs.Names {
+				if !strings.HasPrefix(name.Name, "INSATTR_") || !strings.HasSuffix(name.Name, "_API") {
+					continue
+				}
+				if i >= len(vs.Values) {
+					continue
+				}
+				if value, ok := vs.Values[i].(*ast.Ident); ok && value.Name == "true" {
+					res[strings.TrimSuffix(strings.TrimPrefix(name.Name, "INSATTR_"), "_API")] = true
+				}
+			}
+		}
+	}
+	return res
+}
@@ -0,0 +1,110 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+)
+
+func TestABI(t *testing.T) {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir) // nolint: errcheck
+
+	err = goplugintestutils.WriteFile(tmpDir, "main.go", `
+package main
+
+import (
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type One struct {
+	foundation.BaseContract
+}
+
+func New(name string, owner insolar.Reference) (*One, error) {
+	return &One{}, nil
+}
+
+var INSATTR_Get_API = true
+
+// Get is immutable
+// ins:immutable
+func (c *One) Get(a, b int, data []byte) (*insolar.Reference, error) {
+	return nil, nil
+}
+
+var INSATTR_Set_API = false
+
+func (c *One) Set(map[string]uint64) error {
+	return nil
+}
+`)
+	require.NoError(t, err)
+
+	parsed, err := ParseFile(filepath.Join(tmpDir, "main.go"))
+	require.NoError(t, err)
+
+	expected := &insolar.ContractABI{
+		Contract: "One",
+		Constructors: []insolar.FunctionABI{{
+			Name: "New",
+			Arguments: []insolar.ParameterABI{
+				{Name: "name", Type: "string"},
+				{Name: "owner", Type: "insolar.Reference"},
+			},
+			Results: []insolar.ParameterABI{{Type: "*One"}, {Type: "error"}},
+		}},
+		Methods: []insolar.FunctionABI{{
+			Name: "Get",
+			Arguments: []insolar.ParameterABI{
+				{Name: "a", Type: "int"},
+				{Name: "b", Type: "int"},
+				{Name: "data", Type: "[]byte"},
+			},
+			Results:   []insolar.ParameterABI{{Type: "*insolar.Reference"}, {Type: "error"}},
+			API:       true,
+			Immutable: true,
+		}, {
+			Name:      "Set",
+			Arguments: []insolar.ParameterABI{{Type: "map[string]uint64"}},
+			Results:   []insolar.ParameterABI{{Type: "error"}},
+		}},
+	}
+	require.Equal(t, expected, parsed.ABI())
+	require.NotNil(t, parsed.ABI().Method("Set"))
+	require.Nil(t, parsed.ABI().Method("Unknown"))
+
+	var buf bytes.Buffer
+	err = parsed.WriteABI(&buf)
+	require.NoError(t, err)
+	decoded := &insolar.ContractABI{}
+	err = json.Unmarshal(buf.Bytes(), decoded)
+	require.NoError(t, err)
+	require.Equal(t, expected, decoded)
+}
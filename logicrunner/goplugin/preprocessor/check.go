@@ -0,0 +1,257 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// forbiddenImports are packages giving contract access to environment of the node
+// or to concurrency, results of such contract can't be reproduced by validators.
+// Subpackages of the listed packages are forbidden as well.
+var forbiddenImports = []string{
+	"crypto/rand",
+	"io/ioutil",
+	"math/rand",
+	"net",
+	"os",
+	"path/filepath",
+	"plugin",
+	"runtime",
+	"sync",
+	"syscall",
+	"unsafe",
+}
+
+// forbiddenFunctions are functions of allowed packages that read or wait for wall clock
+var forbiddenFunctions = map[string][]string{
+	"time": {"After", "AfterFunc", "NewTicker", "NewTimer", "Now", "Since", "Sleep", "Tick", "Until"},
+}
+
+// Issue is a problem in contract source found by Check
+type Issue struct {
+	Pos     token.Position
+	Message string
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s", i.Pos, i.Message)
+}
+
+// CheckError lists all issues found in contract source
+type CheckError []Issue
+
+func (e CheckError) Error() string {
+	lines := make([]string, len(e))
+	for i, issue := range e {
+		lines[i] = issue.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Check validates that contract can be executed deterministically, so its calls
+// can be replayed on validation: contract doesn't import forbidden packages, doesn't
+// start goroutines, use channels, read wall clock or iterate over maps, and its
+// methods and constructors accept and return only serializable types.
+// All found issues are returned as CheckError.
+func (pf *ParsedFile) Check() error {
+	c := &checker{
+		pf:      pf,
+		info:    pf.typeInfo(),
+		imports: make(map[string]string),
+	}
+
+	c.checkImports()
+	ast.Inspect(pf.node, c.inspect)
+	c.checkSignatures()
+
+	if len(c.issues) == 0 {
+		return nil
+	}
+	sort.SliceStable(c.issues, func(i, j int) bool {
+		return c.issues[i].Pos.Offset < c.issues[j].Pos.Offset
+	})
+	return c.issues
+}
+
+// typeInfo type checks the file alone. Imported packages are replaced with empty ones,
+// so types declared in the file are resolved and the rest are left invalid.
+func (pf *ParsedFile) typeInfo() *types.Info {
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+	}
+	conf := types.Config{
+		Importer: emptyImporter{},
+		Error:    func(error) {},
+	}
+	_, _ = conf.Check(pf.node.Name.Name, pf.fileSet, []*ast.File{pf.node}, info) // nolint: errcheck
+	return info
+}
+
+type emptyImporter struct{}
+
+func (emptyImporter) Import(importPath string) (*types.Package, error) {
+	pkg := types.NewPackage(importPath, path.Base(importPath))
+	pkg.MarkComplete()
+	return pkg, nil
+}
+
+type checker struct {
+	pf      *ParsedFile
+	info    *types.Info
+	imports map[string]string // local name of imported package -> import path
+	issues  CheckError
+}
+
+func (c *checker) report(node ast.Node, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{
+		Pos:     c.pf.fileSet.Position(node.Pos()),
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *checker) checkImports() {
+	for _, spec := range c.pf.node.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+
+		for _, forbidden := range forbiddenImports {
+			if importPath == forbidden || strings.HasPrefix(importPath, forbidden+"/") {
+				c.report(spec, "import of %q is not allowed", importPath)
+			}
+		}
+
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		c.imports[name] = importPath
+	}
+}
+
+func (c *checker) inspect(node ast.Node) bool {
+	switch n := node.(type) {
+	case *ast.GoStmt:
+		c.report(n, "goroutines are not allowed")
+	case *ast.SelectStmt:
+		c.report(n, "select statements are not allowed")
+	case *ast.ChanType:
+		c.report(n, "channels are not allowed")
+	case *ast.RangeStmt:
+		if c.isMap(n.X) {
+			c.report(n, "iteration over map %s has random order, iterate over sorted keys instead", types.ExprString(n.X))
+		}
+	case *ast.SelectorExpr:
+		pkg, ok := n.X.(*ast.Ident)
+		// package names aren't resolved by parser, unlike local identifiers
+		if !ok || pkg.Obj != nil {
+			break
+		}
+		for _, name := range forbiddenFunctions[c.imports[pkg.Name]] {
+			if n.Sel.Name == name {
+				c.report(n, "%s.%s is not allowed, use time of the call from contract's context", pkg.Name, name)
+			}
+		}
+	}
+	return true
+}
+
+func (c *checker) isMap(expr ast.Expr) bool {
+	tv, ok := c.info.Types[expr]
+	if !ok || tv.Type == nil {
+		return false
+	}
+	_, ok = tv.Type.Underlying().(*types.Map)
+	return ok
+}
+
+func (c *checker) checkSignatures() {
+	for _, fd := range c.pf.methods[c.pf.contract] {
+		c.checkSignature("method", fd)
+	}
+	for _, fd := range c.pf.constructors[c.pf.contract] {
+		c.checkSignature("constructor", fd)
+	}
+	if c.pf.migrate != nil {
+		c.checkSignature("function", c.pf.migrate)
+	}
+}
+
+func (c *checker) checkSignature(kind string, fd *ast.FuncDecl) {
+	for _, field := range fd.Type.Params.List {
+		if c.unsupportedType(field.Type, false, make(map[string]bool)) {
+			c.report(field, "argument of %s %q has type %s that can't be serialized",
+				kind, fd.Name.Name, types.ExprString(field.Type))
+		}
+	}
+	if fd.Type.Results == nil {
+		return
+	}
+	for _, field := range fd.Type.Results.List {
+		// errors in results are converted by wrapper
+		if c.unsupportedType(field.Type, true, make(map[string]bool)) {
+			c.report(field, "result of %s %q has type %s that can't be serialized",
+				kind, fd.Name.Name, types.ExprString(field.Type))
+		}
+	}
+}
+
+// unsupportedType checks that values of type can't be passed between contracts.
+// Channels aren't checked here as they are reported wherever they are used.
+func (c *checker) unsupportedType(expr ast.Expr, allowError bool, seen map[string]bool) bool {
+	switch t := expr.(type) {
+	case *ast.FuncType:
+		return true
+	case *ast.InterfaceType:
+		return t.Methods.NumFields() != 0
+	case *ast.StarExpr:
+		return c.unsupportedType(t.X, false, seen)
+	case *ast.ArrayType:
+		return c.unsupportedType(t.Elt, false, seen)
+	case *ast.Ellipsis:
+		return c.unsupportedType(t.Elt, false, seen)
+	case *ast.MapType:
+		return c.unsupportedType(t.Key, false, seen) || c.unsupportedType(t.Value, false, seen)
+	case *ast.StructType:
+		for _, field := range t.Fields.List {
+			if c.unsupportedType(field.Type, false, seen) {
+				return true
+			}
+		}
+	case *ast.Ident:
+		switch t.Name {
+		case "error":
+			return !allowError
+		case "uintptr", "complex64", "complex128":
+			return true
+		}
+		if spec, ok := c.pf.types[t.Name]; ok && !seen[t.Name] {
+			seen[t.Name] = true
+			return c.unsupportedType(spec.Type, false, seen)
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package preprocessor
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/goplugintestutils"
+)
+
+func checkCode(t *testing.T, code string) error {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir) // nolint: errcheck
+
+	err = goplugintestutils.WriteFile(tmpDir, "main.go", code)
+	require.NoError(t, err)
+
+	parsed, err := ParseFile(filepath.Join(tmpDir, "main.go"))
+	require.NoError(t, err)
+
+	return parsed.Check()
+}
+
+func TestCheck(t *testing.T) {
+	err := checkCode(t, `
+package main
+
+import (
+	"sort"
+	"time"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type Payload struct {
+	Values map[string][]int
+}
+
+type One struct {
+	foundation.BaseContract
+	Expire int64
+	Keys   []string
+}
+
+func New(p Payload) (*One, error) {
+	return &One{}, nil
+}
+
+func (c *One) SortedKeys() ([]string, error) {
+	keys := make([]string, len(c.Keys))
+	for i, k := range c.Keys {
+		keys[i] = k
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (c *One) Expired() (bool, error) {
+	return c.GetContext().Time.After(time.Unix(c.Expire, 0)), nil
+}
+`)
+	require.NoError(t, err)
+
+	err = checkCode(t, `
+package main
+
+import (
+	"math/rand"
+	"net/http"
+	clock "time"
+
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type Handler struct {
+	Callback func()
+}
+
+type One struct {
+	foundation.BaseContract
+	Balances map[string]int
+}
+
+func New(h *Handler) (*One, error) {
+	return &One{}, nil
+}
+
+func (c *One) Total() (int, error) {
+	total := 0
+	for _, b := range c.Balances {
+		total += b
+	}
+	return total, nil
+}
+
+func (c *One) Async(e error) (chan int, error) {
+	ch := make(chan int)
+	go func() {
+		ch <- rand.Int()
+	}()
+	select {
+	case <-clock.After(clock.Second):
+	}
+	return ch, nil
+}
+
+func (c *One) Now() (clock.Time, error) {
+	return clock.Now(), nil
+}
+`)
+	require.Error(t, err)
+	require.IsType(t, CheckError{}, err)
+
+	var messages []string
+	for _, issue := range err.(CheckError) {
+		require.Contains(t, issue.Pos.Filename, "main.go")
+		messages = append(messages, issue.Pos.String()[len(issue.Pos.Filename):]+" "+issue.Message)
+	}
+	require.Equal(t, []string{
+		`:5:2 import of "math/rand" is not allowed`,
+		`:6:2 import of "net/http" is not allowed`,
+		`:21:10 argument of constructor "New" has type *Handler that can't be serialized`,
+		`:27:2 iteration over map c.Balances has random order, iterate over sorted keys instead`,
+		`:33:21 argument of method "Async" has type error that can't be serialized`,
+		`:33:31 channels are not allowed`,
+		`:34:13 channels are not allowed`,
+		`:35:2 goroutines are not allowed`,
+		`:38:2 select statements are not allowed`,
+		`:39:9 clock.After is not allowed, use time of the call from contract's context`,
+		`:45:9 clock.Now is not allowed, use time of the call from contract's context`,
+	}, messages)
+}
+
+func TestCheckRealContracts(t *testing.T) {
+	names, err := GetRealContractsNames()
+	require.NoError(t, err)
+	dir, err := GetRealApplicationDir("contract")
+	require.NoError(t, err)
+
+	for _, name := range names {
+		parsed, err := ParseFile(contractPath(name, dir))
+		require.NoError(t, err)
+		require.NoError(t, parsed.Check(), name)
+	}
+}
@@ -46,6 +46,16 @@ import (
 var foundationPath = "github.com/insolar/insolar/logicrunner/goplugin/foundation"
 var proxyctxPath = "github.com/insolar/insolar/logicrunner/goplugin/proxyctx"
 var corePath = "github.com/insolar/insolar/insolar"
+var builtinPath = "github.com/insolar/insolar/logicrunner/builtin"
+
+// migrateFunctionName is a name of optional contract function converting memory
+// of the previous contract version after prototype upgrade. Expected signature is
+// `func Migrate(oldMemory []byte) (*Contract, error)`.
+const migrateFunctionName = "Migrate"
+
+// immutableDirective in doc comment of a method marks it as immutable: method doesn't change memory of
+// the object and can be called as a query, insgocc generates `INSATTR_<Name>_Immutable` flag for it.
+const immutableDirective = "ins:immutable"
 
 // ParsedFile struct with prepared info we extract from source code
 type ParsedFile struct {
@@ -57,6 +67,7 @@ type ParsedFile struct {
 	types        map[string]*ast.TypeSpec
 	methods      map[string][]*ast.FuncDecl
 	constructors map[string][]*ast.FuncDecl
+	migrate      *ast.FuncDecl
 	contract     string
 }
 
@@ -140,7 +151,11 @@ func (pf *ParsedFile) parseFunctionsAndMethods() error {
 
 		var err error
 		if fd.Recv == nil || fd.Recv.NumFields() == 0 {
-			err = pf.parseConstructor(fd)
+			if fd.Name.Name == migrateFunctionName {
+				err = pf.parseMigrate(fd)
+			} else {
+				err = pf.parseConstructor(fd)
+			}
 		} else {
 			err = pf.parseMethod(fd)
 		}
@@ -158,6 +173,10 @@ func (pf *ParsedFile) parseConstructor(fd *ast.FuncDecl) error {
 		return nil // doesn't look like a constructor
 	}
 
+	if isImmutable(fd) {
+		return errors.Errorf("Constructor %q can't be immutable", name)
+	}
+
 	res := fd.Type.Results
 
 	if res.NumFields() != 2 {
@@ -174,6 +193,22 @@ func (pf *ParsedFile) parseConstructor(fd *ast.FuncDecl) error {
 	return nil
 }
 
+func (pf *ParsedFile) parseMigrate(fd *ast.FuncDecl) error {
+	params := fd.Type.Params
+	if params.NumFields() != 1 || pf.typeName(params.List[0].Type) != "[]byte" {
+		return errors.Errorf("Function %q should accept exactly one '[]byte' argument", migrateFunctionName)
+	}
+
+	res := fd.Type.Results
+	if res.NumFields() != 2 || pf.typeName(res.List[0].Type) != pf.contract || pf.typeName(res.List[1].Type) != "error" {
+		return errors.Errorf("Function %q should return '*%s' and 'error'", migrateFunctionName, pf.contract)
+	}
+
+	pf.migrate = fd
+
+	return nil
+}
+
 func (pf *ParsedFile) parseMethod(fd *ast.FuncDecl) error {
 	name := fd.Name.Name
 
@@ -224,27 +259,61 @@ func (pf *ParsedFile) ContractName() string {
 // WriteWrapper generates and writes into `out` source code
 // of wrapper for the contract
 func (pf *ParsedFile) WriteWrapper(out io.Writer) error {
-	packageName := pf.node.Name.Name
+	return pf.writeWrapper(out, "main", "")
+}
 
+// WriteBuiltinWrapper generates and writes into `out` source code of wrapper
+// that registers the contract in builtin executor under the contract's name.
+// Wrapper is placed in the contract's package.
+func (pf *ParsedFile) WriteBuiltinWrapper(out io.Writer) error {
+	return pf.writeWrapper(out, pf.node.Name.Name, pf.ContractName())
+}
+
+func (pf *ParsedFile) writeWrapper(out io.Writer, packageName string, builtinName string) error {
 	tmpl, err := openTemplate("templates/wrapper.go.tpl")
 	if err != nil {
 		return errors.Wrap(err, "couldn't open template file for wrapper")
 	}
 
+	imports := pf.generateImports(true)
+	if builtinName != "" {
+		imports[fmt.Sprintf(`"%s"`, builtinPath)] = true
+	}
+
+	functions := pf.constructors[pf.contract]
+	if pf.migrate != nil {
+		functions = append(functions, pf.migrate)
+	}
+
 	data := map[string]interface{}{
 		"PackageName":    packageName,
+		"BuiltinName":    builtinName,
 		"ContractType":   pf.contract,
 		"Methods":        pf.functionInfoForWrapper(pf.methods[pf.contract]),
-		"Functions":      pf.functionInfoForWrapper(pf.constructors[pf.contract]),
+		"Functions":      pf.functionInfoForWrapper(functions),
+		"HasMigrate":     pf.migrate != nil,
 		"ParsedCode":     pf.code,
 		"FoundationPath": foundationPath,
-		"Imports":        pf.generateImports(true),
+		"Imports":        imports,
 	}
-	err = tmpl.Execute(out, data)
+
+	var buff bytes.Buffer
+
+	err = tmpl.Execute(&buff, data)
 	if err != nil {
 		return errors.Wrap(err, "couldn't write code output handle")
 	}
 
+	fmtOut, err := format.Source(buff.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "couldn't format code")
+	}
+
+	_, err = out.Write(fmtOut)
+	if err != nil {
+		return errors.Wrap(err, "couldn't write code to output")
+	}
+
 	return nil
 }
 
@@ -257,6 +326,7 @@ func (pf *ParsedFile) functionInfoForWrapper(list []*ast.FuncDecl) []map[string]
 			"Arguments":           numberedVars(fun.Type.Params, "args"),
 			"Results":             numberedVars(fun.Type.Results, "ret"),
 			"ErrorInterfaceInRes": typeIndexes(pf, fun.Type.Results, "error"),
+			"Immutable":           isImmutable(fun),
 		}
 		res = append(res, info)
 	}
@@ -436,6 +506,19 @@ func typeIndexes(parsed *ParsedFile, list *ast.FieldList, t string) []int {
 	return rets
 }
 
+// isImmutable checks that function is marked with immutable directive
+func isImmutable(fd *ast.FuncDecl) bool {
+	if fd.Doc == nil {
+		return false
+	}
+	for _, c := range fd.Doc.List {
+		if strings.TrimSpace(strings.TrimPrefix(c.Text, "//")) == immutableDirective {
+			return true
+		}
+	}
+	return false
+}
+
 func isContractTypeSpec(typeNode *ast.TypeSpec) bool {
 	baseContract := "foundation.BaseContract"
 	st, ok := typeNode.Type.(*ast.StructType)
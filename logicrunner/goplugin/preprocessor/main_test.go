@@ -216,6 +216,96 @@ func New() *One {
 	s.Error(err)
 }
 
+func (s *PreprocessorSuite) TestMigrateParsing() {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	s.NoError(err)
+	defer os.RemoveAll(tmpDir) // nolint: errcheck
+
+	code := `
+package main
+
+type One struct {
+	foundation.BaseContract
+}
+
+func Migrate(oldMemory []byte) (*One, error) {
+	return &One{}, nil
+}
+`
+
+	err = goplugintestutils.WriteFile(tmpDir, "code1", code)
+	s.NoError(err)
+
+	info, err := ParseFile(filepath.Join(tmpDir, "code1"))
+	s.NoError(err)
+	s.NotNil(info.migrate)
+	s.Equal(0, len(info.constructors))
+
+	var bufWrapper bytes.Buffer
+	err = info.WriteWrapper(&bufWrapper)
+	s.NoError(err)
+	s.Contains(bufWrapper.String(), "func INSCONSTRUCTOR_Migrate(")
+	s.Contains(bufWrapper.String(), "Migrate(args0)")
+	s.NotContains(bufWrapper.String(), "return args0, nil")
+
+	code = `
+package main
+
+type One struct {
+	foundation.BaseContract
+}
+`
+
+	err = goplugintestutils.WriteFile(tmpDir, "code1", code)
+	s.NoError(err)
+
+	info, err = ParseFile(filepath.Join(tmpDir, "code1"))
+	s.NoError(err)
+	s.Nil(info.migrate)
+
+	bufWrapper.Reset()
+	err = info.WriteWrapper(&bufWrapper)
+	s.NoError(err)
+	s.Contains(bufWrapper.String(), "func INSCONSTRUCTOR_Migrate(")
+	s.Contains(bufWrapper.String(), "return args0, nil")
+
+	code = `
+package main
+
+type One struct {
+	foundation.BaseContract
+}
+
+func Migrate(oldMemory string) (*One, error) {
+	return &One{}, nil
+}
+`
+
+	err = goplugintestutils.WriteFile(tmpDir, "code1", code)
+	s.NoError(err)
+
+	_, err = ParseFile(filepath.Join(tmpDir, "code1"))
+	s.EqualError(err, `: Function "Migrate" should accept exactly one '[]byte' argument`)
+
+	code = `
+package main
+
+type One struct {
+	foundation.BaseContract
+}
+
+func Migrate(oldMemory []byte) *One {
+	return &One{}
+}
+`
+
+	err = goplugintestutils.WriteFile(tmpDir, "code1", code)
+	s.NoError(err)
+
+	_, err = ParseFile(filepath.Join(tmpDir, "code1"))
+	s.EqualError(err, `: Function "Migrate" should return '*One' and 'error'`)
+}
+
 func (s *PreprocessorSuite) TestCompileContractProxy() {
 
 	tmpDir, err := ioutil.TempDir("", "test-")
@@ -589,6 +679,72 @@ func ( A ) Get() (path.SomeValue, error) {
 	s.NotContains(bufWrapper.String(), `"some/test/import/path"`)
 }
 
+func (s *PreprocessorSuite) TestImmutableMethod() {
+	tmpDir, err := ioutil.TempDir("", "test-")
+	s.NoError(err)
+	defer os.RemoveAll(tmpDir)
+
+	testContract := "/test.go"
+	err = goplugintestutils.WriteFile(tmpDir, testContract, `
+package main
+import (
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type A struct{
+	foundation.BaseContract
+	N int
+}
+
+// Get returns N
+// ins:immutable
+func (a *A) Get() (int, error) {
+	return a.N, nil
+}
+
+func (a *A) Inc() error {
+	a.N++
+	return nil
+}
+`)
+	s.NoError(err)
+
+	parsed, err := ParseFile(tmpDir + testContract)
+	s.NoError(err)
+
+	var bufWrapper bytes.Buffer
+	err = parsed.WriteWrapper(&bufWrapper)
+	s.NoError(err)
+	s.Contains(bufWrapper.String(), "var INSATTR_Get_Immutable = true")
+	s.NotContains(bufWrapper.String(), "INSATTR_Inc_Immutable")
+
+	var bufBuiltin bytes.Buffer
+	err = parsed.WriteBuiltinWrapper(&bufBuiltin)
+	s.NoError(err)
+	s.Regexp(`Immutable: map\[string\]bool\{\s+"Get": true,\s+\}`, bufBuiltin.String())
+
+	err = goplugintestutils.WriteFile(tmpDir, testContract, `
+package main
+import (
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type A struct{
+	foundation.BaseContract
+}
+
+// ins:immutable
+func NewA() (*A, error) {
+	return &A{}, nil
+}
+`)
+	s.NoError(err)
+
+	_, err = ParseFile(tmpDir + testContract)
+	s.Error(err)
+	s.Contains(err.Error(), "Constructor \"NewA\" can't be immutable")
+}
+
 func (s *PreprocessorSuite) TestNotMatchFileNameForProxy() {
 	tmpDir, err := ioutil.TempDir("", "test-")
 	s.NoError(err)
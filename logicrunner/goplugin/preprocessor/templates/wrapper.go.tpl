@@ -14,7 +14,7 @@
 // limitations under the License.
 //
 
-package main
+package {{ .PackageName }}
 
 import (
     {{- range $import, $i := .Imports }}
@@ -83,6 +83,9 @@ func INSMETHOD_GetPrototype(object []byte, data []byte) ([]byte, []byte, error)
 }
 
 {{ range $method := .Methods }}
+{{ if $method.Immutable }}
+var INSATTR_{{ $method.Name }}_Immutable = true
+{{ end }}
 func INSMETHOD_{{ $method.Name }}(object []byte, data []byte) ([]byte, []byte, error) {
     ph := proxyctx.Current
 
@@ -111,11 +114,16 @@ func INSMETHOD_{{ $method.Name }}(object []byte, data []byte) ([]byte, []byte, e
     self.{{ $method.Name }}( {{ $method.Arguments }} )
 {{ end }}
 
+{{ if $method.Immutable }}
+    // immutable method can't change memory of the object
+    state := object
+{{ else }}
     state := []byte{}
     err = ph.Serialize(self, &state)
     if err != nil {
         return nil, nil, err
     }
+{{ end }}
 
 {{ range $i := $method.ErrorInterfaceInRes }}
     ret{{ $i }} = ph.MakeErrorSerializable(ret{{ $i }})
@@ -158,3 +166,49 @@ func INSCONSTRUCTOR_{{ $f.Name }}(data []byte) ([]byte, error) {
     return ret, err
 }
 {{ end }}
+
+{{ if not .HasMigrate }}
+func INSCONSTRUCTOR_Migrate(data []byte) ([]byte, error) {
+    ph := proxyctx.Current
+    args := [1]interface{}{}
+    var args0 []byte
+    args[0] = &args0
+
+    err := ph.Deserialize(data, &args)
+    if err != nil {
+        e := &ExtendableError{ S: "[ FakeMigrate ] ( INSCONSTRUCTOR_* ) ( Generated Method ) Can't deserialize args.Arguments: " + err.Error() }
+        return nil, e
+    }
+
+    return args0, nil
+}
+{{ end }}
+
+{{ if .BuiltinName }}
+func init() {
+    builtin.Register("{{ .BuiltinName }}", &builtin.Contract{
+        Methods: map[string]builtin.ContractMethod{
+            "GetCode": INSMETHOD_GetCode,
+            "GetPrototype": INSMETHOD_GetPrototype,
+        {{- range $method := .Methods }}
+            "{{ $method.Name }}": INSMETHOD_{{ $method.Name }},
+        {{- end }}
+        },
+        Immutable: map[string]bool{
+        {{- range $method := .Methods }}
+        {{- if $method.Immutable }}
+            "{{ $method.Name }}": true,
+        {{- end }}
+        {{- end }}
+        },
+        Constructors: map[string]builtin.ContractConstructor{
+        {{- range $f := .Functions }}
+            "{{ $f.Name }}": INSCONSTRUCTOR_{{ $f.Name }},
+        {{- end }}
+        {{- if not .HasMigrate }}
+            "Migrate": INSCONSTRUCTOR_Migrate,
+        {{- end }}
+        },
+    })
+}
+{{ end }}
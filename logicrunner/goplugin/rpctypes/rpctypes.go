@@ -17,6 +17,8 @@
 package rpctypes
 
 import (
+	"time"
+
 	"github.com/insolar/insolar/insolar"
 )
 
@@ -37,6 +39,16 @@ type DownCallMethodReq struct { // todo it may use foundation.Context
 type DownCallMethodResp struct {
 	Data []byte
 	Ret  insolar.Arguments
+
+	Usage         insolar.ResourceUsage
+	CPUTime       time.Duration               // wall-clock time spent in contract code, only for metrics
+	LimitExceeded *insolar.ResourceLimitError // set if the call was aborted, Data and Ret are empty then
+	Abandoned     bool                        // aborted contract code is still running, the runner should be recycled
+}
+
+// CodeAbandoned returns true if aborted contract code of the call is left running in the runner
+func (r *DownCallMethodResp) CodeAbandoned() bool {
+	return r.Abandoned
 }
 
 // DownCallConstructorReq is a set of arguments for CallConstructor RPC
@@ -51,8 +63,39 @@ type DownCallConstructorReq struct {
 // DownCallConstructorResp is response from CallConstructor RPC in the runner
 type DownCallConstructorResp struct {
 	Ret insolar.Arguments
+
+	Usage         insolar.ResourceUsage
+	CPUTime       time.Duration               // wall-clock time spent in contract code, only for metrics
+	LimitExceeded *insolar.ResourceLimitError // set if the call was aborted, Ret is empty then
+	Abandoned     bool                        // aborted contract code is still running, the runner should be recycled
+}
+
+// CodeAbandoned returns true if aborted contract code of the call is left running in the runner
+func (r *DownCallConstructorResp) CodeAbandoned() bool {
+	return r.Abandoned
+}
+
+// DownPingReq is a set of arguments for Ping RPC in the runner, it's used to check health of the runner
+type DownPingReq struct {
+}
+
+// DownPingResp is response from Ping RPC in the runner
+type DownPingResp struct {
+}
+
+// DownWarmUpReq is a set of arguments for WarmUp RPC in the runner
+type DownWarmUpReq struct {
+	Code   insolar.Reference
+	Binary []byte
+}
+
+// DownWarmUpResp is response from WarmUp RPC in the runner
+type DownWarmUpResp struct {
 }
 
+// PanicErrorPrefix starts errors of calls panicked in the runner
+const PanicErrorPrefix = "panic in runner: "
+
 // UpBaseReq  is a base type for all insgorund -> logicrunner requests
 type UpBaseReq struct {
 	Mode      string
@@ -151,6 +194,31 @@ type UpGetDelegateResp struct {
 	Object insolar.Reference
 }
 
+// UpEmitEventReq is a set of arguments for EmitEvent RPC in goplugin
+type UpEmitEventReq struct {
+	UpBaseReq
+	Name    string
+	Payload []byte
+}
+
+// UpEmitEventResp is response from EmitEvent RPC in goplugin
+type UpEmitEventResp struct {
+}
+
+// UpUpgradePrototypeReq is a set of arguments for UpgradePrototype RPC in goplugin
+type UpUpgradePrototypeReq struct {
+	UpBaseReq
+	Prototype   insolar.Reference
+	Code        []byte
+	MachineType insolar.MachineType
+	ABI         []byte
+}
+
+// UpUpgradePrototypeResp is response from UpgradePrototype RPC in goplugin
+type UpUpgradePrototypeResp struct {
+	Code insolar.Reference
+}
+
 // UpDeactivateObjectReq is a set of arguments for DeactivateObject RPC in goplugin
 type UpDeactivateObjectReq struct {
 	UpBaseReq
@@ -159,3 +227,16 @@ type UpDeactivateObjectReq struct {
 // UpDeactivateObjectResp is response from DeactivateObject RPC in goplugin
 type UpDeactivateObjectResp struct {
 }
+
+// UpstreamRPC is a set of logicrunner services available to contracts, the same
+// insgorund uses over RPC. Executors running contracts inside of insolard call them in process.
+type UpstreamRPC interface {
+	RouteCall(req UpRouteReq, rep *UpRouteResp) error
+	SaveAsChild(req UpSaveAsChildReq, rep *UpSaveAsChildResp) error
+	SaveAsDelegate(req UpSaveAsDelegateReq, rep *UpSaveAsDelegateResp) error
+	GetObjChildrenIterator(req UpGetObjChildrenIteratorReq, rep *UpGetObjChildrenIteratorResp) error
+	GetDelegate(req UpGetDelegateReq, rep *UpGetDelegateResp) error
+	DeactivateObject(req UpDeactivateObjectReq, rep *UpDeactivateObjectResp) error
+	EmitEvent(req UpEmitEventReq, rep *UpEmitEventResp) error
+	UpgradePrototype(req UpUpgradePrototypeReq, rep *UpUpgradePrototypeResp) error
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/insolar/insolar/instrumentation/instracer"
 
 	"github.com/pkg/errors"
+	uuid "github.com/satori/go.uuid"
 
 	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
@@ -41,10 +42,14 @@ import (
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/logicrunner/builtin"
 	"github.com/insolar/insolar/logicrunner/goplugin"
+	"github.com/insolar/insolar/logicrunner/wasm"
 )
 
 const maxQueueLength = 10
 
+// migrateFunctionName is a name of contract function converting memory of the previous code version.
+const migrateFunctionName = "Migrate"
+
 type Ref = insolar.Reference
 
 // Context of one contract execution
@@ -64,6 +69,7 @@ type CurrentExecution struct {
 	RequesterNode *Ref
 	ReturnMode    message.MethodReturnMode
 	SentResult    bool
+	Events        []insolar.ContractEvent // emitted by contract during the execution
 }
 
 type ExecutionQueueResult struct {
@@ -146,6 +152,9 @@ type LogicRunner struct {
 	state      map[Ref]*ObjectState // if object exists, we are validating or executing it right now
 	stateMutex sync.RWMutex
 
+	queries      map[Ref]*ExecutionState // immutable method calls in progress by their query references
+	queriesMutex sync.RWMutex
+
 	sock net.Listener
 }
 
@@ -155,8 +164,9 @@ func NewLogicRunner(cfg *configuration.LogicRunner) (*LogicRunner, error) {
 		return nil, errors.New("LogicRunner have nil configuration")
 	}
 	res := LogicRunner{
-		Cfg:   cfg,
-		state: make(map[Ref]*ObjectState),
+		Cfg:     cfg,
+		state:   make(map[Ref]*ObjectState),
+		queries: make(map[Ref]*ExecutionState),
 	}
 	return &res, nil
 }
@@ -164,13 +174,21 @@ func NewLogicRunner(cfg *configuration.LogicRunner) (*LogicRunner, error) {
 // Start starts logic runner component
 func (lr *LogicRunner) Start(ctx context.Context) error {
 	if lr.Cfg.BuiltIn != nil {
-		bi := builtin.NewBuiltIn(lr.MessageBus, lr.ArtifactManager)
+		bi := builtin.NewBuiltIn(lr.MessageBus, lr.ArtifactManager, &RPC{lr: lr, ps: lr.PulseStorage})
 		if err := lr.RegisterExecutor(insolar.MachineTypeBuiltin, bi); err != nil {
 			return err
 		}
 		lr.machinePrefs = append(lr.machinePrefs, insolar.MachineTypeBuiltin)
 	}
 
+	if lr.Cfg.WASM != nil {
+		w := wasm.NewWASM(lr.Cfg.WASM, lr.ArtifactManager, &RPC{lr: lr, ps: lr.PulseStorage})
+		if err := lr.RegisterExecutor(insolar.MachineTypeWASM, w); err != nil {
+			return err
+		}
+		lr.machinePrefs = append(lr.machinePrefs, insolar.MachineTypeWASM)
+	}
+
 	if lr.Cfg.GoPlugin != nil {
 		if lr.Cfg.RPCListen != "" {
 			StartRPC(ctx, lr, lr.PulseStorage)
@@ -275,6 +293,10 @@ func (lr *LogicRunner) Execute(ctx context.Context, parcel insolar.Parcel) (inso
 	)
 	defer span.End()
 
+	if m, ok := msg.(*message.CallMethod); ok && m.Immutable {
+		return lr.executeQuery(ctx, m)
+	}
+
 	rep, err := lr.executeActual(ctx, parcel, msg)
 	return rep, err
 }
@@ -568,6 +590,7 @@ func (lr *LogicRunner) executeOrValidate(
 		Pulse:           *lr.pulse(ctx),
 		TraceID:         inslogger.TraceID(ctx),
 		CallerPrototype: msg.GetCallerPrototype(),
+		Limits:          lr.resourceLimits(),
 	}
 
 	var re insolar.Reply
@@ -825,6 +848,11 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 		return nil, es.WrapError(err, "no executor registered")
 	}
 
+	err = lr.migrateObject(ctx, es, executor, current.LogicContext, *current.Request)
+	if err != nil {
+		return nil, es.WrapError(err, "couldn't migrate object")
+	}
+
 	newData, result, err := executor.CallMethod(
 		ctx, current.LogicContext, *es.objectbody.CodeRef, es.objectbody.Object, m.Method, m.Arguments,
 	)
@@ -841,7 +869,9 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 			return nil, es.WrapError(err, "couldn't deactivate object")
 		}
 	} else if !bytes.Equal(es.objectbody.Object, newData) {
-		od, err := am.UpdateObject(ctx, Ref{}, *current.Request, es.objectbody.objDescriptor, newData)
+		od, err := am.UpdateObject(
+			ctx, Ref{}, *current.Request, es.objectbody.objDescriptor, newData, es.objectbody.CodeRef,
+		)
 		if err != nil {
 			if strings.Contains(err.Error(), "invalid state record") {
 				es.objectbody = nil
@@ -850,7 +880,7 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 		}
 		es.objectbody.objDescriptor = od
 	}
-	_, err = am.RegisterResult(ctx, m.ObjectRef, *current.Request, result)
+	_, err = am.RegisterResult(ctx, m.ObjectRef, *current.Request, result, current.LogicContext.Usage, es.Current.Events)
 	if err != nil {
 		return nil, es.WrapError(err, "couldn't save results")
 	}
@@ -860,6 +890,144 @@ func (lr *LogicRunner) executeMethodCall(ctx context.Context, es *ExecutionState
 	return &reply.CallMethod{Result: result, Request: *current.Request}, nil
 }
 
+// executeQuery runs immutable method on the latest state of the object. Call isn't queued, its request
+// and result are not registered on ledger and the method is not allowed to change memory of the object.
+func (lr *LogicRunner) executeQuery(ctx context.Context, m *message.CallMethod) (insolar.Reply, error) {
+	ctx, span := instracer.StartSpan(ctx, "LogicRunner.executeQuery")
+	defer span.End()
+
+	err := lr.CheckOurRole(ctx, m, insolar.DynamicRoleVirtualExecutor)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ executeQuery ] can't play role")
+	}
+
+	objDesc, protoDesc, codeDesc, err := lr.getDescriptorsByObjectRef(ctx, m.ObjectRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ executeQuery ] couldn't get descriptors by object reference")
+	}
+	if !m.ProxyPrototype.IsEmpty() && !m.ProxyPrototype.Equal(*protoDesc.HeadRef()) {
+		return nil, errors.New("proxy call error: try to call method of prototype as method of another prototype")
+	}
+
+	executor, err := lr.GetExecutor(codeDesc.MachineType())
+	if err != nil {
+		return nil, errors.Wrap(err, "[ executeQuery ] no executor registered")
+	}
+
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ executeQuery ] can't generate query ID")
+	}
+	pulse := lr.pulse(ctx)
+	ref := m.ObjectRef
+	query := m.ObjectRef
+	query.SetRecord(*insolar.NewID(pulse.PulseNumber, id.Bytes()))
+
+	es := &ExecutionState{
+		Ref: ref,
+		objectbody: &ObjectBody{
+			objDescriptor:   objDesc,
+			Object:          objDesc.Memory(),
+			Prototype:       protoDesc.HeadRef(),
+			CodeMachineType: codeDesc.MachineType(),
+			CodeRef:         codeDesc.Ref(),
+			Parent:          objDesc.Parent(),
+		},
+		Current: &CurrentExecution{
+			Context: ctx,
+			LogicContext: &insolar.LogicCallContext{
+				Mode:            insolar.QueryMode,
+				Caller:          m.GetCaller(),
+				Callee:          &ref,
+				Request:         &query,
+				Prototype:       protoDesc.HeadRef(),
+				Code:            codeDesc.Ref(),
+				Parent:          objDesc.Parent(),
+				Time:            time.Now(),
+				Pulse:           *pulse,
+				TraceID:         inslogger.TraceID(ctx),
+				CallerPrototype: m.GetCallerPrototype(),
+				Limits:          lr.resourceLimits(),
+			},
+			ReturnMode: m.ReturnMode,
+		},
+	}
+	lr.addQuery(query, es)
+	defer lr.removeQuery(query)
+
+	callCtx := es.Current.LogicContext
+	memory := es.objectbody.Object
+	if version := objDesc.Version(); version != nil && !version.Equal(*codeDesc.Ref()) {
+		// object will be migrated on its next mutable call, here migrated memory is used only for the query
+		args, err := insolar.MarshalArgs(memory)
+		if err != nil {
+			return nil, es.WrapError(err, "couldn't marshal object memory")
+		}
+		memory, err = executor.CallConstructor(ctx, callCtx, *codeDesc.Ref(), migrateFunctionName, args)
+		if err != nil {
+			return nil, es.WrapError(err, "migration failed")
+		}
+	}
+
+	// State returned by the query is discarded, it may differ from the stored one byte-wise even if it's not
+	// changed. Executors run only methods marked as immutable in query mode: "ins:immutable" methods of Go
+	// plugins and builtin contracts, methods with INSATTR_<Name>_Immutable export of WebAssembly modules.
+	_, result, err := executor.CallMethod(ctx, callCtx, *codeDesc.Ref(), memory, m.Method, m.Arguments)
+	if err != nil {
+		return nil, es.WrapError(err, "executor error")
+	}
+
+	return &reply.CallMethod{Result: result}, nil
+}
+
+func (lr *LogicRunner) resourceLimits() insolar.ResourceLimits {
+	return insolar.ResourceLimits{
+		Upcalls:    lr.Cfg.Limits.Upcalls,
+		StateBytes: lr.Cfg.Limits.StateBytes,
+		CPUTime:    lr.Cfg.Limits.CPUTime,
+	}
+}
+
+// migrateObject converts object memory produced by previous code version of the prototype. Memory is passed
+// to the Migrate function of the current code, result is saved with the current code as memory version.
+func (lr *LogicRunner) migrateObject(
+	ctx context.Context,
+	es *ExecutionState,
+	executor insolar.MachineLogicExecutor,
+	callCtx *insolar.LogicCallContext,
+	request Ref,
+) error {
+	body := es.objectbody
+	version := body.objDescriptor.Version()
+	if version == nil || version.Equal(*body.CodeRef) {
+		return nil
+	}
+
+	inslogger.FromContext(ctx).Infof(
+		"migrating object %s from code %s to %s", body.objDescriptor.HeadRef(), version, body.CodeRef,
+	)
+
+	args, err := insolar.MarshalArgs(body.Object)
+	if err != nil {
+		return errors.Wrap(err, "couldn't marshal object memory")
+	}
+	newData, err := executor.CallConstructor(ctx, callCtx, *body.CodeRef, migrateFunctionName, args)
+	if err != nil {
+		return errors.Wrap(err, "migration failed")
+	}
+
+	od, err := lr.ArtifactManager.UpdateObject(ctx, Ref{}, request, body.objDescriptor, newData, body.CodeRef)
+	if err != nil {
+		if strings.Contains(err.Error(), "invalid state record") {
+			es.objectbody = nil
+		}
+		return errors.Wrap(err, "couldn't update object")
+	}
+	body.objDescriptor = od
+	body.Object = newData
+	return nil
+}
+
 func (lr *LogicRunner) getDescriptorsByPrototypeRef(
 	ctx context.Context, protoRef Ref,
 ) (
@@ -931,6 +1099,10 @@ func (lr *LogicRunner) executeConstructorCall(
 		return nil, es.WrapError(err, "no executer registered")
 	}
 
+	if m.Method == migrateFunctionName {
+		return nil, es.WrapError(nil, "Migrate can't be called as constructor")
+	}
+
 	newData, err := executor.CallConstructor(ctx, current.LogicContext, *codeDesc.Ref(), m.Method, m.Arguments)
 	if err != nil {
 		return nil, es.WrapError(err, "executer error")
@@ -941,11 +1113,14 @@ func (lr *LogicRunner) executeConstructorCall(
 		_, err = lr.ArtifactManager.ActivateObject(
 			ctx,
 			Ref{}, *current.Request, m.ParentRef, m.PrototypeRef, m.SaveAs == message.Delegate, newData,
+			codeDesc.Ref(),
 		)
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't activate object")
 		}
-		_, err = lr.ArtifactManager.RegisterResult(ctx, *current.Request, *current.Request, nil)
+		_, err = lr.ArtifactManager.RegisterResult(
+			ctx, *current.Request, *current.Request, nil, current.LogicContext.Usage, es.Current.Events,
+		)
 		if err != nil {
 			return nil, es.WrapError(err, "couldn't save results")
 		}
@@ -956,6 +1131,29 @@ func (lr *LogicRunner) executeConstructorCall(
 	}
 }
 
+// IsDrained implements insolar.Drainer, returns true if there are no executions in progress or in queue
+func (lr *LogicRunner) IsDrained(ctx context.Context) bool {
+	lr.stateMutex.RLock()
+	defer lr.stateMutex.RUnlock()
+
+	for _, state := range lr.state {
+		state.Lock()
+		es := state.ExecutionState
+		state.Unlock()
+		if es == nil {
+			continue
+		}
+
+		es.Lock()
+		busy := es.Current != nil || len(es.Queue) > 0
+		es.Unlock()
+		if busy {
+			return false
+		}
+	}
+	return true
+}
+
 func (lr *LogicRunner) OnPulse(ctx context.Context, pulse insolar.Pulse) error {
 	lr.stateMutex.Lock()
 
@@ -1152,6 +1152,7 @@ func (s *LogicRunnerFuncSuite) TestRootDomainContractError() {
 		*cb.Prototypes["rootdomain"],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), nil),
+		cb.Codes["rootdomain"],
 	)
 	s.NoError(err, "create contract")
 	s.NotEqual(rootDomainRef, nil, "contract created")
@@ -1187,11 +1188,12 @@ func (s *LogicRunnerFuncSuite) TestRootDomainContractError() {
 		*cb.Prototypes["member"],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), m),
+		cb.Codes["member"],
 	)
 	s.NoError(err)
 
 	// Updating root domain with root member
-	_, err = am.UpdateObject(ctx, insolar.Reference{}, insolar.Reference{}, rootDomainDesc, goplugintestutils.CBORMarshal(s.T(), rootdomain.RootDomain{RootMember: *rootMemberRef}))
+	_, err = am.UpdateObject(ctx, insolar.Reference{}, insolar.Reference{}, rootDomainDesc, goplugintestutils.CBORMarshal(s.T(), rootdomain.RootDomain{RootMember: *rootMemberRef}), nil)
 	s.NoError(err)
 
 	csRoot := cryptography.NewKeyBoundCryptographyService(rootKey)
@@ -1523,6 +1525,7 @@ func (r *One) CreateAllowance(member string) (error) {
 		*cb.Prototypes["rootdomain"],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), nil),
+		cb.Codes["rootdomain"],
 	)
 	s.NoError(err, "create contract")
 	s.NotEqual(rootDomainRef, nil, "contract created")
@@ -1556,11 +1559,12 @@ func (r *One) CreateAllowance(member string) (error) {
 		*cb.Prototypes["member"],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), m),
+		cb.Codes["member"],
 	)
 	s.NoError(err)
 
 	// Updating root domain with root member
-	_, err = am.UpdateObject(ctx, insolar.Reference{}, insolar.Reference{}, rootDomainDesc, goplugintestutils.CBORMarshal(s.T(), rootdomain.RootDomain{RootMember: *rootMemberRef}))
+	_, err = am.UpdateObject(ctx, insolar.Reference{}, insolar.Reference{}, rootDomainDesc, goplugintestutils.CBORMarshal(s.T(), rootdomain.RootDomain{RootMember: *rootMemberRef}), nil)
 	s.NoError(err)
 
 	cs := cryptography.NewKeyBoundCryptographyService(rootKey)
@@ -1590,6 +1594,7 @@ func (r *One) CreateAllowance(member string) (error) {
 		*cb.Prototypes["one"],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), nil),
+		cb.Codes["one"],
 	)
 	s.NoError(err, "create contract")
 	s.NotEqual(contract, nil, "contract created")
@@ -2044,6 +2049,164 @@ func (c *First) GetName() (string, error) {
 	s.Equal(map[interface{}]interface{}(map[interface{}]interface{}{"S": "[ RouteCall ] on calling main API: proxy call error: try to call method of prototype as method of another prototype"}), res[1])
 }
 
+func executeQuery(
+	ctx context.Context, lr insolar.LogicRunner,
+	objRef insolar.Reference, proxyPrototype insolar.Reference,
+	method string, arguments ...interface{},
+) (
+	insolar.Reply, error,
+) {
+	ctx = inslogger.ContextWithTrace(ctx, utils.RandTraceID())
+
+	argsSerialized, err := insolar.Serialize(arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	rlr := lr.(*LogicRunner)
+
+	bm := message.BaseLogicMessage{
+		Caller: testutils.RandomRef(),
+	}
+
+	return rlr.ContractRequester.CallQuery(ctx, &bm, &objRef, method, argsSerialized, &proxyPrototype)
+}
+
+func (s *LogicRunnerFuncSuite) TestImmutableQueryError() {
+	if parallel {
+		s.T().Parallel()
+	}
+	var contractOneCode = `
+package main
+
+import (
+	"github.com/insolar/insolar/application/proxy/two"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/foundation"
+)
+
+type One struct {
+	foundation.BaseContract
+	Number int
+}
+
+func (c *One) Inc() (int, error) {
+	c.Number++
+	return c.Number, nil
+}
+
+// ins:immutable
+func (c *One) Get() (int, error) {
+	return c.Number, nil
+}
+
+// ins:immutable
+func (c *One) Cheat() (int, error) {
+	c.Number += 100
+	return c.Number, nil
+}
+
+// ins:immutable
+func (c *One) NewChild() error {
+	_, err := two.New().AsChild(c.GetReference())
+	return err
+}
+
+// ins:immutable
+func (c *One) Sum(ref insolar.Reference) (int, error) {
+	n, err := two.GetObject(ref).Get()
+	return c.Number + n, err
+}
+
+// ins:immutable
+func (c *One) IncTwo(ref insolar.Reference) (int, error) {
+	return two.GetObject(ref).Inc()
+}
+`
+	var contractTwoCode = `
+package main
+
+import "github.com/insolar/insolar/logicrunner/goplugin/foundation"
+
+type Two struct {
+	foundation.BaseContract
+	Number int
+}
+
+func New() (*Two, error) {
+	return &Two{Number: 10}, nil
+}
+
+// ins:immutable
+func (c *Two) Get() (int, error) {
+	return c.Number, nil
+}
+
+func (c *Two) Inc() (int, error) {
+	c.Number++
+	return c.Number, nil
+}
+`
+	ctx := context.Background()
+
+	lr, am, cb, pm, cleaner := s.PrepareLrAmCbPm()
+	defer cleaner()
+
+	err := cb.Build(map[string]string{"one": contractOneCode, "two": contractTwoCode})
+	s.NoError(err)
+
+	obj, prototype := s.getObjectInstance(ctx, am, cb, "one")
+	twoObj, twoPrototype := s.getObjectInstance(ctx, am, cb, "two")
+
+	resp, err := executeMethod(ctx, lr, pm, *obj, *prototype, 0, "Inc")
+	s.NoError(err, "contract call")
+	s.Equal(uint64(1), firstMethodRes(s.T(), resp))
+
+	desc, err := am.GetObject(ctx, *obj, nil, false)
+	s.NoError(err)
+	state := desc.StateID()
+
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "Get")
+	s.NoError(err, "query")
+	s.Equal(uint64(1), firstMethodRes(s.T(), resp))
+	s.True(resp.(*reply.CallMethod).Request.IsEmpty(), "request of query is not registered")
+
+	// changes made by immutable method are not saved
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "Cheat")
+	s.NoError(err, "query")
+	s.Equal(uint64(101), firstMethodRes(s.T(), resp))
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "Get")
+	s.NoError(err, "query")
+	s.Equal(uint64(1), firstMethodRes(s.T(), resp))
+
+	// immutable method calls immutable method of another object as query
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "Sum", *twoObj)
+	s.NoError(err, "query")
+	s.Equal(uint64(1), firstMethodRes(s.T(), resp))
+
+	desc, err = am.GetObject(ctx, *obj, nil, false)
+	s.NoError(err)
+	s.Equal(state, desc.StateID(), "queries don't change state of the object")
+
+	_, err = executeQuery(ctx, lr, *obj, *prototype, "Inc")
+	s.Error(err)
+	s.Contains(err.Error(), "Calling non immutable method Inc as query")
+
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "NewChild")
+	s.NoError(err, "query")
+	res := goplugintestutils.CBORUnMarshal(s.T(), resp.(*reply.CallMethod).Result).([]interface{})
+	s.Contains(res[0].(map[interface{}]interface{})["S"], "SaveAsChild is not allowed in immutable method")
+
+	resp, err = executeQuery(ctx, lr, *obj, *prototype, "IncTwo", *twoObj)
+	s.NoError(err, "query")
+	res = goplugintestutils.CBORUnMarshal(s.T(), resp.(*reply.CallMethod).Result).([]interface{})
+	s.Contains(res[1].(map[interface{}]interface{})["S"], "Calling non immutable method Inc as query")
+
+	resp, err = executeMethod(ctx, lr, pm, *twoObj, *twoPrototype, 0, "Get")
+	s.NoError(err, "contract call")
+	s.Equal(uint64(0), firstMethodRes(s.T(), resp))
+}
+
 func (s *LogicRunnerFuncSuite) getObjectInstance(ctx context.Context, am artifacts.Client, cb *goplugintestutils.ContractsBuilder, contractName string) (*insolar.Reference, *insolar.Reference) {
 	domain, err := insolar.NewReferenceFromBase58("4K3NiGuqYGqKPnYp6XeGd2kdN4P9veL6rYcWkLKWXZCu.7ZQboaH24PH42sqZKUvoa7UBrpuuubRtShp6CKNuWGZa")
 	s.Require().NoError(err)
@@ -2063,6 +2226,7 @@ func (s *LogicRunnerFuncSuite) getObjectInstance(ctx context.Context, am artifac
 		*cb.Prototypes[contractName],
 		false,
 		goplugintestutils.CBORMarshal(s.T(), nil),
+		cb.Codes[contractName],
 	)
 	s.NoError(err, "create contract")
 	s.NotEqual(objectRef, nil, "contract created")
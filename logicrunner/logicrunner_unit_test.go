@@ -36,6 +36,7 @@ import (
 	"github.com/insolar/insolar/insolar/message"
 	"github.com/insolar/insolar/insolar/reply"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
 	"github.com/insolar/insolar/testutils"
 	"github.com/insolar/insolar/testutils/network"
 )
@@ -519,6 +520,27 @@ func (suite *LogicRunnerTestSuite) TestHandleStillExecutingMessage() {
 	suite.Equal(true, suite.lr.state[objectRef].ExecutionState.PendingConfirmed)
 }
 
+func (suite *LogicRunnerTestSuite) TestIsDrained() {
+	objectRef := testutils.RandomRef()
+	defer delete(suite.lr.state, objectRef)
+
+	suite.True(suite.lr.IsDrained(suite.ctx))
+
+	suite.lr.state[objectRef] = &ObjectState{
+		ExecutionState: &ExecutionState{
+			Queue: make([]ExecutionQueueElement, 1),
+		},
+	}
+	suite.False(suite.lr.IsDrained(suite.ctx))
+
+	suite.lr.state[objectRef].ExecutionState.Queue = nil
+	suite.lr.state[objectRef].ExecutionState.Current = &CurrentExecution{}
+	suite.False(suite.lr.IsDrained(suite.ctx))
+
+	suite.lr.state[objectRef].ExecutionState.Current = nil
+	suite.True(suite.lr.IsDrained(suite.ctx))
+}
+
 func (suite *LogicRunnerTestSuite) TestReleaseQueue() {
 	tests := map[string]struct {
 		QueueLength     int
@@ -543,13 +565,15 @@ func (suite *LogicRunnerTestSuite) TestReleaseQueue() {
 }
 
 func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
-	suite.am.UpdateObjectMock.Return(nil, nil)
-
 	randRef := testutils.RandomRef()
 
+	od := artifacts.NewObjectDescriptorMock(suite.mc)
+	od.VersionMock.Return(&randRef)
+	suite.am.UpdateObjectMock.Return(od, nil)
+
 	es := &ExecutionState{Queue: make([]ExecutionQueueElement, 0)}
 	es.Queue = append(es.Queue, ExecutionQueueElement{})
-	es.objectbody = &ObjectBody{}
+	es.objectbody = &ObjectBody{objDescriptor: od}
 	es.objectbody.CodeMachineType = insolar.MachineTypeBuiltin
 	es.Current = &CurrentExecution{}
 	es.Current.LogicContext = &insolar.LogicCallContext{}
@@ -584,6 +608,79 @@ func (suite *LogicRunnerTestSuite) TestNoExcessiveAmends() {
 	suite.Require().Equal(uint64(1), suite.am.UpdateObjectCounter)
 }
 
+func (suite *LogicRunnerTestSuite) TestMigrateObject() {
+	oldCode := testutils.RandomRef()
+	newCode := testutils.RandomRef()
+	objRef := testutils.RandomRef()
+	request := testutils.RandomRef()
+
+	oldData := []byte(testutils.RandomString())
+	migratedData := []byte(testutils.RandomString())
+
+	od := artifacts.NewObjectDescriptorMock(suite.mc)
+	od.VersionMock.Return(&oldCode)
+	od.HeadRefMock.Return(&objRef)
+
+	migrated := artifacts.NewObjectDescriptorMock(suite.mc)
+	migrated.VersionMock.Return(&newCode)
+
+	es := &ExecutionState{Queue: make([]ExecutionQueueElement, 0)}
+	es.objectbody = &ObjectBody{
+		objDescriptor:   od,
+		Object:          oldData,
+		CodeRef:         &newCode,
+		CodeMachineType: insolar.MachineTypeBuiltin,
+	}
+	es.Current = &CurrentExecution{
+		LogicContext: &insolar.LogicCallContext{},
+		Request:      &request,
+	}
+
+	expectedArgs, err := insolar.MarshalArgs(oldData)
+	suite.Require().NoError(err)
+
+	mle := testutils.NewMachineLogicExecutorMock(suite.mc)
+	suite.lr.Executors[insolar.MachineTypeBuiltin] = mle
+	mle.CallConstructorMock.Set(func(
+		ctx context.Context, callCtx *insolar.LogicCallContext, code insolar.Reference, name string, args insolar.Arguments,
+	) ([]byte, error) {
+		suite.Require().Equal(newCode, code)
+		suite.Require().Equal(migrateFunctionName, name)
+		suite.Require().Equal(expectedArgs, args)
+		return migratedData, nil
+	})
+	mle.CallMethodMock.Set(func(
+		ctx context.Context, callCtx *insolar.LogicCallContext, code insolar.Reference, data []byte, method string, args insolar.Arguments,
+	) ([]byte, insolar.Arguments, error) {
+		suite.Require().Equal(migratedData, data)
+		return data, nil, nil
+	})
+	suite.am.UpdateObjectMock.Set(func(
+		ctx context.Context, domain insolar.Reference, req insolar.Reference, obj artifacts.ObjectDescriptor, memory []byte, code *insolar.Reference,
+	) (artifacts.ObjectDescriptor, error) {
+		suite.Require().Equal(od, obj)
+		suite.Require().Equal(migratedData, memory)
+		suite.Require().Equal(&newCode, code)
+		return migrated, nil
+	})
+	suite.am.RegisterResultMock.Return(nil, nil)
+
+	msg := &message.CallMethod{ObjectRef: objRef, Method: "some"}
+
+	// object is stored by old code, so it's migrated before the call
+	_, err = suite.lr.executeMethodCall(suite.ctx, es, msg)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), mle.CallConstructorCounter)
+	suite.Require().Equal(uint64(1), suite.am.UpdateObjectCounter)
+	suite.Require().Equal(migratedData, es.objectbody.Object)
+
+	// object is already migrated
+	_, err = suite.lr.executeMethodCall(suite.ctx, es, msg)
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint64(1), mle.CallConstructorCounter)
+	suite.Require().Equal(uint64(1), suite.am.UpdateObjectCounter)
+}
+
 func (suite *LogicRunnerTestSuite) TestHandleAbandonedRequestsNotificationMessage() {
 	objectId := testutils.RandomID()
 	msg := &message.AbandonedRequestsNotification{Object: objectId}
@@ -726,6 +823,7 @@ func (suite *LogicRunnerTestSuite) TestConcurrency() {
 	od.MemoryMock.Return([]byte{1, 2, 3})
 	od.ParentMock.Return(&parentRef)
 	od.HeadRefMock.Return(&objectRef)
+	od.VersionMock.Return(&codeRef)
 
 	pd := artifacts.NewObjectDescriptorMock(suite.T())
 	pd.CodeMock.Return(&codeRef, nil)
@@ -952,6 +1050,7 @@ func (suite *LogicRunnerTestSuite) TestCallMethodWithOnPulse() {
 				od.MemoryMock.Return([]byte{1, 2, 3})
 				od.ParentMock.Return(&parentRef)
 				od.HeadRefMock.Return(&objectRef)
+				od.VersionMock.Return(&codeRef)
 
 				pd := artifacts.NewObjectDescriptorMock(suite.T())
 				pd.CodeMock.Return(&codeRef, nil)
@@ -976,7 +1075,8 @@ func (suite *LogicRunnerTestSuite) TestCallMethodWithOnPulse() {
 				suite.am.GetCodeMock.Return(cd, nil)
 
 				suite.am.RegisterResultFunc = func(
-					ctx context.Context, r1 insolar.Reference, r2 insolar.Reference, mem []byte,
+					ctx context.Context, r1 insolar.Reference, r2 insolar.Reference, mem []byte, usage insolar.ResourceUsage,
+					events []insolar.ContractEvent,
 				) (*insolar.ID, error) {
 					resId := testutils.RandomID()
 					return &resId, nil
@@ -1429,3 +1529,22 @@ func (s LRUnsafeGetLedgerPendingRequestTestSuite) TestUnsafeGetLedgerPendingRequ
 	s.Require().Equal(true, es.LedgerHasMoreRequests)
 	s.Require().Equal(parcel, es.LedgerQueueElement.parcel)
 }
+
+func TestRPC_EmitEvent(t *testing.T) {
+	lr, err := NewLogicRunner(&configuration.LogicRunner{})
+	require.NoError(t, err)
+	callee := testutils.RandomRef()
+	es := &ExecutionState{Current: &CurrentExecution{}}
+	lr.UpsertObjectState(callee).ExecutionState = es
+	gpr := &RPC{lr: lr}
+
+	base := rpctypes.UpBaseReq{Mode: "execution", Callee: callee}
+	err = gpr.EmitEvent(rpctypes.UpEmitEventReq{UpBaseReq: base, Name: "Transfer", Payload: []byte(`{"amount":1}`)}, &rpctypes.UpEmitEventResp{})
+	require.NoError(t, err)
+
+	err = gpr.EmitEvent(rpctypes.UpEmitEventReq{UpBaseReq: base, Name: "Raw", Payload: []byte{0xff, 0x00}}, &rpctypes.UpEmitEventResp{})
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "not valid JSON")
+
+	require.Equal(t, []insolar.ContractEvent{{Name: "Transfer", Payload: []byte(`{"amount":1}`)}}, es.Current.Events)
+}
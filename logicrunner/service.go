@@ -18,6 +18,7 @@ package logicrunner
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"net/rpc"
@@ -79,11 +80,26 @@ func recoverRPC(err *error) {
 	}
 }
 
+// executionState returns state of the execution the upcall is made from
+func (gpr *RPC) executionState(req rpctypes.UpBaseReq) *ExecutionState {
+	if req.Mode == insolar.QueryMode {
+		return gpr.lr.MustQueryState(req.Request)
+	}
+	return gpr.lr.MustObjectState(req.Callee).MustModeState(req.Mode)
+}
+
+// checkMutable returns error for upcalls changing ledger made from immutable methods
+func checkMutable(req rpctypes.UpBaseReq, upcall string) error {
+	if req.Mode == insolar.QueryMode {
+		return errors.Errorf("%s is not allowed in immutable method", upcall)
+	}
+	return nil
+}
+
 // GetCode is an RPC retrieving a code by its reference
 func (gpr *RPC) GetCode(req rpctypes.UpGetCodeReq, reply *rpctypes.UpGetCodeResp) (err error) {
 	defer recoverRPC(&err)
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 	// we don't want to record GetCode messages because of cache
 	ctx = insolar.ContextWithMessageBus(ctx, gpr.lr.MessageBus)
@@ -120,19 +136,33 @@ func MakeBaseMessage(req rpctypes.UpBaseReq, es *ExecutionState) message.BaseLog
 func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (err error) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
-	res, err := gpr.lr.ContractRequester.CallMethod(ctx,
-		&bm,
-		!req.Wait,
-		&req.Object,
-		req.Method,
-		req.Arguments,
-		&req.ProxyPrototype,
-	)
+	var res insolar.Reply
+	if req.Mode == insolar.QueryMode {
+		// immutable method can call only immutable methods of other objects
+		if !req.Wait {
+			return errors.New("call without waiting for results is not allowed in immutable method")
+		}
+		res, err = gpr.lr.ContractRequester.CallQuery(ctx,
+			&bm,
+			&req.Object,
+			req.Method,
+			req.Arguments,
+			&req.ProxyPrototype,
+		)
+	} else {
+		res, err = gpr.lr.ContractRequester.CallMethod(ctx,
+			&bm,
+			!req.Wait,
+			&req.Object,
+			req.Method,
+			req.Arguments,
+			&req.ProxyPrototype,
+		)
+	}
 	if err != nil {
 		return err
 	}
@@ -148,8 +178,12 @@ func (gpr *RPC) RouteCall(req rpctypes.UpRouteReq, rep *rpctypes.UpRouteResp) (e
 func (gpr *RPC) SaveAsChild(req rpctypes.UpSaveAsChildReq, rep *rpctypes.UpSaveAsChildResp) (err error) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	err = checkMutable(req.UpBaseReq, "SaveAsChild")
+	if err != nil {
+		return err
+	}
+
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
@@ -164,8 +198,12 @@ func (gpr *RPC) SaveAsChild(req rpctypes.UpSaveAsChildReq, rep *rpctypes.UpSaveA
 func (gpr *RPC) SaveAsDelegate(req rpctypes.UpSaveAsDelegateReq, rep *rpctypes.UpSaveAsDelegateResp) (err error) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	err = checkMutable(req.UpBaseReq, "SaveAsDelegate")
+	if err != nil {
+		return err
+	}
+
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 
 	bm := MakeBaseMessage(req.UpBaseReq, es)
@@ -188,8 +226,7 @@ func (gpr *RPC) GetObjChildrenIterator(
 ) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 
 	am := gpr.lr.ArtifactManager
@@ -263,8 +300,7 @@ func (gpr *RPC) GetObjChildrenIterator(
 func (gpr *RPC) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDelegateResp) (err error) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	es := gpr.executionState(req.UpBaseReq)
 	ctx := es.Current.Context
 
 	am := gpr.lr.ArtifactManager
@@ -276,12 +312,75 @@ func (gpr *RPC) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDe
 	return nil
 }
 
+// EmitEvent is an RPC collecting event emitted by a contract, events are registered along with the call result.
+// Payload must be JSON, API returns payloads of events as is.
+func (gpr *RPC) EmitEvent(req rpctypes.UpEmitEventReq, rep *rpctypes.UpEmitEventResp) (err error) {
+	defer recoverRPC(&err)
+
+	err = checkMutable(req.UpBaseReq, "EmitEvent")
+	if err != nil {
+		return err
+	}
+	if !json.Valid(req.Payload) {
+		return errors.Errorf("payload of event %q is not valid JSON", req.Name)
+	}
+
+	es := gpr.executionState(req.UpBaseReq)
+	es.Current.Events = append(es.Current.Events, insolar.ContractEvent{Name: req.Name, Payload: req.Payload})
+	return nil
+}
+
+// UpgradePrototype is an RPC deploying new code of a prototype, objects of the prototype
+// are migrated to the new code lazily on their next call. Only domains can upgrade prototypes.
+func (gpr *RPC) UpgradePrototype(req rpctypes.UpUpgradePrototypeReq, rep *rpctypes.UpUpgradePrototypeResp) (err error) {
+	defer recoverRPC(&err)
+
+	err = checkMutable(req.UpBaseReq, "UpgradePrototype")
+	if err != nil {
+		return err
+	}
+
+	es := gpr.executionState(req.UpBaseReq)
+	ctx := es.Current.Context
+	am := gpr.lr.ArtifactManager
+
+	if es.objectbody == nil || !es.objectbody.Parent.Equal(*am.GenesisRef()) {
+		return errors.New("only domain can upgrade prototypes")
+	}
+
+	protoDesc, err := am.GetObject(ctx, req.Prototype, nil, false)
+	if err != nil {
+		return errors.Wrap(err, "couldn't get prototype")
+	}
+	if !protoDesc.IsPrototype() {
+		return errors.New("object is not a prototype")
+	}
+
+	codeID, err := am.DeployCode(ctx, req.Callee, *es.Current.Request, req.Code, req.MachineType, req.ABI)
+	if err != nil {
+		return errors.Wrap(err, "couldn't deploy code")
+	}
+	codeRef := insolar.NewReference(*req.Callee.Record(), *codeID)
+
+	_, err = am.UpdatePrototype(ctx, req.Callee, *es.Current.Request, protoDesc, protoDesc.Memory(), codeRef)
+	if err != nil {
+		return errors.Wrap(err, "couldn't update prototype")
+	}
+
+	rep.Code = *codeRef
+	return nil
+}
+
 // DeactivateObject is an RPC saving data as memory of a contract as child a parent
 func (gpr *RPC) DeactivateObject(req rpctypes.UpDeactivateObjectReq, rep *rpctypes.UpDeactivateObjectResp) (err error) {
 	defer recoverRPC(&err)
 
-	os := gpr.lr.MustObjectState(req.Callee)
-	es := os.MustModeState(req.Mode)
+	err = checkMutable(req.UpBaseReq, "DeactivateObject")
+	if err != nil {
+		return err
+	}
+
+	es := gpr.executionState(req.UpBaseReq)
 	es.deactivate = true
 	return nil
 }
@@ -0,0 +1,341 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package wasm
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/perlin-network/life/exec"
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
+
+// hostModule is a name of the module contracts import host functions from
+const hostModule = "insolar"
+
+// Host functions available to contracts. References are passed as pointers
+// to insolar.RecordRefSize bytes. Upcalls return length of their result that can be
+// copied into contract memory with upcall_result, negative value -(n+1) means error
+// with message of n bytes.
+//
+//    set_state(ptr, len)
+//    set_result(ptr, len)
+//    set_error(ptr, len)
+//    upcall_result(ptr) len
+//    route_call(objPtr, wait, methodPtr, methodLen, argsPtr, argsLen, protoPtr) result
+//    save_as_child(parentPtr, protoPtr, ctorPtr, ctorLen, argsPtr, argsLen) reference
+//    save_as_delegate(intoPtr, protoPtr, ctorPtr, ctorLen, argsPtr, argsLen) reference
+//    get_delegate(objPtr, ofTypePtr) reference
+//    get_children(parentPtr, protoPtr, iteratorPtr, iteratorLen) CBOR of rpctypes.ChildIterator
+//    deactivate_object()
+//    emit_event(namePtr, nameLen, payloadPtr, payloadLen), payload must be JSON
+
+// call is a single execution of contract method or constructor, it provides
+// host functions to the module
+type call struct {
+	callCtx  *insolar.LogicCallContext
+	upstream rpctypes.UpstreamRPC
+	marker   string // export required to run the entry point, set for methods called as queries
+
+	state  []byte
+	result []byte
+	err    string
+
+	upcallOut     []byte
+	upcallsTime   time.Duration
+	cpuTime       time.Duration
+	usage         insolar.ResourceUsage
+	limitExceeded *insolar.ResourceLimitError
+}
+
+func newCall(callCtx *insolar.LogicCallContext, upstream rpctypes.UpstreamRPC) *call {
+	return &call{
+		callCtx:  callCtx,
+		upstream: upstream,
+	}
+}
+
+// importError is raised by the resolver when the module imports something the host doesn't provide.
+// Resolver interface of the VM can't return errors, so it's a panic recovered in newVM.
+type importError struct {
+	kind, module, field string
+}
+
+func (e *importError) Error() string {
+	return fmt.Sprintf("unknown %s %s.%s", e.kind, e.module, e.field)
+}
+
+// ResolveFunc returns host function imported by the module
+func (c *call) ResolveFunc(module, field string) exec.FunctionImport {
+	if module != hostModule {
+		panic(&importError{kind: "function", module: module, field: field})
+	}
+
+	switch field {
+	case "set_state":
+		return c.setState
+	case "set_result":
+		return c.setResult
+	case "set_error":
+		return c.setError
+	case "upcall_result":
+		return c.upcallResult
+	case "route_call":
+		return c.routeCall
+	case "save_as_child":
+		return c.saveAsChild
+	case "save_as_delegate":
+		return c.saveAsDelegate
+	case "get_delegate":
+		return c.getDelegate
+	case "get_children":
+		return c.getChildren
+	case "deactivate_object":
+		return c.deactivateObject
+	case "emit_event":
+		return c.emitEvent
+	}
+	panic(&importError{kind: "function", module: module, field: field})
+}
+
+// ResolveGlobal returns global imported by the module, contracts can't import globals
+func (c *call) ResolveGlobal(module, field string) int64 {
+	panic(&importError{kind: "global", module: module, field: field})
+}
+
+func (c *call) setState(vm *exec.VirtualMachine) int64 {
+	c.state = mustReadMemory(vm, param(vm, 0), param(vm, 1))
+	return 0
+}
+
+func (c *call) setResult(vm *exec.VirtualMachine) int64 {
+	c.result = mustReadMemory(vm, param(vm, 0), param(vm, 1))
+	return 0
+}
+
+func (c *call) setError(vm *exec.VirtualMachine) int64 {
+	c.err = string(mustReadMemory(vm, param(vm, 0), param(vm, 1)))
+	return 0
+}
+
+func (c *call) upcallResult(vm *exec.VirtualMachine) int64 {
+	if err := writeMemory(vm, param(vm, 0), c.upcallOut); err != nil {
+		panic(err)
+	}
+	return int64(len(c.upcallOut))
+}
+
+func (c *call) routeCall(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpRouteReq{
+		UpBaseReq:      c.baseReq(),
+		Object:         mustReadRef(vm, param(vm, 0)),
+		Wait:           param(vm, 1) != 0,
+		Method:         string(mustReadMemory(vm, param(vm, 2), param(vm, 3))),
+		Arguments:      mustReadMemory(vm, param(vm, 4), param(vm, 5)),
+		ProxyPrototype: mustReadRef(vm, param(vm, 6)),
+	}
+	return c.upcall(func() ([]byte, error) {
+		res := rpctypes.UpRouteResp{}
+		err := c.upstream.RouteCall(req, &res)
+		return res.Result, err
+	})
+}
+
+func (c *call) saveAsChild(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpSaveAsChildReq{
+		UpBaseReq:       c.baseReq(),
+		Parent:          mustReadRef(vm, param(vm, 0)),
+		Prototype:       mustReadRef(vm, param(vm, 1)),
+		ConstructorName: string(mustReadMemory(vm, param(vm, 2), param(vm, 3))),
+		ArgsSerialized:  mustReadMemory(vm, param(vm, 4), param(vm, 5)),
+	}
+	return c.upcall(func() ([]byte, error) {
+		res := rpctypes.UpSaveAsChildResp{}
+		err := c.upstream.SaveAsChild(req, &res)
+		if err != nil {
+			return nil, err
+		}
+		return res.Reference[:], nil
+	})
+}
+
+func (c *call) saveAsDelegate(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpSaveAsDelegateReq{
+		UpBaseReq:       c.baseReq(),
+		Into:            mustReadRef(vm, param(vm, 0)),
+		Prototype:       mustReadRef(vm, param(vm, 1)),
+		ConstructorName: string(mustReadMemory(vm, param(vm, 2), param(vm, 3))),
+		ArgsSerialized:  mustReadMemory(vm, param(vm, 4), param(vm, 5)),
+	}
+	return c.upcall(func() ([]byte, error) {
+		res := rpctypes.UpSaveAsDelegateResp{}
+		err := c.upstream.SaveAsDelegate(req, &res)
+		if err != nil {
+			return nil, err
+		}
+		return res.Reference[:], nil
+	})
+}
+
+func (c *call) getDelegate(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpGetDelegateReq{
+		UpBaseReq: c.baseReq(),
+		Object:    mustReadRef(vm, param(vm, 0)),
+		OfType:    mustReadRef(vm, param(vm, 1)),
+	}
+	return c.upcall(func() ([]byte, error) {
+		res := rpctypes.UpGetDelegateResp{}
+		err := c.upstream.GetDelegate(req, &res)
+		if err != nil {
+			return nil, err
+		}
+		return res.Object[:], nil
+	})
+}
+
+func (c *call) getChildren(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpGetObjChildrenIteratorReq{
+		UpBaseReq:  c.baseReq(),
+		Obj:        mustReadRef(vm, param(vm, 0)),
+		Prototype:  mustReadRef(vm, param(vm, 1)),
+		IteratorID: string(mustReadMemory(vm, param(vm, 2), param(vm, 3))),
+	}
+	return c.upcall(func() ([]byte, error) {
+		res := rpctypes.UpGetObjChildrenIteratorResp{}
+		err := c.upstream.GetObjChildrenIterator(req, &res)
+		if err != nil {
+			return nil, err
+		}
+		var out []byte
+		err = codec.NewEncoderBytes(&out, new(codec.CborHandle)).Encode(res.Iterator)
+		return out, err
+	})
+}
+
+func (c *call) deactivateObject(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpDeactivateObjectReq{
+		UpBaseReq: c.baseReq(),
+	}
+	return c.upcall(func() ([]byte, error) {
+		return nil, c.upstream.DeactivateObject(req, &rpctypes.UpDeactivateObjectResp{})
+	})
+}
+
+func (c *call) emitEvent(vm *exec.VirtualMachine) int64 {
+	req := rpctypes.UpEmitEventReq{
+		UpBaseReq: c.baseReq(),
+		Name:      string(mustReadMemory(vm, param(vm, 0), param(vm, 1))),
+		Payload:   mustReadMemory(vm, param(vm, 2), param(vm, 3)),
+	}
+	return c.upcall(func() ([]byte, error) {
+		return nil, c.upstream.EmitEvent(req, &rpctypes.UpEmitEventResp{})
+	})
+}
+
+// upcall accounts and performs call to logicrunner. Execution of the module is aborted
+// if the call exceeds limit of upcalls.
+func (c *call) upcall(f func() ([]byte, error)) int64 {
+	c.usage.Upcalls++
+	if limit := c.callCtx.Limits.Upcalls; limit > 0 && c.usage.Upcalls > limit {
+		c.limitExceeded = &insolar.ResourceLimitError{
+			Resource: insolar.ResourceUpcalls,
+			Limit:    limit,
+			Used:     c.usage.Upcalls,
+		}
+		panic(c.limitExceeded)
+	}
+
+	start := time.Now()
+	out, err := f()
+	c.upcallsTime += time.Since(start)
+
+	if err != nil {
+		c.upcallOut = []byte(err.Error())
+		return -int64(len(c.upcallOut)) - 1
+	}
+	c.upcallOut = out
+	return int64(len(out))
+}
+
+func (c *call) checkLimits() error {
+	limits := c.callCtx.Limits
+	switch {
+	case limits.StateBytes > 0 && c.usage.StateBytes > limits.StateBytes:
+		return &insolar.ResourceLimitError{
+			Resource: insolar.ResourceStateBytes,
+			Limit:    limits.StateBytes,
+			Used:     c.usage.StateBytes,
+		}
+	case limits.CPUTime > 0 && c.cpuTime > limits.CPUTime:
+		return &insolar.ResourceLimitError{
+			Resource: insolar.ResourceCPUTime,
+			Limit:    uint64(limits.CPUTime),
+			Used:     uint64(c.cpuTime),
+		}
+	}
+	return nil
+}
+
+func (c *call) baseReq() rpctypes.UpBaseReq {
+	return rpctypes.UpBaseReq{
+		Mode:      c.callCtx.Mode,
+		Callee:    *c.callCtx.Callee,
+		Prototype: *c.callCtx.Prototype,
+		Request:   *c.callCtx.Request,
+	}
+}
+
+// param returns i32 parameter of the host function
+func param(vm *exec.VirtualMachine, i int) int64 {
+	return int64(uint32(vm.GetCurrentFrame().Locals[i]))
+}
+
+func readMemory(vm *exec.VirtualMachine, ptr, size int64) ([]byte, error) {
+	if ptr < 0 || size < 0 || ptr+size > int64(len(vm.Memory)) {
+		return nil, errors.Errorf("access out of memory bounds: %d bytes at %d", size, ptr)
+	}
+	buf := make([]byte, size)
+	copy(buf, vm.Memory[ptr:ptr+size])
+	return buf, nil
+}
+
+func writeMemory(vm *exec.VirtualMachine, ptr int64, data []byte) error {
+	if ptr < 0 || ptr+int64(len(data)) > int64(len(vm.Memory)) {
+		return errors.Errorf("access out of memory bounds: %d bytes at %d", len(data), ptr)
+	}
+	copy(vm.Memory[ptr:], data)
+	return nil
+}
+
+// mustReadMemory reads memory of the module, out of bounds access aborts execution
+func mustReadMemory(vm *exec.VirtualMachine, ptr, size int64) []byte {
+	buf, err := readMemory(vm, ptr, size)
+	if err != nil {
+		panic(err)
+	}
+	return buf
+}
+
+func mustReadRef(vm *exec.VirtualMachine, ptr int64) insolar.Reference {
+	var ref insolar.Reference
+	copy(ref[:], mustReadMemory(vm, ptr, insolar.RecordRefSize))
+	return ref
+}
@@ -0,0 +1,248 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+// Package wasm - executor of contracts compiled to WebAssembly.
+//
+// Contracts are executed in process by deterministic interpreter with floating point
+// operations disabled, memory of a contract and number of executed instructions are limited.
+//
+// Contract module should export its memory as "memory", function "alloc(size i32) i32"
+// that allocates memory for data passed into the contract and entry points of methods and
+// constructors:
+//
+//    INSMETHOD_<Name>(objPtr, objLen, argsPtr, argsLen i32) i32
+//    INSCONSTRUCTOR_<Name>(argsPtr, argsLen i32) i32
+//
+// Entry point returns 0 on success and stores new state of the object and results of the method
+// with host functions from "insolar" module, see host.go for the full list. Arguments, results and
+// state are serialized the same way as for Go plugins.
+//
+// Methods that can be called as queries should be marked by export of any function named
+// INSATTR_<Name>_Immutable, other methods are rejected in query mode.
+//
+// Contracts that can be upgraded should export INSCONSTRUCTOR_Migrate, it receives memory of
+// the previous code version as the only argument and stores the converted state.
+package wasm
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/perlin-network/life/compiler"
+	"github.com/perlin-network/life/exec"
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+)
+
+const (
+	methodPrefix      = "INSMETHOD_"
+	constructorPrefix = "INSCONSTRUCTOR_"
+	allocExport       = "alloc"
+
+	immutablePrefix = "INSATTR_"
+	immutableSuffix = "_Immutable"
+)
+
+// WASM is a logic executor of contracts compiled to WebAssembly
+type WASM struct {
+	Cfg *configuration.WASM
+	AM  artifacts.Client
+
+	upstream rpctypes.UpstreamRPC
+
+	codeMutex sync.RWMutex
+	code      map[insolar.Reference][]byte
+}
+
+// NewWASM returns a new WASM executor, upcalls of contracts go to provided upstream
+func NewWASM(cfg *configuration.WASM, am artifacts.Client, upstream rpctypes.UpstreamRPC) *WASM {
+	return &WASM{
+		Cfg:      cfg,
+		AM:       am,
+		upstream: upstream,
+		code:     make(map[insolar.Reference][]byte),
+	}
+}
+
+// Stop stops the executor
+func (w *WASM) Stop() error {
+	return nil
+}
+
+// CallMethod runs a method on an object
+func (w *WASM) CallMethod(
+	ctx context.Context, callCtx *insolar.LogicCallContext,
+	code insolar.Reference, data []byte,
+	method string, args insolar.Arguments,
+) (
+	[]byte, insolar.Arguments, error,
+) {
+	ctx, span := instracer.StartSpan(ctx, "wasm.CallMethod")
+	defer span.End()
+
+	if len(data) == 0 {
+		return nil, nil, errors.Errorf("[ CallMethod ] object is nil, method %q", method)
+	}
+
+	c := newCall(callCtx, w.upstream)
+	if callCtx.Mode == insolar.QueryMode {
+		c.marker = immutablePrefix + method + immutableSuffix
+	}
+	err := w.run(ctx, c, code, methodPrefix+method, data, args)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "[ CallMethod ] method %q failed", method)
+	}
+
+	return c.state, c.result, nil
+}
+
+// CallConstructor runs a constructor of a contract
+func (w *WASM) CallConstructor(
+	ctx context.Context, callCtx *insolar.LogicCallContext,
+	code insolar.Reference, name string, args insolar.Arguments,
+) (
+	[]byte, error,
+) {
+	ctx, span := instracer.StartSpan(ctx, "wasm.CallConstructor")
+	defer span.End()
+
+	c := newCall(callCtx, w.upstream)
+	err := w.run(ctx, c, code, constructorPrefix+name, args)
+	if err != nil {
+		return nil, errors.Wrapf(err, "[ CallConstructor ] constructor %q failed", name)
+	}
+
+	return c.state, nil
+}
+
+func (w *WASM) getCode(ctx context.Context, ref insolar.Reference) ([]byte, error) {
+	w.codeMutex.RLock()
+	code, ok := w.code[ref]
+	w.codeMutex.RUnlock()
+	if ok {
+		return code, nil
+	}
+
+	codeDescriptor, err := w.AM.GetCode(ctx, ref)
+	if err != nil {
+		return nil, errors.Wrap(err, "can't find code")
+	}
+	if codeDescriptor.MachineType() != insolar.MachineTypeWASM {
+		return nil, errors.Errorf("code %s is not a WebAssembly module", ref.String())
+	}
+	code, err = codeDescriptor.Code()
+	if err != nil {
+		return nil, errors.Wrap(err, "can't fetch code")
+	}
+
+	w.codeMutex.Lock()
+	w.code[ref] = code
+	w.codeMutex.Unlock()
+
+	return code, nil
+}
+
+func (w *WASM) newVM(code []byte, c *call) (vm *exec.VirtualMachine, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			impErr, ok := r.(*importError)
+			if !ok {
+				panic(r)
+			}
+			vm, err = nil, errors.Wrap(impErr, "can't instantiate module")
+		}
+	}()
+
+	vm, err = exec.NewVirtualMachine(code, exec.VMConfig{
+		MaxMemoryPages:       w.Cfg.MaxMemoryPages,
+		GasLimit:             w.Cfg.GasLimit,
+		DisableFloatingPoint: true,
+	}, c, &compiler.SimpleGasPolicy{GasPerInstruction: 1})
+	if err != nil {
+		return nil, errors.Wrap(err, "can't instantiate module")
+	}
+	return vm, nil
+}
+
+// run copies buffers into memory of a new instance of the module and calls entry point
+// with pointers to them
+func (w *WASM) run(ctx context.Context, c *call, codeRef insolar.Reference, entry string, buffers ...[]byte) error {
+	code, err := w.getCode(ctx, codeRef)
+	if err != nil {
+		return err
+	}
+
+	vm, err := w.newVM(code, c)
+	if err != nil {
+		return err
+	}
+
+	entryID, ok := vm.GetFunctionExport(entry)
+	if !ok {
+		return errors.Errorf("no export %q in the module", entry)
+	}
+	if c.marker != "" {
+		if _, ok := vm.GetFunctionExport(c.marker); !ok {
+			return errors.Errorf("calling non immutable method as query, no export %q in the module", c.marker)
+		}
+	}
+
+	params := make([]int64, 0, 2*len(buffers))
+	if len(buffers) > 0 {
+		allocID, ok := vm.GetFunctionExport(allocExport)
+		if !ok {
+			return errors.Errorf("no export %q in the module", allocExport)
+		}
+		for _, buf := range buffers {
+			ret, err := vm.Run(allocID, int64(len(buf)))
+			if err != nil {
+				return errors.Wrap(err, "can't allocate memory")
+			}
+			ptr := int64(uint32(ret))
+			if err := writeMemory(vm, ptr, buf); err != nil {
+				return errors.Wrap(err, "can't copy data into module memory")
+			}
+			params = append(params, ptr, int64(len(buf)))
+		}
+	}
+
+	start := time.Now()
+	ret, err := vm.Run(entryID, params...)
+	c.cpuTime = time.Since(start) - c.upcallsTime
+	c.usage.StateBytes = uint64(len(c.state))
+	c.callCtx.Usage = c.usage
+
+	if c.limitExceeded != nil {
+		return c.limitExceeded
+	}
+	if err != nil {
+		return errors.Wrap(err, "execution failed")
+	}
+	if ret != 0 {
+		return errors.Errorf("contract returned %d: %s", ret, c.err)
+	}
+	if c.state == nil {
+		return errors.New("contract didn't set state")
+	}
+
+	return c.checkLimits()
+}
@@ -0,0 +1,295 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package wasm
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/gojuno/minimock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/logicrunner/artifacts"
+	"github.com/insolar/insolar/logicrunner/goplugin/rpctypes"
+	"github.com/insolar/insolar/testutils"
+)
+
+func uleb(v uint32) []byte {
+	var res []byte
+	for {
+		b := byte(v & 0x7f)
+		v >>= 7
+		if v == 0 {
+			return append(res, b)
+		}
+		res = append(res, b|0x80)
+	}
+}
+
+func concat(parts ...[]byte) []byte {
+	var res []byte
+	for _, p := range parts {
+		res = append(res, p...)
+	}
+	return res
+}
+
+func vec(items ...[]byte) []byte {
+	return concat(uleb(uint32(len(items))), concat(items...))
+}
+
+func str(s string) []byte {
+	return concat(uleb(uint32(len(s))), []byte(s))
+}
+
+func section(id byte, content []byte) []byte {
+	return concat([]byte{id}, uleb(uint32(len(content))), content)
+}
+
+func body(code ...byte) []byte {
+	b := concat([]byte{0x00}, code, []byte{0x0b})
+	return concat(uleb(uint32(len(b))), b)
+}
+
+// testModule assembles contract module with memory of provided size:
+//
+//    (import "insolar" "set_state" (func $set_state (param i32 i32)))
+//    (import "insolar" "set_result" (func $set_result (param i32 i32)))
+//    (import "insolar" "get_delegate" (func $get_delegate (param i32 i32) (result i32)))
+//    (import "insolar" "upcall_result" (func $upcall_result (param i32) (result i32)))
+//    (memory (export "memory") $pages)
+//    (global $heap (mut i32) (i32.const 1024))
+//    (func (export "alloc") (param i32) (result i32) ...bump allocator...)
+//    (func (export "INSCONSTRUCTOR_New") (param i32 i32) (result i32) ...state = args...)
+//    (func (export "INSMETHOD_Echo") (export "INSATTR_Echo_Immutable") (param i32 i32 i32 i32) (result i32)
+//        ...state = obj, result = args...)
+//    (func (export "INSMETHOD_Delegate") (param i32 i32 i32 i32) (result i32) ...result = get_delegate(args[:64], args[64:])...)
+//    (func (export "INSMETHOD_Loop") (param i32 i32 i32 i32) (result i32) (loop (br 0)) ...)
+//    (func (export "INSMETHOD_Fail") (param i32 i32 i32 i32) (result i32) (i32.const 1))
+func testModule(pages uint32) []byte {
+	i32 := byte(0x7f)
+	types := section(1, vec(
+		concat([]byte{0x60}, vec([]byte{i32}, []byte{i32}), vec()),
+		concat([]byte{0x60}, vec([]byte{i32}, []byte{i32}), vec([]byte{i32})),
+		concat([]byte{0x60}, vec([]byte{i32}), vec([]byte{i32})),
+		concat([]byte{0x60}, vec([]byte{i32}, []byte{i32}, []byte{i32}, []byte{i32}), vec([]byte{i32})),
+	))
+	imports := section(2, vec(
+		concat(str("insolar"), str("set_state"), []byte{0x00, 0x00}),
+		concat(str("insolar"), str("set_result"), []byte{0x00, 0x00}),
+		concat(str("insolar"), str("get_delegate"), []byte{0x00, 0x01}),
+		concat(str("insolar"), str("upcall_result"), []byte{0x00, 0x02}),
+	))
+	functions := section(3, vec([]byte{0x02}, []byte{0x01}, []byte{0x03}, []byte{0x03}, []byte{0x03}, []byte{0x03}))
+	memory := section(5, vec(concat([]byte{0x00}, uleb(pages))))
+	globals := section(6, vec([]byte{i32, 0x01, 0x41, 0x80, 0x08, 0x0b}))
+	exports := section(7, vec(
+		concat(str("memory"), []byte{0x02, 0x00}),
+		concat(str("alloc"), []byte{0x00, 0x04}),
+		concat(str("INSCONSTRUCTOR_New"), []byte{0x00, 0x05}),
+		concat(str("INSMETHOD_Echo"), []byte{0x00, 0x06}),
+		concat(str("INSATTR_Echo_Immutable"), []byte{0x00, 0x06}),
+		concat(str("INSMETHOD_Delegate"), []byte{0x00, 0x07}),
+		concat(str("INSMETHOD_Loop"), []byte{0x00, 0x08}),
+		concat(str("INSMETHOD_Fail"), []byte{0x00, 0x09}),
+	))
+	code := section(10, concat(uleb(6),
+		// alloc
+		body(0x23, 0x00, 0x23, 0x00, 0x20, 0x00, 0x6a, 0x24, 0x00),
+		// INSCONSTRUCTOR_New
+		body(0x20, 0x00, 0x20, 0x01, 0x10, 0x00, 0x41, 0x00),
+		// INSMETHOD_Echo
+		body(0x20, 0x00, 0x20, 0x01, 0x10, 0x00, 0x20, 0x02, 0x20, 0x03, 0x10, 0x01, 0x41, 0x00),
+		// INSMETHOD_Delegate
+		body(
+			0x20, 0x00, 0x20, 0x01, 0x10, 0x00,
+			0x20, 0x02, 0x20, 0x02, 0x41, 0xc0, 0x00, 0x6a, 0x10, 0x02, 0x1a,
+			0x20, 0x02, 0x10, 0x03, 0x1a,
+			0x20, 0x02, 0x41, 0xc0, 0x00, 0x10, 0x01,
+			0x41, 0x00,
+		),
+		// INSMETHOD_Loop
+		body(0x03, 0x40, 0x0c, 0x00, 0x0b, 0x41, 0x00),
+		// INSMETHOD_Fail
+		body(0x41, 0x01),
+	))
+
+	return concat(
+		[]byte{0x00, 0x61, 0x73, 0x6d, 0x01, 0x00, 0x00, 0x00},
+		types, imports, functions, memory, globals, exports, code,
+	)
+}
+
+type upstreamMock struct {
+	rpctypes.UpstreamRPC
+	getDelegate func(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDelegateResp) error
+}
+
+func (u *upstreamMock) GetDelegate(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDelegateResp) error {
+	return u.getDelegate(req, rep)
+}
+
+func newTestWASM(t *testing.T, mc *minimock.Controller, module []byte, upstream rpctypes.UpstreamRPC) *WASM {
+	cd := artifacts.NewCodeDescriptorMock(mc)
+	cd.MachineTypeMock.Return(insolar.MachineTypeWASM)
+	cd.CodeMock.Return(module, nil)
+	am := artifacts.NewClientMock(mc)
+	am.GetCodeMock.Return(cd, nil)
+
+	return NewWASM(&configuration.WASM{MaxMemoryPages: 1, GasLimit: 10000}, am, upstream)
+}
+
+func newCallContext() *insolar.LogicCallContext {
+	callee, prototype, request := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	return &insolar.LogicCallContext{
+		Mode:      "execution",
+		Callee:    &callee,
+		Prototype: &prototype,
+		Request:   &request,
+	}
+}
+
+func TestWASM_CallConstructor(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	w := newTestWASM(t, mc, testModule(1), nil)
+
+	state, err := w.CallConstructor(ctx, newCallContext(), testutils.RandomRef(), "New", []byte("initial state"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("initial state"), state)
+
+	_, err = w.CallConstructor(ctx, newCallContext(), testutils.RandomRef(), "Unknown", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no export "INSCONSTRUCTOR_Unknown"`)
+}
+
+func TestWASM_CallMethod(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	w := newTestWASM(t, mc, testModule(1), nil)
+	callCtx := newCallContext()
+
+	state, result, err := w.CallMethod(ctx, callCtx, testutils.RandomRef(), []byte("state"), "Echo", []byte("args"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("state"), state)
+	require.Equal(t, insolar.Arguments("args"), result)
+	require.Equal(t, uint64(len("state")), callCtx.Usage.StateBytes)
+
+	_, _, err = w.CallMethod(ctx, newCallContext(), testutils.RandomRef(), []byte("state"), "Fail", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "contract returned 1")
+
+	_, _, err = w.CallMethod(ctx, newCallContext(), testutils.RandomRef(), []byte("state"), "Unknown", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `no export "INSMETHOD_Unknown"`)
+}
+
+func TestWASM_Query(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	w := newTestWASM(t, mc, testModule(1), nil)
+	callCtx := newCallContext()
+	callCtx.Mode = insolar.QueryMode
+
+	_, result, err := w.CallMethod(ctx, callCtx, testutils.RandomRef(), []byte("state"), "Echo", []byte("args"))
+	require.NoError(t, err)
+	require.Equal(t, insolar.Arguments("args"), result)
+
+	_, _, err = w.CallMethod(ctx, callCtx, testutils.RandomRef(), []byte("state"), "Fail", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `calling non immutable method as query, no export "INSATTR_Fail_Immutable"`)
+}
+
+func TestWASM_UnknownImport(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	module := bytes.Replace(testModule(1), []byte("set_result"), []byte("get_result"), 1)
+	w := newTestWASM(t, mc, module, nil)
+
+	_, err := w.CallConstructor(ctx, newCallContext(), testutils.RandomRef(), "New", nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't instantiate module")
+	require.Contains(t, err.Error(), "unknown function insolar.get_result")
+}
+
+func TestWASM_Upcall(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	object, ofType, delegate := testutils.RandomRef(), testutils.RandomRef(), testutils.RandomRef()
+	callCtx := newCallContext()
+
+	upstream := &upstreamMock{
+		getDelegate: func(req rpctypes.UpGetDelegateReq, rep *rpctypes.UpGetDelegateResp) error {
+			require.Equal(t, object, req.Object)
+			require.Equal(t, ofType, req.OfType)
+			require.Equal(t, *callCtx.Callee, req.Callee)
+			rep.Object = delegate
+			return nil
+		},
+	}
+	w := newTestWASM(t, mc, testModule(1), upstream)
+
+	_, result, err := w.CallMethod(ctx, callCtx, testutils.RandomRef(), []byte("state"), "Delegate", append(object[:], ofType[:]...))
+	require.NoError(t, err)
+	require.Equal(t, insolar.Arguments(delegate[:]), result)
+	require.Equal(t, uint64(1), callCtx.Usage.Upcalls)
+}
+
+func TestWASM_Limits(t *testing.T) {
+	ctx := context.Background()
+	mc := minimock.NewController(t)
+	defer mc.Finish()
+
+	t.Run("gas", func(t *testing.T) {
+		w := newTestWASM(t, mc, testModule(1), nil)
+		_, _, err := w.CallMethod(ctx, newCallContext(), testutils.RandomRef(), []byte("state"), "Loop", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "gas limit exceeded")
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		w := newTestWASM(t, mc, testModule(2), nil)
+		_, err := w.CallConstructor(ctx, newCallContext(), testutils.RandomRef(), "New", nil)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "can't instantiate module")
+	})
+
+	t.Run("state size", func(t *testing.T) {
+		w := newTestWASM(t, mc, testModule(1), nil)
+		callCtx := newCallContext()
+		callCtx.Limits.StateBytes = 1
+		_, _, err := w.CallMethod(ctx, callCtx, testutils.RandomRef(), []byte("state"), "Echo", nil)
+		require.Error(t, err)
+		limitErr, ok := errors.Cause(err).(*insolar.ResourceLimitError)
+		require.True(t, ok)
+		require.Equal(t, insolar.ResourceStateBytes, limitErr.Resource)
+	})
+}
@@ -0,0 +1,161 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package messagebus
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+const (
+	laneCritical = "critical"
+	laneNormal   = "normal"
+	laneBulk     = "bulk"
+)
+
+// lane limits concurrency of one priority class of incoming messages.
+type lane struct {
+	name        string
+	workers     chan struct{}
+	queueSize   int64
+	queued      int64
+	waitTimeout time.Duration
+}
+
+func newLane(name string, cfg configuration.Lane) *lane {
+	l := &lane{
+		name:        name,
+		queueSize:   int64(cfg.QueueSize),
+		waitTimeout: cfg.WaitTimeout,
+	}
+	if cfg.Workers > 0 {
+		l.workers = make(chan struct{}, cfg.Workers)
+	}
+	return l
+}
+
+// acquire takes a worker slot, waiting in the lane queue if all workers are busy.
+// It returns false when the queue is full, wait timeout expires or context is done.
+// Release function must be called after handling.
+func (l *lane) acquire(ctx context.Context) (func(), bool) {
+	if l.workers == nil {
+		return func() {}, true
+	}
+	ctx = insmetrics.InsertTag(ctx, tagLane, l.name)
+	release := func() {
+		<-l.workers
+	}
+
+	select {
+	case l.workers <- struct{}{}:
+		return release, true
+	default:
+	}
+
+	depth := atomic.AddInt64(&l.queued, 1)
+	if depth > l.queueSize {
+		atomic.AddInt64(&l.queued, -1)
+		stats.Record(ctx, statLaneRejectedTotal.M(1))
+		return nil, false
+	}
+	stats.Record(ctx, statLaneQueueDepth.M(depth))
+
+	if l.waitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, l.waitTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	defer func() {
+		stats.Record(
+			ctx,
+			statLaneQueueDepth.M(atomic.AddInt64(&l.queued, -1)),
+			statLaneWaitTime.M(float64(time.Since(start).Nanoseconds())/1e6),
+		)
+	}()
+
+	select {
+	case l.workers <- struct{}{}:
+		return release, true
+	case <-ctx.Done():
+		return nil, false
+	}
+}
+
+// lanes dispatches incoming messages to priority lanes by message type.
+type lanes struct {
+	byType   map[insolar.MessageType]*lane
+	fallback *lane
+}
+
+func newLanes(cfg configuration.MessageBus) (*lanes, error) {
+	normal := newLane(laneNormal, cfg.NormalLane)
+	ls := &lanes{
+		byType:   map[insolar.MessageType]*lane{},
+		fallback: normal,
+	}
+	err := ls.assign(normal, cfg.NormalLane.Types)
+	if err != nil {
+		return nil, err
+	}
+	err = ls.assign(newLane(laneCritical, cfg.CriticalLane), cfg.CriticalLane.Types)
+	if err != nil {
+		return nil, err
+	}
+	err = ls.assign(newLane(laneBulk, cfg.BulkLane), cfg.BulkLane.Types)
+	if err != nil {
+		return nil, err
+	}
+	return ls, nil
+}
+
+func (ls *lanes) assign(l *lane, names []string) error {
+	for _, name := range names {
+		t, ok := messageTypeByName(name)
+		if !ok {
+			return errors.Errorf("[ newLanes ] unknown message type %q in %s lane config", name, l.name)
+		}
+		ls.byType[t] = l
+	}
+	return nil
+}
+
+func (ls *lanes) get(t insolar.MessageType) *lane {
+	if l, ok := ls.byType[t]; ok {
+		return l
+	}
+	return ls.fallback
+}
+
+func messageTypeByName(name string) (insolar.MessageType, bool) {
+	for t := insolar.TypeCallMethod; t <= insolar.TypeGetEvents; t++ {
+		if strings.EqualFold(t.String(), name) {
+			return t, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,110 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package messagebus
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+)
+
+func TestLanes_DefaultTypes(t *testing.T) {
+	ls, err := newLanes(configuration.NewMessageBus())
+	require.NoError(t, err)
+
+	require.Equal(t, laneCritical, ls.get(insolar.TypeCallMethod).name)
+	require.Equal(t, laneBulk, ls.get(insolar.TypeHeavyPayload).name)
+	require.Equal(t, laneBulk, ls.get(insolar.TypeGetChildren).name)
+	require.Equal(t, laneNormal, ls.get(insolar.TypeGetObject).name)
+}
+
+func TestLanes_ConfiguredTypes(t *testing.T) {
+	cfg := configuration.NewMessageBus()
+	cfg.CriticalLane.Types = []string{"typegetobject", "TypeGetEvents"}
+
+	ls, err := newLanes(cfg)
+	require.NoError(t, err)
+
+	require.Equal(t, laneCritical, ls.get(insolar.TypeGetObject).name)
+	require.Equal(t, laneCritical, ls.get(insolar.TypeGetEvents).name)
+	require.Equal(t, laneNormal, ls.get(insolar.TypeCallMethod).name)
+}
+
+func TestLanes_UnknownType(t *testing.T) {
+	cfg := configuration.NewMessageBus()
+	cfg.BulkLane.Types = []string{"TypeGetObjekt"}
+
+	_, err := newLanes(cfg)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), `unknown message type "TypeGetObjekt" in bulk lane config`)
+}
+
+func TestLane_Unlimited(t *testing.T) {
+	l := newLane(laneCritical, configuration.Lane{})
+
+	for i := 0; i < 10; i++ {
+		_, ok := l.acquire(context.Background())
+		require.True(t, ok)
+	}
+}
+
+func TestLane_RejectsWhenQueueIsFull(t *testing.T) {
+	ctx := context.Background()
+	l := newLane(laneBulk, configuration.Lane{Workers: 1, QueueSize: 1})
+
+	release, ok := l.acquire(ctx)
+	require.True(t, ok)
+
+	acquired := make(chan bool)
+	go func() {
+		waitingRelease, ok := l.acquire(ctx)
+		if ok {
+			waitingRelease()
+		}
+		acquired <- ok
+	}()
+
+	// wait for the second call to take the only queue slot
+	for atomic.LoadInt64(&l.queued) != 1 {
+		time.Sleep(time.Millisecond)
+	}
+
+	_, ok = l.acquire(ctx)
+	require.False(t, ok)
+
+	release()
+	require.True(t, <-acquired)
+}
+
+func TestLane_WaitTimeout(t *testing.T) {
+	ctx := context.Background()
+	l := newLane(laneNormal, configuration.Lane{Workers: 1, QueueSize: 1, WaitTimeout: 10 * time.Millisecond})
+
+	release, ok := l.acquire(ctx)
+	require.True(t, ok)
+	defer release()
+
+	_, ok = l.acquire(ctx)
+	require.False(t, ok)
+	require.Equal(t, int64(0), atomic.LoadInt64(&l.queued))
+}
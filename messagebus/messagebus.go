@@ -53,6 +53,7 @@ type MessageBus struct {
 	PulseStorage               insolar.PulseStorage               `inject:""`
 
 	handlers     map[insolar.MessageType]insolar.MessageHandler
+	lanes        *lanes
 	signmessages bool
 
 	globalLock                  sync.RWMutex
@@ -64,8 +65,13 @@ type MessageBus struct {
 // NewMessageBus creates plain MessageBus instance. It can be used to create Player and Recorder instances that
 // wrap it, providing additional functionality.
 func NewMessageBus(config configuration.Configuration) (*MessageBus, error) {
+	ls, err := newLanes(config.MessageBus)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ NewMessageBus ] bad lanes config")
+	}
 	mb := &MessageBus{
 		handlers:                 map[insolar.MessageType]insolar.MessageHandler{},
+		lanes:                    ls,
 		signmessages:             config.Host.SignMessages,
 		NextPulseMessagePoolChan: make(chan interface{}),
 	}
@@ -243,6 +249,12 @@ func (mb *MessageBus) OnPulse(context.Context, insolar.Pulse) error {
 	return nil
 }
 
+// deliverInLane delivers parcel and releases lane worker slot even if handler panics.
+func (mb *MessageBus) deliverInLane(ctx context.Context, parcel insolar.Parcel, release func()) (insolar.Reply, error) {
+	defer release()
+	return mb.doDeliver(ctx, parcel)
+}
+
 func (mb *MessageBus) doDeliver(ctx context.Context, msg insolar.Parcel) (insolar.Reply, error) {
 
 	var err error
@@ -306,9 +318,11 @@ func (mb *MessageBus) checkPulse(ctx context.Context, parcel insolar.Parcel, loc
 			*message.GetObject,
 			*message.GetDelegate,
 			*message.GetChildren,
+			*message.GetEvents,
 			*message.SetRecord,
 			*message.UpdateObject,
 			*message.RegisterChild,
+			*message.RegisterEvents,
 			*message.SetBlob,
 			*message.GetObjectIndex,
 			*message.GetPendingRequests,
@@ -398,9 +412,18 @@ func (mb *MessageBus) deliver(ctx context.Context, args [][]byte) (result []byte
 	}
 	mb.globalLock.RUnlock()
 
-	resp, err := mb.doDeliver(parcelCtx, parcel)
-	if err != nil {
-		return nil, err
+	var resp insolar.Reply
+	release, ok := mb.lanes.get(parcel.Type()).acquire(
+		insmetrics.InsertTag(parcelCtx, tagMessageType, parcel.Type().String()),
+	)
+	if ok {
+		resp, err = mb.deliverInLane(parcelCtx, parcel, release)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		inslogger.FromContext(parcelCtx).Warnf("MessageBus.deliver rejected parcel, lane is overloaded. Msg Type: %s", parcel.Type())
+		resp = &reply.Error{ErrType: reply.ErrLaneOverloaded}
 	}
 
 	rd, err := reply.Serialize(resp)
@@ -26,6 +26,7 @@ import (
 
 var (
 	tagMessageType = insmetrics.MustTagKey("messageType")
+	tagLane        = insmetrics.MustTagKey("lane")
 )
 
 var (
@@ -44,6 +45,21 @@ var (
 		"time spent on sending parcels",
 		stats.UnitMilliseconds,
 	)
+	statLaneQueueDepth = stats.Int64(
+		"messagebus/lane/queue/depth",
+		"number of parcels waiting for a free worker in lane",
+		stats.UnitDimensionless,
+	)
+	statLaneRejectedTotal = stats.Int64(
+		"messagebus/lane/rejected/count",
+		"number of parcels rejected because lane is saturated",
+		stats.UnitDimensionless,
+	)
+	statLaneWaitTime = stats.Float64(
+		"messagebus/lane/wait/time",
+		"time parcels spent waiting for a free worker in lane",
+		stats.UnitMilliseconds,
+	)
 )
 
 func init() {
@@ -63,6 +79,21 @@ func init() {
 			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
 			TagKeys:     []tag.Key{tagMessageType},
 		},
+		&view.View{
+			Measure:     statLaneQueueDepth,
+			Aggregation: view.LastValue(),
+			TagKeys:     []tag.Key{tagLane},
+		},
+		&view.View{
+			Measure:     statLaneRejectedTotal,
+			Aggregation: view.Sum(),
+			TagKeys:     []tag.Key{tagLane, tagMessageType},
+		},
+		&view.View{
+			Measure:     statLaneWaitTime,
+			Aggregation: view.Distribution(0.001, 0.01, 0.1, 1, 10, 100, 1000, 5000, 10000, 20000),
+			TagKeys:     []tag.Key{tagLane, tagMessageType},
+		},
 	)
 	if err != nil {
 		panic(err)
@@ -35,6 +35,13 @@ func GetInsolarRegistry(nodeRole string) *prometheus.Registry {
 	// insolar collectors
 	registerer.MustRegister(NetworkFutures)
 	registerer.MustRegister(NetworkConnections)
+	registerer.MustRegister(NetworkConnectionsOpened)
+	registerer.MustRegister(NetworkConnectionsReused)
+	registerer.MustRegister(NetworkConnectionsEvicted)
+	registerer.MustRegister(NetworkRelayClients)
+	registerer.MustRegister(NetworkRelayedPacketsTotal)
+	registerer.MustRegister(NetworkRelayedSize)
+	registerer.MustRegister(NetworkRelayRejectedTotal)
 	registerer.MustRegister(NetworkPacketTimeoutTotal)
 	registerer.MustRegister(NetworkPacketReceivedTotal)
 	registerer.MustRegister(NetworkComplete)
@@ -76,6 +76,62 @@ var NetworkConnections = prometheus.NewGauge(prometheus.GaugeOpts{
 	Subsystem: "network",
 })
 
+// NetworkConnectionsOpened is total number of connections opened to peer
+var NetworkConnectionsOpened = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "connections_opened_total",
+	Help:      "Total number of network transport connections opened to peer",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer"})
+
+// NetworkConnectionsReused is total number of sends to peer over already open connection
+var NetworkConnectionsReused = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "connections_reused_total",
+	Help:      "Total number of network transport connections reused for peer",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer"})
+
+// NetworkConnectionsEvicted is total number of connections to peer evicted from pool
+var NetworkConnectionsEvicted = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "connections_evicted_total",
+	Help:      "Total number of network transport connections evicted from pool",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"peer", "reason"})
+
+// NetworkRelayClients is current number of nodes relayed by this node
+var NetworkRelayClients = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name:      "relay_clients",
+	Help:      "Current number of nodes relayed by this node",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+})
+
+// NetworkRelayedPacketsTotal is total number of packets relayed by this node
+var NetworkRelayedPacketsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "relayed_packets_total",
+	Help:      "Total number of packets relayed by this node",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"direction"})
+
+// NetworkRelayedSize is total relayed bytes
+var NetworkRelayedSize = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "relayed_bytes_total",
+	Help:      "Total number of bytes relayed by this node",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"direction"})
+
+// NetworkRelayRejectedTotal is total number of packets dropped by relay
+var NetworkRelayRejectedTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name:      "relay_rejected_total",
+	Help:      "Total number of packets dropped by relay",
+	Namespace: insolarNamespace,
+	Subsystem: "network",
+}, []string{"reason"})
+
 // NetworkComplete is metric that is committed when the node reaches complete network state
 var NetworkComplete = prometheus.NewGauge(prometheus.GaugeOpts{
 	Name:      "complete_network_state",
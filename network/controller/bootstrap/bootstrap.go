@@ -98,6 +98,7 @@ type Bootstrapper interface {
 
 type bootstrapper struct {
 	Certificate     insolar.Certificate       `inject:""`
+	Discovery       network.DiscoveryProvider `inject:""`
 	NodeKeeper      network.NodeKeeper        `inject:""`
 	NetworkSwitcher insolar.NetworkSwitcher   `inject:""`
 	Transport       network.InternalTransport `inject:""`
@@ -220,7 +221,7 @@ func (bc *bootstrapper) Bootstrap(ctx context.Context) (*network.BootstrapResult
 	log.Info("Bootstrapping to discovery node")
 	ctx, span := instracer.StartSpan(ctx, "Bootstrapper.Bootstrap")
 	defer span.End()
-	ch := bc.getDiscoveryNodesChannel(ctx, bc.Certificate.GetDiscoveryNodes(), 1)
+	ch := bc.getDiscoveryNodesChannel(ctx, bc.Discovery.GetDiscoveryNodes(), 1)
 	result := bc.waitResultFromChannel(ctx, ch)
 	if result == nil {
 		return nil, nil, errors.New("Failed to bootstrap to any of discovery nodes")
@@ -295,7 +296,7 @@ func (bc *bootstrapper) BootstrapDiscovery(ctx context.Context) (*network.Bootst
 	logger.Info("[ BootstrapDiscovery ] Network bootstrap between discovery nodes")
 	ctx, span := instracer.StartSpan(ctx, "Bootstrapper.BootstrapDiscovery")
 	defer span.End()
-	discoveryNodes := bc.Certificate.GetDiscoveryNodes()
+	discoveryNodes := bc.Discovery.GetDiscoveryNodes()
 	var err error
 	discoveryNodes, err = RemoveOrigin(discoveryNodes, *bc.Certificate.GetNodeRef())
 	if err != nil {
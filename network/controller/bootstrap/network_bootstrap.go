@@ -76,6 +76,7 @@ type networkBootstrapper struct {
 	SessionManager      SessionManager              `inject:""`
 	AuthController      AuthorizationController     `inject:""`
 	ChallengeController ChallengeResponseController `inject:""`
+	SnapshotController  SnapshotController          `inject:""`
 }
 
 func (nb *networkBootstrapper) Bootstrap(ctx context.Context) (*network.BootstrapResult, error) {
@@ -133,7 +134,25 @@ func (nb *networkBootstrapper) bootstrapJoiner(ctx context.Context) (*network.Bo
 	// origin := nb.NodeKeeper.GetOrigin()
 	// mutableOrigin := origin.(nodenetwork.MutableNode)
 	// mutableOrigin.SetShortID(data.AssignShortID)
-	return result, nb.AuthController.Register(ctx, discoveryNode, sessionID)
+	err = nb.AuthController.Register(ctx, discoveryNode, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	// snapshot is applied after registration, because it switches network to the complete state
+	nb.applySnapshot(ctx, discoveryNode)
+	return result, nil
+}
+
+// applySnapshot sets the network state from the discovery node snapshot. If the snapshot is not available
+// the node learns the network state via consensus during the next pulses.
+func (nb *networkBootstrapper) applySnapshot(ctx context.Context, discoveryNode *DiscoveryNode) {
+	bundle, err := nb.SnapshotController.Fetch(ctx, discoveryNode)
+	if err == nil {
+		err = nb.SnapshotController.Apply(ctx, bundle)
+	}
+	if err != nil {
+		log.Warnf("[ Bootstrap ] Failed to bootstrap from network snapshot of %s: %s", discoveryNode.Host, err)
+	}
 }
 
 func (nb *networkBootstrapper) bootstrapDiscovery(ctx context.Context) (*network.BootstrapResult, error) {
@@ -0,0 +1,429 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+	"sort"
+
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/node"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/pkg/errors"
+	"go.opencensus.io/trace"
+)
+
+// SnapshotController serves and fetches signed network snapshots. A snapshot allows joining node to start
+// from the current network state instead of waiting several pulses to learn it.
+type SnapshotController interface {
+	component.Initer
+
+	// Fetch requests snapshot from the discovery node and checks its signature.
+	Fetch(ctx context.Context, discoveryNode *DiscoveryNode) (*SnapshotBundle, error)
+	// Apply sets state from the verified snapshot to the node keeper, jet storage and pulse manager
+	// and switches network to the complete state.
+	Apply(ctx context.Context, bundle *SnapshotBundle) error
+}
+
+// cloudStateProvider provides the cloud state agreed in the last consensus.
+type cloudStateProvider interface {
+	GetCloudState() *phases.CloudState
+}
+
+type snapshotController struct {
+	Bootstrapper               Bootstrapper                       `inject:""`
+	NodeKeeper                 network.NodeKeeper                 `inject:""`
+	NetworkSwitcher            insolar.NetworkSwitcher            `inject:""`
+	PulseStorage               insolar.PulseStorage               `inject:""`
+	PulseManager               insolar.PulseManager               `inject:""`
+	JetStorage                 jet.Storage                        `inject:""`
+	CloudStateProvider         cloudStateProvider                 `inject:""`
+	Certificate                insolar.Certificate                `inject:""`
+	KeyProcessor               insolar.KeyProcessor               `inject:""`
+	CryptographyService        insolar.CryptographyService        `inject:""`
+	PlatformCryptographyScheme insolar.PlatformCryptographyScheme `inject:""`
+	Transport                  network.InternalTransport          `inject:""`
+
+	options *common.Options
+}
+
+// SnapshotRequest is a request for the signed network snapshot.
+type SnapshotRequest struct{}
+
+// SnapshotResponse contains signed network snapshot or error if discovery node can't serve it.
+type SnapshotResponse struct {
+	Bundle *SnapshotBundle
+	Error  string
+}
+
+// SnapshotBundle is the network state of the discovery node for the latest pulse.
+// Cloud hash is calculated from globules and the previous cloud hash, globule hashes are signed
+// by nodes of the snapshot. Signature is the discovery node signature of the bundle hash.
+type SnapshotBundle struct {
+	Pulse         insolar.Pulse
+	Snapshot      []byte
+	CloudHash     []byte
+	PrevCloudHash []byte
+	Globules      []Globule
+	Jets          []insolar.JetID
+	// Bans are nodes banned by consensus for proven violations with pulse numbers when their bans expire
+	Bans      map[insolar.Reference]insolar.PulseNumber
+	Signature []byte
+}
+
+// Globule is a globule proof of the last consensus with signatures of the globule hash by nodes which agreed on it.
+type Globule struct {
+	GlobuleID  insolar.GlobuleID
+	NodeCount  uint32
+	NodeRoot   []byte
+	Signatures map[insolar.Reference][]byte
+}
+
+func (g *Globule) proof(prevCloudHash []byte) *merkle.GlobuleProof {
+	return &merkle.GlobuleProof{
+		PrevCloudHash: prevCloudHash,
+		GlobuleID:     g.GlobuleID,
+		NodeCount:     g.NodeCount,
+		NodeRoot:      g.NodeRoot,
+	}
+}
+
+func init() {
+	gob.Register(&SnapshotRequest{})
+	gob.Register(&SnapshotResponse{})
+}
+
+func (b *SnapshotBundle) hash(scheme insolar.PlatformCryptographyScheme) []byte {
+	hasher := scheme.IntegrityHasher()
+	number := make([]byte, 4)
+	binary.BigEndian.PutUint32(number, uint32(b.Pulse.PulseNumber))
+	_, _ = hasher.Write(number)
+	_, _ = hasher.Write(b.Pulse.Entropy[:])
+	_, _ = hasher.Write(b.Snapshot)
+	_, _ = hasher.Write(b.CloudHash)
+	_, _ = hasher.Write(b.PrevCloudHash)
+	for _, g := range b.Globules {
+		binary.BigEndian.PutUint32(number, uint32(g.GlobuleID))
+		_, _ = hasher.Write(number)
+		binary.BigEndian.PutUint32(number, g.NodeCount)
+		_, _ = hasher.Write(number)
+		_, _ = hasher.Write(g.NodeRoot)
+	}
+	for _, id := range b.Jets {
+		_, _ = hasher.Write(id[:])
+	}
+	banned := make([]insolar.Reference, 0, len(b.Bans))
+	for ref := range b.Bans {
+		banned = append(banned, ref)
+	}
+	sort.Slice(banned, func(i, j int) bool {
+		return bytes.Compare(banned[i][:], banned[j][:]) < 0
+	})
+	for _, ref := range banned {
+		binary.BigEndian.PutUint32(number, uint32(b.Bans[ref]))
+		_, _ = hasher.Write(ref[:])
+		_, _ = hasher.Write(number)
+	}
+	return hasher.Sum(nil)
+}
+
+// Fetch requests snapshot from the discovery node and checks its signature.
+func (sc *snapshotController) Fetch(ctx context.Context, discoveryNode *DiscoveryNode) (*SnapshotBundle, error) {
+	inslogger.FromContext(ctx).Infof("Requesting network snapshot from host: %s", discoveryNode.Host)
+
+	ctx, span := instracer.StartSpan(ctx, "SnapshotController.Fetch")
+	span.AddAttributes(
+		trace.StringAttribute("node", discoveryNode.Node.GetNodeRef().String()),
+	)
+	defer span.End()
+
+	request := sc.Transport.NewRequestBuilder().Type(types.Snapshot).Data(&SnapshotRequest{}).Build()
+	future, err := sc.Transport.SendRequestPacket(ctx, request, discoveryNode.Host)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error sending snapshot request")
+	}
+	response, err := future.GetResponse(sc.options.PacketTimeout)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting response for snapshot request")
+	}
+	data := response.GetData().(*SnapshotResponse)
+	if data.Bundle == nil {
+		return nil, errors.New("Snapshot rejected: " + data.Error)
+	}
+	err = sc.verify(data.Bundle, discoveryNode.Node)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to verify snapshot")
+	}
+	return data.Bundle, nil
+}
+
+// Apply sets state from the verified snapshot to the node keeper, jet storage and pulse manager
+// and switches network to the complete state, so the node doesn't wait for consensus to learn it.
+func (sc *snapshotController) Apply(ctx context.Context, bundle *SnapshotBundle) error {
+	snapshot := &node.Snapshot{}
+	err := snapshot.Decode(bundle.Snapshot)
+	if err != nil {
+		return errors.Wrap(err, "[ Apply ] Failed to decode snapshot")
+	}
+
+	origin := sc.NodeKeeper.GetOrigin().ID()
+	nodes := make([]insolar.NetworkNode, 0)
+	for _, n := range node.NewAccessor(snapshot).GetActiveNodes() {
+		// the origin is in the list if the node is restarted, it should join the network again via consensus
+		if !n.ID().Equal(origin) {
+			nodes = append(nodes, n)
+		}
+	}
+	sc.NodeKeeper.SetInitialSnapshot(nodes)
+	sc.NodeKeeper.SetCloudHash(bundle.CloudHash)
+	sc.NodeKeeper.SetBans(bundle.Bans)
+	sc.JetStorage.Update(ctx, bundle.Pulse.PulseNumber, true, bundle.Jets...)
+
+	// records of the pulse are not available on the joining node, so the pulse is not persisted
+	err = sc.PulseManager.Set(ctx, bundle.Pulse, false)
+	if err != nil {
+		return errors.Wrap(err, "[ Apply ] Failed to set pulse")
+	}
+	sc.Bootstrapper.SetLastPulse(bundle.Pulse.PulseNumber)
+
+	sc.NodeKeeper.SetIsBootstrapped(true)
+	err = sc.NetworkSwitcher.OnPulse(ctx, bundle.Pulse)
+	if err != nil {
+		return errors.Wrap(err, "[ Apply ] Failed to switch network state")
+	}
+
+	inslogger.FromContext(ctx).Infof("Applied network snapshot for pulse %d: active nodes %d, jets %d",
+		bundle.Pulse.PulseNumber, len(nodes), len(bundle.Jets))
+	return nil
+}
+
+// trustedKeys returns public keys of nodes known to the node before the snapshot is applied:
+// discovery nodes of the certificate and active nodes of the node keeper, e.g. if the node is restarted.
+// Keys of other nodes come from the snapshot itself, so they can be forged by the discovery node serving it.
+func (sc *snapshotController) trustedKeys() map[insolar.Reference]crypto.PublicKey {
+	keys := make(map[insolar.Reference]crypto.PublicKey)
+	for _, n := range sc.NodeKeeper.GetAccessor().GetActiveNodes() {
+		keys[n.ID()] = n.PublicKey()
+	}
+	for _, n := range sc.Certificate.GetDiscoveryNodes() {
+		if ref := n.GetNodeRef(); ref != nil {
+			keys[*ref] = n.GetPublicKey()
+		}
+	}
+	delete(keys, sc.NodeKeeper.GetOrigin().ID())
+	return keys
+}
+
+// verify checks that the bundle is signed by the discovery node, the pulse is signed by pulsars,
+// globule hashes are signed by the BFT majority of the snapshot nodes and the cloud hash is calculated
+// from these globules. Snapshot nodes can be invented by the discovery node, so the bundle is trusted
+// only if the majority of nodes known to the node from trusted sources signed globules of the snapshot
+// with their known keys.
+func (sc *snapshotController) verify(bundle *SnapshotBundle, discovery insolar.DiscoveryNode) error {
+	if len(bundle.Signature) == 0 {
+		return errors.New("[ verify ] Snapshot signature is empty")
+	}
+	signature := insolar.SignatureFromBytes(bundle.Signature)
+	if !sc.CryptographyService.Verify(discovery.GetPublicKey(), signature, bundle.hash(sc.PlatformCryptographyScheme)) {
+		return errors.New("[ verify ] Snapshot signature is invalid")
+	}
+
+	// joining node has no accepted pulses yet, so pulsars of the certificate are the only known ones
+	err := common.VerifyPulseSign(bundle.Pulse, sc.Certificate.GetPulsarPublicKeys(),
+		sc.PlatformCryptographyScheme, sc.KeyProcessor, sc.CryptographyService)
+	if err != nil {
+		return errors.Wrap(err, "[ verify ] Failed to verify snapshot pulse")
+	}
+	err = common.VerifyPulseEntropy(&bundle.Pulse, sc.options.RequireEntropyProofs,
+		sc.PlatformCryptographyScheme, sc.KeyProcessor)
+	if err != nil {
+		return errors.Wrap(err, "[ verify ] Failed to verify snapshot pulse")
+	}
+
+	snapshot := &node.Snapshot{}
+	err = snapshot.Decode(bundle.Snapshot)
+	if err != nil {
+		return errors.Wrap(err, "[ verify ] Failed to decode snapshot")
+	}
+	accessor := node.NewAccessor(snapshot)
+	if len(bundle.Globules) == 0 {
+		return errors.New("[ verify ] Snapshot has no globules")
+	}
+
+	trusted := sc.trustedKeys()
+	if len(trusted) == 0 {
+		return errors.New("[ verify ] No trusted nodes to check snapshot signatures")
+	}
+	trustedSigned := 0
+
+	proofs := make([]*merkle.GlobuleProof, 0, len(bundle.Globules))
+	nodeCount := 0
+	for _, g := range bundle.Globules {
+		proof := g.proof(bundle.PrevCloudHash)
+		globuleHash := merkle.GlobuleHash(sc.PlatformCryptographyScheme, proof)
+		signed := 0
+		for ref, sign := range g.Signatures {
+			n := accessor.GetActiveNode(ref)
+			if n == nil || n.GetGlobuleID() != g.GlobuleID {
+				continue
+			}
+			if !sc.CryptographyService.Verify(n.PublicKey(), insolar.SignatureFromBytes(sign), globuleHash) {
+				continue
+			}
+			signed++
+			key, ok := trusted[ref]
+			if ok && sc.CryptographyService.Verify(key, insolar.SignatureFromBytes(sign), globuleHash) {
+				trustedSigned++
+			}
+		}
+		if signed < int(math.Floor(phases.BFTPercent*float64(g.NodeCount)))+1 {
+			return errors.Errorf("[ verify ] Globule %d hash is signed by %d of %d nodes", g.GlobuleID, signed, g.NodeCount)
+		}
+		proofs = append(proofs, proof)
+		nodeCount += int(g.NodeCount)
+	}
+	if nodeCount != len(accessor.GetActiveNodes()) {
+		return errors.Errorf("[ verify ] Globules have %d nodes, snapshot has %d", nodeCount, len(accessor.GetActiveNodes()))
+	}
+	if trustedSigned < len(trusted)/2+1 {
+		return errors.Errorf("[ verify ] Snapshot is signed by %d of %d trusted nodes", trustedSigned, len(trusted))
+	}
+
+	cloudHash, err := merkle.CloudHash(sc.PlatformCryptographyScheme, &merkle.CloudEntry{
+		ProofSet:      proofs,
+		PrevCloudHash: bundle.PrevCloudHash,
+	})
+	if err != nil {
+		return errors.Wrap(err, "[ verify ] Failed to calculate cloud hash")
+	}
+	if !bytes.Equal(cloudHash, bundle.CloudHash) {
+		return errors.New("[ verify ] Cloud hash doesn't match globules")
+	}
+	return nil
+}
+
+func (sc *snapshotController) buildBundle(ctx context.Context) (*SnapshotBundle, error) {
+	pulse, err := sc.PulseStorage.Current(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ buildBundle ] Failed to get current pulse")
+	}
+	if len(pulse.Signs) == 0 {
+		return nil, errors.New("[ buildBundle ] Current pulse is not signed by pulsars")
+	}
+	cloud := sc.CloudStateProvider.GetCloudState()
+	if cloud == nil {
+		return nil, errors.New("[ buildBundle ] Cloud state is not agreed yet")
+	}
+	snapshot, err := sc.NodeKeeper.GetSnapshotCopy().Encode()
+	if err != nil {
+		return nil, errors.Wrap(err, "[ buildBundle ] Failed to encode snapshot")
+	}
+
+	bundle := &SnapshotBundle{
+		Pulse:         *pulse,
+		Snapshot:      snapshot,
+		CloudHash:     cloud.Hash,
+		PrevCloudHash: cloud.Entry.PrevCloudHash,
+		Jets:          sc.JetStorage.All(ctx, pulse.PulseNumber),
+		Bans:          sc.NodeKeeper.GetBans(),
+	}
+	for _, proof := range cloud.Entry.ProofSet {
+		globule := Globule{
+			GlobuleID:  proof.GlobuleID,
+			NodeCount:  proof.NodeCount,
+			NodeRoot:   proof.NodeRoot,
+			Signatures: make(map[insolar.Reference][]byte, len(cloud.Signatures)),
+		}
+		for ref, sign := range cloud.Signatures {
+			globule.Signatures[ref] = sign.Bytes()
+		}
+		bundle.Globules = append(bundle.Globules, globule)
+	}
+	signature, err := sc.CryptographyService.Sign(bundle.hash(sc.PlatformCryptographyScheme))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ buildBundle ] Failed to sign snapshot")
+	}
+	bundle.Signature = signature.Bytes()
+	return bundle, nil
+}
+
+func (sc *snapshotController) processSnapshotRequest(ctx context.Context, request network.Request) (network.Response, error) {
+	if sc.NetworkSwitcher.GetState() != insolar.CompleteNetworkState {
+		return sc.Transport.BuildResponse(ctx, request, &SnapshotResponse{Error: "network is not ready"}), nil
+	}
+	bundle, err := sc.buildBundle(ctx)
+	if err != nil {
+		return sc.Transport.BuildResponse(ctx, request, &SnapshotResponse{Error: err.Error()}), nil
+	}
+	return sc.Transport.BuildResponse(ctx, request, &SnapshotResponse{Bundle: bundle}), nil
+}
+
+func (sc *snapshotController) Init(ctx context.Context) error {
+	sc.Transport.RegisterPacketHandler(types.Snapshot, sc.processSnapshotRequest)
+	return nil
+}
+
+func NewSnapshotController(options *common.Options) SnapshotController {
+	return &snapshotController{options: options}
+}
@@ -0,0 +1,440 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package bootstrap
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"testing"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/consensus/phases"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/jet"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/merkle"
+	"github.com/insolar/insolar/network/node"
+	"github.com/insolar/insolar/network/nodenetwork"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type staterMock struct{}
+
+func (*staterMock) State() ([]byte, error) {
+	return make([]byte, 64), nil
+}
+
+type cloudStateMock struct {
+	state *phases.CloudState
+}
+
+func (c *cloudStateMock) GetCloudState() *phases.CloudState {
+	return c.state
+}
+
+func newKeyedNode(t *testing.T, role insolar.StaticRole) (insolar.NetworkNode, insolar.CryptographyService) {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	n := node.NewNode(testutils.RandomRef(), role, kp.ExtractPublicKey(key), "127.0.0.1:0", "")
+	return n, cryptography.NewKeyBoundCryptographyService(key)
+}
+
+func newSignedPulse(t *testing.T) insolar.Pulse {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubKey, err := kp.ExportPublicKeyPEM(kp.ExtractPublicKey(key))
+	require.NoError(t, err)
+	service := cryptography.NewKeyBoundCryptographyService(key)
+
+	pulse := pulsar.NewPulse(10, insolar.FirstPulseNumber, &entropygenerator.StandardEntropyGenerator{})
+	pulse.PulseNumber = insolar.FirstPulseNumber + 10
+	var chain insolar.EntropyChainCommitment
+	entropySign, err := service.Sign(insolar.EntropyProofData(pulse.Entropy, chain))
+	require.NoError(t, err)
+	pulse.EntropyProofs = map[string]insolar.PulseEntropyProof{
+		string(pubKey): {Entropy: pulse.Entropy, Signature: entropySign.Bytes()},
+	}
+
+	psc := insolar.PulseSenderConfirmation{
+		PulseNumber:     pulse.PulseNumber,
+		ChosenPublicKey: string(pubKey),
+		Entropy:         pulse.Entropy,
+	}
+	payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
+	hash, err := payload.Hash(platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher())
+	require.NoError(t, err)
+	sign, err := service.Sign(hash)
+	require.NoError(t, err)
+	psc.Signature = sign.Bytes()
+	pulse.Signs = map[string]insolar.PulseSenderConfirmation{string(pubKey): psc}
+	return *pulse
+}
+
+// newCloudState returns cloud state with the globule hash signed by the nodes.
+func newCloudState(t *testing.T, nodeCount int, signers map[insolar.Reference]insolar.CryptographyService) *phases.CloudState {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	proof := &merkle.GlobuleProof{
+		PrevCloudHash: []byte("prev cloud hash"),
+		NodeCount:     uint32(nodeCount),
+		NodeRoot:      []byte("node root"),
+	}
+	globuleHash := merkle.GlobuleHash(scheme, proof)
+	signatures := make(map[insolar.Reference]insolar.Signature)
+	for ref, cs := range signers {
+		sign, err := cs.Sign(globuleHash)
+		require.NoError(t, err)
+		signatures[ref] = *sign
+	}
+	entry := &merkle.CloudEntry{ProofSet: []*merkle.GlobuleProof{proof}, PrevCloudHash: proof.PrevCloudHash}
+	hash, err := merkle.CloudHash(scheme, entry)
+	require.NoError(t, err)
+	return &phases.CloudState{Hash: hash, Entry: entry, Signatures: signatures}
+}
+
+// newDiscoveryCertificate returns certificate listing the nodes as discovery ones.
+func newDiscoveryCertificate(nodes ...insolar.NetworkNode) *certificate.Certificate {
+	cert := &certificate.Certificate{}
+	for _, n := range nodes {
+		cert.BootstrapNodes = append(cert.BootstrapNodes, *certificate.NewBootstrapNode(n.PublicKey(), "", "", n.ID().String()))
+	}
+	return cert
+}
+
+func newTestSnapshotController(t *testing.T, keeper network.NodeKeeper, cs insolar.CryptographyService,
+	pulse insolar.Pulse, cloud *phases.CloudState) *snapshotController {
+
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&pulse, nil)
+	pulseManager := testutils.NewPulseManagerMock(t)
+	pulseManager.SetMock.Return(nil)
+	switcher := testutils.NewNetworkSwitcherMock(t)
+	switcher.OnPulseMock.Return(nil)
+
+	return &snapshotController{
+		Bootstrapper:               NewBootstrapper(nil),
+		NodeKeeper:                 keeper,
+		NetworkSwitcher:            switcher,
+		PulseStorage:               pulseStorage,
+		PulseManager:               pulseManager,
+		JetStorage:                 jet.NewStore(),
+		CloudStateProvider:         &cloudStateMock{state: cloud},
+		Certificate:                &certificate.Certificate{},
+		KeyProcessor:               platformpolicy.NewKeyProcessor(),
+		CryptographyService:        cs,
+		PlatformCryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+		options:                    &common.Options{},
+	}
+}
+
+func TestSnapshotController_BuildAndApply(t *testing.T) {
+	ctx := context.Background()
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	virtual, virtualCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+	joiner, joinerCS := newKeyedNode(t, insolar.StaticRoleLightMaterial)
+	pulse := newSignedPulse(t)
+	cloud := newCloudState(t, 2, map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		virtual.ID():   virtualCS,
+	})
+
+	discoveryKeeper := nodenetwork.NewNodeKeeper(discovery)
+	discoveryKeeper.SetInitialSnapshot([]insolar.NetworkNode{discovery, virtual})
+	server := newTestSnapshotController(t, discoveryKeeper, discoveryCS, pulse, cloud)
+	server.JetStorage.Update(ctx, pulse.PulseNumber, true, *insolar.NewJetID(0, nil))
+	_, _, err := server.JetStorage.Split(ctx, pulse.PulseNumber, *insolar.NewJetID(0, nil))
+	require.NoError(t, err)
+
+	built, err := server.buildBundle(ctx)
+	require.NoError(t, err)
+	require.Len(t, built.Jets, 2)
+
+	// bundle is sent to the joining node in the snapshot response
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&SnapshotResponse{Bundle: built}))
+	response := &SnapshotResponse{}
+	require.NoError(t, gob.NewDecoder(&buf).Decode(response))
+	bundle := response.Bundle
+
+	joinerKeeper := nodenetwork.NewNodeKeeper(joiner)
+	client := newTestSnapshotController(t, joinerKeeper, joinerCS, insolar.Pulse{}, nil)
+	pulseManager := testutils.NewPulseManagerMock(t)
+	pulseManager.SetMock.Return(nil)
+	client.PulseManager = pulseManager
+	client.Certificate = newDiscoveryCertificate(discovery)
+	require.NoError(t, client.verify(bundle, certificate.NewBootstrapNode(discovery.PublicKey(), "", "", "")))
+	require.NoError(t, client.Apply(ctx, bundle))
+
+	require.Len(t, joinerKeeper.GetAccessor().GetActiveNodes(), 2)
+	require.NotNil(t, joinerKeeper.GetAccessor().GetActiveNode(discovery.ID()))
+	require.NotNil(t, joinerKeeper.GetAccessor().GetActiveNode(virtual.ID()))
+	require.Equal(t, []byte(cloud.Hash), joinerKeeper.GetCloudHash())
+	require.ElementsMatch(t, bundle.Jets, client.JetStorage.All(ctx, bundle.Pulse.PulseNumber))
+	require.Equal(t, bundle.Pulse.PulseNumber, client.Bootstrapper.GetLastPulse())
+	require.True(t, joinerKeeper.IsBootstrapped())
+	require.Equal(t, uint64(1), pulseManager.SetCounter)
+	require.Equal(t, uint64(1), client.NetworkSwitcher.(*testutils.NetworkSwitcherMock).OnPulseCounter)
+}
+
+func TestSnapshotController_ApplySkipsOrigin(t *testing.T) {
+	ctx := context.Background()
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	restarted, restartedCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+	pulse := newSignedPulse(t)
+	cloud := newCloudState(t, 2, map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		restarted.ID(): restartedCS,
+	})
+
+	discoveryKeeper := nodenetwork.NewNodeKeeper(discovery)
+	discoveryKeeper.SetInitialSnapshot([]insolar.NetworkNode{discovery, restarted})
+	bundle, err := newTestSnapshotController(t, discoveryKeeper, discoveryCS, pulse, cloud).buildBundle(ctx)
+	require.NoError(t, err)
+
+	keeper := nodenetwork.NewNodeKeeper(restarted)
+	require.NoError(t, newTestSnapshotController(t, keeper, restartedCS, insolar.Pulse{}, nil).Apply(ctx, bundle))
+	require.Len(t, keeper.GetAccessor().GetActiveNodes(), 1)
+	require.Nil(t, keeper.GetAccessor().GetActiveNode(restarted.ID()))
+}
+
+func TestSnapshotController_BuildWithoutCloudState(t *testing.T) {
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	keeper := nodenetwork.NewNodeKeeper(discovery)
+	keeper.SetInitialSnapshot([]insolar.NetworkNode{discovery})
+
+	_, err := newTestSnapshotController(t, keeper, discoveryCS, newSignedPulse(t), nil).buildBundle(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cloud state is not agreed yet")
+}
+
+func TestSnapshotController_VerifyFails(t *testing.T) {
+	ctx := context.Background()
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	virtual, virtualCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+	other, otherCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+	signers := map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		virtual.ID():   virtualCS,
+	}
+
+	keeper := nodenetwork.NewNodeKeeper(discovery)
+	keeper.SetInitialSnapshot([]insolar.NetworkNode{discovery, virtual})
+	server := newTestSnapshotController(t, keeper, discoveryCS, newSignedPulse(t), newCloudState(t, 2, signers))
+	client := newTestSnapshotController(t, nodenetwork.NewNodeKeeper(other), otherCS, insolar.Pulse{}, nil)
+	client.Certificate = newDiscoveryCertificate(discovery)
+	discoveryNode := certificate.NewBootstrapNode(discovery.PublicKey(), "", "", "")
+
+	bundle, err := server.buildBundle(ctx)
+	require.NoError(t, err)
+	err = client.verify(bundle, certificate.NewBootstrapNode(other.PublicKey(), "", "", ""))
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Snapshot signature is invalid")
+
+	bundle.CloudHash = []byte("forged cloud hash")
+	require.Error(t, client.verify(bundle, discoveryNode))
+
+	bundle, err = server.buildBundle(ctx)
+	require.NoError(t, err)
+	bundle.Jets = append(bundle.Jets, *insolar.NewJetID(1, nil))
+	require.Error(t, client.verify(bundle, discoveryNode))
+
+	bundle.Signature = nil
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Snapshot signature is empty")
+}
+
+// signBundle signs the bundle as the discovery node, so checks after the bundle signature are reached.
+func signBundle(t *testing.T, bundle *SnapshotBundle, cs insolar.CryptographyService) {
+	sign, err := cs.Sign(bundle.hash(platformpolicy.NewPlatformCryptographyScheme()))
+	require.NoError(t, err)
+	bundle.Signature = sign.Bytes()
+}
+
+func TestSnapshotController_VerifyPulseAndCloud(t *testing.T) {
+	ctx := context.Background()
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	virtual, virtualCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+	light, lightCS := newKeyedNode(t, insolar.StaticRoleLightMaterial)
+	other, otherCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+
+	keeper := nodenetwork.NewNodeKeeper(discovery)
+	keeper.SetInitialSnapshot([]insolar.NetworkNode{discovery, virtual, light})
+	client := newTestSnapshotController(t, nodenetwork.NewNodeKeeper(other), otherCS, insolar.Pulse{}, nil)
+	client.Certificate = newDiscoveryCertificate(discovery)
+	discoveryNode := certificate.NewBootstrapNode(discovery.PublicKey(), "", "", "")
+	build := func(cloud *phases.CloudState) *SnapshotBundle {
+		bundle, err := newTestSnapshotController(t, keeper, discoveryCS, newSignedPulse(t), cloud).buildBundle(ctx)
+		require.NoError(t, err)
+		return bundle
+	}
+	signers := map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		virtual.ID():   virtualCS,
+		light.ID():     lightCS,
+	}
+
+	bundle := build(newCloudState(t, 3, signers))
+	require.NoError(t, client.verify(bundle, discoveryNode))
+
+	// globule hash is signed by less than BFT majority of nodes
+	bundle = build(newCloudState(t, 3, map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		virtual.ID():   virtualCS,
+	}))
+	err := client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signed by 2 of 3 nodes")
+
+	// signatures of nodes out of the snapshot don't count
+	bundle = build(newCloudState(t, 3, map[insolar.Reference]insolar.CryptographyService{
+		discovery.ID(): discoveryCS,
+		virtual.ID():   virtualCS,
+		other.ID():     otherCS,
+	}))
+	require.Error(t, client.verify(bundle, discoveryNode))
+
+	// globules have less nodes than the snapshot
+	bundle = build(newCloudState(t, 2, signers))
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Globules have 2 nodes, snapshot has 3")
+
+	// cloud hash is signed by the discovery node, but it is not calculated from globules
+	bundle = build(newCloudState(t, 3, signers))
+	bundle.CloudHash = []byte("forged cloud hash")
+	signBundle(t, bundle, discoveryCS)
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Cloud hash doesn't match globules")
+
+	// pulse is not signed by pulsars
+	bundle = build(newCloudState(t, 3, signers))
+	for key, sign := range bundle.Pulse.Signs {
+		sign.Signature = []byte("forged")
+		bundle.Pulse.Signs[key] = sign
+	}
+	signBundle(t, bundle, discoveryCS)
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed to verify snapshot pulse")
+
+	// entropy of pulse doesn't match entropy proofs
+	bundle = build(newCloudState(t, 3, signers))
+	for key, proof := range bundle.Pulse.EntropyProofs {
+		proof.Entropy[0] ^= 1
+		bundle.Pulse.EntropyProofs[key] = proof
+	}
+	signBundle(t, bundle, discoveryCS)
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed to verify snapshot pulse")
+
+	// pulse without entropy proofs is accepted unless proofs are required
+	bundle = build(newCloudState(t, 3, signers))
+	bundle.Pulse.EntropyProofs = nil
+	signBundle(t, bundle, discoveryCS)
+	require.NoError(t, client.verify(bundle, discoveryNode))
+	client.options.RequireEntropyProofs = true
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Failed to verify snapshot pulse")
+}
+
+func TestSnapshotController_VerifyTrustedSigners(t *testing.T) {
+	ctx := context.Background()
+	discovery, discoveryCS := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	honest, _ := newKeyedNode(t, insolar.StaticRoleHeavyMaterial)
+	other, otherCS := newKeyedNode(t, insolar.StaticRoleVirtual)
+
+	// dishonest discovery node invents nodes and signs the globule with their keys
+	invented := make([]insolar.NetworkNode, 0, 3)
+	signers := map[insolar.Reference]insolar.CryptographyService{discovery.ID(): discoveryCS}
+	for i := 0; i < 3; i++ {
+		n, cs := newKeyedNode(t, insolar.StaticRoleVirtual)
+		invented = append(invented, n)
+		signers[n.ID()] = cs
+	}
+	keeper := nodenetwork.NewNodeKeeper(discovery)
+	keeper.SetInitialSnapshot(append([]insolar.NetworkNode{discovery}, invented...))
+	server := newTestSnapshotController(t, keeper, discoveryCS, newSignedPulse(t), newCloudState(t, 4, signers))
+	bundle, err := server.buildBundle(ctx)
+	require.NoError(t, err)
+	discoveryNode := certificate.NewBootstrapNode(discovery.PublicKey(), "", "", "")
+
+	// BFT majority of the snapshot nodes signed, but only one of two discovery nodes did
+	client := newTestSnapshotController(t, nodenetwork.NewNodeKeeper(other), otherCS, insolar.Pulse{}, nil)
+	client.Certificate = newDiscoveryCertificate(discovery, honest)
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "signed by 1 of 2 trusted nodes")
+
+	// node keeper state of the restarted node is trusted too
+	restartedKeeper := nodenetwork.NewNodeKeeper(other)
+	restartedKeeper.SetInitialSnapshot([]insolar.NetworkNode{discovery, invented[0]})
+	client = newTestSnapshotController(t, restartedKeeper, otherCS, insolar.Pulse{}, nil)
+	client.Certificate = newDiscoveryCertificate(discovery, honest)
+	require.NoError(t, client.verify(bundle, discoveryNode))
+
+	// no trusted nodes at all
+	client = newTestSnapshotController(t, nodenetwork.NewNodeKeeper(other), otherCS, insolar.Pulse{}, nil)
+	err = client.verify(bundle, discoveryNode)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "No trusted nodes")
+}
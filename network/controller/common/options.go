@@ -84,4 +84,13 @@ type Options struct {
 
 	// FakePulseDuration is a timeout to new pulse in ms
 	FakePulseDuration time.Duration
+
+	// True - node relays packets of nodes behind NAT
+	IsRelay bool
+
+	// Address of relay node to register on if node is behind NAT
+	RelayAddress string
+
+	// True - pulses without entropy proofs are rejected
+	RequireEntropyProofs bool
 }
@@ -0,0 +1,117 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package common
+
+import (
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
+	"github.com/pkg/errors"
+)
+
+// VerifyPulseSign checks that every sign of the pulse is made by the pulsar it is keyed by and confirms this pulse.
+// If knownPulsars is not empty, the pulse must be signed by the majority of them. Signs of other pulsars
+// are accepted, they are pulsars added or keys rotated by agreement of pulsars after knownPulsars were learned.
+func VerifyPulseSign(
+	pulse insolar.Pulse,
+	knownPulsars []string,
+	scheme insolar.PlatformCryptographyScheme,
+	keyProcessor insolar.KeyProcessor,
+	cryptographyService insolar.CryptographyService,
+) error {
+	if len(pulse.Signs) == 0 {
+		return errors.New("[ VerifyPulseSign ] received empty pulse signs")
+	}
+	known := map[string]bool{}
+	for _, pubKey := range knownPulsars {
+		known[pubKey] = true
+	}
+	signedKnown := 0
+	for pubKey, psc := range pulse.Signs {
+		if known[pubKey] {
+			signedKnown++
+		}
+		if psc.PulseNumber != pulse.PulseNumber || psc.Entropy != pulse.Entropy {
+			return errors.New("[ VerifyPulseSign ] sign confirms another pulse")
+		}
+		payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
+		hash, err := payload.Hash(scheme.IntegrityHasher())
+		if err != nil {
+			return errors.Wrap(err, "[ VerifyPulseSign ] error to get a hash from pulse payload")
+		}
+		key, err := keyProcessor.ImportPublicKeyPEM([]byte(pubKey))
+		if err != nil {
+			return errors.Wrap(err, "[ VerifyPulseSign ] error to import a public key")
+		}
+
+		if !cryptographyService.Verify(key, insolar.SignatureFromBytes(psc.Signature), hash) {
+			return errors.New("[ VerifyPulseSign ] error to verify a pulse")
+		}
+	}
+	if len(known) != 0 && signedKnown < len(known)/2+1 {
+		return errors.Errorf("[ VerifyPulseSign ] pulse is signed by %d of %d known pulsars", signedKnown, len(known))
+	}
+	return nil
+}
+
+// VerifyPulseEntropy checks entropy proofs of the pulse. Pulses without proofs are accepted unless proofs are
+// required, so pulses of pulsars which don't produce proofs are accepted during rolling upgrade.
+func VerifyPulseEntropy(
+	pulse *insolar.Pulse,
+	proofsRequired bool,
+	scheme insolar.PlatformCryptographyScheme,
+	keyProcessor insolar.KeyProcessor,
+) error {
+	if len(pulse.EntropyProofs) == 0 && !proofsRequired {
+		return nil
+	}
+	return entropygenerator.VerifyEntropy(scheme, keyProcessor, pulse)
+}
@@ -118,7 +118,18 @@ func ConfigureOptions(conf configuration.Configuration) *common.Options {
 		BootstrapTimeout:    10 * time.Second,
 		HandshakeSessionTTL: time.Duration(config.HandshakeSessionTTL) * time.Millisecond,
 		FakePulseDuration:   time.Duration(conf.Pulsar.PulseTime) * time.Millisecond,
+		IsRelay:             config.IsRelay,
+		RelayAddress:        relayAddress(config),
+
+		RequireEntropyProofs: conf.Service.RequireEntropyProofs,
+	}
+}
+
+func relayAddress(config configuration.HostNetwork) string {
+	if config.IsRelay {
+		return ""
 	}
+	return config.Relay.Address
 }
 
 // NewNetworkController create new network controller.
@@ -52,14 +52,16 @@ package controller
 
 import (
 	"context"
+	"sync"
 
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/common"
 	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/packet/types"
-	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
 	"github.com/pkg/errors"
 )
 
@@ -75,6 +77,12 @@ type pulseController struct {
 	CryptographyService insolar.CryptographyService        `inject:""`
 	Resolver            network.RoutingTable               `inject:""`
 	Network             network.HostNetwork                `inject:""`
+	Certificate         insolar.Certificate                `inject:""`
+
+	options *common.Options
+
+	lastPulseLock sync.Mutex
+	lastPulse     *insolar.Pulse
 }
 
 func (pc *pulseController) Init(ctx context.Context) error {
@@ -92,6 +100,10 @@ func (pc *pulseController) processPulse(ctx context.Context, request network.Req
 	if !verified {
 		return nil, errors.New("[ pulseController ] processPulse: failed to verify a pulse sign")
 	}
+	err = pc.verifyPulseEntropy(data.Pulse)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ pulseController ] processPulse: failed to verify a pulse entropy")
+	}
 	// if we are a joiner node, we should receive pulse from phase1 packet and ignore pulse from pulsar
 	if !pc.NodeKeeper.GetConsensusInfo().IsJoiner() {
 		go pc.PulseHandler.HandlePulse(context.Background(), data.Pulse)
@@ -107,31 +119,59 @@ func (pc *pulseController) processGetRandomHosts(ctx context.Context, request ne
 	return pc.Network.BuildResponse(ctx, request, &packet.ResponseGetRandomHosts{Hosts: randomHosts}), nil
 }
 
+// knownPulsars returns pulsars which signed the last accepted pulse, so membership changes agreed by pulsars
+// are followed. Pulsars listed in the certificate are used until the first pulse is accepted.
+func (pc *pulseController) knownPulsars() []string {
+	pc.lastPulseLock.Lock()
+	defer pc.lastPulseLock.Unlock()
+
+	if pc.lastPulse == nil {
+		return pc.Certificate.GetPulsarPublicKeys()
+	}
+	keys := make([]string, 0, len(pc.lastPulse.Signs))
+	for key := range pc.lastPulse.Signs {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// verifyPulseSign checks that the pulse is signed by the majority of known pulsars.
 func (pc *pulseController) verifyPulseSign(pulse insolar.Pulse) (bool, error) {
-	hashProvider := pc.CryptographyScheme.IntegrityHasher()
-	if len(pulse.Signs) == 0 {
-		return false, errors.New("[ verifyPulseSign ] received empty pulse signs")
+	err := common.VerifyPulseSign(pulse, pc.knownPulsars(),
+		pc.CryptographyScheme, pc.KeyProcessor, pc.CryptographyService)
+	if err != nil {
+		return false, err
 	}
-	for _, psc := range pulse.Signs {
-		payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
-		hash, err := payload.Hash(hashProvider)
-		if err != nil {
-			return false, errors.Wrap(err, "[ verifyPulseSign ] error to get a hash from pulse payload")
-		}
-		key, err := pc.KeyProcessor.ImportPublicKeyPEM([]byte(psc.ChosenPublicKey))
-		if err != nil {
-			return false, errors.Wrap(err, "[ verifyPulseSign ] error to import a public key")
-		}
+	return true, nil
+}
 
-		verified := pc.CryptographyService.Verify(key, insolar.SignatureFromBytes(psc.Signature), hash)
+// verifyPulseEntropy checks entropy proofs of the pulse and, if the previous pulse was received,
+// that contributions of pulsars continue their committed hash chains.
+func (pc *pulseController) verifyPulseEntropy(pulse insolar.Pulse) error {
+	err := common.VerifyPulseEntropy(&pulse, pc.options.RequireEntropyProofs, pc.CryptographyScheme, pc.KeyProcessor)
+	if err != nil {
+		return err
+	}
 
-		if !verified {
-			return false, errors.New("[ verifyPulseSign ] error to verify a pulse")
+	pc.lastPulseLock.Lock()
+	defer pc.lastPulseLock.Unlock()
+
+	last := pc.lastPulse
+	if last != nil && last.PulseNumber == pulse.PulseNumber {
+		return nil
+	}
+	if last != nil && last.PulseNumber == pulse.PrevPulseNumber && last.NextPulseNumber > last.PulseNumber {
+		// every failed round between pulses could reveal one value of the chain
+		rounds := int((pulse.PulseNumber - last.PulseNumber) / (last.NextPulseNumber - last.PulseNumber))
+		err = entropygenerator.VerifyEntropyChain(pc.CryptographyScheme, last, &pulse, rounds)
+		if err != nil {
+			return err
 		}
 	}
-	return true, nil
+	pc.lastPulse = &pulse
+	return nil
 }
 
-func NewPulseController() PulseController {
-	return &pulseController{}
+func NewPulseController(options *common.Options) PulseController {
+	return &pulseController{options: options}
 }
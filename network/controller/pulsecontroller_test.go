@@ -55,8 +55,10 @@ import (
 	"crypto/rand"
 	"testing"
 
+	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/network/controller/common"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar"
 	"github.com/insolar/insolar/pulsar/entropygenerator"
@@ -71,6 +73,8 @@ func getController(t *testing.T) pulseController {
 		CryptographyScheme:  platformpolicy.NewPlatformCryptographyScheme(),
 		KeyProcessor:        proc,
 		CryptographyService: cryptography.NewKeyBoundCryptographyService(key),
+		Certificate:         &certificate.Certificate{},
+		options:             &common.Options{},
 	}
 }
 
@@ -85,29 +89,43 @@ func getKeys(t *testing.T) (public string, private crypto.PrivateKey) {
 	return string(pubKey), privKey
 }
 
-func TestVerifyPulseSignTrue(t *testing.T) {
-	controller := getController(t)
-	keyStr, privateKey := getKeys(t)
+func signPulse(t *testing.T, pulse *insolar.Pulse, keyStr string, privateKey crypto.PrivateKey) {
+	service := cryptography.NewKeyBoundCryptographyService(privateKey)
 
-	psc := insolar.PulseSenderConfirmation{
-		PulseNumber:     1,
-		ChosenPublicKey: string(keyStr[:]),
-		Entropy:         randomEntropy(),
+	var chain insolar.EntropyChainCommitment
+	entropySign, err := service.Sign(insolar.EntropyProofData(pulse.Entropy, chain))
+	assert.NoError(t, err)
+	pulse.EntropyProofs = map[string]insolar.PulseEntropyProof{
+		keyStr: {Entropy: pulse.Entropy, Signature: entropySign.Bytes()},
 	}
 
+	psc := insolar.PulseSenderConfirmation{
+		PulseNumber:     pulse.PulseNumber,
+		ChosenPublicKey: keyStr,
+		Entropy:         pulse.Entropy,
+	}
 	payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
-	hasher := platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher()
-	hash, err := payload.Hash(hasher)
+	hash, err := payload.Hash(platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher())
 	assert.NoError(t, err)
-	service := cryptography.NewKeyBoundCryptographyService(privateKey)
 	sign, err := service.Sign(hash)
 	assert.NoError(t, err)
-
 	psc.Signature = sign.Bytes()
+	pulse.Signs = map[string]insolar.PulseSenderConfirmation{keyStr: psc}
+}
+
+// addSign adds sign of one more pulsar to the signed pulse.
+func addSign(t *testing.T, pulse *insolar.Pulse, keyStr string, privateKey crypto.PrivateKey) {
+	signed := *pulse
+	signPulse(t, &signed, keyStr, privateKey)
+	pulse.Signs[keyStr] = signed.Signs[keyStr]
+}
+
+func TestVerifyPulseSignTrue(t *testing.T) {
+	controller := getController(t)
+	keyStr, privateKey := getKeys(t)
 
 	pulse := pulsar.NewPulse(1, 0, &entropygenerator.StandardEntropyGenerator{})
-	pulse.Signs = make(map[string]insolar.PulseSenderConfirmation, 1)
-	pulse.Signs["keystr"] = psc
+	signPulse(t, pulse, keyStr, privateKey)
 
 	valid, err := controller.verifyPulseSign(*pulse)
 	assert.NoError(t, err)
@@ -128,11 +146,89 @@ func TestVerifyPulseSignFalse(t *testing.T) {
 
 	pulse := pulsar.NewPulse(1, 0, &entropygenerator.StandardEntropyGenerator{})
 	pulse.Signs = make(map[string]insolar.PulseSenderConfirmation, 1)
-	pulse.Signs["keystr"] = psc
+	pulse.Signs[keyStr] = psc
+
+	valid, err := controller.verifyPulseSign(*pulse)
+	assert.Error(t, err)
+	assert.False(t, valid)
+}
+
+func TestVerifyPulseSign_SignOfAnotherPulsar(t *testing.T) {
+	controller := getController(t)
+	keyStr, privateKey := getKeys(t)
+	anotherKeyStr, _ := getKeys(t)
+
+	pulse := pulsar.NewPulse(1, 0, &entropygenerator.StandardEntropyGenerator{})
+	signPulse(t, pulse, keyStr, privateKey)
+	pulse.Signs[anotherKeyStr] = pulse.Signs[keyStr]
+
+	valid, err := controller.verifyPulseSign(*pulse)
+	assert.Error(t, err)
+	assert.False(t, valid)
+}
+
+func TestVerifyPulseSign_UnknownPulsar(t *testing.T) {
+	controller := getController(t)
+	keyStr, privateKey := getKeys(t)
+	knownKeyStr, _ := getKeys(t)
+	controller.Certificate = &certificate.Certificate{PulsarPublicKeys: []string{knownKeyStr}}
+
+	pulse := pulsar.NewPulse(1, 0, &entropygenerator.StandardEntropyGenerator{})
+	signPulse(t, pulse, keyStr, privateKey)
+
+	valid, err := controller.verifyPulseSign(*pulse)
+	assert.Error(t, err)
+	assert.False(t, valid)
+}
 
+func TestVerifyPulseSign_MembershipChange(t *testing.T) {
+	controller := getController(t)
+	first, firstKey := getKeys(t)
+	second, secondKey := getKeys(t)
+	third, _ := getKeys(t)
+	added, addedKey := getKeys(t)
+	controller.Certificate = &certificate.Certificate{PulsarPublicKeys: []string{first, second, third}}
+
+	// pulsar added by pulsars signs the pulse together with the majority of known ones
+	pulse := pulsar.NewPulse(10, 0, &entropygenerator.StandardEntropyGenerator{})
+	signPulse(t, pulse, first, firstKey)
+	addSign(t, pulse, second, secondKey)
+	addSign(t, pulse, added, addedKey)
 	valid, err := controller.verifyPulseSign(*pulse)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.NoError(t, controller.verifyPulseEntropy(*pulse))
+	assert.ElementsMatch(t, []string{first, second, added}, controller.knownPulsars())
+
+	// the added pulsar is known now, but it isn't the majority
+	next := pulsar.NewPulse(10, pulse.PulseNumber, &entropygenerator.StandardEntropyGenerator{})
+	signPulse(t, next, added, addedKey)
+	valid, err = controller.verifyPulseSign(*next)
 	assert.Error(t, err)
 	assert.False(t, valid)
+
+	// third pulsar is removed by pulsars, so the majority of the last pulse signers is enough
+	addSign(t, next, second, secondKey)
+	valid, err = controller.verifyPulseSign(*next)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+}
+
+func TestVerifyPulseEntropy(t *testing.T) {
+	controller := getController(t)
+	keyStr, privateKey := getKeys(t)
+
+	// pulses of pulsars without entropy proofs are accepted unless proofs are required
+	pulse := pulsar.NewPulse(10, 0, &entropygenerator.StandardEntropyGenerator{})
+	assert.NoError(t, controller.verifyPulseEntropy(*pulse))
+	controller.options.RequireEntropyProofs = true
+	assert.Error(t, controller.verifyPulseEntropy(*pulse))
+
+	signPulse(t, pulse, keyStr, privateKey)
+	assert.NoError(t, controller.verifyPulseEntropy(*pulse))
+
+	pulse.Entropy[0] ^= 1
+	assert.Error(t, controller.verifyPulseEntropy(*pulse))
 }
 
 func randomEntropy() [64]byte {
@@ -0,0 +1,370 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/gob"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/component"
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/insolar/insolar/platformpolicy"
+)
+
+// RelayController registers node behind NAT on relay node and serves relay requests on relay node.
+// Relay node is taken from the configuration of the node behind NAT, relay nodes don't advertise themselves.
+type RelayController interface {
+	component.Initer
+	component.Starter
+	component.Stopper
+}
+
+// RelayCommand is a command of relay request.
+type RelayCommand int
+
+const (
+	// StartRelay asks relay node to start relaying packets of the sender.
+	StartRelay = RelayCommand(iota + 1)
+	// StopRelay asks relay node to stop relaying packets of the sender.
+	StopRelay
+)
+
+// relayRequestTTL is the time relay request is valid for, requests signed earlier are rejected as stale.
+const relayRequestTTL = time.Minute
+
+// RelayRequest is data for request to start or stop relaying.
+type RelayRequest struct {
+	Command     RelayCommand
+	Certificate []byte
+	// Timestamp is unix time of the request in nanoseconds.
+	Timestamp int64
+	// Nonce is a random number, relay node rejects requests with nonce seen before.
+	Nonce uint64
+	// Signature of the sender node ref, relay address, command, timestamp and nonce made with the sender node key.
+	Signature []byte
+}
+
+// RelayResponse is the response for relay request.
+type RelayResponse struct {
+	State relay.State
+	Error string
+}
+
+// RelayState returns state of relaying after request.
+func (r *RelayResponse) RelayState() relay.State {
+	return r.State
+}
+
+// RelayConsensusRequest is data for request that forwards consensus packet to node behind NAT.
+type RelayConsensusRequest struct {
+	Packet []byte
+}
+
+func init() {
+	gob.Register(&RelayRequest{})
+	gob.Register(&RelayResponse{})
+	gob.Register(&RelayConsensusRequest{})
+}
+
+type relayController struct {
+	Transport           network.InternalTransport   `inject:""`
+	ConsensusNetwork    network.ConsensusNetwork    `inject:""`
+	Resolver            network.RoutingTable        `inject:""`
+	Certificate         insolar.Certificate         `inject:""`
+	NetworkCoordinator  insolar.NetworkCoordinator  `inject:""`
+	CryptographyService insolar.CryptographyService `inject:""`
+	Relay               relay.Relay                 `inject:""`
+
+	options *common.Options
+
+	noncesLock sync.Mutex
+	nonces     map[relayNonce]time.Time
+}
+
+type relayNonce struct {
+	nodeID insolar.Reference
+	nonce  uint64
+}
+
+// Init registers relay request handlers.
+func (rc *relayController) Init(ctx context.Context) error {
+	rc.Transport.RegisterPacketHandler(types.Relay, rc.processRelayRequest)
+	if rc.options.IsRelay {
+		// consensus packets for relay clients are sent to consensus address of relay node
+		rc.ConsensusNetwork.RegisterRelayHandler(rc.relayConsensusPacket)
+	}
+	if rc.options.RelayAddress != "" {
+		rc.Transport.RegisterPacketHandler(types.RelayConsensus, rc.processRelayConsensusRequest)
+	}
+	return nil
+}
+
+// Start registers node on relay node if relay address is set.
+func (rc *relayController) Start(ctx context.Context) error {
+	if rc.options.RelayAddress == "" {
+		return nil
+	}
+
+	state, err := rc.sendRelayRequest(ctx, StartRelay)
+	if err != nil {
+		return errors.Wrap(err, "[ Start ] Failed to register on relay node")
+	}
+	if state != relay.Started {
+		return errors.Errorf("[ Start ] Relay node %s did not start relaying, state: %d", rc.options.RelayAddress, state)
+	}
+	inslogger.FromContext(ctx).Infof("Registered on relay node %s", rc.options.RelayAddress)
+	return nil
+}
+
+// Stop unregisters node on relay node if relay address is set.
+func (rc *relayController) Stop(ctx context.Context) error {
+	if rc.options.RelayAddress == "" {
+		return nil
+	}
+
+	if _, err := rc.sendRelayRequest(ctx, StopRelay); err != nil {
+		inslogger.FromContext(ctx).Warn("[ Stop ] Failed to unregister on relay node: ", err.Error())
+	}
+	return nil
+}
+
+func (rc *relayController) sendRelayRequest(ctx context.Context, command RelayCommand) (relay.State, error) {
+	relayHost, err := host.NewHost(rc.options.RelayAddress)
+	if err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to resolve relay address")
+	}
+	serializedCert, err := certificate.Serialize(rc.Certificate)
+	if err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to serialize certificate")
+	}
+	nonce := make([]byte, 8)
+	if _, err := rand.Read(nonce); err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to generate nonce")
+	}
+	data := &RelayRequest{
+		Command:     command,
+		Certificate: serializedCert,
+		Timestamp:   time.Now().UnixNano(),
+		Nonce:       binary.BigEndian.Uint64(nonce),
+	}
+	signature, err := rc.CryptographyService.Sign(relaySignatureData(*rc.Certificate.GetNodeRef(), relayHost.Address.String(), data))
+	if err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to sign relay request")
+	}
+	data.Signature = signature.Bytes()
+
+	request := rc.Transport.NewRequestBuilder().Type(types.Relay).Data(data).Build()
+	future, err := rc.Transport.SendRequestPacket(ctx, request, relayHost)
+	if err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to send relay request")
+	}
+	response, err := future.GetResponse(rc.options.PacketTimeout)
+	if err != nil {
+		return relay.Unknown, errors.Wrap(err, "Failed to get response for relay request")
+	}
+	result := response.GetData().(*RelayResponse)
+	if result.Error != "" {
+		return result.State, errors.New(result.Error)
+	}
+	return result.State, nil
+}
+
+func (rc *relayController) processRelayRequest(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*RelayRequest)
+	sender := request.GetSenderHost()
+	if !rc.options.IsRelay {
+		return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Error, Error: "node is not a relay"}), nil
+	}
+	if err := rc.authenticate(ctx, sender, data); err != nil {
+		inslogger.FromContext(ctx).Warnf("Relay request from %s rejected: %s", sender, err.Error())
+		return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.NoAuth, Error: err.Error()}), nil
+	}
+
+	switch data.Command {
+	case StartRelay:
+		if !rc.Relay.IsClient(sender.NodeID) {
+			if err := rc.Relay.AddClient(sender); err != nil {
+				return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Error, Error: err.Error()}), nil
+			}
+		}
+		inslogger.FromContext(ctx).Infof("Started relaying for node %s", sender.NodeID)
+		return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Started}), nil
+	case StopRelay:
+		if err := rc.Relay.RemoveClient(sender); err != nil {
+			return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Error, Error: err.Error()}), nil
+		}
+		inslogger.FromContext(ctx).Infof("Stopped relaying for node %s", sender.NodeID)
+		return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Stopped}), nil
+	default:
+		return rc.Transport.BuildResponse(ctx, request, &RelayResponse{State: relay.Error, Error: "unknown relay command"}), nil
+	}
+}
+
+// authenticate checks that sender has valid certificate, owns certificate key and publishes relay address.
+func (rc *relayController) authenticate(ctx context.Context, sender *host.Host, data *RelayRequest) error {
+	if sender.Address.String() != rc.Transport.PublicAddress() {
+		return errors.New("sender address is not equal to relay address")
+	}
+	cert, err := certificate.Deserialize(data.Certificate, platformpolicy.NewKeyProcessor())
+	if err != nil {
+		return errors.Wrap(err, "failed to deserialize certificate")
+	}
+	if !cert.GetNodeRef().Equal(sender.NodeID) {
+		return errors.New("certificate node ref is not equal to sender node ref")
+	}
+	valid, err := rc.NetworkCoordinator.ValidateCert(ctx, cert)
+	if !valid {
+		if err == nil {
+			err = errors.New("certificate validation failed")
+		}
+		return err
+	}
+	signed := relaySignatureData(sender.NodeID, sender.Address.String(), data)
+	if !rc.CryptographyService.Verify(cert.GetPublicKey(), insolar.SignatureFromBytes(data.Signature), signed) {
+		return errors.New("invalid signature")
+	}
+	return rc.checkNonce(sender.NodeID, data, time.Now())
+}
+
+// checkNonce rejects stale requests and requests replayed within request TTL.
+func (rc *relayController) checkNonce(nodeID insolar.Reference, data *RelayRequest, now time.Time) error {
+	signedAt := time.Unix(0, data.Timestamp)
+	if now.Sub(signedAt) > relayRequestTTL || signedAt.Sub(now) > relayRequestTTL {
+		return errors.New("request is stale")
+	}
+
+	rc.noncesLock.Lock()
+	defer rc.noncesLock.Unlock()
+
+	for n, expire := range rc.nonces {
+		if now.After(expire) {
+			delete(rc.nonces, n)
+		}
+	}
+	key := relayNonce{nodeID: nodeID, nonce: data.Nonce}
+	if _, ok := rc.nonces[key]; ok {
+		return errors.New("request is replayed")
+	}
+	rc.nonces[key] = signedAt.Add(relayRequestTTL)
+	return nil
+}
+
+// relayConsensusPacket forwards consensus packet to relay client over the connection opened by the client.
+func (rc *relayController) relayConsensusPacket(p packets.ConsensusPacket) bool {
+	receiver, err := rc.Resolver.ResolveConsensus(p.GetTarget())
+	if err != nil || !rc.Relay.IsClient(receiver.NodeID) {
+		return false
+	}
+
+	ctx := context.Background()
+	logger := inslogger.FromContext(ctx)
+	data, err := p.Serialize()
+	if err != nil {
+		logger.Warn("[ relayConsensusPacket ] Failed to serialize consensus packet: ", err.Error())
+		return true
+	}
+	// relay clients publish address of relay node, transport sends packet over client connection
+	clientHost, err := host.NewHostN(rc.Transport.PublicAddress(), receiver.NodeID)
+	if err != nil {
+		logger.Warn("[ relayConsensusPacket ] Failed to create client host: ", err.Error())
+		return true
+	}
+
+	request := rc.Transport.NewRequestBuilder().Type(types.RelayConsensus).Data(&RelayConsensusRequest{Packet: data}).Build()
+	_, err = rc.Transport.SendRequestPacket(ctx, request, clientHost)
+	if err != nil {
+		logger.Warnf("[ relayConsensusPacket ] Failed to relay %s packet to %s: %s", p.GetType(), receiver.NodeID, err.Error())
+	}
+	return true
+}
+
+func (rc *relayController) processRelayConsensusRequest(ctx context.Context, request network.Request) (network.Response, error) {
+	data := request.GetData().(*RelayConsensusRequest)
+	p, err := packets.ExtractPacket(bytes.NewReader(data.Packet))
+	if err != nil {
+		return nil, errors.Wrap(err, "[ processRelayConsensusRequest ] Failed to deserialize consensus packet")
+	}
+	rc.ConsensusNetwork.ProcessRelayedPacket(p)
+	return rc.Transport.BuildResponse(ctx, request, nil), nil
+}
+
+func relaySignatureData(nodeRef insolar.Reference, relayAddress string, request *RelayRequest) []byte {
+	data := append(nodeRef.Bytes(), []byte(relayAddress)...)
+	data = append(data, byte(request.Command))
+
+	buf := make([]byte, 16)
+	binary.BigEndian.PutUint64(buf[:8], uint64(request.Timestamp))
+	binary.BigEndian.PutUint64(buf[8:], request.Nonce)
+	return append(data, buf...)
+}
+
+// NewRelayController creates new relay controller.
+func NewRelayController(options *common.Options) RelayController {
+	return &relayController{
+		options: options,
+		nonces:  make(map[relayNonce]time.Time),
+	}
+}
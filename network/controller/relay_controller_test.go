@@ -0,0 +1,101 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/network/controller/common"
+	"github.com/insolar/insolar/testutils"
+)
+
+func TestRelayController_CheckNonce(t *testing.T) {
+	rc := NewRelayController(&common.Options{}).(*relayController)
+	nodeID := testutils.RandomRef()
+	now := time.Now()
+
+	request := &RelayRequest{Timestamp: now.UnixNano(), Nonce: 1}
+	require.NoError(t, rc.checkNonce(nodeID, request, now))
+
+	err := rc.checkNonce(nodeID, request, now)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "replayed")
+
+	// the same nonce of another node is not a replay
+	require.NoError(t, rc.checkNonce(testutils.RandomRef(), request, now))
+
+	stale := &RelayRequest{Timestamp: now.Add(-2 * relayRequestTTL).UnixNano(), Nonce: 2}
+	err = rc.checkNonce(nodeID, stale, now)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "stale")
+
+	// seen nonces are forgotten after request TTL, when such request is stale anyway
+	later := now.Add(relayRequestTTL + time.Second)
+	require.NoError(t, rc.checkNonce(nodeID, &RelayRequest{Timestamp: later.UnixNano(), Nonce: 3}, later))
+	require.Len(t, rc.nonces, 1)
+}
+
+func TestRelaySignatureData(t *testing.T) {
+	nodeID := testutils.RandomRef()
+	request := &RelayRequest{Command: StartRelay, Timestamp: 1, Nonce: 2}
+	data := relaySignatureData(nodeID, "127.0.0.1:1", request)
+
+	replayed := *request
+	replayed.Nonce = 3
+	require.NotEqual(t, data, relaySignatureData(nodeID, "127.0.0.1:1", &replayed))
+
+	stopped := *request
+	stopped.Command = StopRelay
+	require.NotEqual(t, data, relaySignatureData(nodeID, "127.0.0.1:1", &stopped))
+}
@@ -0,0 +1,262 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// Entry is an address of discovery node provided by a discovery source.
+type Entry struct {
+	Ref insolar.Reference
+	// PublicKey is the key of the node claimed by the source, nil if the source does not provide keys.
+	PublicKey crypto.PublicKey
+	Host      string
+}
+
+// Source provides addresses of discovery nodes.
+type Source interface {
+	// Name returns name of the source for logging.
+	Name() string
+	// Discover returns current addresses of discovery nodes.
+	Discover(ctx context.Context) ([]Entry, error)
+}
+
+type provider struct {
+	Certificate                insolar.Certificate                `inject:""`
+	PlatformCryptographyScheme insolar.PlatformCryptographyScheme `inject:""`
+
+	conf    configuration.Discovery
+	sources []Source
+
+	lock  sync.RWMutex
+	hosts map[insolar.Reference]string
+
+	stop chan struct{}
+}
+
+// NewProvider creates discovery provider with sources from configuration.
+func NewProvider(conf configuration.Discovery) network.DiscoveryProvider {
+	return &provider{
+		conf:  conf,
+		hosts: make(map[insolar.Reference]string),
+		stop:  make(chan struct{}),
+	}
+}
+
+func (p *provider) Init(ctx context.Context) error {
+	if p.conf.SeedList != "" {
+		p.sources = append(p.sources,
+			NewSeedListSource(p.conf.SeedList, p.conf.Timeout, p.Certificate, p.PlatformCryptographyScheme))
+	}
+	if p.conf.DNSDomain != "" {
+		p.sources = append(p.sources, NewDNSSource(p.conf.DNSDomain, net.DefaultResolver))
+	}
+	return nil
+}
+
+func (p *provider) Start(ctx context.Context) error {
+	if len(p.sources) == 0 {
+		return nil
+	}
+	err := p.Refresh(ctx)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ discovery ] Failed to refresh discovery nodes: " + err.Error())
+	}
+	if p.conf.RefreshInterval > 0 {
+		go p.refreshLoop(ctx)
+	}
+	return nil
+}
+
+func (p *provider) Stop(ctx context.Context) error {
+	close(p.stop)
+	return nil
+}
+
+func (p *provider) refreshLoop(ctx context.Context) {
+	ticker := time.NewTicker(p.conf.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			err := p.Refresh(ctx)
+			if err != nil {
+				inslogger.FromContext(ctx).Warn("[ discovery ] Failed to refresh discovery nodes: " + err.Error())
+			}
+		}
+	}
+}
+
+// Refresh updates addresses of discovery nodes from the discovery sources. Addresses from the sources that
+// failed are kept from the previous refresh. Every source is limited by the configured timeout, so Start
+// is not blocked by an unavailable source.
+func (p *provider) Refresh(ctx context.Context) error {
+	var failed []string
+	results := make([][]Entry, len(p.sources))
+	for i, source := range p.sources {
+		entries, err := p.discover(ctx, source)
+		if err != nil {
+			failed = append(failed, source.Name()+": "+err.Error())
+			continue
+		}
+		results[i] = entries
+	}
+
+	hosts := merge(ctx, p.Certificate.GetDiscoveryNodes(), results)
+	p.lock.Lock()
+	for ref, host := range hosts {
+		p.hosts[ref] = host
+	}
+	p.lock.Unlock()
+
+	if len(failed) > 0 {
+		return errors.New("[ Refresh ] " + strings.Join(failed, "; "))
+	}
+	return nil
+}
+
+func (p *provider) discover(ctx context.Context, source Source) ([]Entry, error) {
+	if p.conf.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.conf.Timeout)
+		defer cancel()
+	}
+	return source.Discover(ctx)
+}
+
+// GetDiscoveryNodes returns discovery nodes from the certificate with the resolved addresses.
+func (p *provider) GetDiscoveryNodes() []insolar.DiscoveryNode {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
+	nodes := p.Certificate.GetDiscoveryNodes()
+	result := make([]insolar.DiscoveryNode, 0, len(nodes))
+	for _, n := range nodes {
+		host, ok := p.hosts[*n.GetNodeRef()]
+		if !ok || host == n.GetHost() {
+			result = append(result, n)
+			continue
+		}
+		result = append(result, &discoveryNode{DiscoveryNode: n, host: host})
+	}
+	return result
+}
+
+// discoveryNode is a discovery node from the certificate with the address from a discovery source.
+type discoveryNode struct {
+	insolar.DiscoveryNode
+	host string
+}
+
+func (n *discoveryNode) GetHost() string {
+	return n.host
+}
+
+// merge returns addresses of the certificate discovery nodes. Results are ordered by priority, the first
+// address found for a node wins.
+func merge(ctx context.Context, discoveryNodes []insolar.DiscoveryNode, results [][]Entry) map[insolar.Reference]string {
+	logger := inslogger.FromContext(ctx)
+	keyProc := platformpolicy.NewKeyProcessor()
+
+	known := make(map[insolar.Reference]insolar.DiscoveryNode, len(discoveryNodes))
+	for _, n := range discoveryNodes {
+		known[*n.GetNodeRef()] = n
+	}
+
+	hosts := make(map[insolar.Reference]string)
+	for _, entries := range results {
+		for _, entry := range entries {
+			if _, ok := hosts[entry.Ref]; ok {
+				continue
+			}
+			n, ok := known[entry.Ref]
+			if !ok {
+				logger.Warnf("[ discovery ] Node %s is not a discovery node in certificate, skipping", entry.Ref)
+				continue
+			}
+			if entry.PublicKey != nil && !equalKeys(keyProc, entry.PublicKey, n.GetPublicKey()) {
+				logger.Warnf("[ discovery ] Public key of node %s differs from certificate, skipping", entry.Ref)
+				continue
+			}
+			hosts[entry.Ref] = entry.Host
+		}
+	}
+	return hosts
+}
+
+func equalKeys(keyProc insolar.KeyProcessor, a, b crypto.PublicKey) bool {
+	aBytes, err := keyProc.ExportPublicKeyBinary(a)
+	if err != nil {
+		return false
+	}
+	bBytes, err := keyProc.ExportPublicKeyBinary(b)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
@@ -0,0 +1,361 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/certificate"
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/testutils"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type testNode struct {
+	ref  insolar.Reference
+	key  crypto.PrivateKey
+	pub  crypto.PublicKey
+	pem  string
+	host string
+}
+
+var lastNodeIndex byte
+
+// newTestRef returns unique node reference with deterministic content.
+func newTestRef() insolar.Reference {
+	lastNodeIndex++
+	id := insolar.NewID(insolar.FirstPulseNumber, bytes.Repeat([]byte{lastNodeIndex}, insolar.RecordHashSize))
+	return *insolar.NewReference(*id, *id)
+}
+
+func newTestNode(t *testing.T, host string) *testNode {
+	kp := platformpolicy.NewKeyProcessor()
+	key, err := kp.GeneratePrivateKey()
+	require.NoError(t, err)
+	pub := kp.ExtractPublicKey(key)
+	pem, err := kp.ExportPublicKeyPEM(pub)
+	require.NoError(t, err)
+	return &testNode{ref: newTestRef(), key: key, pub: pub, pem: string(pem), host: host}
+}
+
+func newTestCertificate(t *testing.T, nodes ...*testNode) insolar.Certificate {
+	discoveryNodes := make([]insolar.DiscoveryNode, 0, len(nodes))
+	for _, n := range nodes {
+		discoveryNodes = append(discoveryNodes, certificate.NewBootstrapNode(n.pub, n.pem, n.host, n.ref.String()))
+	}
+	cert := testutils.NewCertificateMock(t)
+	cert.GetDiscoveryNodesMock.Return(discoveryNodes)
+	return cert
+}
+
+type testSource struct {
+	entries []Entry
+	err     error
+}
+
+func (s *testSource) Name() string {
+	return "test"
+}
+
+func (s *testSource) Discover(ctx context.Context) ([]Entry, error) {
+	return s.entries, s.err
+}
+
+func hostsOf(nodes []insolar.DiscoveryNode) map[insolar.Reference]string {
+	result := make(map[insolar.Reference]string)
+	for _, n := range nodes {
+		result[*n.GetNodeRef()] = n.GetHost()
+	}
+	return result
+}
+
+func TestProvider_Refresh(t *testing.T) {
+	ctx := context.Background()
+	n1, n2, n3 := newTestNode(t, "n1:1"), newTestNode(t, "n2:1"), newTestNode(t, "n3:1")
+	unknown := newTestNode(t, "unknown:1")
+	forged := newTestNode(t, "")
+
+	p := NewProvider(configuration.Discovery{}).(*provider)
+	p.Certificate = newTestCertificate(t, n1, n2, n3)
+	first := &testSource{entries: []Entry{
+		{Ref: n1.ref, PublicKey: n1.pub, Host: "n1:2"},
+		{Ref: unknown.ref, PublicKey: unknown.pub, Host: unknown.host},
+		{Ref: n2.ref, PublicKey: forged.pub, Host: "forged:1"},
+	}}
+	second := &testSource{entries: []Entry{
+		{Ref: n1.ref, Host: "n1:3"},
+		{Ref: n2.ref, Host: "n2:3"},
+	}}
+	p.sources = []Source{first, second}
+
+	require.Equal(t, hostsOf(p.Certificate.GetDiscoveryNodes()), hostsOf(p.GetDiscoveryNodes()))
+	require.NoError(t, p.Refresh(ctx))
+	require.Equal(t, map[insolar.Reference]string{
+		n1.ref: "n1:2",
+		n2.ref: "n2:3",
+		n3.ref: "n3:1",
+	}, hostsOf(p.GetDiscoveryNodes()))
+
+	// addresses of failed source are kept
+	first.err = errors.New("unavailable")
+	second.entries = nil
+	err := p.Refresh(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "unavailable")
+	require.Equal(t, "n1:2", hostsOf(p.GetDiscoveryNodes())[n1.ref])
+}
+
+type testResolver struct {
+	srv []*net.SRV
+	txt map[string][]string
+}
+
+func (r *testResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if service != "insolar" || proto != "tcp" || name != "example.com" {
+		return "", nil, errors.New("no such host")
+	}
+	return "_insolar._tcp.example.com.", r.srv, nil
+}
+
+func (r *testResolver) LookupTXT(ctx context.Context, name string) ([]string, error) {
+	records, ok := r.txt[name]
+	if !ok {
+		return nil, errors.New("no such host")
+	}
+	return records, nil
+}
+
+func TestDNSSource(t *testing.T) {
+	ctx := context.Background()
+	n1, n2 := newTestNode(t, ""), newTestNode(t, "")
+	resolver := &testResolver{
+		srv: []*net.SRV{
+			{Target: "n1.example.com.", Port: 7900},
+			{Target: "n2.example.com.", Port: 7901},
+			{Target: "n3.example.com.", Port: 7902},
+		},
+		txt: map[string][]string{
+			"n1.example.com": {"v=spf1 -all", "insolar-ref=" + n1.ref.String()},
+			"n2.example.com": {"insolar-ref=" + n2.ref.String()},
+		},
+	}
+
+	entries, err := NewDNSSource("example.com", resolver).Discover(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []Entry{
+		{Ref: n1.ref, Host: "n1.example.com:7900"},
+		{Ref: n2.ref, Host: "n2.example.com:7901"},
+	}, entries)
+
+	_, err = NewDNSSource("unknown.com", resolver).Discover(ctx)
+	require.Error(t, err)
+}
+
+func newSignedSeedList(t *testing.T, signer *testNode, nodes ...*testNode) *SeedList {
+	return newSignedSeedListVersion(t, 1, time.Now().Add(time.Hour), signer, nodes...)
+}
+
+func newSignedSeedListVersion(t *testing.T, version uint64, expire time.Time, signer *testNode, nodes ...*testNode) *SeedList {
+	list := &SeedList{Version: version, Expire: expire}
+	for _, n := range nodes {
+		list.Nodes = append(list.Nodes, SeedNode{NodeRef: n.ref.String(), PublicKey: n.pem, Host: n.host})
+	}
+	require.NoError(t, list.Sign(signer.ref, signer.key, platformpolicy.NewPlatformCryptographyScheme()))
+	return list
+}
+
+func TestSeedList_Verify(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	n1, n2, other := newTestNode(t, "n1:1"), newTestNode(t, "n2:1"), newTestNode(t, "other:1")
+	cert := newTestCertificate(t, n1, n2)
+
+	list := newSignedSeedList(t, n1, n1, n2)
+	require.NoError(t, list.Verify(cert, scheme))
+
+	list.Nodes[1].Host = "evil:1"
+	err := list.Verify(cert, scheme)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid seed list signature")
+
+	list = newSignedSeedList(t, other, n1, n2)
+	err = list.Verify(cert, scheme)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "is not a discovery node")
+
+	list = newSignedSeedListVersion(t, 1, time.Now().Add(-time.Second), n1, n1, n2)
+	err = list.Verify(cert, scheme)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Seed list expired")
+
+	list = newSignedSeedList(t, n1, n1, n2)
+	list.Expire = list.Expire.Add(time.Hour)
+	err = list.Verify(cert, scheme)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "Invalid seed list signature")
+}
+
+func TestSeedListSource(t *testing.T) {
+	ctx := context.Background()
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	n1, n2 := newTestNode(t, "n1:1"), newTestNode(t, "n2:1")
+	cert := newTestCertificate(t, n1, n2)
+
+	data, err := json.Marshal(newSignedSeedList(t, n2, n1, n2))
+	require.NoError(t, err)
+
+	dir, err := ioutil.TempDir("", "seedlist")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "seeds.json")
+	require.NoError(t, ioutil.WriteFile(path, data, 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+
+	for _, location := range []string{path, server.URL} {
+		entries, err := NewSeedListSource(location, time.Second, cert, scheme).Discover(ctx)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, n1.ref, entries[0].Ref)
+		require.Equal(t, "n1:1", entries[0].Host)
+		require.True(t, equalKeys(platformpolicy.NewKeyProcessor(), n1.pub, entries[0].PublicKey))
+	}
+
+	_, err = NewSeedListSource(filepath.Join(dir, "missing.json"), time.Second, cert, scheme).Discover(ctx)
+	require.Error(t, err)
+}
+
+func TestSeedListSource_Version(t *testing.T) {
+	ctx := context.Background()
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	n1, n2 := newTestNode(t, "n1:1"), newTestNode(t, "n2:1")
+	cert := newTestCertificate(t, n1, n2)
+
+	var data []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer server.Close()
+	serve := func(version uint64) {
+		var err error
+		data, err = json.Marshal(newSignedSeedListVersion(t, version, time.Now().Add(time.Hour), n1, n1, n2))
+		require.NoError(t, err)
+	}
+	source := NewSeedListSource(server.URL, time.Second, cert, scheme)
+
+	serve(2)
+	_, err := source.Discover(ctx)
+	require.NoError(t, err)
+	_, err = source.Discover(ctx)
+	require.NoError(t, err)
+
+	serve(1)
+	_, err = source.Discover(ctx)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "older than accepted version 2")
+
+	serve(3)
+	_, err = source.Discover(ctx)
+	require.NoError(t, err)
+}
+
+func TestSeedListSource_Timeout(t *testing.T) {
+	n1 := newTestNode(t, "n1:1")
+	cert := newTestCertificate(t, n1)
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	source := NewSeedListSource(server.URL, 10*time.Millisecond, cert, platformpolicy.NewPlatformCryptographyScheme())
+	_, err := source.Discover(context.Background())
+	require.Error(t, err)
+}
+
+type blockingSource struct{}
+
+func (s *blockingSource) Name() string {
+	return "blocking"
+}
+
+func (s *blockingSource) Discover(ctx context.Context) ([]Entry, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestProvider_RefreshTimeout(t *testing.T) {
+	n1 := newTestNode(t, "n1:1")
+	p := NewProvider(configuration.Discovery{Timeout: 10 * time.Millisecond}).(*provider)
+	p.Certificate = newTestCertificate(t, n1)
+	p.sources = []Source{&blockingSource{}}
+
+	err := p.Refresh(context.Background())
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "blocking")
+}
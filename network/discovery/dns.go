@@ -0,0 +1,124 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package discovery
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	dnsService   = "insolar"
+	dnsProto     = "tcp"
+	dnsRefPrefix = "insolar-ref="
+)
+
+// Resolver looks up DNS records, net.Resolver implements it.
+type Resolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+type dnsSource struct {
+	domain   string
+	resolver Resolver
+}
+
+// NewDNSSource creates source that reads SRV records _insolar._tcp.<domain>. Node reference of each SRV target is
+// taken from its TXT record "insolar-ref=<node reference>".
+func NewDNSSource(domain string, resolver Resolver) Source {
+	return &dnsSource{domain: domain, resolver: resolver}
+}
+
+func (s *dnsSource) Name() string {
+	return "dns " + s.domain
+}
+
+func (s *dnsSource) Discover(ctx context.Context) ([]Entry, error) {
+	_, records, err := s.resolver.LookupSRV(ctx, dnsService, dnsProto, s.domain)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Discover ] Failed to lookup SRV records")
+	}
+
+	result := make([]Entry, 0, len(records))
+	for _, record := range records {
+		target := strings.TrimSuffix(record.Target, ".")
+		ref, err := s.lookupRef(ctx, target)
+		if err != nil {
+			inslogger.FromContext(ctx).Warnf("[ discovery ] Skipping SRV target %s: %s", target, err)
+			continue
+		}
+		result = append(result, Entry{
+			Ref:  *ref,
+			Host: net.JoinHostPort(target, strconv.Itoa(int(record.Port))),
+		})
+	}
+	return result, nil
+}
+
+func (s *dnsSource) lookupRef(ctx context.Context, target string) (*insolar.Reference, error) {
+	records, err := s.resolver.LookupTXT(ctx, target)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to lookup TXT record")
+	}
+	for _, record := range records {
+		if strings.HasPrefix(record, dnsRefPrefix) {
+			return insolar.NewReferenceFromBase58(strings.TrimPrefix(record, dnsRefPrefix))
+		}
+	}
+	return nil, errors.New("TXT record with node reference not found")
+}
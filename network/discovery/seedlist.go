@@ -0,0 +1,236 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package discovery
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/pkg/errors"
+)
+
+// SeedNode is an address of discovery node in the seed list.
+type SeedNode struct {
+	NodeRef   string `json:"node_ref"`
+	PublicKey string `json:"public_key"`
+	Host      string `json:"host"`
+}
+
+// SeedList is a list of discovery node addresses signed by one of the discovery nodes from the certificate.
+// Version must grow with every update of the list, nodes reject lists older than the last accepted one.
+// Lists are not accepted after Expire, so a leaked old list can't be served forever.
+type SeedList struct {
+	Version   uint64     `json:"version"`
+	Expire    time.Time  `json:"expire"`
+	Nodes     []SeedNode `json:"nodes"`
+	Signer    string     `json:"signer"`
+	Signature []byte     `json:"signature"`
+}
+
+func (l *SeedList) serialize() ([]byte, error) {
+	return json.Marshal(struct {
+		Version uint64
+		Expire  int64
+		Nodes   []SeedNode
+	}{
+		Version: l.Version,
+		Expire:  l.Expire.Unix(),
+		Nodes:   l.Nodes,
+	})
+}
+
+// Sign signs seed list with the key of discovery node signer.
+func (l *SeedList) Sign(signer insolar.Reference, key crypto.PrivateKey, scheme insolar.PlatformCryptographyScheme) error {
+	l.Signer = signer.String()
+	data, err := l.serialize()
+	if err != nil {
+		return errors.Wrap(err, "[ Sign ] Failed to serialize seed list")
+	}
+	signature, err := scheme.Signer(key).Sign(data)
+	if err != nil {
+		return errors.Wrap(err, "[ Sign ] Failed to sign seed list")
+	}
+	l.Signature = signature.Bytes()
+	return nil
+}
+
+// Verify checks that seed list is signed by discovery node from the certificate and is not expired.
+func (l *SeedList) Verify(cert insolar.Certificate, scheme insolar.PlatformCryptographyScheme) error {
+	signer, err := insolar.NewReferenceFromBase58(l.Signer)
+	if err != nil {
+		return errors.Wrap(err, "[ Verify ] Invalid signer reference")
+	}
+	var key crypto.PublicKey
+	for _, n := range cert.GetDiscoveryNodes() {
+		if n.GetNodeRef().Equal(*signer) {
+			key = n.GetPublicKey()
+			break
+		}
+	}
+	if key == nil {
+		return errors.Errorf("[ Verify ] Signer %s is not a discovery node", l.Signer)
+	}
+	data, err := l.serialize()
+	if err != nil {
+		return errors.Wrap(err, "[ Verify ] Failed to serialize seed list")
+	}
+	if !scheme.Verifier(key).Verify(insolar.SignatureFromBytes(l.Signature), data) {
+		return errors.New("[ Verify ] Invalid seed list signature")
+	}
+	if time.Now().After(l.Expire) {
+		return errors.Errorf("[ Verify ] Seed list expired at %s", l.Expire)
+	}
+	return nil
+}
+
+type seedListSource struct {
+	location string
+	cert     insolar.Certificate
+	scheme   insolar.PlatformCryptographyScheme
+	client   *http.Client
+
+	versionLock sync.Mutex
+	version     uint64
+}
+
+// NewSeedListSource creates source that reads signed seed list from the file path or http(s) URL.
+// Requests to URL are limited by timeout.
+func NewSeedListSource(location string, timeout time.Duration, cert insolar.Certificate,
+	scheme insolar.PlatformCryptographyScheme) Source {
+
+	return &seedListSource{
+		location: location,
+		cert:     cert,
+		scheme:   scheme,
+		client:   &http.Client{Timeout: timeout},
+	}
+}
+
+func (s *seedListSource) Name() string {
+	return "seed list " + s.location
+}
+
+func (s *seedListSource) Discover(ctx context.Context) ([]Entry, error) {
+	data, err := s.read(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Discover ] Failed to read seed list")
+	}
+	list := &SeedList{}
+	err = json.Unmarshal(data, list)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Discover ] Failed to parse seed list")
+	}
+	err = list.Verify(s.cert, s.scheme)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Discover ] Failed to verify seed list")
+	}
+	err = s.acceptVersion(list.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ Discover ] Failed to verify seed list")
+	}
+
+	keyProc := platformpolicy.NewKeyProcessor()
+	result := make([]Entry, 0, len(list.Nodes))
+	for _, n := range list.Nodes {
+		ref, err := insolar.NewReferenceFromBase58(n.NodeRef)
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ Discover ] Invalid node reference %s", n.NodeRef)
+		}
+		key, err := keyProc.ImportPublicKeyPEM([]byte(n.PublicKey))
+		if err != nil {
+			return nil, errors.Wrapf(err, "[ Discover ] Invalid public key of node %s", n.NodeRef)
+		}
+		result = append(result, Entry{Ref: *ref, PublicKey: key, Host: n.Host})
+	}
+	return result, nil
+}
+
+// acceptVersion rejects the list version that is older than the last accepted one, so an old signed list
+// can't be used to roll addresses back.
+func (s *seedListSource) acceptVersion(version uint64) error {
+	s.versionLock.Lock()
+	defer s.versionLock.Unlock()
+
+	if version < s.version {
+		return errors.Errorf("[ acceptVersion ] Seed list version %d is older than accepted version %d", version, s.version)
+	}
+	s.version = version
+	return nil
+}
+
+func (s *seedListSource) read(ctx context.Context) ([]byte, error) {
+	if !strings.HasPrefix(s.location, "http://") && !strings.HasPrefix(s.location, "https://") {
+		return ioutil.ReadFile(filepath.Clean(s.location))
+	}
+
+	request, err := http.NewRequest(http.MethodGet, s.location, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.client.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %s", response.Status)
+	}
+	return ioutil.ReadAll(response.Body)
+}
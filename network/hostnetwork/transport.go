@@ -186,11 +186,37 @@ func (h *hostTransport) BuildResponse(ctx context.Context, request network.Reque
 }
 
 func NewInternalTransport(conf configuration.Configuration, nodeRef string) (network.InternalTransport, error) {
-	tp, err := transport.NewTransport(conf.Host.Transport, relay.NewProxy())
+	return newInternalTransport(conf, nodeRef, nil)
+}
+
+// NewInternalRelayTransport creates internal transport that forwards packets of nodes registered in relay list.
+func NewInternalRelayTransport(conf configuration.Configuration, nodeRef string, r relay.Relay) (network.InternalTransport, error) {
+	return newInternalTransport(conf, nodeRef, r)
+}
+
+func newInternalTransport(conf configuration.Configuration, nodeRef string, r relay.Relay) (network.InternalTransport, error) {
+	proxy := relay.NewProxy()
+	relayAddress := conf.Host.Relay.Address
+	if relayAddress != "" && r == nil {
+		// node is not reachable from outside, so it publishes relay address and sends all packets through relay
+		proxy.AddProxyHost(relayAddress)
+	}
+
+	var tp transport.Transport
+	var err error
+	if r != nil {
+		tp, err = transport.NewRelayTransport(conf.Host.Transport, proxy, r)
+	} else {
+		tp, err = transport.NewTransport(conf.Host.Transport, proxy)
+	}
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating transport")
 	}
-	origin, err := getOrigin(tp, nodeRef)
+	publicAddress := tp.PublicAddress()
+	if proxy.IsProxyHost(relayAddress) {
+		publicAddress = relayAddress
+	}
+	origin, err := getOrigin(publicAddress, nodeRef)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting origin")
 	}
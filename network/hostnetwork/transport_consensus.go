@@ -71,8 +71,9 @@ import (
 
 type transportConsensus struct {
 	transportBase
-	Resolver network.RoutingTable `inject:""`
-	handlers map[packets.PacketType]network.ConsensusPacketHandler
+	Resolver     network.RoutingTable `inject:""`
+	handlers     map[packets.PacketType]network.ConsensusPacketHandler
+	relayHandler network.ConsensusRelayHandler
 }
 
 func (tc *transportConsensus) Start(ctx context.Context) error {
@@ -92,6 +93,16 @@ func (tc *transportConsensus) RegisterPacketHandler(t packets.PacketType, handle
 	tc.handlers[t] = handler
 }
 
+// RegisterRelayHandler register a handler function to process incoming packets addressed to other nodes.
+func (tc *transportConsensus) RegisterRelayHandler(handler network.ConsensusRelayHandler) {
+	tc.relayHandler = handler
+}
+
+// ProcessRelayedPacket processes packet forwarded by relay node as if it was received from network.
+func (tc *transportConsensus) ProcessRelayedPacket(p packets.ConsensusPacket) {
+	tc.processPacket(p)
+}
+
 func (tc *transportConsensus) SignAndSendPacket(packet packets.ConsensusPacket,
 	receiver insolar.Reference, service insolar.CryptographyService) error {
 
@@ -129,8 +140,15 @@ func (tc *transportConsensus) processMessage(msg *packet.Packet) {
 		log.Error("Error processing incoming message: failed to convert to ConsensusPacket")
 		return
 	}
+	tc.processPacket(p)
+}
+
+func (tc *transportConsensus) processPacket(p packets.ConsensusPacket) {
 	log.Debugf("Got %s request from host, shortID: %d", p.GetType(), p.GetOrigin())
 	if p.GetTarget() != tc.origin.ShortID {
+		if tc.relayHandler != nil && tc.relayHandler(p) {
+			return
+		}
 		log.Errorf("Error processing incoming message: target ID %d differs from origin %d", p.GetTarget(), tc.origin.ShortID)
 		return
 	}
@@ -156,17 +174,21 @@ func (tc *transportConsensus) processMessage(msg *packet.Packet) {
 	handler(p, sender.NodeID)
 }
 
-func NewConsensusNetwork(address, nodeID string, shortID insolar.ShortNodeID) (network.ConsensusNetwork, error) {
+func NewConsensusNetwork(hostProtocol, address, nodeID string, shortID insolar.ShortNodeID) (network.ConsensusNetwork, error) {
 	conf := configuration.Transport{}
 	conf.Address = address
 	conf.Protocol = "PURE_UDP"
+	if hostProtocol == "MEMORY" {
+		// in-memory cluster must not touch sockets, MEMORY transport carries consensus packets as well
+		conf.Protocol = hostProtocol
+	}
 	conf.BehindNAT = false
 
 	tp, err := transport.NewTransport(conf, relay.NewProxy())
 	if err != nil {
 		return nil, errors.Wrap(err, "error creating transport")
 	}
-	origin, err := getOrigin(tp, nodeID)
+	origin, err := getOrigin(tp.PublicAddress(), nodeID)
 	if err != nil {
 		go tp.Stop()
 		<-tp.Stopped()
@@ -77,12 +77,12 @@ type consensusTransportSuite struct {
 func createTwoConsensusNetworks(id1, id2 insolar.ShortNodeID) (t1, t2 network.ConsensusNetwork, err error) {
 	m := newMockResolver()
 
-	cn1, err := NewConsensusNetwork("127.0.0.1:0", ID1+DOMAIN, id1)
+	cn1, err := NewConsensusNetwork("TCP", "127.0.0.1:0", ID1+DOMAIN, id1)
 	cn1.(*transportConsensus).Resolver = m
 	if err != nil {
 		return nil, nil, err
 	}
-	cn2, err := NewConsensusNetwork("127.0.0.1:0", ID2+DOMAIN, id2)
+	cn2, err := NewConsensusNetwork("TCP", "127.0.0.1:0", ID2+DOMAIN, id2)
 	cn2.(*transportConsensus).Resolver = m
 	if err != nil {
 		return nil, nil, err
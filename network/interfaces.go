@@ -111,6 +111,9 @@ type HostNetwork interface {
 
 type ConsensusPacketHandler func(incomingPacket consensus.ConsensusPacket, sender insolar.Reference)
 
+// ConsensusRelayHandler handles packet addressed to another node and returns true if packet was relayed.
+type ConsensusRelayHandler func(incomingPacket consensus.ConsensusPacket) bool
+
 //go:generate minimock -i github.com/insolar/insolar/network.ConsensusNetwork -o ../testutils/network -s _mock.go
 type ConsensusNetwork interface {
 	component.Starter
@@ -124,6 +127,10 @@ type ConsensusNetwork interface {
 	SignAndSendPacket(packet consensus.ConsensusPacket, receiver insolar.Reference, service insolar.CryptographyService) error
 	// RegisterPacketHandler register a handler function to process incoming requests of a specific type.
 	RegisterPacketHandler(t consensus.PacketType, handler ConsensusPacketHandler)
+	// RegisterRelayHandler register a handler function to process incoming packets addressed to other nodes.
+	RegisterRelayHandler(handler ConsensusRelayHandler)
+	// ProcessRelayedPacket processes packet forwarded by relay node as if it was received from network.
+	ProcessRelayedPacket(packet consensus.ConsensusPacket)
 }
 
 // RequestID is 64 bit unsigned int request id.
@@ -176,6 +183,10 @@ type NodeKeeper interface {
 	GetCloudHash() []byte
 	// SetCloudHash set new cloud hash
 	SetCloudHash([]byte)
+	// GetBans returns nodes banned for proven violations with pulse numbers when their bans expire
+	GetBans() map[insolar.Reference]insolar.PulseNumber
+	// SetBans set nodes banned for proven violations
+	SetBans(map[insolar.Reference]insolar.PulseNumber)
 	// SetInitialSnapshot set initial snapshot for nodekeeper
 	SetInitialSnapshot(nodes []insolar.NetworkNode)
 	// GetAccessor get accessor to the internal snapshot for the current pulse
@@ -314,3 +325,53 @@ type Mutator interface {
 	// AddWorkingNode adds active node to index and underlying snapshot so it is accessible via GetActiveNode(s).
 	AddWorkingNode(n insolar.NetworkNode)
 }
+
+// PhaseTimings collects timing statistics of consensus phases and provides phase timeouts.
+type PhaseTimings interface {
+	// Timeout returns timeout of consensus phase for pulse of given duration.
+	Timeout(phase string, pulseDuration time.Duration) time.Duration
+	// PhaseFinished records actual phase duration and its timeout.
+	PhaseFinished(ctx context.Context, phase string, elapsed, timeout time.Duration)
+	// PacketReceived records delay between phase start and receipt of a packet from peer.
+	PacketReceived(ctx context.Context, phase string, peer insolar.Reference, delay time.Duration)
+	// PacketMissing records peer that did not send a packet before phase timeout.
+	PacketMissing(ctx context.Context, phase string, peer insolar.Reference)
+	// PacketLate records packet from peer received after its phase had finished.
+	PacketLate(ctx context.Context, phase string, peer insolar.Reference)
+	// Status returns collected statistics.
+	Status() PhaseTimingsStatus
+}
+
+// PhaseTimingStatus contains timing statistics of a consensus phase.
+type PhaseTimingStatus struct {
+	Phase string
+	// TimeoutFraction is current phase timeout as a fraction of pulse duration.
+	TimeoutFraction float64
+	LastDuration    time.Duration
+	LastTimeout     time.Duration
+	// PacketDelay is the estimation of packet delay in the phase based on recent rounds.
+	PacketDelay time.Duration
+}
+
+// PeerTimingStatus contains counters of missing and late consensus packets from a peer.
+type PeerTimingStatus struct {
+	Peer    insolar.Reference
+	Missing uint64
+	Late    uint64
+}
+
+// PhaseTimingsStatus contains timing statistics of consensus phases.
+type PhaseTimingsStatus struct {
+	Adaptive bool
+	Phases   []PhaseTimingStatus
+	Peers    []PeerTimingStatus
+}
+
+// DiscoveryProvider provides actual list of discovery nodes. Node identities are taken from the certificate,
+// node addresses can be overridden by the configured discovery sources (DNS SRV records, seed list).
+type DiscoveryProvider interface {
+	// GetDiscoveryNodes returns discovery nodes from the certificate with the resolved addresses.
+	GetDiscoveryNodes() []insolar.DiscoveryNode
+	// Refresh updates addresses of discovery nodes from the discovery sources.
+	Refresh(ctx context.Context) error
+}
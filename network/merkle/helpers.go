@@ -132,3 +132,20 @@ func (mh *merkleHelper) globuleInfoHash(prevCloudHash []byte, globuleID, nodeCou
 func (mh *merkleHelper) globuleHash(globuleInfoHash, globuleNodeRoot []byte) []byte {
 	return mh.doubleSliceHash(globuleInfoHash, globuleNodeRoot)
 }
+
+// PulseHash returns hash of the pulse that is signed by nodes in their pulse proofs.
+func PulseHash(scheme insolar.PlatformCryptographyScheme, pulse *insolar.Pulse) OriginHash {
+	return newMerkleHelper(scheme).pulseHash(pulse)
+}
+
+// GlobuleHash returns hash of the globule that is signed by the node in its globule proof.
+func GlobuleHash(scheme insolar.PlatformCryptographyScheme, proof *GlobuleProof) OriginHash {
+	helper := newMerkleHelper(scheme)
+	globuleInfoHash := helper.globuleInfoHash(proof.PrevCloudHash, uint32(proof.GlobuleID), proof.NodeCount)
+	return helper.globuleHash(globuleInfoHash, proof.NodeRoot)
+}
+
+// CloudHash returns hash of the cloud calculated from globule proofs.
+func CloudHash(scheme insolar.PlatformCryptographyScheme, entry *CloudEntry) (OriginHash, error) {
+	return entry.hash(newMerkleHelper(scheme))
+}
@@ -76,6 +76,18 @@ func (t *merkleHelperSuite) TestMerkleHelperPulseHash() {
 	t.Assert().Equal(expectedHash, actualHash)
 }
 
+func (t *merkleHelperSuite) TestPulseHash() {
+	pulse := &insolar.Pulse{
+		PulseNumber:     insolar.PulseNumber(1337),
+		NextPulseNumber: insolar.PulseNumber(1347),
+		Entropy:         pulsartestutils.MockEntropyGenerator{}.GenerateEntropy(),
+	}
+
+	actualHash := PulseHash(platformpolicy.NewPlatformCryptographyScheme(), pulse)
+
+	t.Assert().Equal(OriginHash(t.mh.pulseHash(pulse)), actualHash)
+}
+
 func (t *merkleHelperSuite) TestMerkleHelperNodeInfoHash() {
 	pulseHash, _ := hex.DecodeString(
 		"bd18c009950389026c5c6f85c838b899d188ec0d667f77948aa72a49747c3ed31835b1bdbb8bd1d1de62846b5f308ae3eac5127c7d36d7d5464985004122cc90",
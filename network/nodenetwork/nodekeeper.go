@@ -106,6 +106,10 @@ func createOrigin(configuration configuration.HostNetwork, certificate insolar.C
 }
 
 func resolveAddress(configuration configuration.HostNetwork) (string, error) {
+	if configuration.Relay.Address != "" && !configuration.IsRelay {
+		// node behind NAT is reachable only through relay
+		return configuration.Relay.Address, nil
+	}
 	conn, address, err := transport.NewConnection(configuration.Transport)
 	if err != nil {
 		return "", err
@@ -138,6 +142,9 @@ type nodekeeper struct {
 	cloudHashLock sync.RWMutex
 	cloudHash     []byte
 
+	bansLock sync.RWMutex
+	bans     map[insolar.Reference]insolar.PulseNumber
+
 	activeLock sync.RWMutex
 	snapshot   *node.Snapshot
 	accessor   *node.Accessor
@@ -204,6 +211,8 @@ func (nk *nodekeeper) Wipe(isDiscovery bool) {
 	nk.cloudHash = nil
 	nk.cloudHashLock.Unlock()
 
+	nk.SetBans(nil)
+
 	nk.SetInitialSnapshot([]insolar.NetworkNode{})
 
 	nk.activeLock.Lock()
@@ -258,6 +267,24 @@ func (nk *nodekeeper) SetCloudHash(cloudHash []byte) {
 	nk.cloudHash = cloudHash
 }
 
+func (nk *nodekeeper) GetBans() map[insolar.Reference]insolar.PulseNumber {
+	nk.bansLock.RLock()
+	defer nk.bansLock.RUnlock()
+
+	result := make(map[insolar.Reference]insolar.PulseNumber, len(nk.bans))
+	for ref, expiry := range nk.bans {
+		result[ref] = expiry
+	}
+	return result
+}
+
+func (nk *nodekeeper) SetBans(bans map[insolar.Reference]insolar.PulseNumber) {
+	nk.bansLock.Lock()
+	defer nk.bansLock.Unlock()
+
+	nk.bans = bans
+}
+
 func (nk *nodekeeper) GetWorkingNodes() []insolar.NetworkNode {
 	return nk.GetAccessor().GetWorkingNodes()
 }
@@ -305,6 +332,9 @@ func (nk *nodekeeper) Sync(ctx context.Context, nodes []insolar.NetworkNode, cla
 	if nk.shouldExit(foundOrigin) {
 		return errors.New("node leave acknowledged by network")
 	}
+	if !foundOrigin && nk.origin.GetState() == insolar.NodeLeaving {
+		return insolar.ErrLeaveApproved
+	}
 
 	return nil
 }
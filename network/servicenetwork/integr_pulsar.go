@@ -83,9 +83,26 @@ func NewTestPulsar(pulseTimeMs, requestsTimeoutMs, pulseDelta int32) (TestPulsar
 	if err != nil {
 		return nil, errors.Wrap(err, "Failed to create distributor transport")
 	}
+	proc := platformpolicy.NewKeyProcessor()
+	key, err := proc.GeneratePrivateKey()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to generate pulsar key")
+	}
+	pem, err := proc.ExportPublicKeyPEM(proc.ExtractPublicKey(key))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to export pulsar public key")
+	}
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	generator, err := entropygenerator.NewHashChainEntropyGenerator(scheme, entropygenerator.DefaultHashChainLength, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create entropy generator")
+	}
 	return &testPulsar{
 		transport:         tp,
-		generator:         &entropygenerator.StandardEntropyGenerator{},
+		generator:         generator,
+		scheme:            scheme,
+		service:           cryptography.NewKeyBoundCryptographyService(key),
+		publicKey:         string(pem),
 		pulseTimeMs:       pulseTimeMs,
 		reqTimeoutMs:      requestsTimeoutMs,
 		pulseDelta:        pulseDelta,
@@ -96,7 +113,10 @@ func NewTestPulsar(pulseTimeMs, requestsTimeoutMs, pulseDelta int32) (TestPulsar
 type testPulsar struct {
 	transport   transport.Transport
 	distributor insolar.PulseDistributor
-	generator   entropygenerator.EntropyGenerator
+	generator   *entropygenerator.HashChainEntropyGenerator
+	scheme      insolar.PlatformCryptographyScheme
+	service     insolar.CryptographyService
+	publicKey   string
 	cm          *component.Manager
 
 	pulseTimeMs  int32
@@ -140,7 +160,6 @@ func (tp *testPulsar) distribute(ctx context.Context) {
 
 	pulse := insolar.Pulse{
 		PulseNumber:      pulseNumber,
-		Entropy:          tp.generator.GenerateEntropy(),
 		NextPulseNumber:  pulseNumber + insolar.PulseNumber(tp.pulseDelta),
 		PrevPulseNumber:  pulseNumber - insolar.PulseNumber(tp.pulseDelta),
 		EpochPulseNumber: 1,
@@ -148,8 +167,7 @@ func (tp *testPulsar) distribute(ctx context.Context) {
 		PulseTimestamp:   timeNow.Unix(),
 	}
 
-	var err error
-	pulse.Signs, err = getPSC(pulse)
+	err := tp.sign(&pulse)
 	if err != nil {
 		log.Errorf("[ distribute ]", err)
 	}
@@ -169,55 +187,48 @@ func (tp *testPulsar) incrementPulse(pulse insolar.Pulse) insolar.Pulse {
 	newPulseNumber := pulse.PulseNumber + insolar.PulseNumber(tp.pulseDelta)
 	newPulse := insolar.Pulse{
 		PulseNumber:      newPulseNumber,
-		Entropy:          tp.generator.GenerateEntropy(),
 		NextPulseNumber:  newPulseNumber + insolar.PulseNumber(tp.pulseDelta),
 		PrevPulseNumber:  pulse.PulseNumber,
 		EpochPulseNumber: pulse.EpochPulseNumber,
 		OriginID:         pulse.OriginID,
 		PulseTimestamp:   time.Now().Unix(),
-		Signs:            pulse.Signs,
 	}
-	var err error
-	newPulse.Signs, err = getPSC(newPulse)
+	err := tp.sign(&newPulse)
 	if err != nil {
 		log.Errorf("[ incermentPulse ]", err)
 	}
 	return newPulse
 }
 
-func getPSC(pulse insolar.Pulse) (map[string]insolar.PulseSenderConfirmation, error) {
-	proc := platformpolicy.NewKeyProcessor()
-	key, err := proc.GeneratePrivateKey()
+// sign generates entropy of the pulse with its proof and signs the pulse, so nodes accept it as a real one
+func (tp *testPulsar) sign(pulse *insolar.Pulse) error {
+	pulse.Entropy = tp.generator.GenerateEntropy()
+	chain := tp.generator.Commitment()
+	entropySign, err := tp.service.Sign(insolar.EntropyProofData(pulse.Entropy, chain))
 	if err != nil {
-		return nil, err
+		return err
 	}
-	pem, err := proc.ExportPublicKeyPEM(proc.ExtractPublicKey(key))
-	if err != nil {
-		return nil, err
+	pulse.EntropyProofs = map[string]insolar.PulseEntropyProof{
+		tp.publicKey: {Entropy: pulse.Entropy, Chain: chain, Signature: entropySign.Bytes()},
 	}
-	result := make(map[string]insolar.PulseSenderConfirmation)
+
 	psc := insolar.PulseSenderConfirmation{
 		PulseNumber:     pulse.PulseNumber,
-		ChosenPublicKey: string(pem),
+		ChosenPublicKey: tp.publicKey,
 		Entropy:         pulse.Entropy,
 	}
-
 	payload := pulsar.PulseSenderConfirmationPayload{PulseSenderConfirmation: psc}
-	hasher := platformpolicy.NewPlatformCryptographyScheme().IntegrityHasher()
-	hash, err := payload.Hash(hasher)
+	hash, err := payload.Hash(tp.scheme.IntegrityHasher())
 	if err != nil {
-		return nil, err
+		return err
 	}
-	service := cryptography.NewKeyBoundCryptographyService(key)
-	sign, err := service.Sign(hash)
+	sign, err := tp.service.Sign(hash)
 	if err != nil {
-		return nil, err
+		return err
 	}
-
 	psc.Signature = sign.Bytes()
-	result["test"] = psc
-
-	return result, nil
+	pulse.Signs = map[string]insolar.PulseSenderConfirmation{tp.publicKey: psc}
+	return nil
 }
 
 func (tp *testPulsar) Stop(ctx context.Context) error {
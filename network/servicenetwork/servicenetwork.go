@@ -68,9 +68,11 @@ import (
 	"github.com/insolar/insolar/network"
 	"github.com/insolar/insolar/network/controller"
 	"github.com/insolar/insolar/network/controller/bootstrap"
+	"github.com/insolar/insolar/network/discovery"
 	"github.com/insolar/insolar/network/hostnetwork"
 	"github.com/insolar/insolar/network/merkle"
 	"github.com/insolar/insolar/network/routing"
+	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -144,14 +146,24 @@ func incrementPort(address string) (string, error) {
 
 // Start implements component.Initer
 func (n *ServiceNetwork) Init(ctx context.Context) error {
-	internalTransport, err := hostnetwork.NewInternalTransport(n.cfg, n.CertificateManager.GetCertificate().GetNodeRef().String())
+	nodeRef := n.CertificateManager.GetCertificate().GetNodeRef().String()
+	relays := relay.NewLimitedRelay(n.cfg.Host.Relay)
+	var internalTransport network.InternalTransport
+	var err error
+	if n.cfg.Host.IsRelay {
+		internalTransport, err = hostnetwork.NewInternalRelayTransport(n.cfg, nodeRef, relays)
+	} else {
+		internalTransport, err = hostnetwork.NewInternalTransport(n.cfg, nodeRef)
+	}
 	if err != nil {
 		return errors.Wrap(err, "Failed to create internal transport")
 	}
 
 	var consensusAddress string
-	if n.cfg.Host.Transport.FixedPublicAddress != "" {
-		// workaround for Consensus transport, port+=1 of default transport
+	behindRelay := n.cfg.Host.Relay.Address != "" && !n.cfg.Host.IsRelay
+	if n.cfg.Host.Transport.FixedPublicAddress != "" || behindRelay {
+		// workaround for Consensus transport, port+=1 of default transport.
+		// Node behind relay publishes consensus address of relay node, relay forwards consensus packets to it.
 		consensusAddress, err = incrementPort(n.cfg.Host.Transport.Address)
 		if err != nil {
 			return errors.Wrap(err, "failed to increment port.")
@@ -161,6 +173,7 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 	}
 
 	consensusNetwork, err := hostnetwork.NewConsensusNetwork(
+		n.cfg.Host.Transport.Protocol,
 		consensusAddress,
 		n.CertificateManager.GetCertificate().GetNodeRef().String(),
 		n.NodeKeeper.GetOrigin().ShortID(),
@@ -179,6 +192,7 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		&routing.Table{},
 		cert,
 		internalTransport,
+		relays,
 		hostNetwork,
 		merkle.NewCalculator(),
 		consensusNetwork,
@@ -186,14 +200,17 @@ func (n *ServiceNetwork) Init(ctx context.Context) error {
 		phases.NewFirstPhase(),
 		phases.NewSecondPhase(),
 		phases.NewThirdPhase(),
-		phases.NewPhaseManager(),
+		phases.NewPhaseManager(n.cfg.Service),
+		discovery.NewProvider(n.cfg.Host.Discovery),
 		bootstrap.NewSessionManager(),
 		controller.NewNetworkController(),
 		controller.NewRPCController(options),
-		controller.NewPulseController(),
+		controller.NewPulseController(options),
+		controller.NewRelayController(options),
 		bootstrap.NewBootstrapper(options),
 		bootstrap.NewAuthorizationController(options),
 		bootstrap.NewChallengeResponseController(options),
+		bootstrap.NewSnapshotController(options),
 		bootstrap.NewNetworkBootstrapper(),
 	)
 	err = n.cm.Init(ctx)
@@ -224,6 +241,7 @@ func (n *ServiceNetwork) Start(ctx context.Context) error {
 	return nil
 }
 
+// Leave implements insolar.Leaver
 func (n *ServiceNetwork) Leave(ctx context.Context, ETA insolar.PulseNumber) {
 	logger := inslogger.FromContext(ctx)
 	logger.Info("Gracefully stopping service network")
@@ -303,7 +321,12 @@ func (n *ServiceNetwork) HandlePulse(ctx context.Context, newPulse insolar.Pulse
 func (n *ServiceNetwork) phaseManagerOnPulse(ctx context.Context, newPulse insolar.Pulse, pulseStartTime time.Time) {
 	logger := inslogger.FromContext(ctx)
 
-	if err := n.PhaseManager.OnPulse(ctx, &newPulse, pulseStartTime); err != nil {
+	err := n.PhaseManager.OnPulse(ctx, &newPulse, pulseStartTime)
+	if err == insolar.ErrLeaveApproved {
+		n.TerminationHandler.OnLeaveApproved(ctx)
+		return
+	}
+	if err != nil {
 		logger.Error("Failed to pass consensus: " + err.Error())
 		n.TerminationHandler.Abort()
 	}
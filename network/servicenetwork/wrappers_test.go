@@ -129,6 +129,14 @@ func (n *nodeKeeperWrapper) SetCloudHash(hash []byte) {
 	n.original.SetCloudHash(hash)
 }
 
+func (n *nodeKeeperWrapper) GetBans() map[insolar.Reference]insolar.PulseNumber {
+	return n.original.GetBans()
+}
+
+func (n *nodeKeeperWrapper) SetBans(bans map[insolar.Reference]insolar.PulseNumber) {
+	n.original.SetBans(bans)
+}
+
 func (n *nodeKeeperWrapper) SetInitialSnapshot(nodes []insolar.NetworkNode) {
 	n.original.SetInitialSnapshot(nodes)
 }
@@ -0,0 +1,216 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package termination
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/insolar/utils"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+)
+
+const (
+	drainCheckInterval = time.Second
+	// defaultDrainTimeout is the time pending work is waited for after the leave is approved, the node is stopped anyway then
+	defaultDrainTimeout = 5 * time.Minute
+)
+
+type terminationHandler struct {
+	Leaver       insolar.Leaver       `inject:""`
+	PulseStorage insolar.PulseStorage `inject:""`
+	LogicRunner  insolar.LogicRunner  `inject:""`
+	PulseManager insolar.PulseManager `inject:""`
+
+	lock         sync.Mutex
+	leaving      bool
+	timedOut     bool // leave wasn't approved in time, the node isn't waiting for approval
+	approved     chan struct{}
+	approvedOnce sync.Once
+
+	drainTimeout time.Duration
+	// stop is called when the node is ready to exit, sends graceful stop signal to the process by default
+	stop func() error
+}
+
+// NewHandler creates termination handler that gracefully stops the node after leave.
+// The node that left the network can rejoin it only after restart, it's bootstrapped
+// via discovery nodes as a joining node then.
+func NewHandler() insolar.TerminationHandler {
+	return &terminationHandler{
+		approved:     make(chan struct{}),
+		drainTimeout: defaultDrainTimeout,
+		stop:         utils.SendGracefulStopSignal,
+	}
+}
+
+// Leave implements insolar.TerminationHandler.
+func (t *terminationHandler) Leave(ctx context.Context, leaveAfterPulses insolar.PulseNumber) (insolar.PulseNumber, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.leaving {
+		return 0, errors.New("[ Leave ] node is already leaving")
+	}
+	pulse, err := t.PulseStorage.Current(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "[ Leave ] failed to get current pulse")
+	}
+
+	ETA := pulse.PulseNumber + leaveAfterPulses*(pulse.NextPulseNumber-pulse.PulseNumber)
+	inslogger.FromContext(ctx).Infof("[ Leave ] Node is leaving the network at pulse %d", ETA)
+	t.Leaver.Leave(ctx, ETA)
+	t.leaving = true
+	t.timedOut = false
+
+	// request context can be cancelled before the node is drained
+	go t.waitAndStop(inslogger.SetLogger(context.Background(), inslogger.FromContext(ctx)), ETA)
+	return ETA, nil
+}
+
+// OnLeaveApproved implements insolar.TerminationHandler.
+func (t *terminationHandler) OnLeaveApproved(ctx context.Context) {
+	t.approvedOnce.Do(func() {
+		inslogger.FromContext(ctx).Info("[ OnLeaveApproved ] Leave is approved by network")
+		close(t.approved)
+
+		t.lock.Lock()
+		defer t.lock.Unlock()
+		if t.timedOut {
+			// waiting for approval has timed out, but the node is out of the active list anyway
+			inslogger.FromContext(ctx).Warn("[ OnLeaveApproved ] Leave is approved after timeout, stopping the node")
+			go t.drainAndStop(inslogger.SetLogger(context.Background(), inslogger.FromContext(ctx)))
+		}
+	})
+}
+
+// Abort implements insolar.TerminationHandler.
+func (t *terminationHandler) Abort() {
+	panic("NetworkNode leave acknowledged by network. Goodbye!")
+}
+
+func (t *terminationHandler) isDrained(ctx context.Context) bool {
+	for _, component := range []interface{}{t.LogicRunner, t.PulseManager} {
+		drainer, ok := component.(insolar.Drainer)
+		if ok && !drainer.IsDrained(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+// waitAndStop waits until the network approves the leave and all pending work is finished, then stops the node.
+// If the leave isn't approved when the network passes the ETA pulse, the node stays in the network
+// and the leave can be requested again.
+func (t *terminationHandler) waitAndStop(ctx context.Context, ETA insolar.PulseNumber) {
+	if !t.waitApproved(ctx, ETA) {
+		t.lock.Lock()
+		t.leaving = false
+		t.timedOut = true
+		t.lock.Unlock()
+		inslogger.FromContext(ctx).Errorf("[ waitAndStop ] Leave at pulse %d is not approved by network, node keeps working", ETA)
+		return
+	}
+	t.drainAndStop(ctx)
+}
+
+// waitApproved returns false if the leave isn't approved till the pulse after ETA.
+func (t *terminationHandler) waitApproved(ctx context.Context, ETA insolar.PulseNumber) bool {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-t.approved:
+			return true
+		case <-ticker.C:
+		}
+		pulse, err := t.PulseStorage.Current(ctx)
+		if err != nil {
+			inslogger.FromContext(ctx).Warn("[ waitApproved ] Failed to get current pulse: " + err.Error())
+			continue
+		}
+		if pulse.PulseNumber > ETA {
+			return false
+		}
+	}
+}
+
+// waitDrained returns false if pending work isn't finished in drain timeout.
+func (t *terminationHandler) waitDrained(ctx context.Context) bool {
+	ticker := time.NewTicker(drainCheckInterval)
+	defer ticker.Stop()
+	deadline := time.After(t.drainTimeout)
+	for !t.isDrained(ctx) {
+		inslogger.FromContext(ctx).Info("[ waitDrained ] Waiting for pending work to finish")
+		select {
+		case <-ticker.C:
+		case <-deadline:
+			return false
+		}
+	}
+	return true
+}
+
+// drainAndStop waits until all pending work is finished or drain timeout expires, then stops the node.
+func (t *terminationHandler) drainAndStop(ctx context.Context) {
+	logger := inslogger.FromContext(ctx)
+	if !t.waitDrained(ctx) {
+		logger.Warnf("[ drainAndStop ] Pending work isn't finished in %s, stopping anyway", t.drainTimeout)
+	}
+
+	logger.Info("[ drainAndStop ] Stopping the node")
+	if err := t.stop(); err != nil {
+		logger.Error("[ drainAndStop ] Failed to stop the node: " + err.Error())
+	}
+}
@@ -0,0 +1,202 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package termination
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+type leaverStub struct {
+	ETA insolar.PulseNumber
+}
+
+func (l *leaverStub) Leave(ctx context.Context, ETA insolar.PulseNumber) {
+	l.ETA = ETA
+}
+
+type drainerStub struct {
+	insolar.LogicRunner
+	drained int32
+}
+
+func (d *drainerStub) IsDrained(ctx context.Context) bool {
+	return atomic.LoadInt32(&d.drained) == 1
+}
+
+func newTestHandler(t *testing.T) (*terminationHandler, *leaverStub, *drainerStub, chan struct{}) {
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentMock.Return(&insolar.Pulse{PulseNumber: 100, NextPulseNumber: 110}, nil)
+
+	leaver := &leaverStub{}
+	drainer := &drainerStub{}
+	stopped := make(chan struct{})
+
+	handler := NewHandler().(*terminationHandler)
+	handler.Leaver = leaver
+	handler.PulseStorage = pulseStorage
+	handler.LogicRunner = drainer
+	handler.drainTimeout = time.Minute
+	handler.stop = func() error {
+		close(stopped)
+		return nil
+	}
+	return handler, leaver, drainer, stopped
+}
+
+func TestTerminationHandler_Leave(t *testing.T) {
+	ctx := context.Background()
+	handler, leaver, drainer, stopped := newTestHandler(t)
+
+	ETA, err := handler.Leave(ctx, 3)
+	require.NoError(t, err)
+	require.Equal(t, insolar.PulseNumber(130), ETA)
+	require.Equal(t, ETA, leaver.ETA)
+
+	_, err = handler.Leave(ctx, 3)
+	require.Error(t, err)
+
+	handler.OnLeaveApproved(ctx)
+	handler.OnLeaveApproved(ctx)
+
+	select {
+	case <-stopped:
+		t.Fatal("node is stopped before pending work is finished")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	atomic.StoreInt32(&drainer.drained, 1)
+	select {
+	case <-stopped:
+	case <-time.After(5 * drainCheckInterval):
+		t.Fatal("node is not stopped after pending work is finished")
+	}
+}
+
+func TestTerminationHandler_NotApproved(t *testing.T) {
+	ctx := context.Background()
+	handler, _, drainer, stopped := newTestHandler(t)
+	atomic.StoreInt32(&drainer.drained, 1)
+
+	_, err := handler.Leave(ctx, 0)
+	require.NoError(t, err)
+
+	select {
+	case <-stopped:
+		t.Fatal("node is stopped before leave is approved")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestTerminationHandler_ApproveTimeout(t *testing.T) {
+	ctx := context.Background()
+	handler, _, drainer, stopped := newTestHandler(t)
+	atomic.StoreInt32(&drainer.drained, 1)
+	var pulseNumber int32 = 100
+	pulseStorage := testutils.NewPulseStorageMock(t)
+	pulseStorage.CurrentFunc = func(context.Context) (*insolar.Pulse, error) {
+		number := insolar.PulseNumber(atomic.LoadInt32(&pulseNumber))
+		return &insolar.Pulse{PulseNumber: number, NextPulseNumber: number + 10}, nil
+	}
+	handler.PulseStorage = pulseStorage
+
+	ETA, err := handler.Leave(ctx, 1)
+	require.NoError(t, err)
+	atomic.StoreInt32(&pulseNumber, int32(ETA)+10)
+
+	// leave is not approved after ETA, the node keeps working and can leave again
+	deadline := time.Now().Add(5 * drainCheckInterval)
+	for _, err = handler.Leave(ctx, 1); err != nil; _, err = handler.Leave(ctx, 1) {
+		require.True(t, time.Now().Before(deadline), "leave is not reset after ETA")
+		time.Sleep(drainCheckInterval / 10)
+	}
+	select {
+	case <-stopped:
+		t.Fatal("node is stopped without leave approval")
+	default:
+	}
+}
+
+func TestTerminationHandler_LateApprove(t *testing.T) {
+	ctx := context.Background()
+	handler, _, drainer, stopped := newTestHandler(t)
+	atomic.StoreInt32(&drainer.drained, 1)
+	handler.timedOut = true
+
+	handler.OnLeaveApproved(ctx)
+	select {
+	case <-stopped:
+	case <-time.After(5 * drainCheckInterval):
+		t.Fatal("node is not stopped after late leave approval")
+	}
+}
+
+func TestTerminationHandler_DrainTimeout(t *testing.T) {
+	ctx := context.Background()
+	handler, _, _, stopped := newTestHandler(t)
+	handler.drainTimeout = 10 * time.Millisecond
+
+	_, err := handler.Leave(ctx, 0)
+	require.NoError(t, err)
+	handler.OnLeaveApproved(ctx)
+
+	select {
+	case <-stopped:
+	case <-time.After(5 * drainCheckInterval):
+		t.Fatal("node is not stopped after drain timeout")
+	}
+}
@@ -55,9 +55,11 @@ import (
 	"io"
 	"sync"
 
+	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/network/transport/packet"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/pkg/errors"
@@ -82,6 +84,7 @@ type baseTransport struct {
 	futureManager futureManager
 	serializer    transportSerializer
 	proxy         relay.Proxy
+	relay         relay.Relay
 	packetHandler packetHandler
 
 	disconnectStarted  chan bool
@@ -91,6 +94,8 @@ type baseTransport struct {
 
 	publicAddress string
 	sendFunc      func(recvAddress string, data []byte) error
+	// relayFunc sends data to relay client over connection opened by the client
+	relayFunc func(nodeID insolar.Reference, data []byte) error
 }
 
 func newBaseTransport(proxy relay.Proxy, publicAddress string) baseTransport {
@@ -161,6 +166,17 @@ func (t *baseTransport) PublicAddress() string {
 }
 
 func (t *baseTransport) SendPacket(ctx context.Context, p *packet.Packet) error {
+	data, err := t.serializer.SerializePacket(p)
+	if err != nil {
+		return errors.Wrap(err, "Failed to serialize packet")
+	}
+
+	if t.isRelayedHost(p.Receiver) {
+		// relay clients publish address of relay node, so they can be reached only over their own connection
+		inslogger.FromContext(ctx).Debugf("Send %s packet to relay client %s with RequestID = %d", p.Type, p.Receiver.NodeID, p.RequestID)
+		return t.relayFunc(p.Receiver.NodeID, data)
+	}
+
 	var recvAddress string
 	if t.proxy.ProxyHostsCount() > 0 {
 		recvAddress = t.proxy.GetNextProxyAddress()
@@ -169,11 +185,69 @@ func (t *baseTransport) SendPacket(ctx context.Context, p *packet.Packet) error
 		recvAddress = p.Receiver.Address.String()
 	}
 
-	data, err := t.serializer.SerializePacket(p)
+	inslogger.FromContext(ctx).Debugf("Send %s packet to %s with RequestID = %d", p.Type, recvAddress, p.RequestID)
+	return t.sendFunc(recvAddress, data)
+}
+
+// handlePacket forwards packet if it belongs to relay client and passes it to packet handler otherwise.
+func (t *baseTransport) handlePacket(ctx context.Context, msg *packet.Packet) {
+	if t.relayPacket(ctx, msg) {
+		return
+	}
+	t.packetHandler.Handle(ctx, msg)
+}
+
+func (t *baseTransport) isRelayedHost(h *host.Host) bool {
+	return t.relay != nil && h != nil && h.Address != nil && h.Address.String() == t.publicAddress
+}
+
+// relayPacket returns true if packet is sent to or from relay client and should not be processed by this node.
+func (t *baseTransport) relayPacket(ctx context.Context, msg *packet.Packet) bool {
+	if t.relay == nil || msg.Sender == nil || msg.Receiver == nil || msg.Receiver.Address == nil {
+		return false
+	}
+
+	var direction string
+	var client insolar.Reference
+	if t.isRelayedHost(msg.Receiver) {
+		if !t.relay.IsClient(msg.Receiver.NodeID) {
+			// packet for this node
+			return false
+		}
+		direction, client = "inbound", msg.Receiver.NodeID
+	} else {
+		if !t.relay.IsClient(msg.Sender.NodeID) {
+			return false
+		}
+		direction, client = "outbound", msg.Sender.NodeID
+	}
+
+	logger := inslogger.FromContext(ctx)
+	if !t.relay.Allow(client) {
+		metrics.NetworkRelayRejectedTotal.WithLabelValues("rate_limit").Inc()
+		logger.Warnf("[ relayPacket ] Relayed packets limit exceeded for client %s, dropping %s packet", client, msg.Type)
+		return true
+	}
+
+	data, err := t.serializer.SerializePacket(msg)
 	if err != nil {
-		return errors.Wrap(err, "Failed to serialize packet")
+		logger.Error("[ relayPacket ] Failed to serialize packet: ", err.Error())
+		return true
 	}
 
-	inslogger.FromContext(ctx).Debugf("Send %s packet to %s with RequestID = %d", p.Type, recvAddress, p.RequestID)
-	return t.sendFunc(recvAddress, data)
+	if direction == "inbound" {
+		err = t.relayFunc(client, data)
+	} else {
+		err = t.sendFunc(msg.Receiver.Address.String(), data)
+	}
+	if err != nil {
+		metrics.NetworkRelayRejectedTotal.WithLabelValues("send_failed").Inc()
+		logger.Warnf("[ relayPacket ] Failed to relay %s packet from %s to %s: %s", msg.Type, msg.Sender, msg.Receiver, err.Error())
+		return true
+	}
+
+	metrics.NetworkRelayedPacketsTotal.WithLabelValues(direction).Inc()
+	metrics.NetworkRelayedSize.WithLabelValues(direction).Add(float64(len(data)))
+	logger.Debugf("[ relayPacket ] Relayed %s packet from %s to %s", msg.Type, msg.Sender, msg.Receiver)
+	return true
 }
@@ -0,0 +1,333 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/packet"
+)
+
+// FaultConfig describes faults injected into outgoing packets.
+type FaultConfig struct {
+	// Latency is a base delay of every packet.
+	Latency time.Duration
+	// Jitter is a maximum random delay added to Latency.
+	Jitter time.Duration
+	// LossRate is a probability of a packet to be dropped.
+	LossRate float64
+	// DuplicateRate is a probability of a packet to be sent twice.
+	DuplicateRate float64
+	// ReorderRate is a probability of a packet to be held back for ReorderDelay, so next packets overtake it.
+	ReorderRate float64
+	// ReorderDelay is an extra delay of reordered packets.
+	ReorderDelay time.Duration
+}
+
+type partition struct {
+	left  map[string]struct{}
+	right map[string]struct{}
+}
+
+func newPartition(left, right []string) partition {
+	p := partition{
+		left:  make(map[string]struct{}, len(left)),
+		right: make(map[string]struct{}, len(right)),
+	}
+	for _, address := range left {
+		p.left[address] = struct{}{}
+	}
+	for _, address := range right {
+		p.right[address] = struct{}{}
+	}
+	return p
+}
+
+func (p partition) separates(from, to string) bool {
+	_, fromLeft := p.left[from]
+	_, fromRight := p.right[from]
+	_, toLeft := p.left[to]
+	_, toRight := p.right[to]
+	return (fromLeft && toRight) || (fromRight && toLeft)
+}
+
+// FaultInjector controls faults of transports created by NewFaultTransport.
+// Single injector may be shared by several transports to emulate partitions between them.
+// All methods are safe to call at runtime while transports are running.
+type FaultInjector struct {
+	mutex      sync.Mutex
+	random     *rand.Rand
+	config     FaultConfig
+	links      map[[2]string]FaultConfig
+	partitions map[string]partition
+}
+
+// NewFaultInjector creates FaultInjector without faults. Random decisions are made with provided seed,
+// so the same seed and the same sequence of packets give the same faults.
+func NewFaultInjector(seed int64) *FaultInjector {
+	return &FaultInjector{
+		random:     rand.New(rand.NewSource(seed)), // nolint: gosec
+		links:      make(map[[2]string]FaultConfig),
+		partitions: make(map[string]partition),
+	}
+}
+
+// SetConfig sets faults for all links without specific config.
+func (fi *FaultInjector) SetConfig(cfg FaultConfig) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.config = cfg
+}
+
+// SetLinkConfig sets faults for packets sent from one address to another.
+func (fi *FaultInjector) SetLinkConfig(from, to string, cfg FaultConfig) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.links[[2]string{from, to}] = cfg
+}
+
+// ResetLinkConfig removes specific faults of link, so it falls back to common config.
+func (fi *FaultInjector) ResetLinkConfig(from, to string) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	delete(fi.links, [2]string{from, to})
+}
+
+// Partition drops all packets between left and right address sets until Heal is called with the same name.
+func (fi *FaultInjector) Partition(name string, left, right []string) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.partitions[name] = newPartition(left, right)
+}
+
+// Heal removes named partition.
+func (fi *FaultInjector) Heal(name string) {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	delete(fi.partitions, name)
+}
+
+// HealAll removes all partitions.
+func (fi *FaultInjector) HealAll() {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	fi.partitions = make(map[string]partition)
+}
+
+type fate struct {
+	drop      bool
+	duplicate bool
+	delay     time.Duration
+}
+
+func (fi *FaultInjector) decide(from, to string) fate {
+	fi.mutex.Lock()
+	defer fi.mutex.Unlock()
+
+	for _, p := range fi.partitions {
+		if p.separates(from, to) {
+			return fate{drop: true}
+		}
+	}
+
+	cfg, ok := fi.links[[2]string{from, to}]
+	if !ok {
+		cfg = fi.config
+	}
+
+	if fi.random.Float64() < cfg.LossRate {
+		return fate{drop: true}
+	}
+	f := fate{
+		duplicate: fi.random.Float64() < cfg.DuplicateRate,
+		delay:     cfg.Latency,
+	}
+	if cfg.Jitter > 0 {
+		f.delay += time.Duration(fi.random.Int63n(int64(cfg.Jitter)))
+	}
+	if fi.random.Float64() < cfg.ReorderRate {
+		f.delay += cfg.ReorderDelay
+	}
+	return f
+}
+
+type faultTransport struct {
+	Transport
+	injector *FaultInjector
+}
+
+// NewFaultTransport wraps transport to inject faults into outgoing packets. Faults are controlled by injector.
+func NewFaultTransport(t Transport, injector *FaultInjector) Transport {
+	return &faultTransport{
+		Transport: t,
+		injector:  injector,
+	}
+}
+
+// SendRequest sends request packet and returns future. Future of dropped request is never resolved.
+func (t *faultTransport) SendRequest(ctx context.Context, msg *packet.Packet) (Future, error) {
+	f := t.injector.decide(t.PublicAddress(), msg.Receiver.Address.String())
+	if !f.drop && f.delay == 0 && !f.duplicate {
+		return t.Transport.SendRequest(ctx, msg)
+	}
+
+	done := make(chan struct{})
+	future := NewFuture(msg.RequestID, msg.Receiver, msg, func(Future) {
+		close(done)
+	})
+	if f.drop {
+		return future, nil
+	}
+
+	go func() {
+		if !t.sleep(f.delay, done) {
+			return
+		}
+		inner, err := t.Transport.SendRequest(ctx, msg)
+		if err != nil {
+			inslogger.FromContext(ctx).Warn("[ faultTransport ] failed to send delayed request: ", err)
+			future.Cancel()
+			return
+		}
+		if f.duplicate {
+			t.sendPacket(ctx, msg)
+		}
+
+		select {
+		case result, ok := <-inner.Result():
+			if !ok {
+				future.Cancel()
+				return
+			}
+			future.SetResult(result)
+		case <-done:
+			inner.Cancel()
+		}
+	}()
+	return future, nil
+}
+
+// SendResponse sends response packet.
+func (t *faultTransport) SendResponse(ctx context.Context, requestID network.RequestID, msg *packet.Packet) error {
+	f := t.injector.decide(t.PublicAddress(), msg.Receiver.Address.String())
+	if f.drop {
+		return nil
+	}
+	if f.delay == 0 && !f.duplicate {
+		return t.Transport.SendResponse(ctx, requestID, msg)
+	}
+
+	go func() {
+		time.Sleep(f.delay)
+		t.sendResponse(ctx, requestID, msg)
+		if f.duplicate {
+			t.sendResponse(ctx, requestID, msg)
+		}
+	}()
+	return nil
+}
+
+// SendPacket sends packet. Dropped packets are lost silently.
+func (t *faultTransport) SendPacket(ctx context.Context, p *packet.Packet) error {
+	f := t.injector.decide(t.PublicAddress(), p.Receiver.Address.String())
+	if f.drop {
+		return nil
+	}
+	if f.delay == 0 && !f.duplicate {
+		return t.Transport.SendPacket(ctx, p)
+	}
+
+	go func() {
+		time.Sleep(f.delay)
+		t.sendPacket(ctx, p)
+		if f.duplicate {
+			t.sendPacket(ctx, p)
+		}
+	}()
+	return nil
+}
+
+func (t *faultTransport) sendPacket(ctx context.Context, p *packet.Packet) {
+	err := t.Transport.SendPacket(ctx, p)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ faultTransport ] failed to send delayed packet: ", err)
+	}
+}
+
+func (t *faultTransport) sendResponse(ctx context.Context, requestID network.RequestID, msg *packet.Packet) {
+	err := t.Transport.SendResponse(ctx, requestID, msg)
+	if err != nil {
+		inslogger.FromContext(ctx).Warn("[ faultTransport ] failed to send delayed response: ", err)
+	}
+}
+
+func (t *faultTransport) sleep(delay time.Duration, cancel <-chan struct{}) bool {
+	if delay == 0 {
+		return true
+	}
+	select {
+	case <-time.After(delay):
+		return true
+	case <-cancel:
+		return false
+	}
+}
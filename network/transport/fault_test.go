@@ -0,0 +1,199 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+)
+
+type sentPacketsTransport struct {
+	Transport
+	mutex     sync.Mutex
+	address   string
+	sent      []*packet.Packet
+	responses []network.RequestID
+}
+
+func (t *sentPacketsTransport) PublicAddress() string {
+	return t.address
+}
+
+func (t *sentPacketsTransport) SendPacket(ctx context.Context, p *packet.Packet) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.sent = append(t.sent, p)
+	return nil
+}
+
+func (t *sentPacketsTransport) SendResponse(ctx context.Context, requestID network.RequestID, p *packet.Packet) error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.responses = append(t.responses, requestID)
+	return nil
+}
+
+func (t *sentPacketsTransport) SendRequest(ctx context.Context, p *packet.Packet) (Future, error) {
+	err := t.SendPacket(ctx, p)
+	return NewFuture(p.RequestID, p.Receiver, p, func(Future) {}), err
+}
+
+func (t *sentPacketsTransport) count() int {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return len(t.sent)
+}
+
+func (t *sentPacketsTransport) waitCount(test *testing.T, count int) {
+	deadline := time.Now().Add(time.Second)
+	for t.count() != count {
+		require.True(test, time.Now().Before(deadline), "packets are not sent in time")
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func newFaultTestPacket(t *testing.T, receiver string, id network.RequestID) *packet.Packet {
+	h, err := host.NewHost(receiver)
+	require.NoError(t, err)
+	return &packet.Packet{Receiver: h, Type: types.Ping, RequestID: id}
+}
+
+func TestFaultTransport_NoFaults(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	ft := NewFaultTransport(inner, NewFaultInjector(0))
+
+	for i := 0; i < 10; i++ {
+		require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", network.RequestID(i))))
+	}
+	require.Equal(t, 10, inner.count())
+}
+
+func TestFaultTransport_Loss(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	injector := NewFaultInjector(0)
+	injector.SetConfig(FaultConfig{LossRate: 1})
+	ft := NewFaultTransport(inner, injector)
+
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 1)))
+	future, err := ft.SendRequest(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 2))
+	require.NoError(t, err)
+	_, err = future.GetResult(10 * time.Millisecond)
+	require.Equal(t, ErrTimeout, err)
+	require.Equal(t, 0, inner.count())
+}
+
+func TestFaultTransport_Duplicate(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	injector := NewFaultInjector(0)
+	injector.SetConfig(FaultConfig{DuplicateRate: 1})
+	ft := NewFaultTransport(inner, injector)
+
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 1)))
+	inner.waitCount(t, 2)
+}
+
+func TestFaultTransport_Latency(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	injector := NewFaultInjector(0)
+	injector.SetLinkConfig("127.0.0.1:1", "127.0.0.1:2", FaultConfig{Latency: 50 * time.Millisecond})
+	ft := NewFaultTransport(inner, injector)
+
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 1)))
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:3", 2)))
+	require.Equal(t, 1, inner.count())
+	inner.waitCount(t, 2)
+
+	injector.ResetLinkConfig("127.0.0.1:1", "127.0.0.1:2")
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 3)))
+	require.Equal(t, 3, inner.count())
+}
+
+func TestFaultTransport_Response(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	injector := NewFaultInjector(0)
+	ft := NewFaultTransport(inner, injector)
+
+	// Responses go through SendResponse of the wrapped transport, it may route them differently from packets.
+	require.NoError(t, ft.SendResponse(context.Background(), 7, newFaultTestPacket(t, "127.0.0.1:2", 0)))
+	require.Equal(t, []network.RequestID{7}, inner.responses)
+	require.Equal(t, 0, inner.count())
+
+	injector.SetConfig(FaultConfig{LossRate: 1})
+	require.NoError(t, ft.SendResponse(context.Background(), 8, newFaultTestPacket(t, "127.0.0.1:2", 0)))
+	require.Equal(t, []network.RequestID{7}, inner.responses)
+}
+
+func TestFaultTransport_Partition(t *testing.T) {
+	inner := &sentPacketsTransport{address: "127.0.0.1:1"}
+	injector := NewFaultInjector(0)
+	injector.Partition("split", []string{"127.0.0.1:1"}, []string{"127.0.0.1:2"})
+	ft := NewFaultTransport(inner, injector)
+
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 1)))
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:3", 2)))
+	require.Equal(t, 1, inner.count())
+
+	injector.Heal("split")
+	require.NoError(t, ft.SendPacket(context.Background(), newFaultTestPacket(t, "127.0.0.1:2", 3)))
+	require.Equal(t, 2, inner.count())
+}
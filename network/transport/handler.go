@@ -114,5 +114,6 @@ func shouldProcessPacket(future Future, msg *packet.Packet) bool {
 	typesShouldBeEqual := msg.Type == future.Request().Type
 	responseIsForRightSender := future.Actor().Equal(*msg.Sender)
 
-	return typesShouldBeEqual && (responseIsForRightSender || msg.Type == types.Ping)
+	// ping and relay requests are sent by address, so node id of the sender is not known in advance
+	return typesShouldBeEqual && (responseIsForRightSender || msg.Type == types.Ping || msg.Type == types.Relay)
 }
@@ -0,0 +1,294 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/pkg/errors"
+
+	"github.com/insolar/insolar/consensus/packets"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/relay"
+)
+
+const (
+	memoryPacketHost      = byte(0)
+	memoryPacketConsensus = byte(1)
+
+	memoryFirstPort = 20000
+)
+
+// memorySerializer serializes both host and consensus packets, so one in-memory protocol serves both networks.
+type memorySerializer struct {
+	base      baseSerializer
+	consensus udpSerializer
+}
+
+func (s *memorySerializer) SerializePacket(q *packet.Packet) ([]byte, error) {
+	var (
+		kind byte
+		data []byte
+		err  error
+	)
+	if _, ok := q.Data.(packets.ConsensusPacket); ok {
+		kind = memoryPacketConsensus
+		data, err = s.consensus.SerializePacket(q)
+	} else {
+		kind = memoryPacketHost
+		data, err = s.base.SerializePacket(q)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{kind}, data...), nil
+}
+
+func (s *memorySerializer) DeserializePacket(conn io.Reader) (*packet.Packet, error) {
+	kind := make([]byte, 1)
+	if _, err := io.ReadFull(conn, kind); err != nil {
+		return nil, err
+	}
+	if kind[0] == memoryPacketConsensus {
+		return s.consensus.DeserializePacket(conn)
+	}
+	return s.base.DeserializePacket(conn)
+}
+
+// memoryRegistry connects in-memory transports of one process by their addresses.
+type memoryRegistry struct {
+	mutex     sync.RWMutex
+	endpoints map[string]*memoryEndpoint
+	nextPort  int
+}
+
+var memoryNetwork = &memoryRegistry{
+	endpoints: make(map[string]*memoryEndpoint),
+	nextPort:  memoryFirstPort,
+}
+
+func (r *memoryRegistry) register(address string, ep *memoryEndpoint) (string, error) {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return "", errors.Wrap(err, "[ register ] Failed to parse address")
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if port == "0" {
+		// ports are allocated with step of two, because consensus network listens next port of host network
+		for {
+			address = net.JoinHostPort(host, strconv.Itoa(r.nextPort))
+			r.nextPort += 2
+			if _, ok := r.endpoints[address]; !ok {
+				break
+			}
+		}
+	}
+	if _, ok := r.endpoints[address]; ok {
+		return "", errors.New("[ register ] Address already in use: " + address)
+	}
+	ep.address = address
+	r.endpoints[address] = ep
+	return address, nil
+}
+
+func (r *memoryRegistry) unregister(ep *memoryEndpoint) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// address could be taken by another transport already
+	if r.endpoints[ep.address] == ep {
+		delete(r.endpoints, ep.address)
+	}
+}
+
+func (r *memoryRegistry) get(address string) *memoryEndpoint {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.endpoints[address]
+}
+
+// memoryEndpoint is the part of memory transport which is reachable from the registry.
+// It queues received packets, so they are handled one by one in order of sending.
+// Endpoint doesn't reference its transport, so transport dropped without Stop is collected
+// and its address is released by finalizer.
+type memoryEndpoint struct {
+	address    string
+	serializer transportSerializer
+
+	mutex     sync.Mutex
+	listening bool
+	queue     []*packet.Packet
+	signal    chan struct{}
+}
+
+func newMemoryEndpoint() *memoryEndpoint {
+	return &memoryEndpoint{
+		serializer: &memorySerializer{},
+		signal:     make(chan struct{}, 1),
+	}
+}
+
+func (ep *memoryEndpoint) setListening(listening bool) {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	ep.listening = listening
+	if !listening {
+		ep.queue = nil
+	}
+}
+
+func (ep *memoryEndpoint) push(data []byte) error {
+	msg, err := ep.serializer.DeserializePacket(bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrap(err, "[ push ] Failed to deserialize packet")
+	}
+
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	if !ep.listening {
+		return errors.New("[ push ] Transport is not listening " + ep.address)
+	}
+	ep.queue = append(ep.queue, msg)
+
+	select {
+	case ep.signal <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (ep *memoryEndpoint) take() []*packet.Packet {
+	ep.mutex.Lock()
+	defer ep.mutex.Unlock()
+
+	queue := ep.queue
+	ep.queue = nil
+	return queue
+}
+
+// memoryTransport delivers packets to other memory transports of the same process without sockets.
+type memoryTransport struct {
+	baseTransport
+
+	endpoint *memoryEndpoint
+	stop     chan struct{}
+}
+
+func newMemoryTransport(address string, proxy relay.Proxy) (*memoryTransport, error) {
+	endpoint := newMemoryEndpoint()
+	publicAddress, err := memoryNetwork.register(address, endpoint)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ newMemoryTransport ] Failed to register transport")
+	}
+
+	transport := &memoryTransport{
+		baseTransport: newBaseTransport(proxy, publicAddress),
+		endpoint:      endpoint,
+		stop:          make(chan struct{}),
+	}
+	transport.serializer = endpoint.serializer
+	transport.sendFunc = memorySend
+	return transport, nil
+}
+
+func memorySend(recvAddress string, data []byte) error {
+	receiver := memoryNetwork.get(recvAddress)
+	if receiver == nil {
+		return errors.New("[ send ] No transport listens address " + recvAddress)
+	}
+	return receiver.push(data)
+}
+
+// Listen starts accepting packets from other memory transports and handles them in order of arrival.
+func (t *memoryTransport) Listen(ctx context.Context, started chan struct{}) error {
+	inslogger.FromContext(ctx).Info("[ Listen ] Start MEMORY transport")
+
+	t.endpoint.setListening(true)
+	started <- struct{}{}
+
+	for {
+		select {
+		case <-t.endpoint.signal:
+			for _, msg := range t.endpoint.take() {
+				t.packetHandler.Handle(context.Background(), msg)
+			}
+		case <-t.stop:
+			<-t.disconnectFinished
+			return nil
+		}
+	}
+}
+
+// Stop stops accepting packets and releases transport address.
+func (t *memoryTransport) Stop() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	log.Info("Stop MEMORY transport")
+	t.prepareDisconnect()
+
+	t.endpoint.setListening(false)
+	memoryNetwork.unregister(t.endpoint)
+	close(t.stop)
+}
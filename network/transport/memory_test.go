@@ -0,0 +1,158 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/network/transport/relay"
+)
+
+func TestMemoryTransport_AllocatesAddress(t *testing.T) {
+	cfg := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:0"}
+
+	t1, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+	t2, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+
+	require.NotEqual(t, "127.0.0.1:0", t1.PublicAddress())
+	require.NotEqual(t, t1.PublicAddress(), t2.PublicAddress())
+
+	t1.Stop()
+	t2.Stop()
+}
+
+func TestMemoryTransport_AddressInUse(t *testing.T) {
+	cfg := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:17030"}
+
+	t1, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+
+	_, err = NewTransport(cfg, relay.NewProxy())
+	require.Error(t, err)
+
+	t1.Stop()
+	t2, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+	t2.Stop()
+}
+
+func TestMemoryTransport_SendToUnknownAddress(t *testing.T) {
+	ctx := context.Background()
+	tp, err := NewTransport(configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:0"}, relay.NewProxy())
+	require.NoError(t, err)
+	defer tp.Stop()
+
+	sender, err := host.NewHost(tp.PublicAddress())
+	require.NoError(t, err)
+	receiver, err := host.NewHost("127.0.0.1:17031")
+	require.NoError(t, err)
+
+	p := packet.NewBuilder(sender).Type(types.Ping).Receiver(receiver).Build()
+	_, err = tp.SendRequest(ctx, p)
+	require.Error(t, err)
+}
+
+func TestMemoryTransport_DeliversInOrder(t *testing.T) {
+	ctx := context.Background()
+	cfg := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:0"}
+
+	t1, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+	t2, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+
+	started := make(chan struct{}, 1)
+	go t2.Listen(ctx, started)
+	<-started
+	defer func() {
+		go t2.Stop()
+		<-t2.Stopped()
+		t2.Close()
+	}()
+
+	sender, err := host.NewHost(t1.PublicAddress())
+	require.NoError(t, err)
+	receiver, err := host.NewHost(t2.PublicAddress())
+	require.NoError(t, err)
+
+	const count = 100
+	for i := 0; i < count; i++ {
+		p := packet.NewBuilder(sender).Type(types.Ping).Receiver(receiver).RequestID(network.RequestID(i)).Build()
+		require.NoError(t, t1.SendPacket(ctx, p))
+	}
+	for i := 0; i < count; i++ {
+		msg := <-t2.Packets()
+		require.Equal(t, network.RequestID(i), msg.RequestID)
+	}
+}
+
+func TestMemoryTransport_StoppedTransportReleasesAddress(t *testing.T) {
+	cfg := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:17032"}
+
+	tp, err := NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+	tp.Stop()
+	require.Nil(t, memoryNetwork.get(cfg.Address))
+
+	tp, err = NewTransport(cfg, relay.NewProxy())
+	require.NoError(t, err)
+	tp.Stop()
+}
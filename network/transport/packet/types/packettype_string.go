@@ -20,11 +20,14 @@ func _() {
 	_ = x[Challenge1-10]
 	_ = x[Challenge2-11]
 	_ = x[Disconnect-12]
+	_ = x[Relay-13]
+	_ = x[Snapshot-14]
+	_ = x[RelayConsensus-15]
 }
 
-const _PacketType_name = "PingRPCCascadePulseGetRandomHostsBootstrapAuthorizeRegisterGenesisChallenge1Challenge2Disconnect"
+const _PacketType_name = "PingRPCCascadePulseGetRandomHostsBootstrapAuthorizeRegisterGenesisChallenge1Challenge2DisconnectRelaySnapshotRelayConsensus"
 
-var _PacketType_index = [...]uint8{0, 4, 7, 14, 19, 33, 42, 51, 59, 66, 76, 86, 96}
+var _PacketType_index = [...]uint8{0, 4, 7, 14, 19, 33, 42, 51, 59, 66, 76, 86, 96, 101, 109, 123}
 
 func (i PacketType) String() string {
 	i -= 1
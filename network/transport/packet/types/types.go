@@ -78,4 +78,10 @@ const (
 	Challenge2
 	// Disconnect is packet type to gracefully disconnect from network.
 	Disconnect
+	// Relay is packet type to start or stop relaying packets of node behind NAT.
+	Relay
+	// Snapshot is packet type to request signed network snapshot from discovery node.
+	Snapshot
+	// RelayConsensus is packet type to forward consensus packet from relay node to node behind NAT.
+	RelayConsensus
 )
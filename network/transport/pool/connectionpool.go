@@ -53,42 +53,68 @@ package pool
 import (
 	"context"
 	"net"
+	"sort"
 	"sync"
+	"sync/atomic"
+	"time"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/metrics"
 )
 
+const (
+	evictReasonLRU  = "lru"
+	evictReasonIdle = "idle"
+)
+
 type connectionPool struct {
 	connectionFactory connectionFactory
+	maxConnections    int
+	idleTimeout       time.Duration
 
 	entryHolder entryHolder
 	mutex       sync.RWMutex
+	// lastReap is unix time in nanoseconds, it is checked without pool lock on every GetConnection
+	lastReap int64
 }
 
-func newConnectionPool(connectionFactory connectionFactory) *connectionPool {
+func newConnectionPool(connectionFactory connectionFactory, config configuration.ConnectionPool) *connectionPool {
 	return &connectionPool{
 		connectionFactory: connectionFactory,
+		maxConnections:    config.MaxConnections,
+		idleTimeout:       config.IdleTimeout,
 
 		entryHolder: newEntryHolder(),
+		lastReap:    time.Now().UnixNano(),
 	}
 }
 
-func (cp *connectionPool) GetConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+func (cp *connectionPool) GetConnection(ctx context.Context, address net.Addr) (net.Conn, func(), error) {
 	logger := inslogger.FromContext(ctx)
 
+	cp.reapIdle(ctx)
+
 	entry, ok := cp.getEntry(address)
 
 	logger.Debugf("[ GetConnection ] Finding entry for connection to %s in pool: %t", address, ok)
 
-	if ok {
-		return entry.Open(ctx)
+	if !ok {
+		logger.Debugf("[ GetConnection ] Missing entry for connection to %s in pool ", address)
+		entry = cp.getOrCreateEntry(ctx, address)
 	}
 
-	logger.Debugf("[ GetConnection ] Missing entry for connection to %s in pool ", address)
-	entry = cp.getOrCreateEntry(ctx, address)
+	conn, err := entry.Open(ctx)
+	if err == errEntryClosed {
+		logger.Debugf("[ GetConnection ] Entry for connection to %s was evicted, creating new one", address)
+		entry = cp.getOrCreateEntry(ctx, address)
+		conn, err = entry.Open(ctx)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
 
-	return entry.Open(ctx)
+	return conn, entry.Release, nil
 }
 
 func (cp *connectionPool) CloseConnection(ctx context.Context, address net.Addr) {
@@ -133,6 +159,12 @@ func (cp *connectionPool) getOrCreateEntry(ctx context.Context, address net.Addr
 
 	entry = newEntry(cp.connectionFactory, address, cp.CloseConnection)
 
+	for cp.maxConnections > 0 && cp.entryHolder.Size() >= cp.maxConnections {
+		if !cp.evictLRU(ctx) {
+			break
+		}
+	}
+
 	cp.entryHolder.Add(address, entry)
 	size := cp.entryHolder.Size()
 	logger.Debugf(
@@ -155,3 +187,68 @@ func (cp *connectionPool) Reset() {
 	cp.entryHolder.Clear()
 	metrics.NetworkConnections.Set(float64(cp.entryHolder.Size()))
 }
+
+// evictLRU closes least recently used connection which is not used right now and returns false if there is
+// no such connection. Must be called under write lock.
+func (cp *connectionPool) evictLRU(ctx context.Context) bool {
+	var entries []entry
+	cp.entryHolder.Iterate(func(e entry) {
+		entries = append(entries, e)
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].LastUsed().Before(entries[j].LastUsed())
+	})
+	for _, e := range entries {
+		if cp.evict(ctx, e, evictReasonLRU) {
+			return true
+		}
+	}
+	return false
+}
+
+// reapIdle closes connections which were not used longer than idle timeout.
+// Pool is checked at most twice per idle timeout.
+func (cp *connectionPool) reapIdle(ctx context.Context) {
+	if cp.idleTimeout <= 0 {
+		return
+	}
+
+	now := time.Now()
+	last := atomic.LoadInt64(&cp.lastReap)
+	if now.Sub(time.Unix(0, last)) < cp.idleTimeout/2 {
+		return
+	}
+	if !atomic.CompareAndSwapInt64(&cp.lastReap, last, now.UnixNano()) {
+		// pool is reaped by another sender
+		return
+	}
+
+	cp.mutex.Lock()
+	defer cp.mutex.Unlock()
+
+	var idle []entry
+	cp.entryHolder.Iterate(func(e entry) {
+		if now.Sub(e.LastUsed()) > cp.idleTimeout {
+			idle = append(idle, e)
+		}
+	})
+	for _, e := range idle {
+		cp.evict(ctx, e, evictReasonIdle)
+	}
+}
+
+// evict closes entry and removes it from pool. Entry used by sender right now is kept in pool,
+// so pool can exceed connections limit until it is released. Must be called under write lock.
+func (cp *connectionPool) evict(ctx context.Context, e entry, reason string) bool {
+	logger := inslogger.FromContext(ctx)
+	if !e.CloseUnused() {
+		logger.Debugf("[ evict ] Connection to %s is in use, skip eviction, reason: %s", e.Address(), reason)
+		return false
+	}
+	logger.Debugf("[ evict ] Evict connection to %s from pool, reason: %s", e.Address(), reason)
+
+	cp.entryHolder.Delete(e.Address())
+	metrics.NetworkConnections.Dec()
+	metrics.NetworkConnectionsEvicted.WithLabelValues(e.Address().String(), reason).Inc()
+	return true
+}
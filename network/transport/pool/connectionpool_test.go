@@ -0,0 +1,185 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package pool
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+)
+
+type pipeConnectionFactory struct {
+	mutex  sync.Mutex
+	remote []net.Conn
+}
+
+func (f *pipeConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+	local, remote := net.Pipe()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	f.remote = append(f.remote, remote)
+
+	return local, nil
+}
+
+func (f *pipeConnectionFactory) created() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	return len(f.remote)
+}
+
+func tcpAddr(t *testing.T, address string) net.Addr {
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	require.NoError(t, err)
+	return addr
+}
+
+func TestConnectionPool_ReusesConnection(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeConnectionFactory{}
+	cp := newConnectionPool(factory, configuration.ConnectionPool{})
+
+	conn1, release1, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	release1()
+	conn2, release2, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	release2()
+
+	require.Equal(t, conn1, conn2)
+	require.Equal(t, 1, factory.created())
+	cp.Reset()
+}
+
+func TestConnectionPool_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeConnectionFactory{}
+	cp := newConnectionPool(factory, configuration.ConnectionPool{MaxConnections: 2})
+
+	_, release, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	release()
+	time.Sleep(time.Millisecond)
+	_, release, err = cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:2"))
+	require.NoError(t, err)
+	release()
+	time.Sleep(time.Millisecond)
+	// touch first connection, so the second one becomes least recently used
+	_, release, err = cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	release()
+	time.Sleep(time.Millisecond)
+	_, release, err = cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:3"))
+	require.NoError(t, err)
+	release()
+
+	require.Equal(t, 2, cp.entryHolder.Size())
+	_, ok := cp.entryHolder.Get(tcpAddr(t, "127.0.0.1:1"))
+	require.True(t, ok)
+	_, ok = cp.entryHolder.Get(tcpAddr(t, "127.0.0.1:2"))
+	require.False(t, ok)
+	cp.Reset()
+}
+
+func TestConnectionPool_ReapsIdleConnections(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeConnectionFactory{}
+	cp := newConnectionPool(factory, configuration.ConnectionPool{IdleTimeout: 20 * time.Millisecond})
+
+	_, release, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	release()
+
+	time.Sleep(30 * time.Millisecond)
+	_, release, err = cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:2"))
+	require.NoError(t, err)
+	release()
+
+	require.Equal(t, 1, cp.entryHolder.Size())
+	_, ok := cp.entryHolder.Get(tcpAddr(t, "127.0.0.1:1"))
+	require.False(t, ok)
+	cp.Reset()
+}
+
+func TestConnectionPool_KeepsConnectionInUse(t *testing.T) {
+	ctx := context.Background()
+	factory := &pipeConnectionFactory{}
+	cp := newConnectionPool(factory, configuration.ConnectionPool{MaxConnections: 1})
+
+	conn, release1, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:1"))
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	_, release2, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:2"))
+	require.NoError(t, err)
+	release2()
+
+	// first connection is still used, so pool exceeds the limit instead of closing it
+	require.Equal(t, 2, cp.entryHolder.Size())
+	require.NoError(t, conn.SetDeadline(time.Now().Add(time.Second)))
+
+	release1()
+	_, release3, err := cp.GetConnection(ctx, tcpAddr(t, "127.0.0.1:3"))
+	require.NoError(t, err)
+	release3()
+
+	require.Equal(t, 1, cp.entryHolder.Size())
+	_, ok := cp.entryHolder.Get(tcpAddr(t, "127.0.0.1:1"))
+	require.False(t, ok)
+	cp.Reset()
+}
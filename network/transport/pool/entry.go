@@ -54,9 +54,11 @@ import (
 	"context"
 	"net"
 	"sync"
+	"time"
 
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/instrumentation/instracer"
+	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network/utils"
 	"github.com/pkg/errors"
 	"go.opencensus.io/trace"
@@ -69,15 +71,21 @@ type entryImpl struct {
 
 	mutex *sync.Mutex
 
-	conn net.Conn
+	conn     net.Conn
+	lastUsed time.Time
+	inUse    int
+	closed   bool
 }
 
+var errEntryClosed = errors.New("entry is closed")
+
 func newEntryImpl(connectionFactory connectionFactory, address net.Addr, onClose onClose) *entryImpl {
 	return &entryImpl{
 		connectionFactory: connectionFactory,
 		address:           address,
 		mutex:             &sync.Mutex{},
 		onClose:           onClose,
+		lastUsed:          time.Now(),
 	}
 }
 
@@ -85,7 +93,14 @@ func (e *entryImpl) Open(ctx context.Context) (net.Conn, error) {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
+	if e.closed {
+		return nil, errEntryClosed
+	}
+
+	e.lastUsed = time.Now()
 	if e.conn != nil {
+		metrics.NetworkConnectionsReused.WithLabelValues(e.address.String()).Inc()
+		e.inUse++
 		return e.conn, nil
 	}
 
@@ -93,11 +108,22 @@ func (e *entryImpl) Open(ctx context.Context) (net.Conn, error) {
 	if err != nil {
 		return nil, err
 	}
+	metrics.NetworkConnectionsOpened.WithLabelValues(e.address.String()).Inc()
 
 	e.conn = conn
+	e.inUse++
 	return e.conn, nil
 }
 
+// Release marks that one of senders doesn't use connection anymore.
+func (e *entryImpl) Release() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	e.lastUsed = time.Now()
+	e.inUse--
+}
+
 func (e *entryImpl) open(ctx context.Context) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 	ctx, span := instracer.StartSpan(ctx, "connectionPool.open")
@@ -115,6 +141,10 @@ func (e *entryImpl) open(ctx context.Context) (net.Conn, error) {
 		b := make([]byte, 1)
 		_, err := conn.Read(b)
 		if err != nil {
+			if e.isClosed() {
+				// connection was closed by pool itself, entry is already removed
+				return
+			}
 			logger.Infof("[ Open ] remote host 'closed' connection to %s: %s", e.address, err)
 			e.onClose(ctx, e.address)
 			return
@@ -130,7 +160,41 @@ func (e *entryImpl) Close() {
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
 
+	e.closed = true
 	if e.conn != nil {
 		utils.CloseVerbose(e.conn)
 	}
 }
+
+// CloseUnused closes entry only if nobody uses its connection and returns true if entry was closed.
+func (e *entryImpl) CloseUnused() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.inUse > 0 {
+		return false
+	}
+	e.closed = true
+	if e.conn != nil {
+		utils.CloseVerbose(e.conn)
+	}
+	return true
+}
+
+func (e *entryImpl) Address() net.Addr {
+	return e.address
+}
+
+func (e *entryImpl) LastUsed() time.Time {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.lastUsed
+}
+
+func (e *entryImpl) isClosed() bool {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	return e.closed
+}
@@ -53,10 +53,15 @@ package pool
 import (
 	"context"
 	"net"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
 )
 
 type ConnectionPool interface {
-	GetConnection(ctx context.Context, address net.Addr) (net.Conn, error)
+	// GetConnection returns connection to address and function which must be called when sender doesn't use
+	// connection anymore. Connections in use are not evicted from pool.
+	GetConnection(ctx context.Context, address net.Addr) (net.Conn, func(), error)
 	CloseConnection(ctx context.Context, address net.Addr)
 	Reset()
 }
@@ -67,7 +72,11 @@ type connectionFactory interface {
 
 type entry interface {
 	Open(ctx context.Context) (net.Conn, error)
+	Release()
 	Close()
+	CloseUnused() bool
+	Address() net.Addr
+	LastUsed() time.Time
 }
 
 type onClose func(ctx context.Context, addr net.Addr)
@@ -91,6 +100,7 @@ func newEntryHolder() entryHolder {
 	return newEntryHolderImpl()
 }
 
-func NewConnectionPool(connectionFactory connectionFactory) ConnectionPool {
-	return newConnectionPool(connectionFactory)
+// NewConnectionPool creates connection pool limited by config. Zero limits keep connections until they are closed.
+func NewConnectionPool(connectionFactory connectionFactory, config configuration.ConnectionPool) ConnectionPool {
+	return newConnectionPool(connectionFactory, config)
 }
@@ -50,6 +50,10 @@
 
 package relay
 
+import (
+	"sync"
+)
+
 // Proxy contains proxy addresses.
 type Proxy interface {
 	// AddProxyHost add an address to proxy list.
@@ -60,10 +64,12 @@ type Proxy interface {
 	GetNextProxyAddress() string
 	// ProxyHostsCount return added proxy count.
 	ProxyHostsCount() int
+	// IsProxyHost returns true if address is in proxy list.
+	IsProxyHost(address string) bool
 }
 
-// Note: thread unsafe!!!
 type proxy struct {
+	mutex     sync.RWMutex
 	proxyList []string
 	iterator  int
 }
@@ -78,6 +84,9 @@ func NewProxy() Proxy {
 
 // AddProxyHost add an address to proxy list.
 func (p *proxy) AddProxyHost(address string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	i := p.getProxyIndex(address)
 
 	if i != -1 {
@@ -88,6 +97,9 @@ func (p *proxy) AddProxyHost(address string) {
 
 // RemoveProxyHost removes proxy address from proxy list.
 func (p *proxy) RemoveProxyHost(address string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	i := p.getProxyIndex(address)
 
 	if i == -1 {
@@ -110,6 +122,9 @@ func (p *proxy) getProxyIndex(address string) int {
 
 // GetNextProxyAddress returns a next address to send from proxy list.
 func (p *proxy) GetNextProxyAddress() string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
 	if len(p.proxyList) == 0 {
 		return ""
 	}
@@ -122,5 +137,16 @@ func (p *proxy) GetNextProxyAddress() string {
 
 // ProxyHostsCount return added proxy count.
 func (p *proxy) ProxyHostsCount() int {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
 	return len(p.proxyList)
 }
+
+// IsProxyHost returns true if address is in proxy list.
+func (p *proxy) IsProxyHost(address string) bool {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	return p.getProxyIndex(address) != -1
+}
@@ -51,7 +51,12 @@
 package relay
 
 import (
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/metrics"
 	"github.com/insolar/insolar/network/transport/host"
 
 	"errors"
@@ -73,6 +78,12 @@ const (
 	NoAuth
 )
 
+// StateResponse is implemented by data of response to relay request.
+// Relay transport binds connection of the client only if response says that relaying was started.
+type StateResponse interface {
+	RelayState() State
+}
+
 // Relay Interface for relaying
 type Relay interface {
 	// AddClient add client to relay list.
@@ -83,57 +94,131 @@ type Relay interface {
 	ClientsCount() int
 	// NeedToRelay returns true if origin host is proxy for target host.
 	NeedToRelay(targetAddress string) bool
+	// IsClient returns true if node with passed id is in relay list.
+	IsClient(nodeID insolar.Reference) bool
+	// Allow returns false if client has exceeded relayed packets limit for the current second.
+	Allow(nodeID insolar.Reference) bool
+}
+
+type client struct {
+	host        *host.Host
+	windowStart time.Time
+	packets     int
 }
 
 type relay struct {
-	clients []*host.Host
+	mutex sync.RWMutex
+
+	clients             []*client
+	maxClients          int
+	maxPacketsPerSecond int
 }
 
 // NewRelay constructs relay list.
 func NewRelay() Relay {
 	return &relay{
-		clients: make([]*host.Host, 0),
+		clients: make([]*client, 0),
+	}
+}
+
+// NewLimitedRelay constructs relay list with clients and traffic limits from configuration.
+func NewLimitedRelay(cfg configuration.Relay) Relay {
+	return &relay{
+		clients:             make([]*client, 0),
+		maxClients:          cfg.MaxClients,
+		maxPacketsPerSecond: cfg.MaxPacketsPerSecond,
 	}
 }
 
 // AddClient add client to relay list.
 func (r *relay) AddClient(host *host.Host) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	if _, n := r.findClient(host.NodeID); n != nil {
 		return errors.New("client exists already")
 	}
-	r.clients = append(r.clients, host)
+	if r.maxClients > 0 && len(r.clients) >= r.maxClients {
+		return errors.New("too many clients")
+	}
+	r.clients = append(r.clients, &client{host: host})
+	metrics.NetworkRelayClients.Set(float64(len(r.clients)))
 	return nil
 }
 
 // RemoveClient removes client from relay list.
 func (r *relay) RemoveClient(host *host.Host) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
 	idx, n := r.findClient(host.NodeID)
 	if n == nil {
 		return errors.New("client not found")
 	}
 	r.clients = append(r.clients[:idx], r.clients[idx+1:]...)
+	metrics.NetworkRelayClients.Set(float64(len(r.clients)))
 	return nil
 }
 
 // ClientsCount - returns clients count.
 func (r *relay) ClientsCount() int {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
 	return len(r.clients)
 }
 
 // NeedToRelay returns true if origin host is proxy for target host.
 func (r *relay) NeedToRelay(targetAddress string) bool {
-	for i := 0; i < r.ClientsCount(); i++ {
-		if r.clients[i].Address.String() == targetAddress {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	for _, c := range r.clients {
+		if c.host.Address.String() == targetAddress {
 			return true
 		}
 	}
 	return false
 }
 
-func (r *relay) findClient(id insolar.Reference) (int, *host.Host) {
-	for idx, hostIterator := range r.clients {
-		if hostIterator.NodeID.Equal(id) {
-			return idx, hostIterator
+// IsClient returns true if node with passed id is in relay list.
+func (r *relay) IsClient(nodeID insolar.Reference) bool {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	_, c := r.findClient(nodeID)
+	return c != nil
+}
+
+// Allow returns false if client has exceeded relayed packets limit for the current second.
+func (r *relay) Allow(nodeID insolar.Reference) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	_, c := r.findClient(nodeID)
+	if c == nil {
+		return false
+	}
+	if r.maxPacketsPerSecond <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	if now.Sub(c.windowStart) >= time.Second {
+		c.windowStart = now
+		c.packets = 0
+	}
+	if c.packets >= r.maxPacketsPerSecond {
+		return false
+	}
+	c.packets++
+	return true
+}
+
+func (r *relay) findClient(id insolar.Reference) (int, *client) {
+	for idx, c := range r.clients {
+		if c.host.NodeID.Equal(id) {
+			return idx, c
 		}
 	}
 	return -1, nil
@@ -54,6 +54,7 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/network/transport/host"
 	"github.com/insolar/insolar/testutils"
 	"github.com/stretchr/testify/require"
@@ -272,3 +273,49 @@ func TestCreateProxy(t *testing.T) {
 
 	require.Equal(t, true, check)
 }
+
+func TestRelay_IsClient(t *testing.T) {
+	relay := NewRelay()
+	hosts := makeHosts(2, t)
+
+	require.NoError(t, relay.AddClient(hosts[0]))
+	require.True(t, relay.IsClient(hosts[0].NodeID))
+	require.False(t, relay.IsClient(hosts[1].NodeID))
+}
+
+func TestRelay_MaxClients(t *testing.T) {
+	relay := NewLimitedRelay(configuration.Relay{MaxClients: 2})
+	hosts := makeHosts(3, t)
+
+	require.NoError(t, relay.AddClient(hosts[0]))
+	require.NoError(t, relay.AddClient(hosts[1]))
+	require.EqualError(t, relay.AddClient(hosts[2]), "too many clients")
+
+	require.NoError(t, relay.RemoveClient(hosts[0]))
+	require.NoError(t, relay.AddClient(hosts[2]))
+	require.Equal(t, 2, relay.ClientsCount())
+}
+
+func TestRelay_Allow(t *testing.T) {
+	relay := NewLimitedRelay(configuration.Relay{MaxPacketsPerSecond: 3})
+	hosts := makeHosts(2, t)
+
+	require.False(t, relay.Allow(hosts[0].NodeID))
+
+	require.NoError(t, relay.AddClient(hosts[0]))
+	require.NoError(t, relay.AddClient(hosts[1]))
+	for i := 0; i < 3; i++ {
+		require.True(t, relay.Allow(hosts[0].NodeID))
+	}
+	require.False(t, relay.Allow(hosts[0].NodeID))
+	require.True(t, relay.Allow(hosts[1].NodeID))
+}
+
+func TestProxy_IsProxyHost(t *testing.T) {
+	addresses := makeAddresses(2, t)
+	proxy := NewProxy()
+
+	proxy.AddProxyHost(addresses[0].String())
+	require.True(t, proxy.IsProxyHost(addresses[0].String()))
+	require.False(t, proxy.IsProxyHost(addresses[1].String()))
+}
@@ -0,0 +1,252 @@
+//
+// Modified BSD 3-Clause Clear License
+//
+// Copyright (c) 2019 Insolar Technologies GmbH
+//
+// All rights reserved.
+//
+// Redistribution and use in source and binary forms, with or without modification,
+// are permitted (subject to the limitations in the disclaimer below) provided that
+// the following conditions are met:
+//  * Redistributions of source code must retain the above copyright notice, this list
+//    of conditions and the following disclaimer.
+//  * Redistributions in binary form must reproduce the above copyright notice, this list
+//    of conditions and the following disclaimer in the documentation and/or other materials
+//    provided with the distribution.
+//  * Neither the name of Insolar Technologies GmbH nor the names of its contributors
+//    may be used to endorse or promote products derived from this software without
+//    specific prior written permission.
+//
+// NO EXPRESS OR IMPLIED LICENSES TO ANY PARTY'S PATENT RIGHTS ARE GRANTED
+// BY THIS LICENSE. THIS SOFTWARE IS PROVIDED BY THE COPYRIGHT HOLDERS
+// AND CONTRIBUTORS "AS IS" AND ANY EXPRESS OR IMPLIED WARRANTIES,
+// INCLUDING, BUT NOT LIMITED TO, THE IMPLIED WARRANTIES OF MERCHANTABILITY
+// AND FITNESS FOR A PARTICULAR PURPOSE ARE DISCLAIMED. IN NO EVENT SHALL
+// THE COPYRIGHT HOLDER OR CONTRIBUTORS BE LIABLE FOR ANY DIRECT, INDIRECT,
+// INCIDENTAL, SPECIAL, EXEMPLARY, OR CONSEQUENTIAL DAMAGES (INCLUDING,
+// BUT NOT LIMITED TO, PROCUREMENT OF SUBSTITUTE GOODS OR SERVICES; LOSS
+// OF USE, DATA, OR PROFITS; OR BUSINESS INTERRUPTION) HOWEVER CAUSED AND
+// ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY, OR TORT
+// (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE USE
+// OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.
+//
+// Notwithstanding any other provisions of this license, it is prohibited to:
+//    (a) use this software,
+//
+//    (b) prepare modifications and derivative works of this software,
+//
+//    (c) distribute this software (including without limitation in source code, binary or
+//        object code form), and
+//
+//    (d) reproduce copies of this software
+//
+//    for any commercial purposes, and/or
+//
+//    for the purposes of making available this software to third parties as a service,
+//    including, without limitation, any software-as-a-service, platform-as-a-service,
+//    infrastructure-as-a-service or other similar online service, irrespective of
+//    whether it competes with the products or services of Insolar Technologies GmbH.
+//
+
+package transport
+
+import (
+	"context"
+	"encoding/gob"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/network/transport/host"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
+	"github.com/insolar/insolar/network/transport/relay"
+	"github.com/insolar/insolar/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTCPTransport(t *testing.T, proxy relay.Proxy, r relay.Relay) Transport {
+	cfg := configuration.Transport{Protocol: "TCP", Address: "127.0.0.1:0", Pool: configuration.NewHostNetwork().Transport.Pool}
+	var tp Transport
+	var err error
+	if r != nil {
+		tp, err = NewRelayTransport(cfg, proxy, r)
+	} else {
+		tp, err = NewTransport(cfg, proxy)
+	}
+	require.NoError(t, err)
+	require.NoError(t, ListenAndWaitUntilReady(context.Background(), tp))
+	return tp
+}
+
+func stopTestTransport(tp Transport) {
+	go tp.Stop()
+	<-tp.Stopped()
+	tp.Close()
+}
+
+func receivePacket(t *testing.T, tp Transport) *packet.Packet {
+	select {
+	case msg := <-tp.Packets():
+		return msg
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "packet was not received")
+		return nil
+	}
+}
+
+type testRelayResponse struct {
+	State relay.State
+}
+
+func (r *testRelayResponse) RelayState() relay.State {
+	return r.State
+}
+
+// startRelaying sends relay request from client and answers it on relay node with passed state.
+func startRelaying(t *testing.T, client, relayTransport Transport, clientHost, relayHost *host.Host, state relay.State) {
+	ctx := context.Background()
+	p := packet.NewBuilder(clientHost).Receiver(relayHost).Type(types.Relay).
+		Request(&packet.RequestTest{Data: []byte("relay")}).Build()
+	future, err := client.SendRequest(ctx, p)
+	require.NoError(t, err)
+
+	msg := receivePacket(t, relayTransport)
+	require.Equal(t, types.Relay, msg.Type)
+	response := packet.NewBuilder(relayHost).Receiver(msg.Sender).Type(types.Relay).
+		Response(&testRelayResponse{State: state}).Build()
+	require.NoError(t, relayTransport.SendResponse(ctx, msg.RequestID, response))
+
+	result, err := future.GetResult(5 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, state, result.Data.(*testRelayResponse).State)
+}
+
+func TestRelayTransport(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	gob.Register(&testRelayResponse{})
+	ctx := context.Background()
+	relays := relay.NewRelay()
+
+	relayTransport := newTestTCPTransport(t, relay.NewProxy(), relays)
+	defer stopTestTransport(relayTransport)
+	relayHost, err := host.NewHost(relayTransport.PublicAddress())
+	require.NoError(t, err)
+
+	// client is behind NAT, so it publishes relay address and sends everything through relay
+	proxy := relay.NewProxy()
+	proxy.AddProxyHost(relayTransport.PublicAddress())
+	clientTransport := newTestTCPTransport(t, proxy, nil)
+	defer stopTestTransport(clientTransport)
+	clientHost, err := host.NewHostN(relayTransport.PublicAddress(), testutils.RandomRef())
+	require.NoError(t, err)
+
+	otherTransport := newTestTCPTransport(t, relay.NewProxy(), nil)
+	defer stopTestTransport(otherTransport)
+	otherHost, err := host.NewHostN(otherTransport.PublicAddress(), testutils.RandomRef())
+	require.NoError(t, err)
+
+	// packets of unregistered node are processed by relay node itself
+	p := packet.NewBuilder(clientHost).Receiver(otherHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("not relayed")}).Build()
+	_, err = clientTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	msg := receivePacket(t, relayTransport)
+	require.Equal(t, []byte("not relayed"), msg.Data.(*packet.RequestTest).Data)
+
+	require.NoError(t, relays.AddClient(clientHost))
+	startRelaying(t, clientTransport, relayTransport, clientHost, relayHost, relay.Started)
+
+	// client -> other
+	p = packet.NewBuilder(clientHost).Receiver(otherHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("request")}).Build()
+	future, err := clientTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	msg = receivePacket(t, otherTransport)
+	require.Equal(t, []byte("request"), msg.Data.(*packet.RequestTest).Data)
+	require.True(t, clientHost.Equal(*msg.Sender))
+
+	// other -> client response
+	response := packet.NewBuilder(otherHost).Receiver(msg.Sender).Type(packet.TestPacket).
+		Response(&packet.RequestTest{Data: []byte("response")}).Build()
+	require.NoError(t, otherTransport.SendResponse(ctx, msg.RequestID, response))
+	result, err := future.GetResult(5 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []byte("response"), result.Data.(*packet.RequestTest).Data)
+
+	// other -> client request
+	p = packet.NewBuilder(otherHost).Receiver(clientHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("inbound")}).Build()
+	_, err = otherTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	msg = receivePacket(t, clientTransport)
+	require.Equal(t, []byte("inbound"), msg.Data.(*packet.RequestTest).Data)
+
+	// relay -> client
+	p = packet.NewBuilder(relayHost).Receiver(clientHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("from relay")}).Build()
+	_, err = relayTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	msg = receivePacket(t, clientTransport)
+	require.Equal(t, []byte("from relay"), msg.Data.(*packet.RequestTest).Data)
+}
+
+func TestNewRelayTransport_UnsupportedProtocol(t *testing.T) {
+	cfg := configuration.Transport{Protocol: "PURE_UDP", Address: "127.0.0.1:0"}
+	_, err := NewRelayTransport(cfg, relay.NewProxy(), relay.NewRelay())
+	require.Error(t, err)
+}
+
+func TestRelayTransport_SpoofedSender(t *testing.T) {
+	gob.Register(&packet.RequestTest{})
+	gob.Register(&testRelayResponse{})
+	ctx := context.Background()
+	relays := relay.NewRelay()
+
+	relayTransport := newTestTCPTransport(t, relay.NewProxy(), relays)
+	defer stopTestTransport(relayTransport)
+	relayHost, err := host.NewHost(relayTransport.PublicAddress())
+	require.NoError(t, err)
+
+	proxy := relay.NewProxy()
+	proxy.AddProxyHost(relayTransport.PublicAddress())
+	clientTransport := newTestTCPTransport(t, proxy, nil)
+	defer stopTestTransport(clientTransport)
+	clientHost, err := host.NewHostN(relayTransport.PublicAddress(), testutils.RandomRef())
+	require.NoError(t, err)
+
+	require.NoError(t, relays.AddClient(clientHost))
+	startRelaying(t, clientTransport, relayTransport, clientHost, relayHost, relay.Started)
+
+	// attacker claims to be the client, but relay node doesn't accept its relay request
+	attackerProxy := relay.NewProxy()
+	attackerProxy.AddProxyHost(relayTransport.PublicAddress())
+	attackerTransport := newTestTCPTransport(t, attackerProxy, nil)
+	defer stopTestTransport(attackerTransport)
+
+	p := packet.NewBuilder(clientHost).Receiver(relayHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("spoofed")}).Build()
+	_, err = attackerTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	receivePacket(t, relayTransport)
+	startRelaying(t, attackerTransport, relayTransport, clientHost, relayHost, relay.NoAuth)
+
+	// packets for the client are still sent over the client connection
+	otherTransport := newTestTCPTransport(t, relay.NewProxy(), nil)
+	defer stopTestTransport(otherTransport)
+	otherHost, err := host.NewHostN(otherTransport.PublicAddress(), testutils.RandomRef())
+	require.NoError(t, err)
+
+	p = packet.NewBuilder(otherHost).Receiver(clientHost).Type(packet.TestPacket).
+		Request(&packet.RequestTest{Data: []byte("inbound")}).Build()
+	_, err = otherTransport.SendRequest(ctx, p)
+	require.NoError(t, err)
+	msg := receivePacket(t, clientTransport)
+	require.Equal(t, []byte("inbound"), msg.Data.(*packet.RequestTest).Data)
+
+	select {
+	case msg := <-attackerTransport.Packets():
+		require.FailNow(t, "attacker received packet of the client", "%v", msg)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
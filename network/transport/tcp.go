@@ -54,12 +54,19 @@ import (
 	"context"
 	"io"
 	"net"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
 	"github.com/insolar/insolar/log"
 	"github.com/insolar/insolar/metrics"
+	"github.com/insolar/insolar/network"
+	"github.com/insolar/insolar/network/transport/packet"
+	"github.com/insolar/insolar/network/transport/packet/types"
 	"github.com/insolar/insolar/network/transport/pool"
 	"github.com/insolar/insolar/network/transport/relay"
 	"github.com/insolar/insolar/network/utils"
@@ -69,18 +76,38 @@ type tcpTransport struct {
 	baseTransport
 
 	pool     pool.ConnectionPool
+	factory  *tcpConnectionFactory
 	listener net.Listener
 	addr     string
+
+	connsMutex sync.Mutex
+	// relayConns are connections opened to relay nodes from proxy list, packets for this node are received over them
+	relayConns map[string]net.Conn
+	// clientConns are connections opened by relay clients to this node
+	clientConns map[insolar.Reference]net.Conn
+	// relayRequests are connections relay requests came from, they are waiting for relay node decision
+	relayRequests map[relayRequestKey]net.Conn
+}
+
+type relayRequestKey struct {
+	nodeID    insolar.Reference
+	requestID network.RequestID
 }
 
-func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string) (*tcpTransport, error) {
+func newTCPTransport(addr string, proxy relay.Proxy, publicAddress string, poolConfig configuration.ConnectionPool) (*tcpTransport, error) {
+	factory := &tcpConnectionFactory{keepalivePeriod: poolConfig.KeepalivePeriod}
 	transport := &tcpTransport{
 		baseTransport: newBaseTransport(proxy, publicAddress),
 		addr:          addr,
-		pool:          pool.NewConnectionPool(&tcpConnectionFactory{}),
+		factory:       factory,
+		pool:          pool.NewConnectionPool(factory, poolConfig),
+		relayConns:    make(map[string]net.Conn),
+		clientConns:   make(map[insolar.Reference]net.Conn),
+		relayRequests: make(map[relayRequestKey]net.Conn),
 	}
 
 	transport.sendFunc = transport.send
+	transport.relayFunc = transport.sendToClient
 
 	return transport, nil
 }
@@ -89,19 +116,18 @@ func (t *tcpTransport) send(address string, data []byte) error {
 	ctx := context.Background()
 	logger := inslogger.FromContext(ctx)
 
-	addr, err := net.ResolveTCPAddr("tcp", address)
-	if err != nil {
-		return errors.Wrap(err, "[ send ] Failed to resolve net address")
+	if t.proxy.IsProxyHost(address) {
+		return t.sendToRelay(ctx, address, data)
 	}
 
-	conn, err := t.pool.GetConnection(ctx, addr)
+	addr, err := net.ResolveTCPAddr("tcp", address)
 	if err != nil {
-		return errors.Wrap(err, "[ send ] Failed to get connection")
+		return errors.Wrap(err, "[ send ] Failed to resolve net address")
 	}
 
 	logger.Debug("[ send ] len = ", len(data))
 
-	n, err := conn.Write(data)
+	n, err := t.write(ctx, addr, data)
 
 	if err != nil {
 		// All this to check is error EPIPE
@@ -110,11 +136,7 @@ func (t *tcpTransport) send(address string, data []byte) error {
 		// 	case *os.SyscallError:
 		// 		if realNetErr.Err == syscall.EPIPE {
 		t.pool.CloseConnection(ctx, addr)
-		conn, err = t.pool.GetConnection(ctx, addr)
-		if err != nil {
-			return errors.Wrap(err, "[ send ] Failed to get connection")
-		}
-		n, err = conn.Write(data)
+		n, err = t.write(ctx, addr, data)
 		// 		}
 		// 	}
 		// }
@@ -127,6 +149,17 @@ func (t *tcpTransport) send(address string, data []byte) error {
 	return errors.Wrap(err, "[ send ] Failed to write data")
 }
 
+// write sends data over pooled connection, connection can't be evicted from pool while data is written.
+func (t *tcpTransport) write(ctx context.Context, addr net.Addr, data []byte) (int, error) {
+	conn, release, err := t.pool.GetConnection(ctx, addr)
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to get connection")
+	}
+	defer release()
+
+	return conn.Write(data)
+}
+
 func (t *tcpTransport) prepareListen() error {
 	t.mutex.Lock()
 	defer t.mutex.Unlock()
@@ -178,10 +211,20 @@ func (t *tcpTransport) Stop() {
 
 	utils.CloseVerbose(t.listener)
 	t.pool.Reset()
+
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+	for address, conn := range t.relayConns {
+		utils.CloseVerbose(conn)
+		delete(t.relayConns, address)
+	}
 }
 
 func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 	defer utils.CloseVerbose(conn)
+	if t.relay != nil {
+		defer t.unbindClientConnection(conn)
+	}
 
 	for {
 		msg, err := t.serializer.DeserializePacket(conn)
@@ -191,20 +234,201 @@ func (t *tcpTransport) handleAcceptedConnection(conn net.Conn) {
 				log.Warn("[ handleAcceptedConnection ] Connection closed by peer")
 				return
 			}
+			if _, ok := err.(*net.OpError); ok {
+				log.Warn("[ handleAcceptedConnection ] Connection closed: ", err.Error())
+				return
+			}
 
 			log.Error("[ handleAcceptedConnection ] Failed to deserialize packet: ", err.Error())
 		} else {
 			ctx, logger := inslogger.WithTraceField(context.Background(), msg.TraceID)
 			logger.Debug("[ handleAcceptedConnection ] Handling packet: ", msg.RequestID)
 
-			go t.packetHandler.Handle(ctx, msg)
+			if t.relay != nil && msg.Type == types.Relay && !msg.IsResponse && !t.addRelayRequest(msg, conn) {
+				logger.Warnf("[ handleAcceptedConnection ] Duplicate relay request %d from %s is dropped", msg.RequestID, msg.Sender)
+				continue
+			}
+			go t.handlePacket(ctx, msg)
+		}
+	}
+}
+
+// SendResponse sends response packet. Response to relay request is written to the connection the request came from.
+// Connection is bound to relay client only when relay node has authenticated the client and started relaying,
+// so packets with spoofed sender can't take over traffic of the client.
+func (t *tcpTransport) SendResponse(ctx context.Context, requestID network.RequestID, msg *packet.Packet) error {
+	if t.relay == nil || msg.Type != types.Relay || msg.Receiver == nil {
+		return t.baseTransport.SendResponse(ctx, requestID, msg)
+	}
+	msg.RequestID = requestID
+
+	nodeID := msg.Receiver.NodeID
+	conn := t.takeRelayRequest(nodeID, requestID)
+	if conn == nil {
+		return errors.New("[ SendResponse ] No connection for relay request from " + nodeID.String())
+	}
+
+	data, err := t.serializer.SerializePacket(msg)
+	if err != nil {
+		return errors.Wrap(err, "[ SendResponse ] Failed to serialize packet")
+	}
+
+	state := relay.Unknown
+	if r, ok := msg.Data.(relay.StateResponse); ok {
+		state = r.RelayState()
+	}
+	if state == relay.Started {
+		t.bindClientConnection(nodeID, conn)
+	}
+
+	n, err := conn.Write(data)
+	if err != nil {
+		return errors.Wrap(err, "[ SendResponse ] Failed to write data")
+	}
+	metrics.NetworkSentSize.Add(float64(n))
+
+	if state == relay.Stopped {
+		t.unbindClient(nodeID, conn)
+	}
+	return nil
+}
+
+// sendToRelay sends data over the dedicated connection to relay node. Relay node sends packets
+// for this node back over the same connection, because node is not reachable from outside.
+func (t *tcpTransport) sendToRelay(ctx context.Context, address string, data []byte) error {
+	conn, err := t.getRelayConnection(ctx, address)
+	if err != nil {
+		return errors.Wrap(err, "[ sendToRelay ] Failed to get relay connection")
+	}
+
+	n, err := conn.Write(data)
+	if err != nil {
+		t.removeRelayConnection(address, conn)
+		utils.CloseVerbose(conn)
+		return errors.Wrap(err, "[ sendToRelay ] Failed to write data")
+	}
+	metrics.NetworkSentSize.Add(float64(n))
+	return nil
+}
+
+func (t *tcpTransport) getRelayConnection(ctx context.Context, address string) (net.Conn, error) {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	if conn, ok := t.relayConns[address]; ok {
+		return conn, nil
+	}
+
+	addr, err := net.ResolveTCPAddr("tcp", address)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ getRelayConnection ] Failed to resolve net address")
+	}
+	conn, err := t.factory.CreateConnection(ctx, addr)
+	if err != nil {
+		return nil, errors.Wrap(err, "[ getRelayConnection ] Failed to create connection")
+	}
+	t.relayConns[address] = conn
+
+	go func() {
+		t.handleAcceptedConnection(conn)
+		t.removeRelayConnection(address, conn)
+	}()
+	return conn, nil
+}
+
+func (t *tcpTransport) removeRelayConnection(address string, conn net.Conn) {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	if t.relayConns[address] == conn {
+		delete(t.relayConns, address)
+	}
+}
+
+// sendToClient sends data to relay client over the connection opened by the client.
+func (t *tcpTransport) sendToClient(nodeID insolar.Reference, data []byte) error {
+	t.connsMutex.Lock()
+	conn, ok := t.clientConns[nodeID]
+	t.connsMutex.Unlock()
+
+	if !ok {
+		return errors.New("[ sendToClient ] No connection from relay client " + nodeID.String())
+	}
+
+	n, err := conn.Write(data)
+	if err != nil {
+		return errors.Wrap(err, "[ sendToClient ] Failed to write data")
+	}
+	metrics.NetworkSentSize.Add(float64(n))
+	return nil
+}
+
+// addRelayRequest remembers connection of relay request and returns false if request with the same id
+// is already waiting for response.
+func (t *tcpTransport) addRelayRequest(msg *packet.Packet, conn net.Conn) bool {
+	if msg.Sender == nil {
+		return false
+	}
+	key := relayRequestKey{nodeID: msg.Sender.NodeID, requestID: msg.RequestID}
+
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	if _, ok := t.relayRequests[key]; ok {
+		return false
+	}
+	t.relayRequests[key] = conn
+	return true
+}
+
+func (t *tcpTransport) takeRelayRequest(nodeID insolar.Reference, requestID network.RequestID) net.Conn {
+	key := relayRequestKey{nodeID: nodeID, requestID: requestID}
+
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	conn := t.relayRequests[key]
+	delete(t.relayRequests, key)
+	return conn
+}
+
+func (t *tcpTransport) bindClientConnection(nodeID insolar.Reference, conn net.Conn) {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	t.clientConns[nodeID] = conn
+}
+
+func (t *tcpTransport) unbindClient(nodeID insolar.Reference, conn net.Conn) {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	if t.clientConns[nodeID] == conn {
+		delete(t.clientConns, nodeID)
+	}
+}
+
+func (t *tcpTransport) unbindClientConnection(conn net.Conn) {
+	t.connsMutex.Lock()
+	defer t.connsMutex.Unlock()
+
+	for nodeID, c := range t.clientConns {
+		if c == conn {
+			delete(t.clientConns, nodeID)
+		}
+	}
+	for key, c := range t.relayRequests {
+		if c == conn {
+			delete(t.relayRequests, key)
 		}
 	}
 }
 
-type tcpConnectionFactory struct{}
+type tcpConnectionFactory struct {
+	keepalivePeriod time.Duration
+}
 
-func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
+func (f *tcpConnectionFactory) CreateConnection(ctx context.Context, address net.Addr) (net.Conn, error) {
 	logger := inslogger.FromContext(ctx)
 	tcpAddress, ok := address.(*net.TCPAddr)
 	if !ok {
@@ -222,6 +446,13 @@ func (*tcpConnectionFactory) CreateConnection(ctx context.Context, address net.A
 		logger.Error("[ createConnection ] Failed to set keep alive")
 	}
 
+	if f.keepalivePeriod > 0 {
+		err = conn.SetKeepAlivePeriod(f.keepalivePeriod)
+		if err != nil {
+			logger.Error("[ createConnection ] Failed to set keep alive period: ", err.Error())
+		}
+	}
+
 	err = conn.SetNoDelay(true)
 	if err != nil {
 		logger.Error("[ createConnection ] Failed to set connection no delay: ", err.Error())
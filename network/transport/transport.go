@@ -98,6 +98,11 @@ type Transport interface {
 
 // NewTransport creates new Transport with particular configuration
 func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, error) {
+	if cfg.Protocol == "MEMORY" {
+		// in-memory transport does not use sockets, so there is no connection to create
+		return newMemoryTransport(cfg.Address, proxy)
+	}
+
 	// TODO: let each transport creates connection in their constructor
 	conn, publicAddress, err := NewConnection(cfg)
 	if err != nil {
@@ -109,7 +114,7 @@ func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, er
 		// TODO: little hack: It's better to change interface for NewConnection
 		utils.CloseVerbose(conn)
 
-		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress)
+		return newTCPTransport(conn.LocalAddr().String(), proxy, publicAddress, cfg.Pool)
 	case "PURE_UDP":
 		// TODO: not little hack: @AndreyBronin rewrite all this mess, please!
 		localAddress := conn.LocalAddr().String()
@@ -124,6 +129,21 @@ func NewTransport(cfg configuration.Transport, proxy relay.Proxy) (Transport, er
 	}
 }
 
+// NewRelayTransport creates new Transport that forwards packets to and from relay clients registered in relay list.
+// Relay clients are reachable only over connections opened by them, so relaying is supported by TCP transport only.
+func NewRelayTransport(cfg configuration.Transport, proxy relay.Proxy, r relay.Relay) (Transport, error) {
+	if cfg.Protocol != "TCP" {
+		return nil, errors.New("[ NewRelayTransport ] Relaying is supported by TCP transport only")
+	}
+
+	t, err := NewTransport(cfg, proxy)
+	if err != nil {
+		return nil, err
+	}
+	t.(*tcpTransport).relay = r
+	return t, nil
+}
+
 // NewConnection creates new Connection from configuration and returns connection and public address
 func NewConnection(cfg configuration.Transport) (net.PacketConn, string, error) {
 	conn, err := connection.NewConnectionFactory().Create(cfg.Address)
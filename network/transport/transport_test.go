@@ -204,6 +204,13 @@ func TestTCPTransport(t *testing.T) {
 	suite.Run(t, NewSuite(cfg1, cfg2))
 }
 
+func TestMemoryTransport(t *testing.T) {
+	cfg1 := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:17020", BehindNAT: false}
+	cfg2 := configuration.Transport{Protocol: "MEMORY", Address: "127.0.0.1:17021", BehindNAT: false}
+
+	suite.Run(t, NewSuite(cfg1, cfg2))
+}
+
 func TestQuicTransport(t *testing.T) {
 	t.Skip("QUIC internals racing atm. Skip until we want to use it in production")
 
@@ -0,0 +1,199 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package entropygenerator
+
+import (
+	"bytes"
+	"encoding/gob"
+	"sync"
+
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/log"
+	"github.com/pkg/errors"
+)
+
+// DefaultHashChainLength is a number of pulses served by one hash chain
+const DefaultHashChainLength = 10000
+
+// ChainEntropyGenerator is the EntropyGenerator which commits to its entropy in advance
+type ChainEntropyGenerator interface {
+	EntropyGenerator
+	// Commitment returns commitment to the chain of the last generated entropy
+	Commitment() insolar.EntropyChainCommitment
+}
+
+// ChainStorage keeps state of hash chains, so the chain is continued after restart of the pulsar
+type ChainStorage interface {
+	// GetEntropyChain returns saved state of hash chains, nil is returned if the state has never been saved.
+	GetEntropyChain() ([]byte, error)
+	// SetEntropyChain saves state of hash chains.
+	SetEntropyChain(state []byte) error
+}
+
+// HashChainEntropyGenerator is the impl of EntropyGenerator with using of a hash chain
+// The chain is built from a random seed as h[i+1] = hash(h[i]) and revealed from the end,
+// so every revealed entropy is a preimage of the previous one and was committed before the previous pulse.
+// The anchor of the next chain is published with every entropy of the current chain,
+// so the next chain is committed long before it is started.
+type HashChainEntropyGenerator struct {
+	scheme  insolar.PlatformCryptographyScheme
+	length  int
+	storage ChainStorage
+
+	lock       sync.Mutex
+	state      chainState
+	chain      []insolar.Entropy
+	commitment insolar.EntropyChainCommitment
+}
+
+// chainState is the persistent state of the generator
+type chainState struct {
+	Seed     insolar.Entropy
+	NextSeed insolar.Entropy
+	Revealed int
+}
+
+// NewHashChainEntropyGenerator creates HashChainEntropyGenerator with chains of provided length.
+// The state of chains is saved to the storage if it isn't nil.
+func NewHashChainEntropyGenerator(scheme insolar.PlatformCryptographyScheme, length int, storage ChainStorage) (*HashChainEntropyGenerator, error) {
+	generator := &HashChainEntropyGenerator{
+		scheme:  scheme,
+		length:  length,
+		storage: storage,
+	}
+
+	var data []byte
+	var err error
+	if storage != nil {
+		data, err = storage.GetEntropyChain()
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewHashChainEntropyGenerator ] failed to get state of hash chain")
+		}
+	}
+	if data == nil {
+		generator.state = chainState{
+			Seed:     (&StandardEntropyGenerator{}).GenerateEntropy(),
+			NextSeed: (&StandardEntropyGenerator{}).GenerateEntropy(),
+		}
+	} else {
+		err = gob.NewDecoder(bytes.NewReader(data)).Decode(&generator.state)
+		if err != nil {
+			return nil, errors.Wrap(err, "[ NewHashChainEntropyGenerator ] failed to decode state of hash chain")
+		}
+	}
+	generator.buildChain()
+	return generator, nil
+}
+
+// GenerateEntropy returns the next value of the hash chain
+func (generator *HashChainEntropyGenerator) GenerateEntropy() insolar.Entropy {
+	generator.lock.Lock()
+	defer generator.lock.Unlock()
+
+	if len(generator.chain) == 0 {
+		generator.state = chainState{
+			Seed:     generator.state.NextSeed,
+			NextSeed: (&StandardEntropyGenerator{}).GenerateEntropy(),
+		}
+		generator.buildChain()
+	}
+	last := len(generator.chain) - 1
+	entropy := generator.chain[last]
+	generator.chain = generator.chain[:last]
+	generator.state.Revealed++
+
+	// the state is saved before entropy is revealed, so the same entropy is never revealed twice
+	err := generator.saveState()
+	if err != nil {
+		log.Error("[ HashChainEntropyGenerator ] failed to save state of hash chain: " + err.Error())
+	}
+	return entropy
+}
+
+// Commitment returns commitment to the current and the next chains
+func (generator *HashChainEntropyGenerator) Commitment() insolar.EntropyChainCommitment {
+	generator.lock.Lock()
+	defer generator.lock.Unlock()
+
+	return generator.commitment
+}
+
+func (generator *HashChainEntropyGenerator) buildChain() {
+	generator.chain = make([]insolar.Entropy, generator.length)
+	generator.chain[0] = generator.state.Seed
+	for i := 1; i < generator.length; i++ {
+		generator.chain[i] = hashEntropy(generator.scheme, generator.chain[i-1])
+	}
+	generator.commitment = insolar.EntropyChainCommitment{
+		Anchor: hashEntropy(generator.scheme, generator.chain[generator.length-1]),
+		Next:   chainAnchor(generator.scheme, generator.state.NextSeed, generator.length),
+	}
+	if generator.state.Revealed > generator.length {
+		generator.state.Revealed = generator.length
+	}
+	generator.chain = generator.chain[:generator.length-generator.state.Revealed]
+}
+
+func (generator *HashChainEntropyGenerator) saveState() error {
+	if generator.storage == nil {
+		return nil
+	}
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(generator.state)
+	if err != nil {
+		return err
+	}
+	return generator.storage.SetEntropyChain(buffer.Bytes())
+}
+
+// chainAnchor returns anchor of the chain of provided length built from the seed
+func chainAnchor(scheme insolar.PlatformCryptographyScheme, seed insolar.Entropy, length int) insolar.Entropy {
+	anchor := seed
+	for i := 0; i < length; i++ {
+		anchor = hashEntropy(scheme, anchor)
+	}
+	return anchor
+}
+
+func hashEntropy(scheme insolar.PlatformCryptographyScheme, entropy insolar.Entropy) insolar.Entropy {
+	var result insolar.Entropy
+	copy(result[:], scheme.IntegrityHasher().Hash(entropy[:]))
+	return result
+}
+
+const (
+	// StandardGenerator is a name of StandardEntropyGenerator in configuration
+	StandardGenerator = "standard"
+	// HashChainGenerator is a name of HashChainEntropyGenerator in configuration
+	HashChainGenerator = "hashchain"
+)
+
+// NewEntropyGenerator creates EntropyGenerator by its name in configuration
+func NewEntropyGenerator(name string, scheme insolar.PlatformCryptographyScheme, storage ChainStorage) (EntropyGenerator, error) {
+	switch name {
+	case StandardGenerator, "":
+		return &StandardEntropyGenerator{}, nil
+	case HashChainGenerator:
+		generator, err := NewHashChainEntropyGenerator(scheme, DefaultHashChainLength, storage)
+		if err != nil {
+			return nil, err
+		}
+		return generator, nil
+	default:
+		return nil, errors.Errorf("[ NewEntropyGenerator ] unknown entropy generator %s", name)
+	}
+}
@@ -0,0 +1,217 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package entropygenerator
+
+import (
+	"testing"
+
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/stretchr/testify/require"
+)
+
+type testPulsar struct {
+	pubKey    string
+	service   insolar.CryptographyService
+	generator EntropyGenerator
+}
+
+func newTestPulsar(t *testing.T, scheme insolar.PlatformCryptographyScheme) *testPulsar {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubKey, err := keyProcessor.ExportPublicKeyPEM(keyProcessor.ExtractPublicKey(privateKey))
+	require.NoError(t, err)
+
+	generator, err := NewHashChainEntropyGenerator(scheme, 10, nil)
+	require.NoError(t, err)
+
+	return &testPulsar{
+		pubKey:    string(pubKey),
+		service:   cryptography.NewKeyBoundCryptographyService(privateKey),
+		generator: generator,
+	}
+}
+
+func makePulse(t *testing.T, pulsars []*testPulsar) *insolar.Pulse {
+	pulse := &insolar.Pulse{
+		EntropyProofs: map[string]insolar.PulseEntropyProof{},
+		Signs:         map[string]insolar.PulseSenderConfirmation{},
+	}
+	for _, p := range pulsars {
+		entropy := p.generator.GenerateEntropy()
+		var chain insolar.EntropyChainCommitment
+		if generator, ok := p.generator.(ChainEntropyGenerator); ok {
+			chain = generator.Commitment()
+		}
+		sign, err := p.service.Sign(insolar.EntropyProofData(entropy, chain))
+		require.NoError(t, err)
+
+		pulse.EntropyProofs[p.pubKey] = insolar.PulseEntropyProof{Entropy: entropy, Chain: chain, Signature: sign.Bytes()}
+		pulse.Signs[p.pubKey] = insolar.PulseSenderConfirmation{}
+		for i := range pulse.Entropy {
+			pulse.Entropy[i] ^= entropy[i]
+		}
+	}
+	return pulse
+}
+
+func TestNewEntropyGenerator(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+
+	generator, err := NewEntropyGenerator(StandardGenerator, scheme, nil)
+	require.NoError(t, err)
+	require.IsType(t, &StandardEntropyGenerator{}, generator)
+
+	generator, err = NewEntropyGenerator(HashChainGenerator, scheme, nil)
+	require.NoError(t, err)
+	require.IsType(t, &HashChainEntropyGenerator{}, generator)
+
+	_, err = NewEntropyGenerator("unknown", scheme, nil)
+	require.Error(t, err)
+}
+
+func TestHashChainEntropyGenerator_GenerateEntropy(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	generator, err := NewHashChainEntropyGenerator(scheme, 3, nil)
+	require.NoError(t, err)
+
+	first := generator.GenerateEntropy()
+	commitment := generator.Commitment()
+	require.Equal(t, commitment.Anchor, hashEntropy(scheme, first))
+	second := generator.GenerateEntropy()
+	third := generator.GenerateEntropy()
+	require.Equal(t, first, hashEntropy(scheme, second))
+	require.Equal(t, second, hashEntropy(scheme, third))
+	require.Equal(t, commitment, generator.Commitment())
+
+	// chain is exhausted, the committed next one is started
+	fourth := generator.GenerateEntropy()
+	require.NotEqual(t, third, hashEntropy(scheme, fourth))
+	require.Equal(t, commitment.Next, generator.Commitment().Anchor)
+	require.Equal(t, commitment.Next, hashEntropy(scheme, fourth))
+}
+
+type memoryChainStorage struct {
+	state []byte
+}
+
+func (s *memoryChainStorage) GetEntropyChain() ([]byte, error) {
+	return s.state, nil
+}
+
+func (s *memoryChainStorage) SetEntropyChain(state []byte) error {
+	s.state = state
+	return nil
+}
+
+func TestHashChainEntropyGenerator_ContinuesChainAfterRestart(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	storage := &memoryChainStorage{}
+
+	generator, err := NewHashChainEntropyGenerator(scheme, 3, storage)
+	require.NoError(t, err)
+	first := generator.GenerateEntropy()
+	commitment := generator.Commitment()
+
+	restarted, err := NewHashChainEntropyGenerator(scheme, 3, storage)
+	require.NoError(t, err)
+	require.Equal(t, commitment, restarted.Commitment())
+	require.Equal(t, first, hashEntropy(scheme, restarted.GenerateEntropy()))
+	restarted.GenerateEntropy()
+
+	// the last value of the chain was revealed before restart, so the next chain is started
+	restarted, err = NewHashChainEntropyGenerator(scheme, 3, storage)
+	require.NoError(t, err)
+	require.Equal(t, commitment.Next, hashEntropy(scheme, restarted.GenerateEntropy()))
+}
+
+func TestVerifyEntropy(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	pulsars := []*testPulsar{newTestPulsar(t, scheme), newTestPulsar(t, scheme), newTestPulsar(t, scheme)}
+
+	pulse := makePulse(t, pulsars)
+	require.NoError(t, VerifyEntropy(scheme, keyProcessor, pulse))
+
+	forged := *pulse
+	forged.Entropy[0] ^= 1
+	require.Error(t, VerifyEntropy(scheme, keyProcessor, &forged))
+
+	forged = *pulse
+	forged.EntropyProofs = map[string]insolar.PulseEntropyProof{}
+	for key, proof := range pulse.EntropyProofs {
+		proof.Entropy[0] ^= 1
+		forged.EntropyProofs[key] = proof
+		break
+	}
+	require.Error(t, VerifyEntropy(scheme, keyProcessor, &forged))
+
+	require.Error(t, VerifyEntropy(scheme, keyProcessor, &insolar.Pulse{}))
+}
+
+func TestVerifyEntropyChain(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	pulsars := []*testPulsar{newTestPulsar(t, scheme), newTestPulsar(t, scheme)}
+
+	prev := makePulse(t, pulsars)
+	pulse := makePulse(t, pulsars)
+	require.NoError(t, VerifyEntropyChain(scheme, prev, pulse, 0))
+
+	// entropy revealed in a failed round
+	pulsars[0].generator.GenerateEntropy()
+	next := makePulse(t, pulsars)
+	require.Error(t, VerifyEntropyChain(scheme, pulse, next, 0))
+	require.NoError(t, VerifyEntropyChain(scheme, pulse, next, 1))
+
+	// pulsar with a new chain
+	generator, err := NewHashChainEntropyGenerator(scheme, 10, nil)
+	require.NoError(t, err)
+	pulsars[1].generator = generator
+	require.Error(t, VerifyEntropyChain(scheme, next, makePulse(t, pulsars), 1))
+}
+
+func TestVerifyEntropyChain_CommittedNextChain(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	pulsars := []*testPulsar{newTestPulsar(t, scheme)}
+
+	// chains of 10 values, so the 11th pulse is made by the next chain
+	prev := makePulse(t, pulsars)
+	for i := 0; i < 10; i++ {
+		pulse := makePulse(t, pulsars)
+		require.NoError(t, VerifyEntropyChain(scheme, prev, pulse, 0))
+		prev = pulse
+	}
+}
+
+func TestVerifyEntropy_UnsignedContribution(t *testing.T) {
+	scheme := platformpolicy.NewPlatformCryptographyScheme()
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	pulsars := []*testPulsar{newTestPulsar(t, scheme), newTestPulsar(t, scheme)}
+
+	pulse := makePulse(t, pulsars)
+	delete(pulse.Signs, pulsars[0].pubKey)
+	require.Error(t, VerifyEntropy(scheme, keyProcessor, pulse))
+
+	// commitment to the chain is signed with entropy
+	pulse = makePulse(t, pulsars)
+	proof := pulse.EntropyProofs[pulsars[0].pubKey]
+	proof.Chain.Next[0] ^= 1
+	pulse.EntropyProofs[pulsars[0].pubKey] = proof
+	require.Error(t, VerifyEntropy(scheme, keyProcessor, pulse))
+}
@@ -0,0 +1,97 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package entropygenerator
+
+import (
+	"github.com/insolar/insolar/insolar"
+	"github.com/pkg/errors"
+)
+
+// VerifyEntropy checks that entropy of the pulse is combined from contributions signed by pulsars.
+// Contributions are accepted only from pulsars which signed the pulse, so signatures of pulse.Signs
+// must be verified before.
+func VerifyEntropy(scheme insolar.PlatformCryptographyScheme, keyProcessor insolar.KeyProcessor, pulse *insolar.Pulse) error {
+	if len(pulse.EntropyProofs) == 0 {
+		return errors.New("[ VerifyEntropy ] pulse has no entropy proofs")
+	}
+
+	var entropy insolar.Entropy
+	for pubKey, proof := range pulse.EntropyProofs {
+		if _, ok := pulse.Signs[pubKey]; !ok {
+			return errors.Errorf("[ VerifyEntropy ] pulsar %s didn't sign the pulse", pubKey)
+		}
+		key, err := keyProcessor.ImportPublicKeyPEM([]byte(pubKey))
+		if err != nil {
+			return errors.Wrap(err, "[ VerifyEntropy ] failed to import public key of pulsar")
+		}
+		data := insolar.EntropyProofData(proof.Entropy, proof.Chain)
+		if !scheme.Verifier(key).Verify(insolar.SignatureFromBytes(proof.Signature), data) {
+			return errors.Errorf("[ VerifyEntropy ] wrong signature of entropy of pulsar %s", pubKey)
+		}
+		for i := range entropy {
+			entropy[i] ^= proof.Entropy[i]
+		}
+	}
+
+	if entropy != pulse.Entropy {
+		return errors.New("[ VerifyEntropy ] entropy of pulse doesn't match entropy proofs")
+	}
+	return nil
+}
+
+// VerifyEntropyChain checks that contributions of pulsars to the pulse are preimages of their contributions
+// to the previous pulse, as HashChainEntropyGenerator produces them. When the chain of the pulsar is exhausted,
+// the contribution must be a preimage of the anchor of the next chain committed in the previous pulse.
+// maxGap is a number of contributions which could be revealed in failed rounds between pulses.
+// Pulsars absent in one of pulses and pulsars without hash chains in the previous pulse are skipped.
+func VerifyEntropyChain(scheme insolar.PlatformCryptographyScheme, prev, pulse *insolar.Pulse, maxGap int) error {
+	for pubKey, proof := range pulse.EntropyProofs {
+		prevProof, ok := prev.EntropyProofs[pubKey]
+		if !ok {
+			continue
+		}
+
+		var target insolar.Entropy
+		switch {
+		case prevProof.Chain == (insolar.EntropyChainCommitment{}):
+			// pulsar didn't use hash chains, so it committed to nothing
+			continue
+		case proof.Chain.Anchor == prevProof.Chain.Anchor:
+			target = prevProof.Entropy
+		case proof.Chain.Anchor == prevProof.Chain.Next:
+			target = proof.Chain.Anchor
+		default:
+			return errors.Errorf("[ VerifyEntropyChain ] pulsar %s started uncommitted chain", pubKey)
+		}
+
+		if !isLinked(scheme, proof.Entropy, target, maxGap+1) {
+			return errors.Errorf("[ VerifyEntropyChain ] entropy of pulsar %s isn't linked with previous pulse", pubKey)
+		}
+	}
+	return nil
+}
+
+// isLinked checks that target is got from entropy by hashing it at most maxHashes times
+func isLinked(scheme insolar.PlatformCryptographyScheme, entropy, target insolar.Entropy, maxHashes int) bool {
+	for i := 0; i < maxHashes; i++ {
+		entropy = hashEntropy(scheme, entropy)
+		if entropy == target {
+			return true
+		}
+	}
+	return false
+}
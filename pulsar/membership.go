@@ -0,0 +1,361 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/pkg/errors"
+	"github.com/ugorji/go/codec"
+)
+
+// MembershipChangeType is a type of change of the pulsars set
+type MembershipChangeType uint8
+
+const (
+	// AddPulsar adds a new pulsar
+	AddPulsar MembershipChangeType = iota + 1
+	// RemovePulsar removes a pulsar
+	RemovePulsar
+	// RotatePulsarKey replaces a key of a pulsar
+	RotatePulsarKey
+)
+
+// MembershipChange is a change of the pulsars set proposed by pulsars
+type MembershipChange struct {
+	Type MembershipChangeType
+	// PublicKey is a key of the pulsar to add, remove or rotate
+	PublicKey string
+	// NewPublicKey is a new key of the pulsar, used by RotatePulsarKey only
+	NewPublicKey   string
+	Address        string
+	ConnectionType configuration.ConnectionType
+}
+
+// Hash calculates hash of the change voted in the pulse
+// The pulse number is hashed too, so votes of one round can't be replayed in another one
+func (change *MembershipChange) Hash(hashProvider insolar.Hasher, pulseNumber insolar.PulseNumber) ([]byte, error) {
+	var b bytes.Buffer
+	err := codec.NewEncoder(&b, &codec.CborHandle{}).Encode(change)
+	if err != nil {
+		return nil, err
+	}
+	_, err = hashProvider.Write(b.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	_, err = hashProvider.Write(pulseNumber.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	return hashProvider.Sum(nil), nil
+}
+
+// MembershipVote is a change signed by the pulsar which proposes it in the round of the pulse
+type MembershipVote struct {
+	Change      MembershipChange
+	PulseNumber insolar.PulseNumber
+	Signature   []byte
+}
+
+// AgreedMembershipChange is a change with signatures of pulsars voted for it in the round of the pulse
+type AgreedMembershipChange struct {
+	Change      MembershipChange
+	PulseNumber insolar.PulseNumber
+	// Votes are signatures of the change by public keys of pulsars
+	Votes map[string][]byte
+}
+
+// ProposeMembershipChange proposes the change to other pulsars in the next rounds
+// The change is applied after the pulse, in which the most of pulsars voted for it
+func (currentPulsar *Pulsar) ProposeMembershipChange(ctx context.Context, change MembershipChange) error {
+	_, isMember := currentPulsar.getNeighbours()[change.PublicKey]
+	isMember = isMember || change.PublicKey == currentPulsar.PublicKeyRaw
+	switch change.Type {
+	case AddPulsar:
+		if isMember {
+			return errors.New("[ ProposeMembershipChange ] pulsar is already a member")
+		}
+		if len(change.Address) == 0 {
+			return errors.New("[ ProposeMembershipChange ] address of a new pulsar is empty")
+		}
+	case RemovePulsar, RotatePulsarKey:
+		if !isMember {
+			return errors.New("[ ProposeMembershipChange ] pulsar is not a member")
+		}
+	default:
+		return errors.Errorf("[ ProposeMembershipChange ] unknown change type %v", change.Type)
+	}
+	_, err := currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(change.PublicKey))
+	if err != nil {
+		return errors.Wrap(err, "[ ProposeMembershipChange ] wrong public key")
+	}
+	if change.Type == RotatePulsarKey {
+		_, err = currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(change.NewPublicKey))
+		if err != nil {
+			return errors.Wrap(err, "[ ProposeMembershipChange ] wrong new public key")
+		}
+	}
+
+	currentPulsar.membershipLock.Lock()
+	defer currentPulsar.membershipLock.Unlock()
+	for _, proposed := range currentPulsar.proposedChanges {
+		if proposed == change {
+			return nil
+		}
+	}
+	currentPulsar.proposedChanges = append(currentPulsar.proposedChanges, change)
+	inslogger.FromContext(ctx).Infof("[ ProposeMembershipChange ] proposed change %v of pulsar %v", change.Type, change.PublicKey)
+	return nil
+}
+
+// getProposedChanges returns own changes which are not applied yet
+func (currentPulsar *Pulsar) getProposedChanges() []MembershipChange {
+	currentPulsar.membershipLock.RLock()
+	defer currentPulsar.membershipLock.RUnlock()
+	return append([]MembershipChange{}, currentPulsar.proposedChanges...)
+}
+
+// getMembershipVotes signs own changes, which are not applied yet, as votes in the round of the pulse
+func (currentPulsar *Pulsar) getMembershipVotes(ctx context.Context, pulseNumber insolar.PulseNumber) []MembershipVote {
+	var votes []MembershipVote
+	for _, change := range currentPulsar.getProposedChanges() {
+		hash, err := change.Hash(currentPulsar.PlatformCryptographyScheme.IntegrityHasher(), pulseNumber)
+		if err != nil {
+			inslogger.FromContext(ctx).Error("[ getMembershipVotes ] failed to calculate hash: ", err)
+			continue
+		}
+		signature, err := currentPulsar.CryptographyService.Sign(hash)
+		if err != nil {
+			inslogger.FromContext(ctx).Error("[ getMembershipVotes ] failed to sign: ", err)
+			continue
+		}
+		votes = append(votes, MembershipVote{Change: change, PulseNumber: pulseNumber, Signature: signature.Bytes()})
+	}
+	return votes
+}
+
+// verifyMembershipVote checks that the vote is signed by the pulsar with the public key in the round of the pulse
+func (currentPulsar *Pulsar) verifyMembershipVote(pubKey string, change MembershipChange, pulseNumber insolar.PulseNumber, signature []byte) (string, bool) {
+	publicKey, err := currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(pubKey))
+	if err != nil {
+		return "", false
+	}
+	hash, err := change.Hash(currentPulsar.PlatformCryptographyScheme.IntegrityHasher(), pulseNumber)
+	if err != nil {
+		return "", false
+	}
+	return hex.EncodeToString(hash), currentPulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(signature), hash)
+}
+
+// addMembershipVotes saves votes of the pulsar received in the round of the pulse, votes of other rounds are rejected
+func (currentPulsar *Pulsar) addMembershipVotes(ctx context.Context, pubKey string, pulseNumber insolar.PulseNumber, votes []MembershipVote) {
+	currentPulsar.membershipLock.Lock()
+	defer currentPulsar.membershipLock.Unlock()
+
+	for _, vote := range votes {
+		if vote.PulseNumber != pulseNumber {
+			inslogger.FromContext(ctx).Warnf("[ addMembershipVotes ] stale vote of pulse %v from %v", vote.PulseNumber, pubKey)
+			continue
+		}
+		key, ok := currentPulsar.verifyMembershipVote(pubKey, vote.Change, vote.PulseNumber, vote.Signature)
+		if !ok {
+			inslogger.FromContext(ctx).Warnf("[ addMembershipVotes ] wrong vote signature from %v", pubKey)
+			continue
+		}
+		agreed, ok := currentPulsar.currentSlotVotes[key]
+		if !ok {
+			agreed = &AgreedMembershipChange{Change: vote.Change, PulseNumber: vote.PulseNumber, Votes: map[string][]byte{}}
+			currentPulsar.currentSlotVotes[key] = agreed
+		}
+		agreed.Votes[pubKey] = vote.Signature
+	}
+}
+
+// agreeMembershipChanges selects changes voted by the most of pulsars in the current round
+func (currentPulsar *Pulsar) agreeMembershipChanges(ctx context.Context) {
+	pulseNumber := currentPulsar.ProcessingPulseNumber
+	currentPulsar.addMembershipVotes(ctx, currentPulsar.PublicKeyRaw, pulseNumber, currentPulsar.getMembershipVotes(ctx, pulseNumber))
+
+	currentPulsar.membershipLock.Lock()
+	defer currentPulsar.membershipLock.Unlock()
+	currentPulsar.agreedChanges = nil
+	minimumVotes := minimumNonTraitorsCount(len(currentPulsar.Neighbours) + 1)
+	for _, agreed := range currentPulsar.currentSlotVotes {
+		if len(agreed.Votes) >= minimumVotes {
+			currentPulsar.agreedChanges = append(currentPulsar.agreedChanges, *agreed)
+		}
+	}
+}
+
+// getAgreedChanges returns changes agreed in the current round
+func (currentPulsar *Pulsar) getAgreedChanges() []AgreedMembershipChange {
+	currentPulsar.membershipLock.RLock()
+	defer currentPulsar.membershipLock.RUnlock()
+	return currentPulsar.agreedChanges
+}
+
+// applyMembershipChanges checks votes of changes received with the pulse and applies confirmed ones
+// Only changes voted in the round of the pulse are applied, and changes of every pulse are applied once
+func (currentPulsar *Pulsar) applyMembershipChanges(ctx context.Context, pulseNumber insolar.PulseNumber, changes []AgreedMembershipChange) {
+	if len(changes) == 0 {
+		return
+	}
+	logger := inslogger.FromContext(ctx)
+
+	currentPulsar.membershipLock.Lock()
+	defer currentPulsar.membershipLock.Unlock()
+
+	if pulseNumber <= currentPulsar.appliedPulse {
+		logger.Warnf("[ applyMembershipChanges ] changes of pulse %v are stale", pulseNumber)
+		return
+	}
+	currentPulsar.appliedPulse = pulseNumber
+
+	minimumVotes := minimumNonTraitorsCount(len(currentPulsar.Neighbours) + 1)
+	neighbours := make(map[string]*Neighbour, len(currentPulsar.Neighbours))
+	for key, neighbour := range currentPulsar.Neighbours {
+		neighbours[key] = neighbour
+	}
+
+	for _, agreed := range changes {
+		if agreed.PulseNumber != pulseNumber {
+			logger.Warnf("[ applyMembershipChanges ] change %v of pulsar %v is voted in another pulse", agreed.Change.Type, agreed.Change.PublicKey)
+			continue
+		}
+		votes := 0
+		for pubKey, signature := range agreed.Votes {
+			_, isMember := currentPulsar.Neighbours[pubKey]
+			if !isMember && pubKey != currentPulsar.PublicKeyRaw {
+				continue
+			}
+			if _, ok := currentPulsar.verifyMembershipVote(pubKey, agreed.Change, agreed.PulseNumber, signature); ok {
+				votes++
+			}
+		}
+		if votes < minimumVotes {
+			logger.Warnf("[ applyMembershipChanges ] change %v of pulsar %v isn't confirmed", agreed.Change.Type, agreed.Change.PublicKey)
+			continue
+		}
+
+		err := currentPulsar.applyMembershipChange(neighbours, agreed.Change)
+		if err != nil {
+			logger.Error("[ applyMembershipChanges ] ", err)
+			continue
+		}
+		logger.Infof("[ applyMembershipChanges ] applied change %v of pulsar %v", agreed.Change.Type, agreed.Change.PublicKey)
+		currentPulsar.removeProposedChange(agreed.Change)
+	}
+
+	// Neighbours map is replaced, not modified, so readers ranging over it aren't affected
+	currentPulsar.Neighbours = neighbours
+
+	err := currentPulsar.Storage.SetNeighbours(currentPulsar.getNeighboursAddresses())
+	if err != nil {
+		logger.Error("[ applyMembershipChanges ] failed to save pulsars: ", err)
+	}
+}
+
+func (currentPulsar *Pulsar) applyMembershipChange(neighbours map[string]*Neighbour, change MembershipChange) error {
+	if change.PublicKey == currentPulsar.PublicKeyRaw && change.Type != AddPulsar {
+		// the pulsar with a rotated key should be restarted with the new one
+		currentPulsar.isRemoved = true
+		return nil
+	}
+
+	switch change.Type {
+	case AddPulsar:
+		if change.PublicKey == currentPulsar.PublicKeyRaw {
+			return nil
+		}
+		publicKey, err := currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(change.PublicKey))
+		if err != nil {
+			return err
+		}
+		neighbours[change.PublicKey] = &Neighbour{
+			ConnectionType:    change.ConnectionType,
+			ConnectionAddress: change.Address,
+			PublicKey:         publicKey,
+			OutgoingClient:    currentPulsar.rpcWrapperFactory.CreateWrapper(),
+		}
+	case RemovePulsar:
+		currentPulsar.closeNeighbour(neighbours[change.PublicKey])
+		delete(neighbours, change.PublicKey)
+	case RotatePulsarKey:
+		neighbour, ok := neighbours[change.PublicKey]
+		if !ok {
+			return errors.Errorf("pulsar %v is not a member", change.PublicKey)
+		}
+		publicKey, err := currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(change.NewPublicKey))
+		if err != nil {
+			return err
+		}
+		currentPulsar.closeNeighbour(neighbour)
+		delete(neighbours, change.PublicKey)
+		neighbours[change.NewPublicKey] = &Neighbour{
+			ConnectionType:    neighbour.ConnectionType,
+			ConnectionAddress: neighbour.ConnectionAddress,
+			PublicKey:         publicKey,
+			OutgoingClient:    currentPulsar.rpcWrapperFactory.CreateWrapper(),
+		}
+	}
+	return nil
+}
+
+func (currentPulsar *Pulsar) closeNeighbour(neighbour *Neighbour) {
+	if neighbour == nil || neighbour.OutgoingClient == nil || !neighbour.OutgoingClient.IsInitialised() {
+		return
+	}
+	err := neighbour.OutgoingClient.Close()
+	if err != nil {
+		inslogger.FromContext(context.Background()).Warn("[ closeNeighbour ] ", err)
+	}
+}
+
+func (currentPulsar *Pulsar) removeProposedChange(change MembershipChange) {
+	proposed := currentPulsar.proposedChanges[:0]
+	for _, proposedChange := range currentPulsar.proposedChanges {
+		if proposedChange != change {
+			proposed = append(proposed, proposedChange)
+		}
+	}
+	currentPulsar.proposedChanges = proposed
+}
+
+func (currentPulsar *Pulsar) getNeighboursAddresses() []configuration.PulsarNodeAddress {
+	addresses := make([]configuration.PulsarNodeAddress, 0, len(currentPulsar.Neighbours))
+	for key, neighbour := range currentPulsar.Neighbours {
+		addresses = append(addresses, configuration.PulsarNodeAddress{
+			Address:        neighbour.ConnectionAddress,
+			ConnectionType: neighbour.ConnectionType,
+			PublicKey:      key,
+		})
+	}
+	return addresses
+}
+
+// IsRemoved returns true if the pulsar is removed from the pulsars set
+func (currentPulsar *Pulsar) IsRemoved() bool {
+	currentPulsar.membershipLock.RLock()
+	defer currentPulsar.membershipLock.RUnlock()
+	return currentPulsar.isRemoved
+}
@@ -0,0 +1,235 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/cryptography"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/platformpolicy"
+	"github.com/insolar/insolar/pulsar/pulsartestutils"
+)
+
+const membershipTestPulse = insolar.PulseNumber(100)
+
+func newMembershipTestPulsar(t *testing.T) *Pulsar {
+	keyProcessor := platformpolicy.NewKeyProcessor()
+	privateKey, err := keyProcessor.GeneratePrivateKey()
+	require.NoError(t, err)
+	publicKey := keyProcessor.ExtractPublicKey(privateKey)
+	publicKeyRaw, err := keyProcessor.ExportPublicKeyPEM(publicKey)
+	require.NoError(t, err)
+
+	pulsar := &Pulsar{
+		Neighbours:                 map[string]*Neighbour{},
+		PublicKey:                  publicKey,
+		PublicKeyRaw:               string(publicKeyRaw),
+		CryptographyService:        cryptography.NewKeyBoundCryptographyService(privateKey),
+		KeyProcessor:               keyProcessor,
+		PlatformCryptographyScheme: platformpolicy.NewPlatformCryptographyScheme(),
+	}
+	pulsar.clearState()
+	pulsar.ProcessingPulseNumber = membershipTestPulse
+	return pulsar
+}
+
+func connectMembershipTestPulsars(pulsars ...*Pulsar) {
+	for _, pulsar := range pulsars {
+		for _, neighbour := range pulsars {
+			if neighbour == pulsar {
+				continue
+			}
+			pulsar.Neighbours[neighbour.PublicKeyRaw] = &Neighbour{
+				ConnectionType:    configuration.TCP,
+				ConnectionAddress: neighbour.PublicKeyRaw[:10],
+				PublicKey:         neighbour.PublicKey,
+			}
+		}
+	}
+}
+
+func TestPulsar_ProposeMembershipChange(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	first := newMembershipTestPulsar(t)
+	second := newMembershipTestPulsar(t)
+	newcomer := newMembershipTestPulsar(t)
+	connectMembershipTestPulsars(first, second)
+
+	err := first.ProposeMembershipChange(ctx, MembershipChange{Type: AddPulsar, PublicKey: second.PublicKeyRaw, Address: "second"})
+	require.Error(t, err)
+	err = first.ProposeMembershipChange(ctx, MembershipChange{Type: RemovePulsar, PublicKey: newcomer.PublicKeyRaw})
+	require.Error(t, err)
+	err = first.ProposeMembershipChange(ctx, MembershipChange{Type: AddPulsar, PublicKey: newcomer.PublicKeyRaw})
+	require.Error(t, err)
+	err = first.ProposeMembershipChange(ctx, MembershipChange{Type: RotatePulsarKey, PublicKey: second.PublicKeyRaw, NewPublicKey: "wrong"})
+	require.Error(t, err)
+
+	change := MembershipChange{Type: AddPulsar, PublicKey: newcomer.PublicKeyRaw, Address: "newcomer"}
+	require.NoError(t, first.ProposeMembershipChange(ctx, change))
+	require.NoError(t, first.ProposeMembershipChange(ctx, change))
+
+	votes := first.getMembershipVotes(ctx, membershipTestPulse)
+	require.Len(t, votes, 1)
+	_, ok := second.verifyMembershipVote(first.PublicKeyRaw, votes[0].Change, membershipTestPulse, votes[0].Signature)
+	require.True(t, ok)
+	_, ok = second.verifyMembershipVote(first.PublicKeyRaw, votes[0].Change, membershipTestPulse+1, votes[0].Signature)
+	require.False(t, ok)
+}
+
+func TestPulsar_MembershipChange_AgreedAndApplied(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	first := newMembershipTestPulsar(t)
+	second := newMembershipTestPulsar(t)
+	third := newMembershipTestPulsar(t)
+	newcomer := newMembershipTestPulsar(t)
+	connectMembershipTestPulsars(first, second, third)
+
+	change := MembershipChange{Type: AddPulsar, PublicKey: newcomer.PublicKeyRaw, Address: "newcomer", ConnectionType: configuration.TCP}
+	for _, pulsar := range []*Pulsar{first, second, third} {
+		require.NoError(t, pulsar.ProposeMembershipChange(ctx, change))
+	}
+
+	// all of three pulsars are needed for the agreement
+	first.addMembershipVotes(ctx, second.PublicKeyRaw, membershipTestPulse, second.getMembershipVotes(ctx, membershipTestPulse))
+	first.agreeMembershipChanges(ctx)
+	require.Empty(t, first.getAgreedChanges())
+
+	first.addMembershipVotes(ctx, third.PublicKeyRaw, membershipTestPulse, third.getMembershipVotes(ctx, membershipTestPulse))
+	first.agreeMembershipChanges(ctx)
+	agreed := first.getAgreedChanges()
+	require.Len(t, agreed, 1)
+	require.Len(t, agreed[0].Votes, 3)
+
+	factoryMock := NewRPCClientWrapperFactoryMock(t)
+	factoryMock.CreateWrapperMock.Return(&pulsartestutils.CustomRPCWrapperMock{})
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.SetNeighboursFunc = func(neighbours []configuration.PulsarNodeAddress) error {
+		require.Len(t, neighbours, 3)
+		return nil
+	}
+	second.rpcWrapperFactory = factoryMock
+	second.Storage = storage
+
+	second.applyMembershipChanges(ctx, membershipTestPulse, agreed)
+
+	require.Len(t, second.Neighbours, 3)
+	require.Contains(t, second.Neighbours, newcomer.PublicKeyRaw)
+	require.Equal(t, "newcomer", second.Neighbours[newcomer.PublicKeyRaw].ConnectionAddress)
+	require.Empty(t, second.getProposedChanges())
+	require.Equal(t, uint64(1), storage.SetNeighboursCounter)
+
+	// replayed changes aren't applied again
+	second.applyMembershipChanges(ctx, membershipTestPulse, agreed)
+	require.Equal(t, uint64(1), storage.SetNeighboursCounter)
+}
+
+func TestPulsar_MembershipChange_StaleVotes(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	first := newMembershipTestPulsar(t)
+	second := newMembershipTestPulsar(t)
+	newcomer := newMembershipTestPulsar(t)
+	connectMembershipTestPulsars(first, second)
+
+	change := MembershipChange{Type: AddPulsar, PublicKey: newcomer.PublicKeyRaw, Address: "newcomer", ConnectionType: configuration.TCP}
+	require.NoError(t, first.ProposeMembershipChange(ctx, change))
+	require.NoError(t, second.ProposeMembershipChange(ctx, change))
+
+	// votes of the previous round aren't counted
+	first.addMembershipVotes(ctx, second.PublicKeyRaw, membershipTestPulse, second.getMembershipVotes(ctx, membershipTestPulse-10))
+	first.agreeMembershipChanges(ctx)
+	require.Empty(t, first.getAgreedChanges())
+
+	first.addMembershipVotes(ctx, second.PublicKeyRaw, membershipTestPulse, second.getMembershipVotes(ctx, membershipTestPulse))
+	first.agreeMembershipChanges(ctx)
+	agreed := first.getAgreedChanges()
+	require.Len(t, agreed, 1)
+
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.SetNeighboursMock.Return(nil)
+	second.Storage = storage
+
+	// changes agreed in another round aren't applied
+	second.applyMembershipChanges(ctx, membershipTestPulse+10, agreed)
+	require.Len(t, second.Neighbours, 1)
+}
+
+func TestPulsar_MembershipChange_NotConfirmed(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	first := newMembershipTestPulsar(t)
+	second := newMembershipTestPulsar(t)
+	stranger := newMembershipTestPulsar(t)
+	connectMembershipTestPulsars(first, second)
+
+	change := MembershipChange{Type: RemovePulsar, PublicKey: second.PublicKeyRaw}
+	require.NoError(t, first.ProposeMembershipChange(ctx, change))
+	vote := first.getMembershipVotes(ctx, membershipTestPulse)[0]
+
+	strangerHash, err := change.Hash(stranger.PlatformCryptographyScheme.IntegrityHasher(), membershipTestPulse)
+	require.NoError(t, err)
+	strangerSign, err := stranger.CryptographyService.Sign(strangerHash)
+	require.NoError(t, err)
+
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.SetNeighboursMock.Return(nil)
+	second.Storage = storage
+
+	second.applyMembershipChanges(ctx, membershipTestPulse, []AgreedMembershipChange{{
+		Change:      change,
+		PulseNumber: membershipTestPulse,
+		Votes: map[string][]byte{
+			first.PublicKeyRaw:    vote.Signature,
+			stranger.PublicKeyRaw: strangerSign.Bytes(),
+		},
+	}})
+
+	require.False(t, second.IsRemoved())
+	require.Len(t, second.Neighbours, 1)
+}
+
+func TestPulsar_MembershipChange_RemoveItself(t *testing.T) {
+	ctx := inslogger.TestContext(t)
+	first := newMembershipTestPulsar(t)
+	second := newMembershipTestPulsar(t)
+	connectMembershipTestPulsars(first, second)
+
+	change := MembershipChange{Type: RemovePulsar, PublicKey: second.PublicKeyRaw}
+	require.NoError(t, first.ProposeMembershipChange(ctx, change))
+	require.NoError(t, second.ProposeMembershipChange(ctx, change))
+
+	first.addMembershipVotes(ctx, second.PublicKeyRaw, membershipTestPulse, second.getMembershipVotes(ctx, membershipTestPulse))
+	first.agreeMembershipChanges(ctx)
+	agreed := first.getAgreedChanges()
+	require.Len(t, agreed, 1)
+
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.SetNeighboursMock.Return(nil)
+	first.Storage = storage
+	second.Storage = storage
+
+	second.applyMembershipChanges(ctx, membershipTestPulse, agreed)
+	require.True(t, second.IsRemoved())
+	require.Error(t, second.StartConsensusProcess(ctx, 123))
+
+	first.applyMembershipChanges(ctx, membershipTestPulse, agreed)
+	require.False(t, first.IsRemoved())
+	require.Empty(t, first.Neighbours)
+}
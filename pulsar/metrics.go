@@ -19,10 +19,18 @@ package pulsar
 import (
 	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+
+	"github.com/insolar/insolar/instrumentation/insmetrics"
+)
+
+var (
+	tagFailedState = insmetrics.MustTagKey("failedState")
 )
 
 var (
 	statPulseGenerated = stats.Int64("pulsar/pulse/generated", "count of generated pulses", stats.UnitDimensionless)
+	statRoundFailed    = stats.Int64("pulsar/round/failed", "count of failed consensus rounds", stats.UnitDimensionless)
 )
 
 func init() {
@@ -33,6 +41,13 @@ func init() {
 			Measure:     statPulseGenerated,
 			Aggregation: view.Sum(),
 		},
+		&view.View{
+			Name:        statRoundFailed.Name(),
+			Description: statRoundFailed.Description(),
+			Measure:     statRoundFailed,
+			Aggregation: view.Count(),
+			TagKeys:     []tag.Key{tagFailedState},
+		},
 	)
 	if err != nil {
 		panic(err)
@@ -64,6 +64,7 @@ func (handler *Handler) isRequestValid(ctx context.Context, request *Payload) (s
 		return false, neighbour, errors.New("signature check failed")
 	}
 
+	handler.Pulsar.status.neighbourSeen(request.PublicKey)
 	return true, neighbour, nil
 }
 
@@ -152,6 +153,7 @@ func (handler *Handler) ReceiveSignatureForEntropy(request *Payload, response *P
 	bftCell := &BftCell{}
 	bftCell.SetSign(requestBody.EntropySignature)
 	handler.Pulsar.AddItemToVector(request.PublicKey, bftCell)
+	handler.Pulsar.addMembershipVotes(ctx, request.PublicKey, handler.Pulsar.ProcessingPulseNumber, requestBody.MembershipVotes)
 
 	return nil
 }
@@ -184,7 +186,8 @@ func (handler *Handler) ReceiveEntropy(request *Payload, response *Payload) erro
 			return err
 		}
 
-		isVerified := handler.Pulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(btfCell.GetSign()), requestBody.Entropy[:])
+		data := insolar.EntropyProofData(requestBody.Entropy, requestBody.Chain)
+		isVerified := handler.Pulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(btfCell.GetSign()), data)
 		if err != nil || !isVerified {
 			handler.Pulsar.AddItemToVector(request.PublicKey, nil)
 			inslog.Errorf("signature and Entropy aren't matched")
@@ -192,6 +195,7 @@ func (handler *Handler) ReceiveEntropy(request *Payload, response *Payload) erro
 		}
 
 		btfCell.SetEntropy(requestBody.Entropy)
+		btfCell.SetChain(requestBody.Chain)
 		btfCell.SetIsEntropyReceived(true)
 	}
 
@@ -324,6 +328,33 @@ func (handler *Handler) ReceivePulse(request *Payload, response *Payload) error
 
 	handler.Pulsar.SetLastPulse(&requestBody.Pulse)
 	handler.Pulsar.ProcessingPulseNumber = 0
+	handler.Pulsar.status.roundFinished(requestBody.Pulse.PulseNumber, RoundReceived, 0, nil)
+	handler.Pulsar.applyMembershipChanges(ctx, requestBody.Pulse.PulseNumber, requestBody.MembershipChanges)
 
 	return nil
 }
+
+// GetLastPulse is a handler of call for the last pulse of the pulsar
+// It's used by pulsars, which catch up with others after downtime
+func (handler *Handler) GetLastPulse(request *Payload, response *Payload) error {
+	ctx, inslog := inslogger.WithTraceField(context.Background(), handler.Pulsar.ID)
+	ctx, span := instracer.StartSpan(ctx, "Pulsar.Handler.GetLastPulse")
+	defer span.End()
+
+	inslog.Infof("[GetLastPulse] from %v", request.PublicKey)
+	ok, _, err := handler.isRequestValid(ctx, request)
+	if !ok {
+		if err != nil {
+			inslog.Error(err)
+		}
+		return err
+	}
+
+	message, err := handler.Pulsar.preparePayload(&PulsePayload{Pulse: *handler.Pulsar.GetLastPulse()})
+	if err != nil {
+		inslog.Error(err)
+		return err
+	}
+	*response = *message
+	return nil
+}
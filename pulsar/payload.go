@@ -56,6 +56,8 @@ func (hp *HandshakePayload) Hash(hashProvider insolar.Hasher) ([]byte, error) {
 type EntropySignaturePayload struct {
 	PulseNumber      insolar.PulseNumber
 	EntropySignature []byte
+	// MembershipVotes aren't included in the hash, because every vote is signed by the sender
+	MembershipVotes []MembershipVote
 }
 
 // Hash calculates hash of payload
@@ -76,11 +78,12 @@ func (es *EntropySignaturePayload) Hash(hashProvider insolar.Hasher) ([]byte, er
 type EntropyPayload struct {
 	PulseNumber insolar.PulseNumber
 	Entropy     insolar.Entropy
+	Chain       insolar.EntropyChainCommitment
 }
 
 // Hash calculates hash of payload
 func (ep *EntropyPayload) Hash(hashProvider insolar.Hasher) ([]byte, error) {
-	_, err := hashProvider.Write(ep.Entropy[:])
+	_, err := hashProvider.Write(insolar.EntropyProofData(ep.Entropy, ep.Chain))
 	if err != nil {
 		return nil, err
 	}
@@ -115,6 +118,7 @@ func (vp *VectorPayload) Hash(hashProvider insolar.Hasher) ([]byte, error) {
 		threadSaveCell := &BftCell{
 			Sign:              threadUnsafeCell.GetSign(),
 			Entropy:           threadUnsafeCell.GetEntropy(),
+			Chain:             threadUnsafeCell.GetChain(),
 			IsEntropyReceived: threadUnsafeCell.GetIsEntropyReceived(),
 		}
 
@@ -138,7 +142,8 @@ func (vp *VectorPayload) Hash(hashProvider insolar.Hasher) ([]byte, error) {
 
 // PulsePayload is a struct for sending finished pulse to all pulsars
 type PulsePayload struct {
-	Pulse insolar.Pulse
+	Pulse             insolar.Pulse
+	MembershipChanges []AgreedMembershipChange
 }
 
 // Hash calculates hash of payload
@@ -183,6 +188,18 @@ func (pp *PulsePayload) Hash(hashProvider insolar.Hasher) ([]byte, error) {
 		return nil, err
 	}
 
+	if len(pp.MembershipChanges) > 0 {
+		var changes bytes.Buffer
+		err = codec.NewEncoder(&changes, cborH).Encode(pp.MembershipChanges)
+		if err != nil {
+			return nil, err
+		}
+		_, err = hashProvider.Write(changes.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return hashProvider.Sum(nil), nil
 }
 
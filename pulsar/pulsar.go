@@ -24,6 +24,7 @@ import (
 	"net/rpc"
 	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/instrumentation/inslogger"
@@ -47,6 +48,7 @@ type Pulsar struct {
 	SockConnectionType configuration.ConnectionType
 	RPCServer          *rpc.Server
 
+	// Neighbours is replaced on membership changes, so it should be read with getNeighbours
 	Neighbours map[string]*Neighbour
 
 	PublicKey    crypto.PublicKey
@@ -63,6 +65,8 @@ type Pulsar struct {
 	generatedEntropyLock sync.RWMutex
 
 	GeneratedEntropySign []byte
+	// GeneratedEntropyChain is a commitment to the hash chain of generated entropy, it is signed with entropy
+	GeneratedEntropyChain insolar.EntropyChainCommitment
 
 	currentSlotEntropy     *insolar.Entropy
 	currentSlotEntropyLock sync.RWMutex
@@ -71,9 +75,17 @@ type Pulsar struct {
 
 	currentSlotSenderConfirmationsLock sync.RWMutex
 	CurrentSlotSenderConfirmations     map[string]insolar.PulseSenderConfirmation
+	CurrentSlotEntropyProofs           map[string]insolar.PulseEntropyProof
 
 	ProcessingPulseNumber insolar.PulseNumber
 
+	membershipLock   sync.RWMutex
+	proposedChanges  []MembershipChange
+	currentSlotVotes map[string]*AgreedMembershipChange
+	agreedChanges    []AgreedMembershipChange
+	appliedPulse     insolar.PulseNumber
+	isRemoved        bool
+
 	lastPulseLock sync.RWMutex
 	lastPulse     *insolar.Pulse
 
@@ -89,6 +101,10 @@ type Pulsar struct {
 	PlatformCryptographyScheme insolar.PlatformCryptographyScheme
 	KeyProcessor               insolar.KeyProcessor
 	PulseDistributor           insolar.PulseDistributor
+
+	rpcWrapperFactory RPCClientWrapperFactory
+
+	status *statusCollector
 }
 
 // NewPulsar creates a new pulse with using of custom GeneratedEntropy Generator
@@ -124,6 +140,8 @@ func NewPulsar(
 		Storage:                    storage,
 		EntropyGenerator:           entropyGenerator,
 		StateSwitcher:              stateSwitcher,
+		rpcWrapperFactory:          rpcWrapperFactory,
+		status:                     newStatusCollector(),
 	}
 	pulsar.clearState()
 
@@ -178,6 +196,8 @@ func NewPulsar(
 	gob.Register(insolar.PulseSenderConfirmation{})
 	gob.Register(&PulsePayload{})
 	gob.Register(&PulseSenderConfirmationPayload{})
+	gob.Register(MembershipVote{})
+	gob.Register(AgreedMembershipChange{})
 
 	return pulsar, nil
 }
@@ -198,7 +218,7 @@ func (currentPulsar *Pulsar) StartServer(ctx context.Context) {
 // StopServer stops listening of the rpc-server
 func (currentPulsar *Pulsar) StopServer(ctx context.Context) {
 	inslogger.FromContext(ctx).Debugf("[StopServer] address - %v", currentPulsar.Config.MainListenerAddress)
-	for _, neighbour := range currentPulsar.Neighbours {
+	for _, neighbour := range currentPulsar.getNeighbours() {
 		if neighbour.OutgoingClient != nil && neighbour.OutgoingClient.IsInitialised() {
 			err := neighbour.OutgoingClient.Close()
 			if err != nil {
@@ -236,7 +256,7 @@ func (currentPulsar *Pulsar) EstablishConnectionToPulsar(ctx context.Context, pu
 	}
 
 	var rep Payload
-	message, err := currentPulsar.preparePayload(&HandshakePayload{Entropy: currentPulsar.EntropyGenerator.GenerateEntropy()})
+	message, err := currentPulsar.preparePayload(&HandshakePayload{Entropy: (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()})
 	if err != nil {
 		return err
 	}
@@ -265,7 +285,7 @@ func (currentPulsar *Pulsar) CheckConnectionsToPulsars(ctx context.Context) {
 	defer span.End()
 
 	logger := inslogger.FromContext(ctx)
-	for pubKey, neighbour := range currentPulsar.Neighbours {
+	for pubKey, neighbour := range currentPulsar.getNeighbours() {
 		logger.Debugf("[CheckConnectionsToPulsars] refresh with %v", neighbour.ConnectionAddress)
 		if neighbour.OutgoingClient == nil || !neighbour.OutgoingClient.IsInitialised() {
 			err := currentPulsar.EstablishConnectionToPulsar(ctx, pubKey)
@@ -275,9 +295,12 @@ func (currentPulsar *Pulsar) CheckConnectionsToPulsars(ctx context.Context) {
 			}
 		}
 
+		checkStarted := time.Now()
 		healthCheckCall := neighbour.OutgoingClient.Go(HealthCheck.String(), nil, nil, nil)
 		replyCall := <-healthCheckCall.Done
-		if replyCall.Error != nil {
+		if replyCall.Error == nil {
+			currentPulsar.status.neighbourChecked(pubKey, time.Since(checkStarted))
+		} else {
 			logger.Warnf("Problems with connection to %v, with error - %v", neighbour.ConnectionAddress, replyCall.Error)
 			neighbour.OutgoingClient.ResetClient()
 			err := currentPulsar.EstablishConnectionToPulsar(ctx, pubKey)
@@ -290,6 +313,103 @@ func (currentPulsar *Pulsar) CheckConnectionsToPulsars(ctx context.Context) {
 	}
 }
 
+// CatchUp requests the last pulse from other pulsars and saves the freshest confirmed one
+// It's used after downtime of the pulsar, for not starting consensus from the outdated pulse
+func (currentPulsar *Pulsar) CatchUp(ctx context.Context) error {
+	ctx, span := instracer.StartSpan(ctx, "Pulsar.CatchUp")
+	defer span.End()
+
+	logger := inslogger.FromContext(ctx)
+	message, err := currentPulsar.preparePayload(&HandshakePayload{Entropy: (&entropygenerator.StandardEntropyGenerator{}).GenerateEntropy()})
+	if err != nil {
+		return errors.Wrap(err, "[ CatchUp ] failed to prepare payload")
+	}
+
+	var freshestPulse *insolar.Pulse
+	for _, neighbour := range currentPulsar.getNeighbours() {
+		if neighbour.OutgoingClient == nil || !neighbour.OutgoingClient.IsInitialised() {
+			continue
+		}
+		var rep Payload
+		call := neighbour.OutgoingClient.Go(GetLastPulse.String(), message, &rep, nil)
+		reply := <-call.Done
+		if reply.Error != nil {
+			logger.Warnf("[ CatchUp ] request to %v finished with error - %v", neighbour.ConnectionAddress, reply.Error)
+			continue
+		}
+		pulse, err := currentPulsar.checkCatchUpReply(reply.Reply.(*Payload))
+		if err != nil {
+			logger.Warnf("[ CatchUp ] wrong reply from %v - %v", neighbour.ConnectionAddress, err)
+			continue
+		}
+		if freshestPulse == nil || pulse.PulseNumber > freshestPulse.PulseNumber {
+			freshestPulse = pulse
+		}
+	}
+
+	if freshestPulse == nil || freshestPulse.PulseNumber <= currentPulsar.GetLastPulse().PulseNumber {
+		return nil
+	}
+
+	err = currentPulsar.Storage.SavePulse(freshestPulse)
+	if err != nil {
+		return errors.Wrap(err, "[ CatchUp ] failed to save pulse")
+	}
+	err = currentPulsar.Storage.SetLastPulse(freshestPulse)
+	if err != nil {
+		return errors.Wrap(err, "[ CatchUp ] failed to save last pulse")
+	}
+	currentPulsar.SetLastPulse(freshestPulse)
+	logger.Infof("[ CatchUp ] latest pulse is %v", freshestPulse.PulseNumber)
+	return nil
+}
+
+// checkCatchUpReply checks that the pulse is sent by a neighbour and confirmed by the most of pulsars
+func (currentPulsar *Pulsar) checkCatchUpReply(reply *Payload) (*insolar.Pulse, error) {
+	if _, err := currentPulsar.FetchNeighbour(reply.PublicKey); err != nil {
+		return nil, err
+	}
+	body, ok := reply.Body.(*PulsePayload)
+	if !ok {
+		return nil, errors.New("unexpected payload")
+	}
+	result, err := currentPulsar.checkPayloadSignature(reply)
+	if err != nil {
+		return nil, err
+	}
+	if !result {
+		return nil, errors.New("signature check failed")
+	}
+
+	confirmations := 0
+	for pubKey, sign := range body.Pulse.Signs {
+		if _, err := currentPulsar.FetchNeighbour(pubKey); err != nil && pubKey != currentPulsar.PublicKeyRaw {
+			continue
+		}
+		publicKey, err := currentPulsar.KeyProcessor.ImportPublicKeyPEM([]byte(pubKey))
+		if err != nil {
+			continue
+		}
+		payload := PulseSenderConfirmationPayload{insolar.PulseSenderConfirmation{
+			ChosenPublicKey: sign.ChosenPublicKey,
+			Entropy:         sign.Entropy,
+			PulseNumber:     sign.PulseNumber,
+		}}
+		hash, err := payload.Hash(currentPulsar.PlatformCryptographyScheme.IntegrityHasher())
+		if err != nil {
+			return nil, err
+		}
+		if currentPulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(sign.Signature), hash) {
+			confirmations++
+		}
+	}
+	if confirmations < currentPulsar.getMinimumNonTraitorsCount() {
+		return nil, errors.Errorf("pulse %v isn't confirmed, confirmations - %v", body.Pulse.PulseNumber, confirmations)
+	}
+
+	return &body.Pulse, nil
+}
+
 // StartConsensusProcess starts process of calculating consensus between pulsars
 func (currentPulsar *Pulsar) StartConsensusProcess(ctx context.Context, pulseNumber insolar.PulseNumber) error {
 	ctx, span := instracer.StartSpan(ctx, "Pulsar.StartConsensusProcess")
@@ -302,6 +422,11 @@ func (currentPulsar *Pulsar) StartConsensusProcess(ctx context.Context, pulseNum
 	currentPulsar.StartProcessLock.Lock()
 	logger.Debugf("[After StartProcessLock]")
 
+	if currentPulsar.IsRemoved() {
+		currentPulsar.StartProcessLock.Unlock()
+		return errors.New("pulsar is removed from the pulsars set or its key is rotated")
+	}
+
 	if pulseNumber == currentPulsar.ProcessingPulseNumber {
 		logger.Debugf("[pulseNumber == currentPulsar.ProcessingPulseNumber] return nil")
 		currentPulsar.StartProcessLock.Unlock()
@@ -321,6 +446,7 @@ func (currentPulsar *Pulsar) StartConsensusProcess(ctx context.Context, pulseNum
 		return err
 	}
 	currentPulsar.ProcessingPulseNumber = pulseNumber
+	currentPulsar.status.roundStarted(pulseNumber)
 
 	inslog := inslogger.FromContext(ctx)
 
@@ -340,6 +466,7 @@ func (currentPulsar *Pulsar) StartConsensusProcess(ctx context.Context, pulseNum
 
 	currentPulsar.AddItemToVector(currentPulsar.PublicKeyRaw, &BftCell{
 		Entropy:           *currentPulsar.GetGeneratedEntropy(),
+		Chain:             currentPulsar.GeneratedEntropyChain,
 		IsEntropyReceived: true,
 		Sign:              currentPulsar.GeneratedEntropySign,
 	})
@@ -913,4 +913,16 @@ func TestPulsar_verify_Success(t *testing.T) {
 	require.NotNil(t, pulsar.CurrentSlotPulseSender)
 	require.Equal(t, expectedEntropy, *pulsar.GetCurrentSlotEntropy())
 	require.Equal(t, uint64(1), mockSwitcher.SwitchToStateCounter)
+
+	require.Len(t, pulsar.CurrentSlotEntropyProofs, 3)
+	require.Equal(t, insolar.PulseEntropyProof{Entropy: secondEntropy, Signature: secondSign}, pulsar.CurrentSlotEntropyProofs[publicKeySecond])
+	pulse := &insolar.Pulse{
+		Entropy:       expectedEntropy,
+		EntropyProofs: pulsar.CurrentSlotEntropyProofs,
+		Signs:         map[string]insolar.PulseSenderConfirmation{},
+	}
+	for key := range pulse.EntropyProofs {
+		pulse.Signs[key] = insolar.PulseSenderConfirmation{}
+	}
+	require.NoError(t, entropygenerator.VerifyEntropy(pulsar.PlatformCryptographyScheme, pulsar.KeyProcessor, pulse))
 }
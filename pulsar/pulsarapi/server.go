@@ -0,0 +1,405 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsarapi
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/pulsar"
+	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+	"github.com/pkg/errors"
+)
+
+// MaxPulsesInRange is a maximum count of pulses returned by a single range request.
+const MaxPulsesInRange = 1000
+
+// Sign is a confirmation of the pulse from one of pulsars.
+type Sign struct {
+	PublicKey       string
+	ChosenPublicKey string
+	Entropy         []byte
+	Signature       []byte
+}
+
+// EntropyProof is a signed contribution of one of pulsars to entropy of the pulse.
+// ChainAnchor and NextChainAnchor commit to the hash chains of the pulsar, they are signed with entropy.
+type EntropyProof struct {
+	PublicKey       string
+	Entropy         []byte
+	ChainAnchor     []byte
+	NextChainAnchor []byte
+	Signature       []byte
+}
+
+// Pulse is a pulse with confirmations of pulsars agreed on it.
+type Pulse struct {
+	PulseNumber      uint32
+	PrevPulseNumber  uint32
+	NextPulseNumber  uint32
+	PulseTimestamp   int64
+	EpochPulseNumber int
+	Entropy          []byte
+	Signs            []Sign
+	EntropyProofs    []EntropyProof
+}
+
+// MembershipChange is a request for changing the pulsars set.
+// Type is one of "add", "remove" or "rotate".
+type MembershipChange struct {
+	Type           string
+	PublicKey      string
+	NewPublicKey   string
+	Address        string
+	ConnectionType string
+}
+
+var membershipChangeTypes = map[string]pulsar.MembershipChangeType{
+	"add":    pulsar.AddPulsar,
+	"remove": pulsar.RemovePulsar,
+	"rotate": pulsar.RotatePulsarKey,
+}
+
+// NeighbourStatus describes connectivity with one of other pulsars.
+type NeighbourStatus struct {
+	PublicKey string
+	Address   string
+	Connected bool
+	LastSeen  time.Time
+	LatencyMs float64
+}
+
+// RoundStatus describes one of recent consensus rounds.
+type RoundStatus struct {
+	PulseNumber uint32
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Outcome     string
+	FailedState string
+	Error       string
+}
+
+// Status is a state of pulsar's state machine, its neighbours and recent rounds.
+type Status struct {
+	PublicKey             string
+	State                 string
+	ProcessingPulseNumber uint32
+	LastPulseNumber       uint32
+	Neighbours            []NeighbourStatus
+	Rounds                []RoundStatus
+	BftGrid               []pulsar.BftRowStatus
+	FailedRounds          map[string]int
+}
+
+// PulsarService is a running pulsar, which API exposes.
+type PulsarService interface {
+	// ProposeMembershipChange proposes changes of the pulsars set to other pulsars.
+	ProposeMembershipChange(ctx context.Context, change pulsar.MembershipChange) error
+	// GetStatus returns internal state of the pulsar.
+	GetStatus() pulsar.Status
+}
+
+// Server serves HTTP API with history of pulses saved by pulsar and its status.
+// Changes of the pulsars set could be proposed from the local host only.
+type Server struct {
+	storage pulsarstorage.PulsarStorage
+	pulsar  PulsarService
+	server  *http.Server
+}
+
+// NewServer creates new Server listening on provided address.
+func NewServer(address string, storage pulsarstorage.PulsarStorage, pulsar PulsarService) *Server {
+	s := &Server{storage: storage, pulsar: pulsar}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/pulse", s.handlePulse)
+	mux.HandleFunc("/api/pulse/signs", s.handleSigns)
+	mux.HandleFunc("/api/pulses", s.handlePulses)
+	mux.HandleFunc("/api/membership", s.handleMembership)
+	mux.HandleFunc("/api/status", s.handleStatus)
+	s.server = &http.Server{Addr: address, Handler: mux}
+
+	return s
+}
+
+// Handler returns http handler of Server.
+func (s *Server) Handler() http.Handler {
+	return s.server.Handler
+}
+
+// Start starts serving requests in background.
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.server.Addr)
+	if err != nil {
+		return errors.Wrap(err, "[ Start ] failed to listen")
+	}
+
+	inslogger.FromContext(ctx).Info("Starting pulsar API server on ", s.server.Addr)
+	go func() {
+		if err := s.server.Serve(listener); err != http.ErrServerClosed {
+			inslogger.FromContext(ctx).Error("[ Start ] pulsar API server failed: ", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the server.
+func (s *Server) Stop(ctx context.Context) error {
+	return errors.Wrap(s.server.Shutdown(ctx), "[ Stop ] failed to shutdown pulsar API server")
+}
+
+// handlePulse returns pulse by number or time, or the last pulse if none of them is set.
+func (s *Server) handlePulse(w http.ResponseWriter, r *http.Request) {
+	pulse, err := s.findPulse(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, newPulse(pulse))
+}
+
+// handleSigns returns confirmations of pulsars agreed on the pulse.
+func (s *Server) handleSigns(w http.ResponseWriter, r *http.Request) {
+	pulse, err := s.findPulse(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, newPulse(pulse).Signs)
+}
+
+// handlePulses returns pulses with numbers in range [from, to].
+func (s *Server) handlePulses(w http.ResponseWriter, r *http.Request) {
+	from, err := parsePulseNumber(r, "from")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	to, err := parsePulseNumber(r, "to")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	limit := MaxPulsesInRange
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			writeError(w, badRequestError("limit"))
+			return
+		}
+		if limit > MaxPulsesInRange {
+			limit = MaxPulsesInRange
+		}
+	}
+
+	pulses, err := s.storage.GetPulses(from, to, limit)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	result := make([]Pulse, 0, len(pulses))
+	for i := range pulses {
+		result = append(result, newPulse(&pulses[i]))
+	}
+	writeJSON(w, result)
+}
+
+// handleMembership proposes the change of the pulsars set, it's applied after agreement of the most of pulsars.
+func (s *Server) handleMembership(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !isLocalRequest(r) {
+		http.Error(w, "membership could be changed only from local host", http.StatusForbidden)
+		return
+	}
+
+	var request MembershipChange
+	err := json.NewDecoder(r.Body).Decode(&request)
+	if err != nil {
+		writeError(w, badRequestError("body"))
+		return
+	}
+	changeType, ok := membershipChangeTypes[request.Type]
+	if !ok {
+		writeError(w, badRequestError("Type"))
+		return
+	}
+	connectionType := configuration.TCP
+	if len(request.ConnectionType) != 0 {
+		connectionType = configuration.ConnectionType(request.ConnectionType)
+	}
+
+	err = s.pulsar.ProposeMembershipChange(r.Context(), pulsar.MembershipChange{
+		Type:           changeType,
+		PublicKey:      request.PublicKey,
+		NewPublicKey:   request.NewPublicKey,
+		Address:        request.Address,
+		ConnectionType: connectionType,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// handleStatus returns state of the pulsar, its neighbours and recent rounds.
+func (s *Server) handleStatus(w http.ResponseWriter, r *http.Request) {
+	status := s.pulsar.GetStatus()
+	result := Status{
+		PublicKey:             status.PublicKey,
+		State:                 status.State,
+		ProcessingPulseNumber: uint32(status.ProcessingPulseNumber),
+		LastPulseNumber:       uint32(status.LastPulseNumber),
+		Neighbours:            make([]NeighbourStatus, 0, len(status.Neighbours)),
+		Rounds:                make([]RoundStatus, 0, len(status.Rounds)),
+		BftGrid:               status.BftGrid,
+		FailedRounds:          status.FailedRounds,
+	}
+	for _, neighbour := range status.Neighbours {
+		result.Neighbours = append(result.Neighbours, NeighbourStatus{
+			PublicKey: neighbour.PublicKey,
+			Address:   neighbour.Address,
+			Connected: neighbour.Connected,
+			LastSeen:  neighbour.LastSeen,
+			LatencyMs: float64(neighbour.Latency) / float64(time.Millisecond),
+		})
+	}
+	for _, round := range status.Rounds {
+		result.Rounds = append(result.Rounds, RoundStatus{
+			PulseNumber: uint32(round.PulseNumber),
+			StartedAt:   round.StartedAt,
+			FinishedAt:  round.FinishedAt,
+			Outcome:     round.Outcome,
+			FailedState: round.FailedState,
+			Error:       round.Error,
+		})
+	}
+	writeJSON(w, result)
+}
+
+func isLocalRequest(r *http.Request) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func (s *Server) findPulse(r *http.Request) (*insolar.Pulse, error) {
+	query := r.URL.Query()
+	switch {
+	case query.Get("number") != "":
+		pulseNumber, err := parsePulseNumber(r, "number")
+		if err != nil {
+			return nil, err
+		}
+		return s.storage.GetPulse(pulseNumber)
+	case query.Get("time") != "":
+		timestamp, err := strconv.ParseInt(query.Get("time"), 10, 64)
+		if err != nil {
+			return nil, badRequestError("time")
+		}
+		return s.storage.GetPulseByTime(timestamp)
+	default:
+		return s.storage.GetLastPulse()
+	}
+}
+
+func newPulse(pulse *insolar.Pulse) Pulse {
+	result := Pulse{
+		PulseNumber:      uint32(pulse.PulseNumber),
+		PrevPulseNumber:  uint32(pulse.PrevPulseNumber),
+		NextPulseNumber:  uint32(pulse.NextPulseNumber),
+		PulseTimestamp:   pulse.PulseTimestamp,
+		EpochPulseNumber: pulse.EpochPulseNumber,
+		Entropy:          pulse.Entropy[:],
+		Signs:            make([]Sign, 0, len(pulse.Signs)),
+		EntropyProofs:    make([]EntropyProof, 0, len(pulse.EntropyProofs)),
+	}
+	for key, sign := range pulse.Signs {
+		entropy := sign.Entropy
+		result.Signs = append(result.Signs, Sign{
+			PublicKey:       key,
+			ChosenPublicKey: sign.ChosenPublicKey,
+			Entropy:         entropy[:],
+			Signature:       sign.Signature,
+		})
+	}
+	sort.Slice(result.Signs, func(i, j int) bool {
+		return result.Signs[i].PublicKey < result.Signs[j].PublicKey
+	})
+	for key, proof := range pulse.EntropyProofs {
+		entropy := proof.Entropy
+		anchor, next := proof.Chain.Anchor, proof.Chain.Next
+		result.EntropyProofs = append(result.EntropyProofs, EntropyProof{
+			PublicKey:       key,
+			Entropy:         entropy[:],
+			ChainAnchor:     anchor[:],
+			NextChainAnchor: next[:],
+			Signature:       proof.Signature,
+		})
+	}
+	sort.Slice(result.EntropyProofs, func(i, j int) bool {
+		return result.EntropyProofs[i].PublicKey < result.EntropyProofs[j].PublicKey
+	})
+	return result
+}
+
+type badRequestError string
+
+func (e badRequestError) Error() string {
+	return "bad value of parameter " + string(e)
+}
+
+func parsePulseNumber(r *http.Request, param string) (insolar.PulseNumber, error) {
+	value, err := strconv.ParseUint(r.URL.Query().Get(param), 10, 32)
+	if err != nil {
+		return 0, badRequestError(param)
+	}
+	return insolar.PulseNumber(value), nil
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	if _, ok := err.(badRequestError); ok {
+		status = http.StatusBadRequest
+	} else if err == pulsarstorage.ErrPulseNotFound {
+		status = http.StatusNotFound
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,180 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsarapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/insolar/insolar/pulsar"
+	"github.com/insolar/insolar/pulsar/pulsartestutils"
+	pulsarstorage "github.com/insolar/insolar/pulsar/storage"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func testPulse(pn insolar.PulseNumber) *insolar.Pulse {
+	return &insolar.Pulse{
+		PulseNumber:     pn,
+		NextPulseNumber: pn + 10,
+		PulseTimestamp:  1000,
+		Signs: map[string]insolar.PulseSenderConfirmation{
+			"second": {PulseNumber: pn, ChosenPublicKey: "first", Signature: []byte{2}},
+			"first":  {PulseNumber: pn, ChosenPublicKey: "first", Signature: []byte{1}},
+		},
+	}
+}
+
+func doRequest(t *testing.T, server *Server, url string, result interface{}) int {
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", url, nil))
+	if recorder.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), result))
+	}
+	return recorder.Code
+}
+
+func TestServer_Pulse(t *testing.T) {
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.GetPulseFunc = func(pn insolar.PulseNumber) (*insolar.Pulse, error) {
+		if pn != 65537 {
+			return nil, pulsarstorage.ErrPulseNotFound
+		}
+		return testPulse(pn), nil
+	}
+	storage.GetPulseByTimeMock.Expect(1005).Return(testPulse(65547), nil)
+	storage.GetLastPulseMock.Return(testPulse(65557), nil)
+	server := NewServer("", storage, nil)
+
+	var pulse Pulse
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/pulse?number=65537", &pulse))
+	require.Equal(t, uint32(65537), pulse.PulseNumber)
+	require.Equal(t, uint32(65547), pulse.NextPulseNumber)
+	require.Len(t, pulse.Signs, 2)
+	require.Equal(t, "first", pulse.Signs[0].PublicKey)
+	require.Equal(t, []byte{1}, pulse.Signs[0].Signature)
+
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/pulse?time=1005", &pulse))
+	require.Equal(t, uint32(65547), pulse.PulseNumber)
+
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/pulse", &pulse))
+	require.Equal(t, uint32(65557), pulse.PulseNumber)
+
+	var signs []Sign
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/pulse/signs?number=65537", &signs))
+	require.Len(t, signs, 2)
+	require.Equal(t, "second", signs[1].PublicKey)
+
+	require.Equal(t, http.StatusNotFound, doRequest(t, server, "/api/pulse?number=65538", &pulse))
+	require.Equal(t, http.StatusBadRequest, doRequest(t, server, "/api/pulse?number=abc", &pulse))
+}
+
+func TestServer_Pulses(t *testing.T) {
+	storage := pulsartestutils.NewPulsarStorageMock(t)
+	storage.GetPulsesMock.Expect(65537, 65557, MaxPulsesInRange).Return(
+		[]insolar.Pulse{*testPulse(65537), *testPulse(65547)}, nil,
+	)
+	server := NewServer("", storage, nil)
+
+	var pulses []Pulse
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/pulses?from=65537&to=65557&limit=100000", &pulses))
+	require.Len(t, pulses, 2)
+	require.Equal(t, uint32(65547), pulses[1].PulseNumber)
+
+	require.Equal(t, http.StatusBadRequest, doRequest(t, server, "/api/pulses?from=65537", &pulses))
+	require.Equal(t, http.StatusBadRequest, doRequest(t, server, "/api/pulses?from=65537&to=65557&limit=0", &pulses))
+}
+
+type pulsarServiceMock struct {
+	changes []pulsar.MembershipChange
+	status  pulsar.Status
+}
+
+func (p *pulsarServiceMock) GetStatus() pulsar.Status {
+	return p.status
+}
+
+func (p *pulsarServiceMock) ProposeMembershipChange(ctx context.Context, change pulsar.MembershipChange) error {
+	if len(change.PublicKey) == 0 {
+		return errors.New("empty key")
+	}
+	p.changes = append(p.changes, change)
+	return nil
+}
+
+func TestServer_Membership(t *testing.T) {
+	proposer := &pulsarServiceMock{}
+	server := NewServer("", pulsartestutils.NewPulsarStorageMock(t), proposer)
+
+	doPost := func(remoteAddr string, body string) int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest("POST", "/api/membership", strings.NewReader(body))
+		request.RemoteAddr = remoteAddr
+		server.Handler().ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	require.Equal(t, http.StatusAccepted, doPost("127.0.0.1:1234", `{"Type": "add", "PublicKey": "key", "Address": "127.0.0.1:58091"}`))
+	require.Equal(t, []pulsar.MembershipChange{{
+		Type:           pulsar.AddPulsar,
+		PublicKey:      "key",
+		Address:        "127.0.0.1:58091",
+		ConnectionType: configuration.TCP,
+	}}, proposer.changes)
+
+	require.Equal(t, http.StatusForbidden, doPost("10.0.0.1:1234", `{"Type": "remove", "PublicKey": "key"}`))
+	require.Equal(t, http.StatusBadRequest, doPost("127.0.0.1:1234", `{"Type": "unknown", "PublicKey": "key"}`))
+	require.Equal(t, http.StatusBadRequest, doPost("127.0.0.1:1234", `{"Type": "remove"}`))
+	require.Len(t, proposer.changes, 1)
+
+	recorder := httptest.NewRecorder()
+	server.Handler().ServeHTTP(recorder, httptest.NewRequest("GET", "/api/membership", nil))
+	require.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestServer_Status(t *testing.T) {
+	service := &pulsarServiceMock{status: pulsar.Status{
+		State:           pulsar.WaitingForStart.String(),
+		LastPulseNumber: 65537,
+		Neighbours: []pulsar.NeighbourStatus{
+			{PublicKey: "second", Address: "127.0.0.1:58093", Connected: true, Latency: 1500 * time.Microsecond},
+		},
+		Rounds: []pulsar.RoundStatus{
+			{PulseNumber: 65537, Outcome: pulsar.RoundSent},
+			{PulseNumber: 65547, Outcome: pulsar.RoundFailed, FailedState: pulsar.WaitingForVectors.String(), Error: "bft is broken"},
+		},
+		FailedRounds: map[string]int{pulsar.WaitingForVectors.String(): 1},
+	}}
+	server := NewServer("", pulsartestutils.NewPulsarStorageMock(t), service)
+
+	var status Status
+	require.Equal(t, http.StatusOK, doRequest(t, server, "/api/status", &status))
+	require.Equal(t, "WaitingForStart", status.State)
+	require.Equal(t, uint32(65537), status.LastPulseNumber)
+	require.Len(t, status.Neighbours, 1)
+	require.Equal(t, 1.5, status.Neighbours[0].LatencyMs)
+	require.Len(t, status.Rounds, 2)
+	require.Equal(t, "WaitingForVectors", status.Rounds[1].FailedState)
+	require.Equal(t, 1, status.FailedRounds["WaitingForVectors"])
+}
@@ -40,13 +40,14 @@ func (currentPulsar *Pulsar) broadcastSignatureOfEntropy(ctx context.Context) {
 	payload, err := currentPulsar.preparePayload(&EntropySignaturePayload{
 		PulseNumber:      currentPulsar.ProcessingPulseNumber,
 		EntropySignature: currentPulsar.GeneratedEntropySign,
+		MembershipVotes:  currentPulsar.getMembershipVotes(ctx, currentPulsar.ProcessingPulseNumber),
 	})
 	if err != nil {
 		currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
 		return
 	}
 
-	for _, neighbour := range currentPulsar.Neighbours {
+	for _, neighbour := range currentPulsar.getNeighbours() {
 		broadcastCall := neighbour.OutgoingClient.Go(ReceiveSignatureForEntropy.String(),
 			payload,
 			nil,
@@ -79,7 +80,7 @@ func (currentPulsar *Pulsar) broadcastVector(ctx context.Context) {
 		return
 	}
 
-	for _, neighbour := range currentPulsar.Neighbours {
+	for _, neighbour := range currentPulsar.getNeighbours() {
 		broadcastCall := neighbour.OutgoingClient.Go(ReceiveVector.String(),
 			payload,
 			nil,
@@ -104,13 +105,14 @@ func (currentPulsar *Pulsar) broadcastEntropy(ctx context.Context) {
 	payload, err := currentPulsar.preparePayload(&EntropyPayload{
 		PulseNumber: currentPulsar.ProcessingPulseNumber,
 		Entropy:     *currentPulsar.GetGeneratedEntropy(),
+		Chain:       currentPulsar.GeneratedEntropyChain,
 	})
 	if err != nil {
 		currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
 		return
 	}
 
-	for _, neighbour := range currentPulsar.Neighbours {
+	for _, neighbour := range currentPulsar.getNeighbours() {
 		broadcastCall := neighbour.OutgoingClient.Go(ReceiveEntropy.String(),
 			payload,
 			nil,
@@ -122,7 +124,7 @@ func (currentPulsar *Pulsar) broadcastEntropy(ctx context.Context) {
 	}
 }
 
-func (currentPulsar *Pulsar) sendPulseToPulsars(ctx context.Context, pulse insolar.Pulse) {
+func (currentPulsar *Pulsar) sendPulseToPulsars(ctx context.Context, pulse insolar.Pulse, changes []AgreedMembershipChange) {
 	logger := inslogger.FromContext(ctx)
 	ctx, span := instracer.StartSpan(ctx, "Pulsar.sendPulseToPulsars")
 	defer span.End()
@@ -133,7 +135,7 @@ func (currentPulsar *Pulsar) sendPulseToPulsars(ctx context.Context, pulse insol
 	}
 
 	currentPulsar.currentSlotSenderConfirmationsLock.RLock()
-	payload, err := currentPulsar.preparePayload(&PulsePayload{Pulse: pulse})
+	payload, err := currentPulsar.preparePayload(&PulsePayload{Pulse: pulse, MembershipChanges: changes})
 	currentPulsar.currentSlotSenderConfirmationsLock.RUnlock()
 
 	if err != nil {
@@ -141,7 +143,7 @@ func (currentPulsar *Pulsar) sendPulseToPulsars(ctx context.Context, pulse insol
 		return
 	}
 
-	for _, neighbour := range currentPulsar.Neighbours {
+	for _, neighbour := range currentPulsar.getNeighbours() {
 		broadcastCall := neighbour.OutgoingClient.Go(ReceivePulse.String(),
 			payload,
 			nil,
@@ -151,6 +153,8 @@ func (currentPulsar *Pulsar) sendPulseToPulsars(ctx context.Context, pulse insol
 			logger.Warnf("Response to %v finished with error - %v", neighbour.ConnectionAddress, reply.Error)
 		}
 	}
+
+	currentPulsar.applyMembershipChanges(ctx, pulse.PulseNumber, changes)
 }
 
 func (currentPulsar *Pulsar) sendVector(ctx context.Context) {
@@ -235,7 +239,12 @@ func (currentPulsar *Pulsar) sendPulseSign(ctx context.Context) {
 		return
 	}
 
-	call := currentPulsar.Neighbours[currentPulsar.CurrentSlotPulseSender].OutgoingClient.Go(ReceiveChosenSignature.String(), message, nil, nil)
+	sender, err := currentPulsar.FetchNeighbour(currentPulsar.CurrentSlotPulseSender)
+	if err != nil {
+		currentPulsar.StateSwitcher.SwitchToState(ctx, Failed, err)
+		return
+	}
+	call := sender.OutgoingClient.Go(ReceiveChosenSignature.String(), message, nil, nil)
 	reply := <-call.Done
 	if reply.Error != nil {
 		// Here should be retry
@@ -262,6 +271,7 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 		PulseNumber:      currentPulsar.ProcessingPulseNumber,
 		Entropy:          *currentPulsar.GetCurrentSlotEntropy(),
 		Signs:            currentPulsar.CurrentSlotSenderConfirmations,
+		EntropyProofs:    currentPulsar.CurrentSlotEntropyProofs,
 		NextPulseNumber:  currentPulsar.ProcessingPulseNumber + insolar.PulseNumber(currentPulsar.Config.NumberDelta),
 		PrevPulseNumber:  currentPulsar.lastPulse.PulseNumber,
 		EpochPulseNumber: 1,
@@ -275,7 +285,7 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 		logger.Debug("Before sending to network")
 		currentPulsar.PulseDistributor.Distribute(ctx, pulseForSending)
 	}()
-	go currentPulsar.sendPulseToPulsars(ctx, pulseForSending)
+	go currentPulsar.sendPulseToPulsars(ctx, pulseForSending, currentPulsar.getAgreedChanges())
 
 	err := currentPulsar.Storage.SavePulse(&pulseForSending)
 	if err != nil {
@@ -289,6 +299,7 @@ func (currentPulsar *Pulsar) sendPulseToNodesAndPulsars(ctx context.Context) {
 	logger.Infof("Latest pulse is %v", pulseForSending.PulseNumber)
 
 	stats.Record(ctx, statPulseGenerated.M(1))
+	currentPulsar.status.roundFinished(pulseForSending.PulseNumber, RoundSent, 0, nil)
 
 	currentPulsar.StateSwitcher.SwitchToState(ctx, WaitingForStart, nil)
 }
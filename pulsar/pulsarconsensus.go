@@ -49,6 +49,7 @@ type BftCell struct {
 
 	Sign              []byte
 	Entropy           insolar.Entropy
+	Chain             insolar.EntropyChainCommitment
 	IsEntropyReceived bool
 }
 
@@ -80,6 +81,20 @@ func (bftCell *BftCell) GetEntropy() insolar.Entropy {
 	return bftCell.Entropy
 }
 
+// SetChain sets Chain in the thread-safe way
+func (bftCell *BftCell) SetChain(chain insolar.EntropyChainCommitment) {
+	bftCell.entropyLock.Lock()
+	defer bftCell.entropyLock.Unlock()
+	bftCell.Chain = chain
+}
+
+// GetChain gets Chain in the thread-safe way
+func (bftCell *BftCell) GetChain() insolar.EntropyChainCommitment {
+	bftCell.entropyLock.RLock()
+	defer bftCell.entropyLock.RUnlock()
+	return bftCell.Chain
+}
+
 // SetIsEntropyReceived sets IsEntropyReceived in the thread-safe way
 func (bftCell *BftCell) SetIsEntropyReceived(isEntropyReceived bool) {
 	bftCell.isEntropyReceivedLock.Lock()
@@ -107,6 +122,13 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 	if currentPulsar.isStandalone() {
 		currentPulsar.SetCurrentSlotEntropy(currentPulsar.GetGeneratedEntropy())
 		currentPulsar.CurrentSlotPulseSender = currentPulsar.PublicKeyRaw
+		currentPulsar.setEntropyProofs(map[string]insolar.PulseEntropyProof{
+			currentPulsar.PublicKeyRaw: {
+				Entropy:   *currentPulsar.GetGeneratedEntropy(),
+				Chain:     currentPulsar.GeneratedEntropyChain,
+				Signature: currentPulsar.GeneratedEntropySign,
+			},
+		})
 
 		payload := PulseSenderConfirmationPayload{insolar.PulseSenderConfirmation{
 			ChosenPublicKey: currentPulsar.CurrentSlotPulseSender,
@@ -134,6 +156,7 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 		}
 		currentPulsar.currentSlotSenderConfirmationsLock.Unlock()
 
+		currentPulsar.agreeMembershipChanges(ctx)
 		currentPulsar.StateSwitcher.SwitchToState(ctx, SendingPulse, nil)
 
 		return
@@ -145,10 +168,11 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 	}
 
 	var finalEntropySet []insolar.Entropy
+	entropyProofs := map[string]insolar.PulseEntropyProof{}
 
 	keys := []string{currentPulsar.PublicKeyRaw}
 	activePulsars := []*bftMember{{currentPulsar.PublicKeyRaw, currentPulsar.PublicKey}}
-	for key, neighbour := range currentPulsar.Neighbours {
+	for key, neighbour := range currentPulsar.getNeighbours() {
 		activePulsars = append(activePulsars, &bftMember{key, neighbour.PublicKey})
 		keys = append(keys, key)
 	}
@@ -156,7 +180,10 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 	// Check NxN consensus-matrix
 	wrongVectors := 0
 	for _, column := range activePulsars {
+		// contributions are counted by signed data, so pulsars agree both on entropy and chain commitment
 		currentColumnStat := map[string]int{}
+		currentColumnSigns := map[string][]byte{}
+		currentColumnChains := map[string]insolar.EntropyChainCommitment{}
 		for _, row := range activePulsars {
 			bftCell := currentPulsar.GetBftGridItem(row.PubPem, column.PubPem)
 
@@ -171,27 +198,36 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 				continue
 			}
 
-			entropy := bftCell.GetEntropy()
-			ok := currentPulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(bftCell.GetSign()), entropy[:])
+			data := insolar.EntropyProofData(bftCell.GetEntropy(), bftCell.GetChain())
+			ok := currentPulsar.CryptographyService.Verify(publicKey, insolar.SignatureFromBytes(bftCell.GetSign()), data)
 			if !ok {
 				currentColumnStat["nil"]++
 				continue
 			}
 
-			currentColumnStat[string(entropy[:])]++
+			currentColumnStat[string(data)]++
+			currentColumnSigns[string(data)] = bftCell.GetSign()
+			currentColumnChains[string(data)] = bftCell.GetChain()
 		}
 
 		maxConfirmationsForEntropy := int(0)
+		var chosenKey string
 		var chosenEntropy insolar.Entropy
 		for key, value := range currentColumnStat {
 			if value > maxConfirmationsForEntropy && key != "nil" {
 				maxConfirmationsForEntropy = value
+				chosenKey = key
 				copy(chosenEntropy[:], []byte(key)[:insolar.EntropySize])
 			}
 		}
 
 		if maxConfirmationsForEntropy >= currentPulsar.getMinimumNonTraitorsCount() {
 			finalEntropySet = append(finalEntropySet, chosenEntropy)
+			entropyProofs[column.PubPem] = insolar.PulseEntropyProof{
+				Entropy:   chosenEntropy,
+				Chain:     currentColumnChains[chosenKey],
+				Signature: currentColumnSigns[chosenKey],
+			}
 		} else {
 			wrongVectors++
 		}
@@ -206,6 +242,9 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 		return
 	}
 
+	currentPulsar.setEntropyProofs(entropyProofs)
+	currentPulsar.agreeMembershipChanges(ctx)
+
 	var finalEntropy insolar.Entropy
 
 	for _, tempEntropy := range finalEntropySet {
@@ -216,6 +255,13 @@ func (currentPulsar *Pulsar) verify(ctx context.Context) {
 	currentPulsar.finalizeBft(ctx, finalEntropy, keys)
 }
 
+// setEntropyProofs saves contributions of pulsars to entropy of the current slot
+func (currentPulsar *Pulsar) setEntropyProofs(proofs map[string]insolar.PulseEntropyProof) {
+	currentPulsar.currentSlotSenderConfirmationsLock.Lock()
+	defer currentPulsar.currentSlotSenderConfirmationsLock.Unlock()
+	currentPulsar.CurrentSlotEntropyProofs = proofs
+}
+
 func (currentPulsar *Pulsar) finalizeBft(ctx context.Context, finalEntropy insolar.Entropy, activePulsars []string) {
 	ctx, span := instracer.StartSpan(ctx, "Pulsar.finalizeBft")
 	defer span.End()
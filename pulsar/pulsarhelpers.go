@@ -22,14 +22,17 @@ import (
 
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/instrumentation/inslogger"
+	"github.com/insolar/insolar/instrumentation/insmetrics"
 	"github.com/insolar/insolar/log"
+	"github.com/insolar/insolar/pulsar/entropygenerator"
 	"github.com/insolar/insolar/utils/entropy"
 	"github.com/pkg/errors"
+	"go.opencensus.io/stats"
 )
 
 // FetchNeighbour searches neighbour of the pulsar by pubKey of a neighbout
 func (currentPulsar *Pulsar) FetchNeighbour(pubKey string) (*Neighbour, error) {
-	neighbour, ok := currentPulsar.Neighbours[pubKey]
+	neighbour, ok := currentPulsar.getNeighbours()[pubKey]
 	if !ok {
 		return nil, errors.New("forbidden connection")
 	}
@@ -41,23 +44,41 @@ func (currentPulsar *Pulsar) IsStateFailed() bool {
 	return currentPulsar.StateSwitcher.GetState() == Failed
 }
 
+// getNeighbours returns the current pulsars set
+// The map is replaced on membership changes and never modified, so it can be ranged over without the lock
+func (currentPulsar *Pulsar) getNeighbours() map[string]*Neighbour {
+	currentPulsar.membershipLock.RLock()
+	defer currentPulsar.membershipLock.RUnlock()
+	return currentPulsar.Neighbours
+}
+
 func (currentPulsar *Pulsar) isStandalone() bool {
-	return len(currentPulsar.Neighbours) == 0
+	return len(currentPulsar.getNeighbours()) == 0
 }
 
 func (currentPulsar *Pulsar) getMaxTraitorsCount() int {
-	nodes := len(currentPulsar.Neighbours) + 1
-	return (nodes - 1) / 3
+	return maxTraitorsCount(len(currentPulsar.getNeighbours()) + 1)
 }
 
 func (currentPulsar *Pulsar) getMinimumNonTraitorsCount() int {
-	nodes := len(currentPulsar.Neighbours) + 1
-	return nodes - currentPulsar.getMaxTraitorsCount()
+	return minimumNonTraitorsCount(len(currentPulsar.getNeighbours()) + 1)
+}
+
+func maxTraitorsCount(nodes int) int {
+	return (nodes - 1) / 3
 }
 
-func (currentPulsar *Pulsar) handleErrorState(ctx context.Context, err error) {
+func minimumNonTraitorsCount(nodes int) int {
+	return nodes - maxTraitorsCount(nodes)
+}
+
+func (currentPulsar *Pulsar) handleErrorState(ctx context.Context, failedState State, err error) {
 	inslogger.FromContext(ctx).Error(err)
 
+	currentPulsar.status.roundFinished(currentPulsar.ProcessingPulseNumber, RoundFailed, failedState, err)
+	ctx = insmetrics.InsertTag(ctx, tagFailedState, failedState.String())
+	stats.Record(ctx, statRoundFailed.M(1))
+
 	currentPulsar.clearState()
 }
 
@@ -66,6 +87,7 @@ func (currentPulsar *Pulsar) clearState() {
 	currentPulsar.SetGeneratedEntropy(nil)
 	log.Debug("currentPulsar.GeneratedEntropySign")
 	currentPulsar.GeneratedEntropySign = []byte{}
+	currentPulsar.GeneratedEntropyChain = insolar.EntropyChainCommitment{}
 	log.Debug("currentPulsar.SetCurrentSlotEntropy(nil)")
 	currentPulsar.SetCurrentSlotEntropy(nil)
 	log.Debug("currentPulsar.CurrentSlotPulseSender = ")
@@ -74,9 +96,15 @@ func (currentPulsar *Pulsar) clearState() {
 	currentPulsar.currentSlotSenderConfirmationsLock.Lock()
 	log.Debug("currentPulsar.CurrentSlotSenderConfirmations = map[string]insolar.PulseSenderConfirmation{}")
 	currentPulsar.CurrentSlotSenderConfirmations = map[string]insolar.PulseSenderConfirmation{}
+	currentPulsar.CurrentSlotEntropyProofs = map[string]insolar.PulseEntropyProof{}
 	log.Debug("currentPulsar.currentSlotSenderConfirmationsLock.Unlock()")
 	currentPulsar.currentSlotSenderConfirmationsLock.Unlock()
 
+	currentPulsar.membershipLock.Lock()
+	currentPulsar.currentSlotVotes = map[string]*AgreedMembershipChange{}
+	currentPulsar.agreedChanges = nil
+	currentPulsar.membershipLock.Unlock()
+
 	log.Debug("currentPulsar.ClearVector()")
 	currentPulsar.ClearVector()
 	log.Debug("currentPulsar.BftGridLock.Lock(")
@@ -91,11 +119,16 @@ func (currentPulsar *Pulsar) generateNewEntropyAndSign() error {
 	e := currentPulsar.EntropyGenerator.GenerateEntropy()
 	currentPulsar.SetGeneratedEntropy(&e)
 
-	sign, err := currentPulsar.CryptographyService.Sign(currentPulsar.GetGeneratedEntropy()[:])
+	var chain insolar.EntropyChainCommitment
+	if generator, ok := currentPulsar.EntropyGenerator.(entropygenerator.ChainEntropyGenerator); ok {
+		chain = generator.Commitment()
+	}
+	sign, err := currentPulsar.CryptographyService.Sign(insolar.EntropyProofData(e, chain))
 	if err != nil {
 		return err
 	}
 	currentPulsar.GeneratedEntropySign = sign.Bytes()
+	currentPulsar.GeneratedEntropyChain = chain
 
 	return nil
 }
@@ -205,6 +238,7 @@ func (currentPulsar *Pulsar) CreateVectorCopy() map[string]*BftCell {
 	for key, value := range currentPulsar.ownedBftRow {
 		newMap[key] = &BftCell{
 			Entropy:           value.GetEntropy(),
+			Chain:             value.GetChain(),
 			IsEntropyReceived: value.GetIsEntropyReceived(),
 			Sign:              value.GetSign(),
 		}
@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/gojuno/minimock"
+	configuration "github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
 
 	testify_assert "github.com/stretchr/testify/assert"
@@ -40,20 +41,56 @@ type PulsarStorageMock struct {
 	ClosePreCounter uint64
 	CloseMock       mPulsarStorageMockClose
 
+	GetEntropyChainFunc       func() (r []byte, r1 error)
+	GetEntropyChainCounter    uint64
+	GetEntropyChainPreCounter uint64
+	GetEntropyChainMock       mPulsarStorageMockGetEntropyChain
+
 	GetLastPulseFunc       func() (r *insolar.Pulse, r1 error)
 	GetLastPulseCounter    uint64
 	GetLastPulsePreCounter uint64
 	GetLastPulseMock       mPulsarStorageMockGetLastPulse
 
+	GetNeighboursFunc       func() (r []configuration.PulsarNodeAddress, r1 error)
+	GetNeighboursCounter    uint64
+	GetNeighboursPreCounter uint64
+	GetNeighboursMock       mPulsarStorageMockGetNeighbours
+
+	GetPulseFunc       func(p insolar.PulseNumber) (r *insolar.Pulse, r1 error)
+	GetPulseCounter    uint64
+	GetPulsePreCounter uint64
+	GetPulseMock       mPulsarStorageMockGetPulse
+
+	GetPulseByTimeFunc       func(p int64) (r *insolar.Pulse, r1 error)
+	GetPulseByTimeCounter    uint64
+	GetPulseByTimePreCounter uint64
+	GetPulseByTimeMock       mPulsarStorageMockGetPulseByTime
+
+	GetPulsesFunc       func(p insolar.PulseNumber, p1 insolar.PulseNumber, p2 int) (r []insolar.Pulse, r1 error)
+	GetPulsesCounter    uint64
+	GetPulsesPreCounter uint64
+	GetPulsesMock       mPulsarStorageMockGetPulses
+
 	SavePulseFunc       func(p *insolar.Pulse) (r error)
 	SavePulseCounter    uint64
 	SavePulsePreCounter uint64
 	SavePulseMock       mPulsarStorageMockSavePulse
 
+	SetEntropyChainFunc       func(p []byte) (r error)
+	SetEntropyChainCounter    uint64
+	SetEntropyChainPreCounter uint64
+	SetEntropyChainMock       mPulsarStorageMockSetEntropyChain
+
 	SetLastPulseFunc       func(p *insolar.Pulse) (r error)
 	SetLastPulseCounter    uint64
 	SetLastPulsePreCounter uint64
 	SetLastPulseMock       mPulsarStorageMockSetLastPulse
+
+	SetNeighboursFunc       func(p []configuration.PulsarNodeAddress) (r error)
+	SetNeighboursCounter    uint64
+	SetNeighboursPreCounter uint64
+	SetNeighboursMock       mPulsarStorageMockSetNeighbours
+
 }
 
 //NewPulsarStorageMock returns a mock for github.com/insolar/insolar/pulsar/storage.PulsarStorage
@@ -65,9 +102,16 @@ func NewPulsarStorageMock(t minimock.Tester) *PulsarStorageMock {
 	}
 
 	m.CloseMock = mPulsarStorageMockClose{mock: m}
+	m.GetEntropyChainMock = mPulsarStorageMockGetEntropyChain{mock: m}
 	m.GetLastPulseMock = mPulsarStorageMockGetLastPulse{mock: m}
+	m.GetNeighboursMock = mPulsarStorageMockGetNeighbours{mock: m}
+	m.GetPulseMock = mPulsarStorageMockGetPulse{mock: m}
+	m.GetPulseByTimeMock = mPulsarStorageMockGetPulseByTime{mock: m}
+	m.GetPulsesMock = mPulsarStorageMockGetPulses{mock: m}
 	m.SavePulseMock = mPulsarStorageMockSavePulse{mock: m}
+	m.SetEntropyChainMock = mPulsarStorageMockSetEntropyChain{mock: m}
 	m.SetLastPulseMock = mPulsarStorageMockSetLastPulse{mock: m}
+	m.SetNeighboursMock = mPulsarStorageMockSetNeighbours{mock: m}
 
 	return m
 }
@@ -114,6 +158,48 @@ func (m *PulsarStorageMock) CloseMinimockPreCounter() uint64 {
 	return atomic.LoadUint64(&m.ClosePreCounter)
 }
 
+type mPulsarStorageMockGetEntropyChain struct {
+	mock *PulsarStorageMock
+}
+
+//Return sets up a mock for PulsarStorage.GetEntropyChain to return Return's arguments
+func (m *mPulsarStorageMockGetEntropyChain) Return(r []byte, r1 error) *PulsarStorageMock {
+	m.mock.GetEntropyChainFunc = func() ([]byte, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetEntropyChain method
+func (m *mPulsarStorageMockGetEntropyChain) Set(f func() (r []byte, r1 error)) *PulsarStorageMock {
+	m.mock.GetEntropyChainFunc = f
+
+	return m.mock
+}
+
+//GetEntropyChain implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetEntropyChain() (r []byte, r1 error) {
+	atomic.AddUint64(&m.GetEntropyChainPreCounter, 1)
+	defer atomic.AddUint64(&m.GetEntropyChainCounter, 1)
+
+	if m.GetEntropyChainFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetEntropyChain")
+		return
+	}
+
+	return m.GetEntropyChainFunc()
+}
+
+//GetEntropyChainMinimockCounter returns a count of PulsarStorageMock.GetEntropyChainFunc invocations
+func (m *PulsarStorageMock) GetEntropyChainMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetEntropyChainCounter)
+}
+
+//GetEntropyChainMinimockPreCounter returns the value of PulsarStorageMock.GetEntropyChain invocations
+func (m *PulsarStorageMock) GetEntropyChainMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetEntropyChainPreCounter)
+}
+
 type mPulsarStorageMockGetLastPulse struct {
 	mock *PulsarStorageMock
 }
@@ -156,6 +242,248 @@ func (m *PulsarStorageMock) GetLastPulseMinimockPreCounter() uint64 {
 	return atomic.LoadUint64(&m.GetLastPulsePreCounter)
 }
 
+type mPulsarStorageMockGetNeighbours struct {
+	mock *PulsarStorageMock
+}
+
+//Return sets up a mock for PulsarStorage.GetNeighbours to return Return's arguments
+func (m *mPulsarStorageMockGetNeighbours) Return(r []configuration.PulsarNodeAddress, r1 error) *PulsarStorageMock {
+	m.mock.GetNeighboursFunc = func() ([]configuration.PulsarNodeAddress, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetNeighbours method
+func (m *mPulsarStorageMockGetNeighbours) Set(f func() (r []configuration.PulsarNodeAddress, r1 error)) *PulsarStorageMock {
+	m.mock.GetNeighboursFunc = f
+
+	return m.mock
+}
+
+//GetNeighbours implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetNeighbours() (r []configuration.PulsarNodeAddress, r1 error) {
+	atomic.AddUint64(&m.GetNeighboursPreCounter, 1)
+	defer atomic.AddUint64(&m.GetNeighboursCounter, 1)
+
+	if m.GetNeighboursFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetNeighbours")
+		return
+	}
+
+	return m.GetNeighboursFunc()
+}
+
+//GetNeighboursMinimockCounter returns a count of PulsarStorageMock.GetNeighboursFunc invocations
+func (m *PulsarStorageMock) GetNeighboursMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetNeighboursCounter)
+}
+
+//GetNeighboursMinimockPreCounter returns the value of PulsarStorageMock.GetNeighbours invocations
+func (m *PulsarStorageMock) GetNeighboursMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetNeighboursPreCounter)
+}
+
+type mPulsarStorageMockGetPulse struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulseParams
+}
+
+//PulsarStorageMockGetPulseParams represents input parameters of the PulsarStorage.GetPulse
+type PulsarStorageMockGetPulseParams struct {
+	p insolar.PulseNumber
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulse
+func (m *mPulsarStorageMockGetPulse) Expect(p insolar.PulseNumber) *mPulsarStorageMockGetPulse {
+	m.mockExpectations = &PulsarStorageMockGetPulseParams{p}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulse to return Return's arguments
+func (m *mPulsarStorageMockGetPulse) Return(r *insolar.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulseFunc = func(p insolar.PulseNumber) (*insolar.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulse method
+func (m *mPulsarStorageMockGetPulse) Set(f func(p insolar.PulseNumber) (r *insolar.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulseFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulse implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulse(p insolar.PulseNumber) (r *insolar.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulsePreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulseCounter, 1)
+
+	if m.GetPulseMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulseMock.mockExpectations, PulsarStorageMockGetPulseParams{p},
+			"PulsarStorage.GetPulse got unexpected parameters")
+
+		if m.GetPulseFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulse")
+
+			return
+		}
+	}
+
+	if m.GetPulseFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulse")
+		return
+	}
+
+	return m.GetPulseFunc(p)
+}
+
+//GetPulseMinimockCounter returns a count of PulsarStorageMock.GetPulseFunc invocations
+func (m *PulsarStorageMock) GetPulseMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulseCounter)
+}
+
+//GetPulseMinimockPreCounter returns the value of PulsarStorageMock.GetPulse invocations
+func (m *PulsarStorageMock) GetPulseMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsePreCounter)
+}
+
+type mPulsarStorageMockGetPulseByTime struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulseByTimeParams
+}
+
+//PulsarStorageMockGetPulseByTimeParams represents input parameters of the PulsarStorage.GetPulseByTime
+type PulsarStorageMockGetPulseByTimeParams struct {
+	p int64
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulseByTime
+func (m *mPulsarStorageMockGetPulseByTime) Expect(p int64) *mPulsarStorageMockGetPulseByTime {
+	m.mockExpectations = &PulsarStorageMockGetPulseByTimeParams{p}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulseByTime to return Return's arguments
+func (m *mPulsarStorageMockGetPulseByTime) Return(r *insolar.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulseByTimeFunc = func(p int64) (*insolar.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulseByTime method
+func (m *mPulsarStorageMockGetPulseByTime) Set(f func(p int64) (r *insolar.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulseByTimeFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulseByTime implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulseByTime(p int64) (r *insolar.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulseByTimePreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulseByTimeCounter, 1)
+
+	if m.GetPulseByTimeMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulseByTimeMock.mockExpectations, PulsarStorageMockGetPulseByTimeParams{p},
+			"PulsarStorage.GetPulseByTime got unexpected parameters")
+
+		if m.GetPulseByTimeFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulseByTime")
+
+			return
+		}
+	}
+
+	if m.GetPulseByTimeFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulseByTime")
+		return
+	}
+
+	return m.GetPulseByTimeFunc(p)
+}
+
+//GetPulseByTimeMinimockCounter returns a count of PulsarStorageMock.GetPulseByTimeFunc invocations
+func (m *PulsarStorageMock) GetPulseByTimeMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulseByTimeCounter)
+}
+
+//GetPulseByTimeMinimockPreCounter returns the value of PulsarStorageMock.GetPulseByTime invocations
+func (m *PulsarStorageMock) GetPulseByTimeMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulseByTimePreCounter)
+}
+
+type mPulsarStorageMockGetPulses struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockGetPulsesParams
+}
+
+//PulsarStorageMockGetPulsesParams represents input parameters of the PulsarStorage.GetPulses
+type PulsarStorageMockGetPulsesParams struct {
+	p insolar.PulseNumber
+	p1 insolar.PulseNumber
+	p2 int
+}
+
+//Expect sets up expected params for the PulsarStorage.GetPulses
+func (m *mPulsarStorageMockGetPulses) Expect(p insolar.PulseNumber, p1 insolar.PulseNumber, p2 int) *mPulsarStorageMockGetPulses {
+	m.mockExpectations = &PulsarStorageMockGetPulsesParams{p, p1, p2}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.GetPulses to return Return's arguments
+func (m *mPulsarStorageMockGetPulses) Return(r []insolar.Pulse, r1 error) *PulsarStorageMock {
+	m.mock.GetPulsesFunc = func(p insolar.PulseNumber, p1 insolar.PulseNumber, p2 int) ([]insolar.Pulse, error) {
+		return r, r1
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.GetPulses method
+func (m *mPulsarStorageMockGetPulses) Set(f func(p insolar.PulseNumber, p1 insolar.PulseNumber, p2 int) (r []insolar.Pulse, r1 error)) *PulsarStorageMock {
+	m.mock.GetPulsesFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//GetPulses implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) GetPulses(p insolar.PulseNumber, p1 insolar.PulseNumber, p2 int) (r []insolar.Pulse, r1 error) {
+	atomic.AddUint64(&m.GetPulsesPreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulsesCounter, 1)
+
+	if m.GetPulsesMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.GetPulsesMock.mockExpectations, PulsarStorageMockGetPulsesParams{p, p1, p2},
+			"PulsarStorage.GetPulses got unexpected parameters")
+
+		if m.GetPulsesFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.GetPulses")
+
+			return
+		}
+	}
+
+	if m.GetPulsesFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.GetPulses")
+		return
+	}
+
+	return m.GetPulsesFunc(p, p1, p2)
+}
+
+//GetPulsesMinimockCounter returns a count of PulsarStorageMock.GetPulsesFunc invocations
+func (m *PulsarStorageMock) GetPulsesMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesCounter)
+}
+
+//GetPulsesMinimockPreCounter returns the value of PulsarStorageMock.GetPulses invocations
+func (m *PulsarStorageMock) GetPulsesMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsesPreCounter)
+}
+
 type mPulsarStorageMockSavePulse struct {
 	mock             *PulsarStorageMock
 	mockExpectations *PulsarStorageMockSavePulseParams
@@ -222,6 +550,72 @@ func (m *PulsarStorageMock) SavePulseMinimockPreCounter() uint64 {
 	return atomic.LoadUint64(&m.SavePulsePreCounter)
 }
 
+type mPulsarStorageMockSetEntropyChain struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockSetEntropyChainParams
+}
+
+//PulsarStorageMockSetEntropyChainParams represents input parameters of the PulsarStorage.SetEntropyChain
+type PulsarStorageMockSetEntropyChainParams struct {
+	p []byte
+}
+
+//Expect sets up expected params for the PulsarStorage.SetEntropyChain
+func (m *mPulsarStorageMockSetEntropyChain) Expect(p []byte) *mPulsarStorageMockSetEntropyChain {
+	m.mockExpectations = &PulsarStorageMockSetEntropyChainParams{p}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.SetEntropyChain to return Return's arguments
+func (m *mPulsarStorageMockSetEntropyChain) Return(r error) *PulsarStorageMock {
+	m.mock.SetEntropyChainFunc = func(p []byte) error {
+		return r
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.SetEntropyChain method
+func (m *mPulsarStorageMockSetEntropyChain) Set(f func(p []byte) (r error)) *PulsarStorageMock {
+	m.mock.SetEntropyChainFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//SetEntropyChain implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) SetEntropyChain(p []byte) (r error) {
+	atomic.AddUint64(&m.SetEntropyChainPreCounter, 1)
+	defer atomic.AddUint64(&m.SetEntropyChainCounter, 1)
+
+	if m.SetEntropyChainMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.SetEntropyChainMock.mockExpectations, PulsarStorageMockSetEntropyChainParams{p},
+			"PulsarStorage.SetEntropyChain got unexpected parameters")
+
+		if m.SetEntropyChainFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.SetEntropyChain")
+
+			return
+		}
+	}
+
+	if m.SetEntropyChainFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.SetEntropyChain")
+		return
+	}
+
+	return m.SetEntropyChainFunc(p)
+}
+
+//SetEntropyChainMinimockCounter returns a count of PulsarStorageMock.SetEntropyChainFunc invocations
+func (m *PulsarStorageMock) SetEntropyChainMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetEntropyChainCounter)
+}
+
+//SetEntropyChainMinimockPreCounter returns the value of PulsarStorageMock.SetEntropyChain invocations
+func (m *PulsarStorageMock) SetEntropyChainMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetEntropyChainPreCounter)
+}
+
 type mPulsarStorageMockSetLastPulse struct {
 	mock             *PulsarStorageMock
 	mockExpectations *PulsarStorageMockSetLastPulseParams
@@ -288,6 +682,72 @@ func (m *PulsarStorageMock) SetLastPulseMinimockPreCounter() uint64 {
 	return atomic.LoadUint64(&m.SetLastPulsePreCounter)
 }
 
+type mPulsarStorageMockSetNeighbours struct {
+	mock             *PulsarStorageMock
+	mockExpectations *PulsarStorageMockSetNeighboursParams
+}
+
+//PulsarStorageMockSetNeighboursParams represents input parameters of the PulsarStorage.SetNeighbours
+type PulsarStorageMockSetNeighboursParams struct {
+	p []configuration.PulsarNodeAddress
+}
+
+//Expect sets up expected params for the PulsarStorage.SetNeighbours
+func (m *mPulsarStorageMockSetNeighbours) Expect(p []configuration.PulsarNodeAddress) *mPulsarStorageMockSetNeighbours {
+	m.mockExpectations = &PulsarStorageMockSetNeighboursParams{p}
+	return m
+}
+
+//Return sets up a mock for PulsarStorage.SetNeighbours to return Return's arguments
+func (m *mPulsarStorageMockSetNeighbours) Return(r error) *PulsarStorageMock {
+	m.mock.SetNeighboursFunc = func(p []configuration.PulsarNodeAddress) error {
+		return r
+	}
+	return m.mock
+}
+
+//Set uses given function f as a mock of PulsarStorage.SetNeighbours method
+func (m *mPulsarStorageMockSetNeighbours) Set(f func(p []configuration.PulsarNodeAddress) (r error)) *PulsarStorageMock {
+	m.mock.SetNeighboursFunc = f
+	m.mockExpectations = nil
+	return m.mock
+}
+
+//SetNeighbours implements github.com/insolar/insolar/pulsar/storage.PulsarStorage interface
+func (m *PulsarStorageMock) SetNeighbours(p []configuration.PulsarNodeAddress) (r error) {
+	atomic.AddUint64(&m.SetNeighboursPreCounter, 1)
+	defer atomic.AddUint64(&m.SetNeighboursCounter, 1)
+
+	if m.SetNeighboursMock.mockExpectations != nil {
+		testify_assert.Equal(m.t, *m.SetNeighboursMock.mockExpectations, PulsarStorageMockSetNeighboursParams{p},
+			"PulsarStorage.SetNeighbours got unexpected parameters")
+
+		if m.SetNeighboursFunc == nil {
+
+			m.t.Fatal("No results are set for the PulsarStorageMock.SetNeighbours")
+
+			return
+		}
+	}
+
+	if m.SetNeighboursFunc == nil {
+		m.t.Fatal("Unexpected call to PulsarStorageMock.SetNeighbours")
+		return
+	}
+
+	return m.SetNeighboursFunc(p)
+}
+
+//SetNeighboursMinimockCounter returns a count of PulsarStorageMock.SetNeighboursFunc invocations
+func (m *PulsarStorageMock) SetNeighboursMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetNeighboursCounter)
+}
+
+//SetNeighboursMinimockPreCounter returns the value of PulsarStorageMock.SetNeighbours invocations
+func (m *PulsarStorageMock) SetNeighboursMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetNeighboursPreCounter)
+}
+
 //ValidateCallCounters checks that all mocked methods of the interface have been called at least once
 //Deprecated: please use MinimockFinish method or use Finish method of minimock.Controller
 func (m *PulsarStorageMock) ValidateCallCounters() {
@@ -296,18 +756,46 @@ func (m *PulsarStorageMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to PulsarStorageMock.Close")
 	}
 
+	if m.GetEntropyChainFunc != nil && atomic.LoadUint64(&m.GetEntropyChainCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetEntropyChain")
+	}
+
 	if m.GetLastPulseFunc != nil && atomic.LoadUint64(&m.GetLastPulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.GetLastPulse")
 	}
 
+	if m.GetNeighboursFunc != nil && atomic.LoadUint64(&m.GetNeighboursCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetNeighbours")
+	}
+
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulse")
+	}
+
+	if m.GetPulseByTimeFunc != nil && atomic.LoadUint64(&m.GetPulseByTimeCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulseByTime")
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulses")
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SavePulse")
 	}
 
+	if m.SetEntropyChainFunc != nil && atomic.LoadUint64(&m.SetEntropyChainCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.SetEntropyChain")
+	}
+
 	if m.SetLastPulseFunc != nil && atomic.LoadUint64(&m.SetLastPulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SetLastPulse")
 	}
 
+	if m.SetNeighboursFunc != nil && atomic.LoadUint64(&m.SetNeighboursCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.SetNeighbours")
+	}
+
 }
 
 //CheckMocksCalled checks that all mocked methods of the interface have been called at least once
@@ -330,18 +818,46 @@ func (m *PulsarStorageMock) MinimockFinish() {
 		m.t.Fatal("Expected call to PulsarStorageMock.Close")
 	}
 
+	if m.GetEntropyChainFunc != nil && atomic.LoadUint64(&m.GetEntropyChainCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetEntropyChain")
+	}
+
 	if m.GetLastPulseFunc != nil && atomic.LoadUint64(&m.GetLastPulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.GetLastPulse")
 	}
 
+	if m.GetNeighboursFunc != nil && atomic.LoadUint64(&m.GetNeighboursCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetNeighbours")
+	}
+
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulse")
+	}
+
+	if m.GetPulseByTimeFunc != nil && atomic.LoadUint64(&m.GetPulseByTimeCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulseByTime")
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.GetPulses")
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SavePulse")
 	}
 
+	if m.SetEntropyChainFunc != nil && atomic.LoadUint64(&m.SetEntropyChainCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.SetEntropyChain")
+	}
+
 	if m.SetLastPulseFunc != nil && atomic.LoadUint64(&m.SetLastPulseCounter) == 0 {
 		m.t.Fatal("Expected call to PulsarStorageMock.SetLastPulse")
 	}
 
+	if m.SetNeighboursFunc != nil && atomic.LoadUint64(&m.SetNeighboursCounter) == 0 {
+		m.t.Fatal("Expected call to PulsarStorageMock.SetNeighbours")
+	}
+
 }
 
 //Wait waits for all mocked methods to be called at least once
@@ -357,9 +873,16 @@ func (m *PulsarStorageMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && (m.CloseFunc == nil || atomic.LoadUint64(&m.CloseCounter) > 0)
+		ok = ok && (m.GetEntropyChainFunc == nil || atomic.LoadUint64(&m.GetEntropyChainCounter) > 0)
 		ok = ok && (m.GetLastPulseFunc == nil || atomic.LoadUint64(&m.GetLastPulseCounter) > 0)
+		ok = ok && (m.GetNeighboursFunc == nil || atomic.LoadUint64(&m.GetNeighboursCounter) > 0)
+		ok = ok && (m.GetPulseFunc == nil || atomic.LoadUint64(&m.GetPulseCounter) > 0)
+		ok = ok && (m.GetPulseByTimeFunc == nil || atomic.LoadUint64(&m.GetPulseByTimeCounter) > 0)
+		ok = ok && (m.GetPulsesFunc == nil || atomic.LoadUint64(&m.GetPulsesCounter) > 0)
 		ok = ok && (m.SavePulseFunc == nil || atomic.LoadUint64(&m.SavePulseCounter) > 0)
+		ok = ok && (m.SetEntropyChainFunc == nil || atomic.LoadUint64(&m.SetEntropyChainCounter) > 0)
 		ok = ok && (m.SetLastPulseFunc == nil || atomic.LoadUint64(&m.SetLastPulseCounter) > 0)
+		ok = ok && (m.SetNeighboursFunc == nil || atomic.LoadUint64(&m.SetNeighboursCounter) > 0)
 
 		if ok {
 			return
@@ -372,18 +895,46 @@ func (m *PulsarStorageMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to PulsarStorageMock.Close")
 			}
 
+			if m.GetEntropyChainFunc != nil && atomic.LoadUint64(&m.GetEntropyChainCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetEntropyChain")
+			}
+
 			if m.GetLastPulseFunc != nil && atomic.LoadUint64(&m.GetLastPulseCounter) == 0 {
 				m.t.Error("Expected call to PulsarStorageMock.GetLastPulse")
 			}
 
+			if m.GetNeighboursFunc != nil && atomic.LoadUint64(&m.GetNeighboursCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetNeighbours")
+			}
+
+			if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulse")
+			}
+
+			if m.GetPulseByTimeFunc != nil && atomic.LoadUint64(&m.GetPulseByTimeCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulseByTime")
+			}
+
+			if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.GetPulses")
+			}
+
 			if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 				m.t.Error("Expected call to PulsarStorageMock.SavePulse")
 			}
 
+			if m.SetEntropyChainFunc != nil && atomic.LoadUint64(&m.SetEntropyChainCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.SetEntropyChain")
+			}
+
 			if m.SetLastPulseFunc != nil && atomic.LoadUint64(&m.SetLastPulseCounter) == 0 {
 				m.t.Error("Expected call to PulsarStorageMock.SetLastPulse")
 			}
 
+			if m.SetNeighboursFunc != nil && atomic.LoadUint64(&m.SetNeighboursCounter) == 0 {
+				m.t.Error("Expected call to PulsarStorageMock.SetNeighbours")
+			}
+
 			m.t.Fatalf("Some mocks were not called on time: %s", timeout)
 			return
 		default:
@@ -400,17 +951,45 @@ func (m *PulsarStorageMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if m.GetEntropyChainFunc != nil && atomic.LoadUint64(&m.GetEntropyChainCounter) == 0 {
+		return false
+	}
+
 	if m.GetLastPulseFunc != nil && atomic.LoadUint64(&m.GetLastPulseCounter) == 0 {
 		return false
 	}
 
+	if m.GetNeighboursFunc != nil && atomic.LoadUint64(&m.GetNeighboursCounter) == 0 {
+		return false
+	}
+
+	if m.GetPulseFunc != nil && atomic.LoadUint64(&m.GetPulseCounter) == 0 {
+		return false
+	}
+
+	if m.GetPulseByTimeFunc != nil && atomic.LoadUint64(&m.GetPulseByTimeCounter) == 0 {
+		return false
+	}
+
+	if m.GetPulsesFunc != nil && atomic.LoadUint64(&m.GetPulsesCounter) == 0 {
+		return false
+	}
+
 	if m.SavePulseFunc != nil && atomic.LoadUint64(&m.SavePulseCounter) == 0 {
 		return false
 	}
 
+	if m.SetEntropyChainFunc != nil && atomic.LoadUint64(&m.SetEntropyChainCounter) == 0 {
+		return false
+	}
+
 	if m.SetLastPulseFunc != nil && atomic.LoadUint64(&m.SetLastPulseCounter) == 0 {
 		return false
 	}
 
+	if m.SetNeighboursFunc != nil && atomic.LoadUint64(&m.SetNeighboursCounter) == 0 {
+		return false
+	}
+
 	return true
 }
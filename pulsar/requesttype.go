@@ -41,6 +41,9 @@ const (
 
 	// ReceivePulse is a method for receiving pulse from the sender
 	ReceivePulse RequestType = "Pulsar.ReceivePulse"
+
+	// GetLastPulse is a method for requesting the last pulse from peers
+	GetLastPulse RequestType = "Pulsar.GetLastPulse"
 )
 
 func (state RequestType) String() string {
@@ -104,7 +104,8 @@ func (switcher *StateSwitcherImpl) SwitchToState(ctx context.Context, state Stat
 	defer span.End()
 
 	logger := inslogger.FromContext(ctx)
-	logger.Debugf("Switch state from %v to %v, node - %v", switcher.GetState().String(), state.String(), switcher.pulsar.Config.MainListenerAddress)
+	previousState := switcher.GetState()
+	logger.Debugf("Switch state from %v to %v, node - %v", previousState.String(), state.String(), switcher.pulsar.Config.MainListenerAddress)
 	if state < switcher.GetState() && (state != WaitingForStart && state != Failed) {
 		logger.Panic("Attempt to set a backward step. %v", switcher.pulsar.Config.MainListenerAddress)
 	}
@@ -135,7 +136,7 @@ func (switcher *StateSwitcherImpl) SwitchToState(ctx context.Context, state Stat
 	case SendingPulse:
 		switcher.pulsar.sendPulseToNodesAndPulsars(ctx)
 	case Failed:
-		switcher.pulsar.handleErrorState(ctx, args.(error))
+		switcher.pulsar.handleErrorState(ctx, previousState, args.(error))
 		switcher.setState(WaitingForStart)
 	}
 }
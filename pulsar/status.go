@@ -0,0 +1,226 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+// RecentRoundsCount is a count of the latest rounds kept for the status
+const RecentRoundsCount = 20
+
+// Outcomes of consensus rounds
+const (
+	RoundInProgress = "in progress"
+	RoundSent       = "sent"
+	RoundReceived   = "received"
+	RoundFailed     = "failed"
+)
+
+// NeighbourStatus describes connectivity with one of other pulsars
+type NeighbourStatus struct {
+	PublicKey string
+	Address   string
+	Connected bool
+	// LastSeen is a time of the last valid message or health check of the neighbour
+	LastSeen time.Time
+	// Latency is a duration of the last health check
+	Latency time.Duration
+}
+
+// RoundStatus describes one of consensus rounds
+type RoundStatus struct {
+	PulseNumber insolar.PulseNumber
+	StartedAt   time.Time
+	FinishedAt  time.Time
+	Outcome     string
+	// FailedState is a state, in which the round is failed
+	FailedState string
+	Error       string
+}
+
+// BftRowStatus describes a vector received from one of pulsars in the current round
+type BftRowStatus struct {
+	PublicKey string
+	Cells     int
+	// Entropies is a count of cells with revealed entropy
+	Entropies int
+}
+
+// Status is a snapshot of the pulsar's internal state
+type Status struct {
+	PublicKey             string
+	State                 string
+	ProcessingPulseNumber insolar.PulseNumber
+	LastPulseNumber       insolar.PulseNumber
+	Neighbours            []NeighbourStatus
+	Rounds                []RoundStatus
+	BftGrid               []BftRowStatus
+	// FailedRounds are counters of failed rounds by states, in which they are failed
+	FailedRounds map[string]int
+}
+
+// statusCollector keeps statistics of the pulsar, which isn't a part of consensus state
+// Methods of nil collector do nothing
+type statusCollector struct {
+	lock         sync.Mutex
+	seen         map[string]time.Time
+	latencies    map[string]time.Duration
+	rounds       []RoundStatus
+	failedRounds map[string]int
+}
+
+func newStatusCollector() *statusCollector {
+	return &statusCollector{
+		seen:         map[string]time.Time{},
+		latencies:    map[string]time.Duration{},
+		failedRounds: map[string]int{},
+	}
+}
+
+func (collector *statusCollector) neighbourSeen(pubKey string) {
+	if collector == nil {
+		return
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.seen[pubKey] = time.Now()
+}
+
+func (collector *statusCollector) neighbourChecked(pubKey string, latency time.Duration) {
+	if collector == nil {
+		return
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.seen[pubKey] = time.Now()
+	collector.latencies[pubKey] = latency
+}
+
+func (collector *statusCollector) roundStarted(pulseNumber insolar.PulseNumber) {
+	if collector == nil {
+		return
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+	collector.rounds = append(collector.rounds, RoundStatus{
+		PulseNumber: pulseNumber,
+		StartedAt:   time.Now(),
+		Outcome:     RoundInProgress,
+	})
+	if len(collector.rounds) > RecentRoundsCount {
+		collector.rounds = collector.rounds[len(collector.rounds)-RecentRoundsCount:]
+	}
+}
+
+// roundFinished sets outcome of the round, the round is added if it's started by another pulsar
+func (collector *statusCollector) roundFinished(pulseNumber insolar.PulseNumber, outcome string, failedState State, err error) {
+	if collector == nil {
+		return
+	}
+	collector.lock.Lock()
+	defer collector.lock.Unlock()
+
+	var round *RoundStatus
+	for i := range collector.rounds {
+		if collector.rounds[i].PulseNumber == pulseNumber && collector.rounds[i].Outcome == RoundInProgress {
+			round = &collector.rounds[i]
+		}
+	}
+	if round == nil {
+		collector.rounds = append(collector.rounds, RoundStatus{PulseNumber: pulseNumber, StartedAt: time.Now()})
+		if len(collector.rounds) > RecentRoundsCount {
+			collector.rounds = collector.rounds[len(collector.rounds)-RecentRoundsCount:]
+		}
+		round = &collector.rounds[len(collector.rounds)-1]
+	}
+
+	round.FinishedAt = time.Now()
+	round.Outcome = outcome
+	if err != nil {
+		round.FailedState = failedState.String()
+		round.Error = err.Error()
+		collector.failedRounds[round.FailedState]++
+	}
+}
+
+// GetStatus returns the current state of the pulsar, its neighbours and recent rounds
+func (currentPulsar *Pulsar) GetStatus() Status {
+	status := Status{
+		PublicKey:             currentPulsar.PublicKeyRaw,
+		State:                 currentPulsar.StateSwitcher.GetState().String(),
+		ProcessingPulseNumber: currentPulsar.ProcessingPulseNumber,
+		Neighbours:            []NeighbourStatus{},
+		BftGrid:               []BftRowStatus{},
+		FailedRounds:          map[string]int{},
+	}
+	if lastPulse := currentPulsar.GetLastPulse(); lastPulse != nil {
+		status.LastPulseNumber = lastPulse.PulseNumber
+	}
+
+	collector := currentPulsar.status
+	if collector == nil {
+		collector = newStatusCollector()
+	}
+	// the set is taken under the membership read lock, it's replaced on changes, so it can be ranged over safely
+	neighbours := currentPulsar.getNeighbours()
+	collector.lock.Lock()
+	for key, neighbour := range neighbours {
+		status.Neighbours = append(status.Neighbours, NeighbourStatus{
+			PublicKey: key,
+			Address:   neighbour.ConnectionAddress,
+			Connected: neighbour.OutgoingClient != nil && neighbour.OutgoingClient.IsInitialised(),
+			LastSeen:  collector.seen[key],
+			Latency:   collector.latencies[key],
+		})
+	}
+	status.Rounds = append([]RoundStatus{}, collector.rounds...)
+	for state, count := range collector.failedRounds {
+		status.FailedRounds[state] = count
+	}
+	collector.lock.Unlock()
+
+	sort.Slice(status.Neighbours, func(i, j int) bool {
+		return status.Neighbours[i].Address < status.Neighbours[j].Address
+	})
+
+	currentPulsar.BftGridLock.RLock()
+	for key, row := range currentPulsar.bftGrid {
+		rowStatus := BftRowStatus{PublicKey: key}
+		for _, cell := range row {
+			if cell == nil {
+				continue
+			}
+			rowStatus.Cells++
+			if cell.GetIsEntropyReceived() {
+				rowStatus.Entropies++
+			}
+		}
+		status.BftGrid = append(status.BftGrid, rowStatus)
+	}
+	currentPulsar.BftGridLock.RUnlock()
+
+	sort.Slice(status.BftGrid, func(i, j int) bool {
+		return status.BftGrid[i].PublicKey < status.BftGrid[j].PublicKey
+	})
+
+	return status
+}
@@ -0,0 +1,119 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsar
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/insolar/insolar/insolar"
+)
+
+func TestStatusCollector_Rounds(t *testing.T) {
+	collector := newStatusCollector()
+
+	collector.roundStarted(65537)
+	collector.roundFinished(65537, RoundSent, 0, nil)
+	collector.roundStarted(65547)
+	collector.roundFinished(65547, RoundFailed, WaitingForVectors, errors.New("bft is broken"))
+	// the round is started by another pulsar
+	collector.roundFinished(65557, RoundReceived, 0, nil)
+
+	require.Len(t, collector.rounds, 3)
+	require.Equal(t, RoundSent, collector.rounds[0].Outcome)
+	require.Equal(t, RoundFailed, collector.rounds[1].Outcome)
+	require.Equal(t, "WaitingForVectors", collector.rounds[1].FailedState)
+	require.Equal(t, "bft is broken", collector.rounds[1].Error)
+	require.Equal(t, insolar.PulseNumber(65557), collector.rounds[2].PulseNumber)
+	require.Equal(t, map[string]int{"WaitingForVectors": 1}, collector.failedRounds)
+
+	for i := 0; i < RecentRoundsCount; i++ {
+		collector.roundStarted(insolar.PulseNumber(70000 + i))
+	}
+	require.Len(t, collector.rounds, RecentRoundsCount)
+	require.Equal(t, insolar.PulseNumber(70000), collector.rounds[0].PulseNumber)
+}
+
+func TestPulsar_GetStatus(t *testing.T) {
+	switcher := NewStateSwitcherMock(t)
+	switcher.GetStateMock.Return(WaitingForVectors)
+
+	pulsar := &Pulsar{
+		PublicKeyRaw:          "first",
+		ProcessingPulseNumber: 65547,
+		StateSwitcher:         switcher,
+		status:                newStatusCollector(),
+		Neighbours: map[string]*Neighbour{
+			"second": {ConnectionAddress: "127.0.0.1:58093"},
+			"third":  {ConnectionAddress: "127.0.0.1:58092"},
+		},
+	}
+	pulsar.SetLastPulse(&insolar.Pulse{PulseNumber: 65537})
+	pulsar.clearState()
+	pulsar.SetBftGridItem("second", map[string]*BftCell{
+		"first":  {IsEntropyReceived: true},
+		"second": {},
+		"third":  nil,
+	})
+	pulsar.status.neighbourChecked("second", time.Millisecond)
+
+	status := pulsar.GetStatus()
+	require.Equal(t, "WaitingForVectors", status.State)
+	require.Equal(t, insolar.PulseNumber(65537), status.LastPulseNumber)
+	require.Equal(t, insolar.PulseNumber(65547), status.ProcessingPulseNumber)
+
+	require.Len(t, status.Neighbours, 2)
+	require.Equal(t, "third", status.Neighbours[0].PublicKey)
+	require.True(t, status.Neighbours[0].LastSeen.IsZero())
+	require.Equal(t, "second", status.Neighbours[1].PublicKey)
+	require.Equal(t, time.Millisecond, status.Neighbours[1].Latency)
+	require.False(t, status.Neighbours[1].Connected)
+
+	require.Equal(t, []BftRowStatus{{PublicKey: "second", Cells: 2, Entropies: 1}}, status.BftGrid)
+}
+
+func TestPulsar_GetStatus_ConcurrentMembershipChange(t *testing.T) {
+	switcher := NewStateSwitcherMock(t)
+	switcher.GetStateMock.Return(WaitingForStart)
+
+	pulsar := &Pulsar{
+		StateSwitcher: switcher,
+		status:        newStatusCollector(),
+		Neighbours:    map[string]*Neighbour{"second": {ConnectionAddress: "127.0.0.1:58093"}},
+	}
+	pulsar.clearState()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			pulsar.GetStatus()
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		// the same way as applyMembershipChanges does
+		pulsar.membershipLock.Lock()
+		pulsar.Neighbours = map[string]*Neighbour{"third": {ConnectionAddress: "127.0.0.1:58092"}}
+		pulsar.membershipLock.Unlock()
+	}
+	<-done
+
+	require.Len(t, pulsar.GetStatus().Neighbours, 1)
+}
@@ -17,12 +17,31 @@
 package pulsarstorage
 
 import (
+	"github.com/insolar/insolar/configuration"
 	"github.com/insolar/insolar/insolar"
+	"github.com/pkg/errors"
 )
 
+// ErrPulseNotFound is returned when requested pulse is absent in the storage.
+var ErrPulseNotFound = errors.New("pulse not found")
+
 type PulsarStorage interface {
 	GetLastPulse() (*insolar.Pulse, error)
 	SetLastPulse(pulse *insolar.Pulse) error
 	SavePulse(pulse *insolar.Pulse) error
+	// GetPulse returns saved pulse by its number.
+	GetPulse(pulseNumber insolar.PulseNumber) (*insolar.Pulse, error)
+	// GetPulses returns saved pulses with numbers in [begin, end], but not more than limit.
+	GetPulses(begin, end insolar.PulseNumber, limit int) ([]insolar.Pulse, error)
+	// GetPulseByTime returns the latest saved pulse generated not later than timestamp (unix seconds).
+	GetPulseByTime(timestamp int64) (*insolar.Pulse, error)
+	// GetNeighbours returns saved pulsars set, nil is returned if the set has never been saved.
+	GetNeighbours() ([]configuration.PulsarNodeAddress, error)
+	// SetNeighbours saves pulsars set after membership changes.
+	SetNeighbours(neighbours []configuration.PulsarNodeAddress) error
+	// GetEntropyChain returns saved state of entropy hash chains, nil is returned if the state has never been saved.
+	GetEntropyChain() ([]byte, error)
+	// SetEntropyChain saves state of entropy hash chains.
+	SetEntropyChain(state []byte) error
 	Close() error
 }
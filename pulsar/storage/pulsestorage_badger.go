@@ -18,7 +18,9 @@ package pulsarstorage
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/gob"
+	"math"
 	"path/filepath"
 
 	"github.com/dgraph-io/badger"
@@ -30,8 +32,11 @@ import (
 type RecordID string
 
 const (
-	LastPulseRecordID RecordID = "lastPulse"
-	PulseRecordID     RecordID = "pulse"
+	LastPulseRecordID  RecordID = "lastPulse"
+	PulseRecordID      RecordID = "pulse"
+	TimeIndexRecordID  RecordID = "timeIndex"
+	NeighboursRecordID RecordID = "neighbours"
+	EntropyChainID     RecordID = "entropyChain"
 )
 
 // NewDB returns pulsar.storage.db with BadgerDB instance initialized by opts.
@@ -123,6 +128,45 @@ func (storage *BadgerStorageImpl) SetLastPulse(pulse *insolar.Pulse) error {
 	})
 }
 
+func pulseKey(pulseNumber insolar.PulseNumber) []byte {
+	return append([]byte(PulseRecordID), pulseNumber.Bytes()...)
+}
+
+// timeIndexKey builds the key of time index, pulse number is a part of the key to keep keys unique.
+func timeIndexKey(timestamp int64, pulseNumber insolar.PulseNumber) []byte {
+	key := []byte(TimeIndexRecordID)
+	ts := make([]byte, 8)
+	binary.BigEndian.PutUint64(ts, uint64(timestamp))
+	key = append(key, ts...)
+	return append(key, pulseNumber.Bytes()...)
+}
+
+func decodePulse(val []byte) (*insolar.Pulse, error) {
+	var pulse insolar.Pulse
+	decoder := gob.NewDecoder(bytes.NewBuffer(val))
+	err := decoder.Decode(&pulse)
+	if err != nil {
+		return nil, err
+	}
+	return &pulse, nil
+}
+
+func getPulse(txn *badger.Txn, pulseNumber insolar.PulseNumber) (*insolar.Pulse, error) {
+	item, err := txn.Get(pulseKey(pulseNumber))
+	if err == badger.ErrKeyNotFound {
+		return nil, ErrPulseNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	val, err := item.Value()
+	if err != nil {
+		return nil, err
+	}
+	return decodePulse(val)
+}
+
+// SavePulse saves pulse and indexes it by pulse number and generation time.
 func (storage *BadgerStorageImpl) SavePulse(pulse *insolar.Pulse) error {
 	var buffer bytes.Buffer
 	enc := gob.NewEncoder(&buffer)
@@ -130,14 +174,139 @@ func (storage *BadgerStorageImpl) SavePulse(pulse *insolar.Pulse) error {
 	if err != nil {
 		return err
 	}
-	pulseNumber := pulse.PulseNumber.Bytes()
-	key := []byte(PulseRecordID)
-	key = append(key, pulseNumber...)
 
 	return storage.db.Update(func(txn *badger.Txn) error {
-		err := txn.Set(key, buffer.Bytes())
+		err := txn.Set(pulseKey(pulse.PulseNumber), buffer.Bytes())
+		if err != nil {
+			return err
+		}
+		return txn.Set(timeIndexKey(pulse.PulseTimestamp, pulse.PulseNumber), pulse.PulseNumber.Bytes())
+	})
+}
+
+// GetPulse returns saved pulse by its number.
+func (storage *BadgerStorageImpl) GetPulse(pulseNumber insolar.PulseNumber) (*insolar.Pulse, error) {
+	var pulse *insolar.Pulse
+	err := storage.db.View(func(txn *badger.Txn) error {
+		var err error
+		pulse, err = getPulse(txn, pulseNumber)
 		return err
 	})
+	return pulse, err
+}
+
+// GetPulses returns saved pulses with numbers in [begin, end] ordered by pulse number, but not more than limit.
+func (storage *BadgerStorageImpl) GetPulses(begin, end insolar.PulseNumber, limit int) ([]insolar.Pulse, error) {
+	prefix := []byte(PulseRecordID)
+	endKey := pulseKey(end)
+	pulses := []insolar.Pulse{}
+
+	err := storage.db.View(func(txn *badger.Txn) error {
+		it := txn.NewIterator(badger.DefaultIteratorOptions)
+		defer it.Close()
+
+		for it.Seek(pulseKey(begin)); it.ValidForPrefix(prefix) && len(pulses) < limit; it.Next() {
+			key := it.Item().Key()
+			if len(key) != len(endKey) {
+				continue
+			}
+			if bytes.Compare(key, endKey) > 0 {
+				break
+			}
+			val, err := it.Item().Value()
+			if err != nil {
+				return err
+			}
+			pulse, err := decodePulse(val)
+			if err != nil {
+				return err
+			}
+			pulses = append(pulses, *pulse)
+		}
+		return nil
+	})
+	return pulses, err
+}
+
+// GetPulseByTime returns the latest saved pulse generated not later than timestamp (unix seconds).
+func (storage *BadgerStorageImpl) GetPulseByTime(timestamp int64) (*insolar.Pulse, error) {
+	prefix := []byte(TimeIndexRecordID)
+	var pulse *insolar.Pulse
+
+	err := storage.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Reverse = true
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		it.Seek(timeIndexKey(timestamp, math.MaxUint32))
+		if !it.ValidForPrefix(prefix) {
+			return ErrPulseNotFound
+		}
+		val, err := it.Item().Value()
+		if err != nil {
+			return err
+		}
+		pulse, err = getPulse(txn, insolar.NewPulseNumber(val))
+		return err
+	})
+	return pulse, err
+}
+
+// GetNeighbours returns saved pulsars set, nil is returned if the set has never been saved.
+func (storage *BadgerStorageImpl) GetNeighbours() ([]configuration.PulsarNodeAddress, error) {
+	var neighbours []configuration.PulsarNodeAddress
+	err := storage.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(NeighboursRecordID))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		val, err := item.Value()
+		if err != nil {
+			return err
+		}
+		return gob.NewDecoder(bytes.NewBuffer(val)).Decode(&neighbours)
+	})
+	return neighbours, err
+}
+
+// SetNeighbours saves pulsars set after membership changes.
+func (storage *BadgerStorageImpl) SetNeighbours(neighbours []configuration.PulsarNodeAddress) error {
+	var buffer bytes.Buffer
+	err := gob.NewEncoder(&buffer).Encode(neighbours)
+	if err != nil {
+		return err
+	}
+	return storage.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(NeighboursRecordID), buffer.Bytes())
+	})
+}
+
+// GetEntropyChain returns saved state of entropy hash chains, nil is returned if the state has never been saved.
+func (storage *BadgerStorageImpl) GetEntropyChain() ([]byte, error) {
+	var state []byte
+	err := storage.db.View(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(EntropyChainID))
+		if err == badger.ErrKeyNotFound {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		state, err = item.ValueCopy(nil)
+		return err
+	})
+	return state, err
+}
+
+// SetEntropyChain saves state of entropy hash chains.
+func (storage *BadgerStorageImpl) SetEntropyChain(state []byte) error {
+	return storage.db.Update(func(txn *badger.Txn) error {
+		return txn.Set([]byte(EntropyChainID), state)
+	})
 }
 
 func (storage *BadgerStorageImpl) Close() error {
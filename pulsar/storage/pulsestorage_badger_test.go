@@ -0,0 +1,125 @@
+//
+// Copyright 2019 Insolar Technologies GmbH
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+
+package pulsarstorage
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/insolar"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStorage(t *testing.T) (PulsarStorage, func()) {
+	dir, err := ioutil.TempDir("", "pulsar-storage-")
+	require.NoError(t, err)
+
+	conf := configuration.NewPulsar()
+	conf.Storage.DataDirectory = dir
+	storage, err := NewStorageBadger(conf, nil)
+	require.NoError(t, err)
+
+	return storage, func() {
+		storage.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func savePulses(t *testing.T, storage PulsarStorage, count int) []insolar.Pulse {
+	pulses := make([]insolar.Pulse, 0, count)
+	for i := 0; i < count; i++ {
+		pulse := insolar.Pulse{
+			PulseNumber:    insolar.FirstPulseNumber + insolar.PulseNumber(i*10),
+			PulseTimestamp: int64(1000 + i*10),
+			Signs:          map[string]insolar.PulseSenderConfirmation{},
+		}
+		pulse.Entropy[0] = byte(i)
+		require.NoError(t, storage.SavePulse(&pulse))
+		pulses = append(pulses, pulse)
+	}
+	return pulses
+}
+
+func TestBadgerStorageImpl_GetPulse(t *testing.T) {
+	storage, cleaner := newTestStorage(t)
+	defer cleaner()
+	pulses := savePulses(t, storage, 3)
+
+	pulse, err := storage.GetPulse(pulses[1].PulseNumber)
+	require.NoError(t, err)
+	require.Equal(t, pulses[1], *pulse)
+
+	_, err = storage.GetPulse(pulses[1].PulseNumber + 1)
+	require.Equal(t, ErrPulseNotFound, err)
+}
+
+func TestBadgerStorageImpl_GetPulses(t *testing.T) {
+	storage, cleaner := newTestStorage(t)
+	defer cleaner()
+	pulses := savePulses(t, storage, 5)
+
+	result, err := storage.GetPulses(pulses[1].PulseNumber, pulses[3].PulseNumber, 10)
+	require.NoError(t, err)
+	require.Equal(t, pulses[1:4], result)
+
+	result, err = storage.GetPulses(pulses[1].PulseNumber-1, pulses[4].PulseNumber+1, 2)
+	require.NoError(t, err)
+	require.Equal(t, pulses[1:3], result)
+
+	result, err = storage.GetPulses(pulses[4].PulseNumber+1, pulses[4].PulseNumber+100, 10)
+	require.NoError(t, err)
+	require.Empty(t, result)
+}
+
+func TestBadgerStorageImpl_GetPulseByTime(t *testing.T) {
+	storage, cleaner := newTestStorage(t)
+	defer cleaner()
+	pulses := savePulses(t, storage, 3)
+
+	pulse, err := storage.GetPulseByTime(pulses[1].PulseTimestamp)
+	require.NoError(t, err)
+	require.Equal(t, pulses[1].PulseNumber, pulse.PulseNumber)
+
+	pulse, err = storage.GetPulseByTime(pulses[1].PulseTimestamp + 5)
+	require.NoError(t, err)
+	require.Equal(t, pulses[1].PulseNumber, pulse.PulseNumber)
+
+	pulse, err = storage.GetPulseByTime(pulses[2].PulseTimestamp + 1000)
+	require.NoError(t, err)
+	require.Equal(t, pulses[2].PulseNumber, pulse.PulseNumber)
+}
+
+func TestBadgerStorageImpl_Neighbours(t *testing.T) {
+	storage, cleaner := newTestStorage(t)
+	defer cleaner()
+
+	neighbours, err := storage.GetNeighbours()
+	require.NoError(t, err)
+	require.Nil(t, neighbours)
+
+	expected := []configuration.PulsarNodeAddress{
+		{Address: "127.0.0.1:58091", ConnectionType: configuration.TCP, PublicKey: "first"},
+		{Address: "127.0.0.1:58093", ConnectionType: configuration.TCP, PublicKey: "second"},
+	}
+	require.NoError(t, storage.SetNeighbours(expected))
+
+	neighbours, err = storage.GetNeighbours()
+	require.NoError(t, err)
+	require.Equal(t, expected, neighbours)
+}
@@ -236,6 +236,9 @@ func main() {
 	writePulsarConfig(pulsarConfig)
 	writePromConfig(pctx)
 
+	if len(pulsarConfig.Pulsar.APIListenerAddress) != 0 {
+		pwConfig.Pulsars = append(pwConfig.Pulsars, pulsarConfig.Pulsar.APIListenerAddress)
+	}
 	pwConfig.Interval = 500 * time.Millisecond
 	pwConfig.Timeout = 1 * time.Second
 	err = pulsewatcher.WriteConfig(filepath.Join(outputDir, "/utils"), pulsewatcherFileName, pwConfig)
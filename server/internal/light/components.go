@@ -23,6 +23,7 @@ import (
 	"github.com/insolar/insolar/certificate"
 	"github.com/insolar/insolar/component"
 	"github.com/insolar/insolar/configuration"
+	"github.com/insolar/insolar/consensus/phases"
 	"github.com/insolar/insolar/contractrequester"
 	"github.com/insolar/insolar/cryptography"
 	"github.com/insolar/insolar/genesisdataprovider"
@@ -37,6 +38,7 @@ import (
 	"github.com/insolar/insolar/network/nodenetwork"
 	"github.com/insolar/insolar/network/servicenetwork"
 	"github.com/insolar/insolar/network/state"
+	"github.com/insolar/insolar/network/termination"
 	"github.com/insolar/insolar/networkcoordinator"
 	"github.com/insolar/insolar/platformpolicy"
 	"github.com/insolar/insolar/pulsar"
@@ -109,7 +111,7 @@ func initComponents(
 
 ) (*component.Manager, error) {
 	cm := component.Manager{}
-	terminationHandler := insolar.NewTerminationHandler()
+	terminationHandler := termination.NewHandler()
 
 	nodeNetwork, err := nodenetwork.NewNodeNetwork(cfg.Host, certManager.GetCertificate())
 	checkError(ctx, err, "failed to start NodeNetwork")
@@ -161,6 +163,7 @@ func initComponents(
 		keyProcessor,
 		certManager,
 		nodeNetwork,
+		phases.NewPhaseTimings(cfg.Service),
 		nw,
 	)
 
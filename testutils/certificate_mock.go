@@ -23,6 +23,11 @@ type CertificateMock struct {
 	GetDiscoveryNodesPreCounter uint64
 	GetDiscoveryNodesMock       mCertificateMockGetDiscoveryNodes
 
+	GetPulsarPublicKeysFunc       func() (r []string)
+	GetPulsarPublicKeysCounter    uint64
+	GetPulsarPublicKeysPreCounter uint64
+	GetPulsarPublicKeysMock       mCertificateMockGetPulsarPublicKeys
+
 	GetDiscoverySignsFunc       func() (r map[insolar.Reference][]byte)
 	GetDiscoverySignsCounter    uint64
 	GetDiscoverySignsPreCounter uint64
@@ -63,6 +68,7 @@ func NewCertificateMock(t minimock.Tester) *CertificateMock {
 	}
 
 	m.GetDiscoveryNodesMock = mCertificateMockGetDiscoveryNodes{mock: m}
+	m.GetPulsarPublicKeysMock = mCertificateMockGetPulsarPublicKeys{mock: m}
 	m.GetDiscoverySignsMock = mCertificateMockGetDiscoverySigns{mock: m}
 	m.GetNodeRefMock = mCertificateMockGetNodeRef{mock: m}
 	m.GetPublicKeyMock = mCertificateMockGetPublicKey{mock: m}
@@ -207,6 +213,140 @@ func (m *CertificateMock) GetDiscoveryNodesFinished() bool {
 	return true
 }
 
+type mCertificateMockGetPulsarPublicKeys struct {
+	mock              *CertificateMock
+	mainExpectation   *CertificateMockGetPulsarPublicKeysExpectation
+	expectationSeries []*CertificateMockGetPulsarPublicKeysExpectation
+}
+
+type CertificateMockGetPulsarPublicKeysExpectation struct {
+	result *CertificateMockGetPulsarPublicKeysResult
+}
+
+type CertificateMockGetPulsarPublicKeysResult struct {
+	r []string
+}
+
+//Expect specifies that invocation of Certificate.GetPulsarPublicKeys is expected from 1 to Infinity times
+func (m *mCertificateMockGetPulsarPublicKeys) Expect() *mCertificateMockGetPulsarPublicKeys {
+	m.mock.GetPulsarPublicKeysFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CertificateMockGetPulsarPublicKeysExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of Certificate.GetPulsarPublicKeys
+func (m *mCertificateMockGetPulsarPublicKeys) Return(r []string) *CertificateMock {
+	m.mock.GetPulsarPublicKeysFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &CertificateMockGetPulsarPublicKeysExpectation{}
+	}
+	m.mainExpectation.result = &CertificateMockGetPulsarPublicKeysResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of Certificate.GetPulsarPublicKeys is expected once
+func (m *mCertificateMockGetPulsarPublicKeys) ExpectOnce() *CertificateMockGetPulsarPublicKeysExpectation {
+	m.mock.GetPulsarPublicKeysFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &CertificateMockGetPulsarPublicKeysExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *CertificateMockGetPulsarPublicKeysExpectation) Return(r []string) {
+	e.result = &CertificateMockGetPulsarPublicKeysResult{r}
+}
+
+//Set uses given function f as a mock of Certificate.GetPulsarPublicKeys method
+func (m *mCertificateMockGetPulsarPublicKeys) Set(f func() (r []string)) *CertificateMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetPulsarPublicKeysFunc = f
+	return m.mock
+}
+
+//GetPulsarPublicKeys implements github.com/insolar/insolar/insolar.Certificate interface
+func (m *CertificateMock) GetPulsarPublicKeys() (r []string) {
+	counter := atomic.AddUint64(&m.GetPulsarPublicKeysPreCounter, 1)
+	defer atomic.AddUint64(&m.GetPulsarPublicKeysCounter, 1)
+
+	if len(m.GetPulsarPublicKeysMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetPulsarPublicKeysMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to CertificateMock.GetPulsarPublicKeys.")
+			return
+		}
+
+		result := m.GetPulsarPublicKeysMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the CertificateMock.GetPulsarPublicKeys")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetPulsarPublicKeysMock.mainExpectation != nil {
+
+		result := m.GetPulsarPublicKeysMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the CertificateMock.GetPulsarPublicKeys")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetPulsarPublicKeysFunc == nil {
+		m.t.Fatalf("Unexpected call to CertificateMock.GetPulsarPublicKeys.")
+		return
+	}
+
+	return m.GetPulsarPublicKeysFunc()
+}
+
+//GetPulsarPublicKeysMinimockCounter returns a count of CertificateMock.GetPulsarPublicKeysFunc invocations
+func (m *CertificateMock) GetPulsarPublicKeysMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsarPublicKeysCounter)
+}
+
+//GetPulsarPublicKeysMinimockPreCounter returns the value of CertificateMock.GetPulsarPublicKeys invocations
+func (m *CertificateMock) GetPulsarPublicKeysMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetPulsarPublicKeysPreCounter)
+}
+
+//GetPulsarPublicKeysFinished returns true if mock invocations count is ok
+func (m *CertificateMock) GetPulsarPublicKeysFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetPulsarPublicKeysMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetPulsarPublicKeysCounter) == uint64(len(m.GetPulsarPublicKeysMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetPulsarPublicKeysMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetPulsarPublicKeysCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetPulsarPublicKeysFunc != nil {
+		return atomic.LoadUint64(&m.GetPulsarPublicKeysCounter) > 0
+	}
+
+	return true
+}
+
 type mCertificateMockGetDiscoverySigns struct {
 	mock              *CertificateMock
 	mainExpectation   *CertificateMockGetDiscoverySignsExpectation
@@ -1019,6 +1159,10 @@ func (m *CertificateMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to CertificateMock.GetDiscoveryNodes")
 	}
 
+	if !m.GetPulsarPublicKeysFinished() {
+		m.t.Fatal("Expected call to CertificateMock.GetPulsarPublicKeys")
+	}
+
 	if !m.GetDiscoverySignsFinished() {
 		m.t.Fatal("Expected call to CertificateMock.GetDiscoverySigns")
 	}
@@ -1064,6 +1208,10 @@ func (m *CertificateMock) MinimockFinish() {
 		m.t.Fatal("Expected call to CertificateMock.GetDiscoveryNodes")
 	}
 
+	if !m.GetPulsarPublicKeysFinished() {
+		m.t.Fatal("Expected call to CertificateMock.GetPulsarPublicKeys")
+	}
+
 	if !m.GetDiscoverySignsFinished() {
 		m.t.Fatal("Expected call to CertificateMock.GetDiscoverySigns")
 	}
@@ -1103,6 +1251,7 @@ func (m *CertificateMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.GetDiscoveryNodesFinished()
+		ok = ok && m.GetPulsarPublicKeysFinished()
 		ok = ok && m.GetDiscoverySignsFinished()
 		ok = ok && m.GetNodeRefFinished()
 		ok = ok && m.GetPublicKeyFinished()
@@ -1121,6 +1270,10 @@ func (m *CertificateMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to CertificateMock.GetDiscoveryNodes")
 			}
 
+			if !m.GetPulsarPublicKeysFinished() {
+				m.t.Error("Expected call to CertificateMock.GetPulsarPublicKeys")
+			}
+
 			if !m.GetDiscoverySignsFinished() {
 				m.t.Error("Expected call to CertificateMock.GetDiscoverySigns")
 			}
@@ -1161,6 +1314,10 @@ func (m *CertificateMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetPulsarPublicKeysFinished() {
+		return false
+	}
+
 	if !m.GetDiscoverySignsFinished() {
 		return false
 	}
@@ -30,6 +30,16 @@ type ContractRequesterMock struct {
 	CallMethodPreCounter uint64
 	CallMethodMock       mContractRequesterMockCallMethod
 
+	CallQueryFunc       func(p context.Context, p1 insolar.Message, p2 *insolar.Reference, p3 string, p4 insolar.Arguments, p5 *insolar.Reference) (r insolar.Reply, r1 error)
+	CallQueryCounter    uint64
+	CallQueryPreCounter uint64
+	CallQueryMock       mContractRequesterMockCallQuery
+
+	SendQueryFunc       func(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) (r insolar.Reply, r1 error)
+	SendQueryCounter    uint64
+	SendQueryPreCounter uint64
+	SendQueryMock       mContractRequesterMockSendQuery
+
 	SendRequestFunc       func(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) (r insolar.Reply, r1 error)
 	SendRequestCounter    uint64
 	SendRequestPreCounter uint64
@@ -46,6 +56,8 @@ func NewContractRequesterMock(t minimock.Tester) *ContractRequesterMock {
 
 	m.CallConstructorMock = mContractRequesterMockCallConstructor{mock: m}
 	m.CallMethodMock = mContractRequesterMockCallMethod{mock: m}
+	m.CallQueryMock = mContractRequesterMockCallQuery{mock: m}
+	m.SendQueryMock = mContractRequesterMockSendQuery{mock: m}
 	m.SendRequestMock = mContractRequesterMockSendRequest{mock: m}
 
 	return m
@@ -364,6 +376,314 @@ func (m *ContractRequesterMock) CallMethodFinished() bool {
 	return true
 }
 
+type mContractRequesterMockCallQuery struct {
+	mock              *ContractRequesterMock
+	mainExpectation   *ContractRequesterMockCallQueryExpectation
+	expectationSeries []*ContractRequesterMockCallQueryExpectation
+}
+
+type ContractRequesterMockCallQueryExpectation struct {
+	input  *ContractRequesterMockCallQueryInput
+	result *ContractRequesterMockCallQueryResult
+}
+
+type ContractRequesterMockCallQueryInput struct {
+	p  context.Context
+	p1 insolar.Message
+	p2 *insolar.Reference
+	p3 string
+	p4 insolar.Arguments
+	p5 *insolar.Reference
+}
+
+type ContractRequesterMockCallQueryResult struct {
+	r  insolar.Reply
+	r1 error
+}
+
+//Expect specifies that invocation of ContractRequester.CallQuery is expected from 1 to Infinity times
+func (m *mContractRequesterMockCallQuery) Expect(p context.Context, p1 insolar.Message, p2 *insolar.Reference, p3 string, p4 insolar.Arguments, p5 *insolar.Reference) *mContractRequesterMockCallQuery {
+	m.mock.CallQueryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockCallQueryExpectation{}
+	}
+	m.mainExpectation.input = &ContractRequesterMockCallQueryInput{p, p1, p2, p3, p4, p5}
+	return m
+}
+
+//Return specifies results of invocation of ContractRequester.CallQuery
+func (m *mContractRequesterMockCallQuery) Return(r insolar.Reply, r1 error) *ContractRequesterMock {
+	m.mock.CallQueryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockCallQueryExpectation{}
+	}
+	m.mainExpectation.result = &ContractRequesterMockCallQueryResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ContractRequester.CallQuery is expected once
+func (m *mContractRequesterMockCallQuery) ExpectOnce(p context.Context, p1 insolar.Message, p2 *insolar.Reference, p3 string, p4 insolar.Arguments, p5 *insolar.Reference) *ContractRequesterMockCallQueryExpectation {
+	m.mock.CallQueryFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ContractRequesterMockCallQueryExpectation{}
+	expectation.input = &ContractRequesterMockCallQueryInput{p, p1, p2, p3, p4, p5}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ContractRequesterMockCallQueryExpectation) Return(r insolar.Reply, r1 error) {
+	e.result = &ContractRequesterMockCallQueryResult{r, r1}
+}
+
+//Set uses given function f as a mock of ContractRequester.CallQuery method
+func (m *mContractRequesterMockCallQuery) Set(f func(p context.Context, p1 insolar.Message, p2 *insolar.Reference, p3 string, p4 insolar.Arguments, p5 *insolar.Reference) (r insolar.Reply, r1 error)) *ContractRequesterMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.CallQueryFunc = f
+	return m.mock
+}
+
+//CallQuery implements github.com/insolar/insolar/insolar.ContractRequester interface
+func (m *ContractRequesterMock) CallQuery(p context.Context, p1 insolar.Message, p2 *insolar.Reference, p3 string, p4 insolar.Arguments, p5 *insolar.Reference) (r insolar.Reply, r1 error) {
+	counter := atomic.AddUint64(&m.CallQueryPreCounter, 1)
+	defer atomic.AddUint64(&m.CallQueryCounter, 1)
+
+	if len(m.CallQueryMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.CallQueryMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ContractRequesterMock.CallQuery. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
+			return
+		}
+
+		input := m.CallQueryMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ContractRequesterMockCallQueryInput{p, p1, p2, p3, p4, p5}, "ContractRequester.CallQuery got unexpected parameters")
+
+		result := m.CallQueryMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.CallQuery")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.CallQueryMock.mainExpectation != nil {
+
+		input := m.CallQueryMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ContractRequesterMockCallQueryInput{p, p1, p2, p3, p4, p5}, "ContractRequester.CallQuery got unexpected parameters")
+		}
+
+		result := m.CallQueryMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.CallQuery")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.CallQueryFunc == nil {
+		m.t.Fatalf("Unexpected call to ContractRequesterMock.CallQuery. %v %v %v %v %v %v", p, p1, p2, p3, p4, p5)
+		return
+	}
+
+	return m.CallQueryFunc(p, p1, p2, p3, p4, p5)
+}
+
+//CallQueryMinimockCounter returns a count of ContractRequesterMock.CallQueryFunc invocations
+func (m *ContractRequesterMock) CallQueryMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.CallQueryCounter)
+}
+
+//CallQueryMinimockPreCounter returns the value of ContractRequesterMock.CallQuery invocations
+func (m *ContractRequesterMock) CallQueryMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.CallQueryPreCounter)
+}
+
+//CallQueryFinished returns true if mock invocations count is ok
+func (m *ContractRequesterMock) CallQueryFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.CallQueryMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.CallQueryCounter) == uint64(len(m.CallQueryMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.CallQueryMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.CallQueryCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.CallQueryFunc != nil {
+		return atomic.LoadUint64(&m.CallQueryCounter) > 0
+	}
+
+	return true
+}
+
+type mContractRequesterMockSendQuery struct {
+	mock              *ContractRequesterMock
+	mainExpectation   *ContractRequesterMockSendQueryExpectation
+	expectationSeries []*ContractRequesterMockSendQueryExpectation
+}
+
+type ContractRequesterMockSendQueryExpectation struct {
+	input  *ContractRequesterMockSendQueryInput
+	result *ContractRequesterMockSendQueryResult
+}
+
+type ContractRequesterMockSendQueryInput struct {
+	p  context.Context
+	p1 *insolar.Reference
+	p2 string
+	p3 []interface{}
+}
+
+type ContractRequesterMockSendQueryResult struct {
+	r  insolar.Reply
+	r1 error
+}
+
+//Expect specifies that invocation of ContractRequester.SendQuery is expected from 1 to Infinity times
+func (m *mContractRequesterMockSendQuery) Expect(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) *mContractRequesterMockSendQuery {
+	m.mock.SendQueryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockSendQueryExpectation{}
+	}
+	m.mainExpectation.input = &ContractRequesterMockSendQueryInput{p, p1, p2, p3}
+	return m
+}
+
+//Return specifies results of invocation of ContractRequester.SendQuery
+func (m *mContractRequesterMockSendQuery) Return(r insolar.Reply, r1 error) *ContractRequesterMock {
+	m.mock.SendQueryFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ContractRequesterMockSendQueryExpectation{}
+	}
+	m.mainExpectation.result = &ContractRequesterMockSendQueryResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ContractRequester.SendQuery is expected once
+func (m *mContractRequesterMockSendQuery) ExpectOnce(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) *ContractRequesterMockSendQueryExpectation {
+	m.mock.SendQueryFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ContractRequesterMockSendQueryExpectation{}
+	expectation.input = &ContractRequesterMockSendQueryInput{p, p1, p2, p3}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *ContractRequesterMockSendQueryExpectation) Return(r insolar.Reply, r1 error) {
+	e.result = &ContractRequesterMockSendQueryResult{r, r1}
+}
+
+//Set uses given function f as a mock of ContractRequester.SendQuery method
+func (m *mContractRequesterMockSendQuery) Set(f func(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) (r insolar.Reply, r1 error)) *ContractRequesterMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SendQueryFunc = f
+	return m.mock
+}
+
+//SendQuery implements github.com/insolar/insolar/insolar.ContractRequester interface
+func (m *ContractRequesterMock) SendQuery(p context.Context, p1 *insolar.Reference, p2 string, p3 []interface{}) (r insolar.Reply, r1 error) {
+	counter := atomic.AddUint64(&m.SendQueryPreCounter, 1)
+	defer atomic.AddUint64(&m.SendQueryCounter, 1)
+
+	if len(m.SendQueryMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SendQueryMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ContractRequesterMock.SendQuery. %v %v %v %v", p, p1, p2, p3)
+			return
+		}
+
+		input := m.SendQueryMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ContractRequesterMockSendQueryInput{p, p1, p2, p3}, "ContractRequester.SendQuery got unexpected parameters")
+
+		result := m.SendQueryMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.SendQuery")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.SendQueryMock.mainExpectation != nil {
+
+		input := m.SendQueryMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ContractRequesterMockSendQueryInput{p, p1, p2, p3}, "ContractRequester.SendQuery got unexpected parameters")
+		}
+
+		result := m.SendQueryMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the ContractRequesterMock.SendQuery")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.SendQueryFunc == nil {
+		m.t.Fatalf("Unexpected call to ContractRequesterMock.SendQuery. %v %v %v %v", p, p1, p2, p3)
+		return
+	}
+
+	return m.SendQueryFunc(p, p1, p2, p3)
+}
+
+//SendQueryMinimockCounter returns a count of ContractRequesterMock.SendQueryFunc invocations
+func (m *ContractRequesterMock) SendQueryMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SendQueryCounter)
+}
+
+//SendQueryMinimockPreCounter returns the value of ContractRequesterMock.SendQuery invocations
+func (m *ContractRequesterMock) SendQueryMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SendQueryPreCounter)
+}
+
+//SendQueryFinished returns true if mock invocations count is ok
+func (m *ContractRequesterMock) SendQueryFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SendQueryMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SendQueryCounter) == uint64(len(m.SendQueryMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SendQueryMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SendQueryCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SendQueryFunc != nil {
+		return atomic.LoadUint64(&m.SendQueryCounter) > 0
+	}
+
+	return true
+}
+
 type mContractRequesterMockSendRequest struct {
 	mock              *ContractRequesterMock
 	mainExpectation   *ContractRequesterMockSendRequestExpectation
@@ -529,6 +849,14 @@ func (m *ContractRequesterMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ContractRequesterMock.CallMethod")
 	}
 
+	if !m.CallQueryFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.CallQuery")
+	}
+
+	if !m.SendQueryFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.SendQuery")
+	}
+
 	if !m.SendRequestFinished() {
 		m.t.Fatal("Expected call to ContractRequesterMock.SendRequest")
 	}
@@ -558,6 +886,14 @@ func (m *ContractRequesterMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ContractRequesterMock.CallMethod")
 	}
 
+	if !m.CallQueryFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.CallQuery")
+	}
+
+	if !m.SendQueryFinished() {
+		m.t.Fatal("Expected call to ContractRequesterMock.SendQuery")
+	}
+
 	if !m.SendRequestFinished() {
 		m.t.Fatal("Expected call to ContractRequesterMock.SendRequest")
 	}
@@ -578,6 +914,8 @@ func (m *ContractRequesterMock) MinimockWait(timeout time.Duration) {
 		ok := true
 		ok = ok && m.CallConstructorFinished()
 		ok = ok && m.CallMethodFinished()
+		ok = ok && m.CallQueryFinished()
+		ok = ok && m.SendQueryFinished()
 		ok = ok && m.SendRequestFinished()
 
 		if ok {
@@ -595,6 +933,14 @@ func (m *ContractRequesterMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ContractRequesterMock.CallMethod")
 			}
 
+			if !m.CallQueryFinished() {
+				m.t.Error("Expected call to ContractRequesterMock.CallQuery")
+			}
+
+			if !m.SendQueryFinished() {
+				m.t.Error("Expected call to ContractRequesterMock.SendQuery")
+			}
+
 			if !m.SendRequestFinished() {
 				m.t.Error("Expected call to ContractRequesterMock.SendRequest")
 			}
@@ -619,6 +965,14 @@ func (m *ContractRequesterMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.CallQueryFinished() {
+		return false
+	}
+
+	if !m.SendQueryFinished() {
+		return false
+	}
+
 	if !m.SendRequestFinished() {
 		return false
 	}
@@ -29,6 +29,11 @@ type DelegationTokenFactoryMock struct {
 	IssueGetCodeRedirectPreCounter uint64
 	IssueGetCodeRedirectMock       mDelegationTokenFactoryMockIssueGetCodeRedirect
 
+	IssueGetEventsRedirectFunc       func(p *insolar.Reference, p1 insolar.Message) (r insolar.DelegationToken, r1 error)
+	IssueGetEventsRedirectCounter    uint64
+	IssueGetEventsRedirectPreCounter uint64
+	IssueGetEventsRedirectMock       mDelegationTokenFactoryMockIssueGetEventsRedirect
+
 	IssueGetObjectRedirectFunc       func(p *insolar.Reference, p1 insolar.Message) (r insolar.DelegationToken, r1 error)
 	IssueGetObjectRedirectCounter    uint64
 	IssueGetObjectRedirectPreCounter uint64
@@ -55,6 +60,7 @@ func NewDelegationTokenFactoryMock(t minimock.Tester) *DelegationTokenFactoryMoc
 
 	m.IssueGetChildrenRedirectMock = mDelegationTokenFactoryMockIssueGetChildrenRedirect{mock: m}
 	m.IssueGetCodeRedirectMock = mDelegationTokenFactoryMockIssueGetCodeRedirect{mock: m}
+	m.IssueGetEventsRedirectMock = mDelegationTokenFactoryMockIssueGetEventsRedirect{mock: m}
 	m.IssueGetObjectRedirectMock = mDelegationTokenFactoryMockIssueGetObjectRedirect{mock: m}
 	m.IssuePendingExecutionMock = mDelegationTokenFactoryMockIssuePendingExecution{mock: m}
 	m.VerifyMock = mDelegationTokenFactoryMockVerify{mock: m}
@@ -364,6 +370,157 @@ func (m *DelegationTokenFactoryMock) IssueGetCodeRedirectFinished() bool {
 	return true
 }
 
+type mDelegationTokenFactoryMockIssueGetEventsRedirect struct {
+	mock              *DelegationTokenFactoryMock
+	mainExpectation   *DelegationTokenFactoryMockIssueGetEventsRedirectExpectation
+	expectationSeries []*DelegationTokenFactoryMockIssueGetEventsRedirectExpectation
+}
+
+type DelegationTokenFactoryMockIssueGetEventsRedirectExpectation struct {
+	input  *DelegationTokenFactoryMockIssueGetEventsRedirectInput
+	result *DelegationTokenFactoryMockIssueGetEventsRedirectResult
+}
+
+type DelegationTokenFactoryMockIssueGetEventsRedirectInput struct {
+	p  *insolar.Reference
+	p1 insolar.Message
+}
+
+type DelegationTokenFactoryMockIssueGetEventsRedirectResult struct {
+	r  insolar.DelegationToken
+	r1 error
+}
+
+//Expect specifies that invocation of DelegationTokenFactory.IssueGetEventsRedirect is expected from 1 to Infinity times
+func (m *mDelegationTokenFactoryMockIssueGetEventsRedirect) Expect(p *insolar.Reference, p1 insolar.Message) *mDelegationTokenFactoryMockIssueGetEventsRedirect {
+	m.mock.IssueGetEventsRedirectFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DelegationTokenFactoryMockIssueGetEventsRedirectExpectation{}
+	}
+	m.mainExpectation.input = &DelegationTokenFactoryMockIssueGetEventsRedirectInput{p, p1}
+	return m
+}
+
+//Return specifies results of invocation of DelegationTokenFactory.IssueGetEventsRedirect
+func (m *mDelegationTokenFactoryMockIssueGetEventsRedirect) Return(r insolar.DelegationToken, r1 error) *DelegationTokenFactoryMock {
+	m.mock.IssueGetEventsRedirectFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &DelegationTokenFactoryMockIssueGetEventsRedirectExpectation{}
+	}
+	m.mainExpectation.result = &DelegationTokenFactoryMockIssueGetEventsRedirectResult{r, r1}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of DelegationTokenFactory.IssueGetEventsRedirect is expected once
+func (m *mDelegationTokenFactoryMockIssueGetEventsRedirect) ExpectOnce(p *insolar.Reference, p1 insolar.Message) *DelegationTokenFactoryMockIssueGetEventsRedirectExpectation {
+	m.mock.IssueGetEventsRedirectFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &DelegationTokenFactoryMockIssueGetEventsRedirectExpectation{}
+	expectation.input = &DelegationTokenFactoryMockIssueGetEventsRedirectInput{p, p1}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *DelegationTokenFactoryMockIssueGetEventsRedirectExpectation) Return(r insolar.DelegationToken, r1 error) {
+	e.result = &DelegationTokenFactoryMockIssueGetEventsRedirectResult{r, r1}
+}
+
+//Set uses given function f as a mock of DelegationTokenFactory.IssueGetEventsRedirect method
+func (m *mDelegationTokenFactoryMockIssueGetEventsRedirect) Set(f func(p *insolar.Reference, p1 insolar.Message) (r insolar.DelegationToken, r1 error)) *DelegationTokenFactoryMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.IssueGetEventsRedirectFunc = f
+	return m.mock
+}
+
+//IssueGetEventsRedirect implements github.com/insolar/insolar/insolar.DelegationTokenFactory interface
+func (m *DelegationTokenFactoryMock) IssueGetEventsRedirect(p *insolar.Reference, p1 insolar.Message) (r insolar.DelegationToken, r1 error) {
+	counter := atomic.AddUint64(&m.IssueGetEventsRedirectPreCounter, 1)
+	defer atomic.AddUint64(&m.IssueGetEventsRedirectCounter, 1)
+
+	if len(m.IssueGetEventsRedirectMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.IssueGetEventsRedirectMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to DelegationTokenFactoryMock.IssueGetEventsRedirect. %v %v", p, p1)
+			return
+		}
+
+		input := m.IssueGetEventsRedirectMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, DelegationTokenFactoryMockIssueGetEventsRedirectInput{p, p1}, "DelegationTokenFactory.IssueGetEventsRedirect got unexpected parameters")
+
+		result := m.IssueGetEventsRedirectMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the DelegationTokenFactoryMock.IssueGetEventsRedirect")
+			return
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.IssueGetEventsRedirectMock.mainExpectation != nil {
+
+		input := m.IssueGetEventsRedirectMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, DelegationTokenFactoryMockIssueGetEventsRedirectInput{p, p1}, "DelegationTokenFactory.IssueGetEventsRedirect got unexpected parameters")
+		}
+
+		result := m.IssueGetEventsRedirectMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the DelegationTokenFactoryMock.IssueGetEventsRedirect")
+		}
+
+		r = result.r
+		r1 = result.r1
+
+		return
+	}
+
+	if m.IssueGetEventsRedirectFunc == nil {
+		m.t.Fatalf("Unexpected call to DelegationTokenFactoryMock.IssueGetEventsRedirect. %v %v", p, p1)
+		return
+	}
+
+	return m.IssueGetEventsRedirectFunc(p, p1)
+}
+
+//IssueGetEventsRedirectMinimockCounter returns a count of DelegationTokenFactoryMock.IssueGetEventsRedirectFunc invocations
+func (m *DelegationTokenFactoryMock) IssueGetEventsRedirectMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.IssueGetEventsRedirectCounter)
+}
+
+//IssueGetEventsRedirectMinimockPreCounter returns the value of DelegationTokenFactoryMock.IssueGetEventsRedirect invocations
+func (m *DelegationTokenFactoryMock) IssueGetEventsRedirectMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.IssueGetEventsRedirectPreCounter)
+}
+
+//IssueGetEventsRedirectFinished returns true if mock invocations count is ok
+func (m *DelegationTokenFactoryMock) IssueGetEventsRedirectFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.IssueGetEventsRedirectMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.IssueGetEventsRedirectCounter) == uint64(len(m.IssueGetEventsRedirectMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.IssueGetEventsRedirectMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.IssueGetEventsRedirectCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.IssueGetEventsRedirectFunc != nil {
+		return atomic.LoadUint64(&m.IssueGetEventsRedirectCounter) > 0
+	}
+
+	return true
+}
+
 type mDelegationTokenFactoryMockIssueGetObjectRedirect struct {
 	mock              *DelegationTokenFactoryMock
 	mainExpectation   *DelegationTokenFactoryMockIssueGetObjectRedirectExpectation
@@ -828,6 +985,10 @@ func (m *DelegationTokenFactoryMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetCodeRedirect")
 	}
 
+	if !m.IssueGetEventsRedirectFinished() {
+		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetEventsRedirect")
+	}
+
 	if !m.IssueGetObjectRedirectFinished() {
 		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetObjectRedirect")
 	}
@@ -865,6 +1026,10 @@ func (m *DelegationTokenFactoryMock) MinimockFinish() {
 		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetCodeRedirect")
 	}
 
+	if !m.IssueGetEventsRedirectFinished() {
+		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetEventsRedirect")
+	}
+
 	if !m.IssueGetObjectRedirectFinished() {
 		m.t.Fatal("Expected call to DelegationTokenFactoryMock.IssueGetObjectRedirect")
 	}
@@ -893,6 +1058,7 @@ func (m *DelegationTokenFactoryMock) MinimockWait(timeout time.Duration) {
 		ok := true
 		ok = ok && m.IssueGetChildrenRedirectFinished()
 		ok = ok && m.IssueGetCodeRedirectFinished()
+		ok = ok && m.IssueGetEventsRedirectFinished()
 		ok = ok && m.IssueGetObjectRedirectFinished()
 		ok = ok && m.IssuePendingExecutionFinished()
 		ok = ok && m.VerifyFinished()
@@ -912,6 +1078,10 @@ func (m *DelegationTokenFactoryMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to DelegationTokenFactoryMock.IssueGetCodeRedirect")
 			}
 
+			if !m.IssueGetEventsRedirectFinished() {
+				m.t.Error("Expected call to DelegationTokenFactoryMock.IssueGetEventsRedirect")
+			}
+
 			if !m.IssueGetObjectRedirectFinished() {
 				m.t.Error("Expected call to DelegationTokenFactoryMock.IssueGetObjectRedirect")
 			}
@@ -944,6 +1114,10 @@ func (m *DelegationTokenFactoryMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.IssueGetEventsRedirectFinished() {
+		return false
+	}
+
 	if !m.IssueGetObjectRedirectFinished() {
 		return false
 	}
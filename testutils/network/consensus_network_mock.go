@@ -27,6 +27,11 @@ type ConsensusNetworkMock struct {
 	GetNodeIDPreCounter uint64
 	GetNodeIDMock       mConsensusNetworkMockGetNodeID
 
+	ProcessRelayedPacketFunc       func(p packets.ConsensusPacket)
+	ProcessRelayedPacketCounter    uint64
+	ProcessRelayedPacketPreCounter uint64
+	ProcessRelayedPacketMock       mConsensusNetworkMockProcessRelayedPacket
+
 	PublicAddressFunc       func() (r string)
 	PublicAddressCounter    uint64
 	PublicAddressPreCounter uint64
@@ -37,6 +42,11 @@ type ConsensusNetworkMock struct {
 	RegisterPacketHandlerPreCounter uint64
 	RegisterPacketHandlerMock       mConsensusNetworkMockRegisterPacketHandler
 
+	RegisterRelayHandlerFunc       func(p network.ConsensusRelayHandler)
+	RegisterRelayHandlerCounter    uint64
+	RegisterRelayHandlerPreCounter uint64
+	RegisterRelayHandlerMock       mConsensusNetworkMockRegisterRelayHandler
+
 	SignAndSendPacketFunc       func(p packets.ConsensusPacket, p1 insolar.Reference, p2 insolar.CryptographyService) (r error)
 	SignAndSendPacketCounter    uint64
 	SignAndSendPacketPreCounter uint64
@@ -62,8 +72,10 @@ func NewConsensusNetworkMock(t minimock.Tester) *ConsensusNetworkMock {
 	}
 
 	m.GetNodeIDMock = mConsensusNetworkMockGetNodeID{mock: m}
+	m.ProcessRelayedPacketMock = mConsensusNetworkMockProcessRelayedPacket{mock: m}
 	m.PublicAddressMock = mConsensusNetworkMockPublicAddress{mock: m}
 	m.RegisterPacketHandlerMock = mConsensusNetworkMockRegisterPacketHandler{mock: m}
+	m.RegisterRelayHandlerMock = mConsensusNetworkMockRegisterRelayHandler{mock: m}
 	m.SignAndSendPacketMock = mConsensusNetworkMockSignAndSendPacket{mock: m}
 	m.StartMock = mConsensusNetworkMockStart{mock: m}
 	m.StopMock = mConsensusNetworkMockStop{mock: m}
@@ -205,6 +217,129 @@ func (m *ConsensusNetworkMock) GetNodeIDFinished() bool {
 	return true
 }
 
+type mConsensusNetworkMockProcessRelayedPacket struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockProcessRelayedPacketExpectation
+	expectationSeries []*ConsensusNetworkMockProcessRelayedPacketExpectation
+}
+
+type ConsensusNetworkMockProcessRelayedPacketExpectation struct {
+	input *ConsensusNetworkMockProcessRelayedPacketInput
+}
+
+type ConsensusNetworkMockProcessRelayedPacketInput struct {
+	p packets.ConsensusPacket
+}
+
+//Expect specifies that invocation of ConsensusNetwork.ProcessRelayedPacket is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockProcessRelayedPacket) Expect(p packets.ConsensusPacket) *mConsensusNetworkMockProcessRelayedPacket {
+	m.mock.ProcessRelayedPacketFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockProcessRelayedPacketExpectation{}
+	}
+	m.mainExpectation.input = &ConsensusNetworkMockProcessRelayedPacketInput{p}
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.ProcessRelayedPacket
+func (m *mConsensusNetworkMockProcessRelayedPacket) Return() *ConsensusNetworkMock {
+	m.mock.ProcessRelayedPacketFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockProcessRelayedPacketExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.ProcessRelayedPacket is expected once
+func (m *mConsensusNetworkMockProcessRelayedPacket) ExpectOnce(p packets.ConsensusPacket) *ConsensusNetworkMockProcessRelayedPacketExpectation {
+	m.mock.ProcessRelayedPacketFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockProcessRelayedPacketExpectation{}
+	expectation.input = &ConsensusNetworkMockProcessRelayedPacketInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.ProcessRelayedPacket method
+func (m *mConsensusNetworkMockProcessRelayedPacket) Set(f func(p packets.ConsensusPacket)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.ProcessRelayedPacketFunc = f
+	return m.mock
+}
+
+//ProcessRelayedPacket implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) ProcessRelayedPacket(p packets.ConsensusPacket) {
+	counter := atomic.AddUint64(&m.ProcessRelayedPacketPreCounter, 1)
+	defer atomic.AddUint64(&m.ProcessRelayedPacketCounter, 1)
+
+	if len(m.ProcessRelayedPacketMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.ProcessRelayedPacketMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.ProcessRelayedPacket. %v", p)
+			return
+		}
+
+		input := m.ProcessRelayedPacketMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ConsensusNetworkMockProcessRelayedPacketInput{p}, "ConsensusNetwork.ProcessRelayedPacket got unexpected parameters")
+
+		return
+	}
+
+	if m.ProcessRelayedPacketMock.mainExpectation != nil {
+
+		input := m.ProcessRelayedPacketMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ConsensusNetworkMockProcessRelayedPacketInput{p}, "ConsensusNetwork.ProcessRelayedPacket got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.ProcessRelayedPacketFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.ProcessRelayedPacket. %v", p)
+		return
+	}
+
+	m.ProcessRelayedPacketFunc(p)
+}
+
+//ProcessRelayedPacketMinimockCounter returns a count of ConsensusNetworkMock.ProcessRelayedPacketFunc invocations
+func (m *ConsensusNetworkMock) ProcessRelayedPacketMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.ProcessRelayedPacketCounter)
+}
+
+//ProcessRelayedPacketMinimockPreCounter returns the value of ConsensusNetworkMock.ProcessRelayedPacket invocations
+func (m *ConsensusNetworkMock) ProcessRelayedPacketMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.ProcessRelayedPacketPreCounter)
+}
+
+//ProcessRelayedPacketFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) ProcessRelayedPacketFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.ProcessRelayedPacketMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.ProcessRelayedPacketCounter) == uint64(len(m.ProcessRelayedPacketMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.ProcessRelayedPacketMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.ProcessRelayedPacketCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.ProcessRelayedPacketFunc != nil {
+		return atomic.LoadUint64(&m.ProcessRelayedPacketCounter) > 0
+	}
+
+	return true
+}
+
 type mConsensusNetworkMockPublicAddress struct {
 	mock              *ConsensusNetworkMock
 	mainExpectation   *ConsensusNetworkMockPublicAddressExpectation
@@ -463,6 +598,129 @@ func (m *ConsensusNetworkMock) RegisterPacketHandlerFinished() bool {
 	return true
 }
 
+type mConsensusNetworkMockRegisterRelayHandler struct {
+	mock              *ConsensusNetworkMock
+	mainExpectation   *ConsensusNetworkMockRegisterRelayHandlerExpectation
+	expectationSeries []*ConsensusNetworkMockRegisterRelayHandlerExpectation
+}
+
+type ConsensusNetworkMockRegisterRelayHandlerExpectation struct {
+	input *ConsensusNetworkMockRegisterRelayHandlerInput
+}
+
+type ConsensusNetworkMockRegisterRelayHandlerInput struct {
+	p network.ConsensusRelayHandler
+}
+
+//Expect specifies that invocation of ConsensusNetwork.RegisterRelayHandler is expected from 1 to Infinity times
+func (m *mConsensusNetworkMockRegisterRelayHandler) Expect(p network.ConsensusRelayHandler) *mConsensusNetworkMockRegisterRelayHandler {
+	m.mock.RegisterRelayHandlerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockRegisterRelayHandlerExpectation{}
+	}
+	m.mainExpectation.input = &ConsensusNetworkMockRegisterRelayHandlerInput{p}
+	return m
+}
+
+//Return specifies results of invocation of ConsensusNetwork.RegisterRelayHandler
+func (m *mConsensusNetworkMockRegisterRelayHandler) Return() *ConsensusNetworkMock {
+	m.mock.RegisterRelayHandlerFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &ConsensusNetworkMockRegisterRelayHandlerExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of ConsensusNetwork.RegisterRelayHandler is expected once
+func (m *mConsensusNetworkMockRegisterRelayHandler) ExpectOnce(p network.ConsensusRelayHandler) *ConsensusNetworkMockRegisterRelayHandlerExpectation {
+	m.mock.RegisterRelayHandlerFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &ConsensusNetworkMockRegisterRelayHandlerExpectation{}
+	expectation.input = &ConsensusNetworkMockRegisterRelayHandlerInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of ConsensusNetwork.RegisterRelayHandler method
+func (m *mConsensusNetworkMockRegisterRelayHandler) Set(f func(p network.ConsensusRelayHandler)) *ConsensusNetworkMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.RegisterRelayHandlerFunc = f
+	return m.mock
+}
+
+//RegisterRelayHandler implements github.com/insolar/insolar/network.ConsensusNetwork interface
+func (m *ConsensusNetworkMock) RegisterRelayHandler(p network.ConsensusRelayHandler) {
+	counter := atomic.AddUint64(&m.RegisterRelayHandlerPreCounter, 1)
+	defer atomic.AddUint64(&m.RegisterRelayHandlerCounter, 1)
+
+	if len(m.RegisterRelayHandlerMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.RegisterRelayHandlerMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to ConsensusNetworkMock.RegisterRelayHandler. %v", p)
+			return
+		}
+
+		input := m.RegisterRelayHandlerMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, ConsensusNetworkMockRegisterRelayHandlerInput{p}, "ConsensusNetwork.RegisterRelayHandler got unexpected parameters")
+
+		return
+	}
+
+	if m.RegisterRelayHandlerMock.mainExpectation != nil {
+
+		input := m.RegisterRelayHandlerMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, ConsensusNetworkMockRegisterRelayHandlerInput{p}, "ConsensusNetwork.RegisterRelayHandler got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.RegisterRelayHandlerFunc == nil {
+		m.t.Fatalf("Unexpected call to ConsensusNetworkMock.RegisterRelayHandler. %v", p)
+		return
+	}
+
+	m.RegisterRelayHandlerFunc(p)
+}
+
+//RegisterRelayHandlerMinimockCounter returns a count of ConsensusNetworkMock.RegisterRelayHandlerFunc invocations
+func (m *ConsensusNetworkMock) RegisterRelayHandlerMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.RegisterRelayHandlerCounter)
+}
+
+//RegisterRelayHandlerMinimockPreCounter returns the value of ConsensusNetworkMock.RegisterRelayHandler invocations
+func (m *ConsensusNetworkMock) RegisterRelayHandlerMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.RegisterRelayHandlerPreCounter)
+}
+
+//RegisterRelayHandlerFinished returns true if mock invocations count is ok
+func (m *ConsensusNetworkMock) RegisterRelayHandlerFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.RegisterRelayHandlerMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.RegisterRelayHandlerCounter) == uint64(len(m.RegisterRelayHandlerMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.RegisterRelayHandlerMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.RegisterRelayHandlerCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.RegisterRelayHandlerFunc != nil {
+		return atomic.LoadUint64(&m.RegisterRelayHandlerCounter) > 0
+	}
+
+	return true
+}
+
 type mConsensusNetworkMockSignAndSendPacket struct {
 	mock              *ConsensusNetworkMock
 	mainExpectation   *ConsensusNetworkMockSignAndSendPacketExpectation
@@ -914,6 +1172,10 @@ func (m *ConsensusNetworkMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.GetNodeID")
 	}
 
+	if !m.ProcessRelayedPacketFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.ProcessRelayedPacket")
+	}
+
 	if !m.PublicAddressFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.PublicAddress")
 	}
@@ -922,6 +1184,10 @@ func (m *ConsensusNetworkMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterPacketHandler")
 	}
 
+	if !m.RegisterRelayHandlerFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterRelayHandler")
+	}
+
 	if !m.SignAndSendPacketFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.SignAndSendPacket")
 	}
@@ -955,6 +1221,10 @@ func (m *ConsensusNetworkMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.GetNodeID")
 	}
 
+	if !m.ProcessRelayedPacketFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.ProcessRelayedPacket")
+	}
+
 	if !m.PublicAddressFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.PublicAddress")
 	}
@@ -963,6 +1233,10 @@ func (m *ConsensusNetworkMock) MinimockFinish() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterPacketHandler")
 	}
 
+	if !m.RegisterRelayHandlerFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterRelayHandler")
+	}
+
 	if !m.SignAndSendPacketFinished() {
 		m.t.Fatal("Expected call to ConsensusNetworkMock.SignAndSendPacket")
 	}
@@ -990,8 +1264,10 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.GetNodeIDFinished()
+		ok = ok && m.ProcessRelayedPacketFinished()
 		ok = ok && m.PublicAddressFinished()
 		ok = ok && m.RegisterPacketHandlerFinished()
+		ok = ok && m.RegisterRelayHandlerFinished()
 		ok = ok && m.SignAndSendPacketFinished()
 		ok = ok && m.StartFinished()
 		ok = ok && m.StopFinished()
@@ -1007,6 +1283,10 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ConsensusNetworkMock.GetNodeID")
 			}
 
+			if !m.ProcessRelayedPacketFinished() {
+				m.t.Fatal("Expected call to ConsensusNetworkMock.ProcessRelayedPacket")
+			}
+
 			if !m.PublicAddressFinished() {
 				m.t.Error("Expected call to ConsensusNetworkMock.PublicAddress")
 			}
@@ -1015,6 +1295,10 @@ func (m *ConsensusNetworkMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to ConsensusNetworkMock.RegisterPacketHandler")
 			}
 
+			if !m.RegisterRelayHandlerFinished() {
+				m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterRelayHandler")
+			}
+
 			if !m.SignAndSendPacketFinished() {
 				m.t.Error("Expected call to ConsensusNetworkMock.SignAndSendPacket")
 			}
@@ -1043,6 +1327,10 @@ func (m *ConsensusNetworkMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.ProcessRelayedPacketFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.ProcessRelayedPacket")
+	}
+
 	if !m.PublicAddressFinished() {
 		return false
 	}
@@ -1051,6 +1339,10 @@ func (m *ConsensusNetworkMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.RegisterRelayHandlerFinished() {
+		m.t.Fatal("Expected call to ConsensusNetworkMock.RegisterRelayHandler")
+	}
+
 	if !m.SignAndSendPacketFinished() {
 		return false
 	}
@@ -28,6 +28,11 @@ type NodeKeeperMock struct {
 	GetAccessorPreCounter uint64
 	GetAccessorMock       mNodeKeeperMockGetAccessor
 
+	GetBansFunc       func() (r map[insolar.Reference]insolar.PulseNumber)
+	GetBansCounter    uint64
+	GetBansPreCounter uint64
+	GetBansMock       mNodeKeeperMockGetBans
+
 	GetClaimQueueFunc       func() (r network.ClaimQueue)
 	GetClaimQueueCounter    uint64
 	GetClaimQueuePreCounter uint64
@@ -88,6 +93,11 @@ type NodeKeeperMock struct {
 	MoveSyncToActivePreCounter uint64
 	MoveSyncToActiveMock       mNodeKeeperMockMoveSyncToActive
 
+	SetBansFunc       func(p map[insolar.Reference]insolar.PulseNumber)
+	SetBansCounter    uint64
+	SetBansPreCounter uint64
+	SetBansMock       mNodeKeeperMockSetBans
+
 	SetCloudHashFunc       func(p []byte)
 	SetCloudHashCounter    uint64
 	SetCloudHashPreCounter uint64
@@ -118,6 +128,7 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	}
 
 	m.GetAccessorMock = mNodeKeeperMockGetAccessor{mock: m}
+	m.GetBansMock = mNodeKeeperMockGetBans{mock: m}
 	m.GetClaimQueueMock = mNodeKeeperMockGetClaimQueue{mock: m}
 	m.GetCloudHashMock = mNodeKeeperMockGetCloudHash{mock: m}
 	m.GetConsensusInfoMock = mNodeKeeperMockGetConsensusInfo{mock: m}
@@ -130,6 +141,7 @@ func NewNodeKeeperMock(t minimock.Tester) *NodeKeeperMock {
 	m.GetWorkingNodesByRoleMock = mNodeKeeperMockGetWorkingNodesByRole{mock: m}
 	m.IsBootstrappedMock = mNodeKeeperMockIsBootstrapped{mock: m}
 	m.MoveSyncToActiveMock = mNodeKeeperMockMoveSyncToActive{mock: m}
+	m.SetBansMock = mNodeKeeperMockSetBans{mock: m}
 	m.SetCloudHashMock = mNodeKeeperMockSetCloudHash{mock: m}
 	m.SetInitialSnapshotMock = mNodeKeeperMockSetInitialSnapshot{mock: m}
 	m.SetIsBootstrappedMock = mNodeKeeperMockSetIsBootstrapped{mock: m}
@@ -272,6 +284,140 @@ func (m *NodeKeeperMock) GetAccessorFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockGetBans struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockGetBansExpectation
+	expectationSeries []*NodeKeeperMockGetBansExpectation
+}
+
+type NodeKeeperMockGetBansExpectation struct {
+	result *NodeKeeperMockGetBansResult
+}
+
+type NodeKeeperMockGetBansResult struct {
+	r map[insolar.Reference]insolar.PulseNumber
+}
+
+//Expect specifies that invocation of NodeKeeper.GetBans is expected from 1 to Infinity times
+func (m *mNodeKeeperMockGetBans) Expect() *mNodeKeeperMockGetBans {
+	m.mock.GetBansFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockGetBansExpectation{}
+	}
+
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.GetBans
+func (m *mNodeKeeperMockGetBans) Return(r map[insolar.Reference]insolar.PulseNumber) *NodeKeeperMock {
+	m.mock.GetBansFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockGetBansExpectation{}
+	}
+	m.mainExpectation.result = &NodeKeeperMockGetBansResult{r}
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.GetBans is expected once
+func (m *mNodeKeeperMockGetBans) ExpectOnce() *NodeKeeperMockGetBansExpectation {
+	m.mock.GetBansFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockGetBansExpectation{}
+
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+func (e *NodeKeeperMockGetBansExpectation) Return(r map[insolar.Reference]insolar.PulseNumber) {
+	e.result = &NodeKeeperMockGetBansResult{r}
+}
+
+//Set uses given function f as a mock of NodeKeeper.GetBans method
+func (m *mNodeKeeperMockGetBans) Set(f func() (r map[insolar.Reference]insolar.PulseNumber)) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.GetBansFunc = f
+	return m.mock
+}
+
+//GetBans implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) GetBans() (r map[insolar.Reference]insolar.PulseNumber) {
+	counter := atomic.AddUint64(&m.GetBansPreCounter, 1)
+	defer atomic.AddUint64(&m.GetBansCounter, 1)
+
+	if len(m.GetBansMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.GetBansMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.GetBans.")
+			return
+		}
+
+		result := m.GetBansMock.expectationSeries[counter-1].result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.GetBans")
+			return
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetBansMock.mainExpectation != nil {
+
+		result := m.GetBansMock.mainExpectation.result
+		if result == nil {
+			m.t.Fatal("No results are set for the NodeKeeperMock.GetBans")
+		}
+
+		r = result.r
+
+		return
+	}
+
+	if m.GetBansFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.GetBans.")
+		return
+	}
+
+	return m.GetBansFunc()
+}
+
+//GetBansMinimockCounter returns a count of NodeKeeperMock.GetBansFunc invocations
+func (m *NodeKeeperMock) GetBansMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.GetBansCounter)
+}
+
+//GetBansMinimockPreCounter returns the value of NodeKeeperMock.GetBans invocations
+func (m *NodeKeeperMock) GetBansMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.GetBansPreCounter)
+}
+
+//GetBansFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) GetBansFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.GetBansMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.GetBansCounter) == uint64(len(m.GetBansMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.GetBansMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.GetBansCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.GetBansFunc != nil {
+		return atomic.LoadUint64(&m.GetBansCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockGetClaimQueue struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockGetClaimQueueExpectation
@@ -1938,6 +2084,129 @@ func (m *NodeKeeperMock) MoveSyncToActiveFinished() bool {
 	return true
 }
 
+type mNodeKeeperMockSetBans struct {
+	mock              *NodeKeeperMock
+	mainExpectation   *NodeKeeperMockSetBansExpectation
+	expectationSeries []*NodeKeeperMockSetBansExpectation
+}
+
+type NodeKeeperMockSetBansExpectation struct {
+	input *NodeKeeperMockSetBansInput
+}
+
+type NodeKeeperMockSetBansInput struct {
+	p map[insolar.Reference]insolar.PulseNumber
+}
+
+//Expect specifies that invocation of NodeKeeper.SetBans is expected from 1 to Infinity times
+func (m *mNodeKeeperMockSetBans) Expect(p map[insolar.Reference]insolar.PulseNumber) *mNodeKeeperMockSetBans {
+	m.mock.SetBansFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockSetBansExpectation{}
+	}
+	m.mainExpectation.input = &NodeKeeperMockSetBansInput{p}
+	return m
+}
+
+//Return specifies results of invocation of NodeKeeper.SetBans
+func (m *mNodeKeeperMockSetBans) Return() *NodeKeeperMock {
+	m.mock.SetBansFunc = nil
+	m.expectationSeries = nil
+
+	if m.mainExpectation == nil {
+		m.mainExpectation = &NodeKeeperMockSetBansExpectation{}
+	}
+
+	return m.mock
+}
+
+//ExpectOnce specifies that invocation of NodeKeeper.SetBans is expected once
+func (m *mNodeKeeperMockSetBans) ExpectOnce(p map[insolar.Reference]insolar.PulseNumber) *NodeKeeperMockSetBansExpectation {
+	m.mock.SetBansFunc = nil
+	m.mainExpectation = nil
+
+	expectation := &NodeKeeperMockSetBansExpectation{}
+	expectation.input = &NodeKeeperMockSetBansInput{p}
+	m.expectationSeries = append(m.expectationSeries, expectation)
+	return expectation
+}
+
+//Set uses given function f as a mock of NodeKeeper.SetBans method
+func (m *mNodeKeeperMockSetBans) Set(f func(p map[insolar.Reference]insolar.PulseNumber)) *NodeKeeperMock {
+	m.mainExpectation = nil
+	m.expectationSeries = nil
+
+	m.mock.SetBansFunc = f
+	return m.mock
+}
+
+//SetBans implements github.com/insolar/insolar/network.NodeKeeper interface
+func (m *NodeKeeperMock) SetBans(p map[insolar.Reference]insolar.PulseNumber) {
+	counter := atomic.AddUint64(&m.SetBansPreCounter, 1)
+	defer atomic.AddUint64(&m.SetBansCounter, 1)
+
+	if len(m.SetBansMock.expectationSeries) > 0 {
+		if counter > uint64(len(m.SetBansMock.expectationSeries)) {
+			m.t.Fatalf("Unexpected call to NodeKeeperMock.SetBans. %v", p)
+			return
+		}
+
+		input := m.SetBansMock.expectationSeries[counter-1].input
+		testify_assert.Equal(m.t, *input, NodeKeeperMockSetBansInput{p}, "NodeKeeper.SetBans got unexpected parameters")
+
+		return
+	}
+
+	if m.SetBansMock.mainExpectation != nil {
+
+		input := m.SetBansMock.mainExpectation.input
+		if input != nil {
+			testify_assert.Equal(m.t, *input, NodeKeeperMockSetBansInput{p}, "NodeKeeper.SetBans got unexpected parameters")
+		}
+
+		return
+	}
+
+	if m.SetBansFunc == nil {
+		m.t.Fatalf("Unexpected call to NodeKeeperMock.SetBans. %v", p)
+		return
+	}
+
+	m.SetBansFunc(p)
+}
+
+//SetBansMinimockCounter returns a count of NodeKeeperMock.SetBansFunc invocations
+func (m *NodeKeeperMock) SetBansMinimockCounter() uint64 {
+	return atomic.LoadUint64(&m.SetBansCounter)
+}
+
+//SetBansMinimockPreCounter returns the value of NodeKeeperMock.SetBans invocations
+func (m *NodeKeeperMock) SetBansMinimockPreCounter() uint64 {
+	return atomic.LoadUint64(&m.SetBansPreCounter)
+}
+
+//SetBansFinished returns true if mock invocations count is ok
+func (m *NodeKeeperMock) SetBansFinished() bool {
+	// if expectation series were set then invocations count should be equal to expectations count
+	if len(m.SetBansMock.expectationSeries) > 0 {
+		return atomic.LoadUint64(&m.SetBansCounter) == uint64(len(m.SetBansMock.expectationSeries))
+	}
+
+	// if main expectation was set then invocations count should be greater than zero
+	if m.SetBansMock.mainExpectation != nil {
+		return atomic.LoadUint64(&m.SetBansCounter) > 0
+	}
+
+	// if func was set then invocations count should be greater than zero
+	if m.SetBansFunc != nil {
+		return atomic.LoadUint64(&m.SetBansCounter) > 0
+	}
+
+	return true
+}
+
 type mNodeKeeperMockSetCloudHash struct {
 	mock              *NodeKeeperMock
 	mainExpectation   *NodeKeeperMockSetCloudHashExpectation
@@ -2464,6 +2733,10 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetAccessor")
 	}
 
+	if !m.GetBansFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.GetBans")
+	}
+
 	if !m.GetClaimQueueFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetClaimQueue")
 	}
@@ -2512,6 +2785,10 @@ func (m *NodeKeeperMock) ValidateCallCounters() {
 		m.t.Fatal("Expected call to NodeKeeperMock.MoveSyncToActive")
 	}
 
+	if !m.SetBansFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.SetBans")
+	}
+
 	if !m.SetCloudHashFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.SetCloudHash")
 	}
@@ -2549,6 +2826,10 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetAccessor")
 	}
 
+	if !m.GetBansFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.GetBans")
+	}
+
 	if !m.GetClaimQueueFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.GetClaimQueue")
 	}
@@ -2597,6 +2878,10 @@ func (m *NodeKeeperMock) MinimockFinish() {
 		m.t.Fatal("Expected call to NodeKeeperMock.MoveSyncToActive")
 	}
 
+	if !m.SetBansFinished() {
+		m.t.Fatal("Expected call to NodeKeeperMock.SetBans")
+	}
+
 	if !m.SetCloudHashFinished() {
 		m.t.Fatal("Expected call to NodeKeeperMock.SetCloudHash")
 	}
@@ -2628,6 +2913,7 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 	for {
 		ok := true
 		ok = ok && m.GetAccessorFinished()
+		ok = ok && m.GetBansFinished()
 		ok = ok && m.GetClaimQueueFinished()
 		ok = ok && m.GetCloudHashFinished()
 		ok = ok && m.GetConsensusInfoFinished()
@@ -2640,6 +2926,7 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 		ok = ok && m.GetWorkingNodesByRoleFinished()
 		ok = ok && m.IsBootstrappedFinished()
 		ok = ok && m.MoveSyncToActiveFinished()
+		ok = ok && m.SetBansFinished()
 		ok = ok && m.SetCloudHashFinished()
 		ok = ok && m.SetInitialSnapshotFinished()
 		ok = ok && m.SetIsBootstrappedFinished()
@@ -2656,6 +2943,10 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.GetAccessor")
 			}
 
+			if !m.GetBansFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.GetBans")
+			}
+
 			if !m.GetClaimQueueFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.GetClaimQueue")
 			}
@@ -2704,6 +2995,10 @@ func (m *NodeKeeperMock) MinimockWait(timeout time.Duration) {
 				m.t.Error("Expected call to NodeKeeperMock.MoveSyncToActive")
 			}
 
+			if !m.SetBansFinished() {
+				m.t.Error("Expected call to NodeKeeperMock.SetBans")
+			}
+
 			if !m.SetCloudHashFinished() {
 				m.t.Error("Expected call to NodeKeeperMock.SetCloudHash")
 			}
@@ -2736,6 +3031,10 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.GetBansFinished() {
+		return false
+	}
+
 	if !m.GetClaimQueueFinished() {
 		return false
 	}
@@ -2784,6 +3083,10 @@ func (m *NodeKeeperMock) AllMocksCalled() bool {
 		return false
 	}
 
+	if !m.SetBansFinished() {
+		return false
+	}
+
 	if !m.SetCloudHashFinished() {
 		return false
 	}
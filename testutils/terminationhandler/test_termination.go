@@ -17,6 +17,8 @@
 package terminationhandler
 
 import (
+	"context"
+
 	"github.com/insolar/insolar/insolar"
 	"github.com/insolar/insolar/log"
 )
@@ -27,6 +29,14 @@ func NewTestHandler() insolar.TerminationHandler {
 	return &testTerminationHandler{}
 }
 
+func (t *testTerminationHandler) Leave(ctx context.Context, leaveAfterPulses insolar.PulseNumber) (insolar.PulseNumber, error) {
+	return 0, nil
+}
+
+func (t *testTerminationHandler) OnLeaveApproved(ctx context.Context) {
+	log.Info("NetworkNode leave approved by network")
+}
+
 func (t *testTerminationHandler) Abort() {
 	log.Error("NetworkNode leave acknowledged by network. Goodbye!")
 }